@@ -24,12 +24,15 @@ import (
 	"github.com/traPtitech/traQ/service/counter"
 	"github.com/traPtitech/traQ/service/fcm"
 	"github.com/traPtitech/traQ/service/imaging"
+	"github.com/traPtitech/traQ/service/mailer"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/oidc"
+	"github.com/traPtitech/traQ/service/push"
 	"github.com/traPtitech/traQ/service/qall"
 	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/search"
 	"github.com/traPtitech/traQ/service/variable"
+	"github.com/traPtitech/traQ/service/webpush"
 	"github.com/traPtitech/traQ/utils/storage"
 )
 
@@ -87,6 +90,8 @@ type Config struct {
 			// LifeTime 待機接続維持時間. 0は無制限 (default: 0)
 			LifeTime int `mapstructure:"lifetime" yaml:"lifetime"`
 		} `mapstructure:"connection" yaml:"connection"`
+		// FullTextSearch ESが未設定の場合にMariaDBの全文検索をメッセージ検索に使用するかどうか (default: false)
+		FullTextSearch bool `mapstructure:"fullTextSearch" yaml:"fullTextSearch"`
 	} `mapstructure:"mariadb" yaml:"mariadb"`
 
 	// ES Elasticsearch設定
@@ -187,6 +192,35 @@ type Config struct {
 		} `mapstructure:"serviceAccount" yaml:"serviceAccount"`
 	} `mapstructure:"firebase" yaml:"firebase"`
 
+	// WebPush Web Push設定
+	WebPush struct {
+		// VAPID VAPID設定
+		VAPID struct {
+			// PrivateKey base64urlエンコードされたP-256秘密鍵 (`traQ webpush keygen`で生成できます)
+			PrivateKey string `mapstructure:"privateKey" yaml:"privateKey"`
+			// Subject プッシュサービスに通知する連絡先 (mailto: またはhttps: のURL)
+			Subject string `mapstructure:"subject" yaml:"subject"`
+		} `mapstructure:"vapid" yaml:"vapid"`
+	} `mapstructure:"webPush" yaml:"webPush"`
+
+	// SMTP メール送信設定 (メールダイジェストに使用)
+	SMTP struct {
+		// Host SMTPサーバーのホスト名 空の場合はメールを送信しません
+		Host string `mapstructure:"host" yaml:"host"`
+		// Port SMTPサーバーのポート番号 (default: 587)
+		Port int `mapstructure:"port" yaml:"port"`
+		// Username SMTP認証のユーザー名 空の場合は認証しません
+		Username string `mapstructure:"username" yaml:"username"`
+		// Password SMTP認証のパスワード
+		Password string `mapstructure:"password" yaml:"password"`
+		// From 送信元メールアドレス
+		From string `mapstructure:"from" yaml:"from"`
+		// TLS 接続開始時からTLSを使用するかどうか (default: false)
+		//
+		// falseの場合、サーバーが対応していればSTARTTLSを使用します
+		TLS bool `mapstructure:"tls" yaml:"tls"`
+	} `mapstructure:"smtp" yaml:"smtp"`
+
 	// OAuth2 OAuth2認可サーバー設定
 	OAuth2 struct {
 		// IsRefreshEnabled リフレッシュトークンを有効にするかどうか (default: false)
@@ -287,6 +321,7 @@ func init() {
 	viper.SetDefault("mariadb.connection.maxOpen", 0)
 	viper.SetDefault("mariadb.connection.maxIdle", 2)
 	viper.SetDefault("mariadb.connection.lifetime", 0)
+	viper.SetDefault("mariadb.fullTextSearch", false)
 	viper.SetDefault("es.url", "")
 	viper.SetDefault("es.username", "elastic")
 	viper.SetDefault("es.password", "password")
@@ -312,6 +347,14 @@ func init() {
 	viper.SetDefault("gcp.serviceAccount.file", "")
 	viper.SetDefault("gcp.stackdriver.profiler.enabled", false)
 	viper.SetDefault("firebase.serviceAccount.file", "")
+	viper.SetDefault("webPush.vapid.privateKey", "")
+	viper.SetDefault("webPush.vapid.subject", "")
+	viper.SetDefault("smtp.host", "")
+	viper.SetDefault("smtp.port", 587)
+	viper.SetDefault("smtp.username", "")
+	viper.SetDefault("smtp.password", "")
+	viper.SetDefault("smtp.from", "")
+	viper.SetDefault("smtp.tls", false)
 	viper.SetDefault("oauth2.isRefreshEnabled", false)
 	viper.SetDefault("oauth2.accessTokenExp", 60*60*24*365)
 	viper.SetDefault("externalAuthentication.enabled", false)
@@ -468,10 +511,31 @@ func newFCMClientIfAvailable(repo repository.Repository, logger *zap.Logger, unr
 	return fcm.NewNullClient(), nil
 }
 
-func initSearchServiceIfAvailable(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config search.ESEngineConfig) (search.Engine, error) {
+func newWebPushClientIfAvailable(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, config webpush.Config) (webpush.Client, error) {
+	if config.Valid() {
+		return webpush.NewClient(repo, logger, unreadCounter, config)
+	}
+	return webpush.NewNullClient(), nil
+}
+
+func newMailerIfAvailable(config mailer.Config) (mailer.Mailer, error) {
+	if config.Valid() {
+		return mailer.NewSMTPMailer(config)
+	}
+	return mailer.NewNullMailer(), nil
+}
+
+func providePushClient(fcm fcm.Client, webPush webpush.Client) push.Client {
+	return push.NewMultiClient(fcm, webPush)
+}
+
+func initSearchServiceIfAvailable(mm message.Manager, cm channel.Manager, repo repository.Repository, db *gorm.DB, logger *zap.Logger, config search.ESEngineConfig, mariaDBConfig search.MariaDBEngineConfig) (search.Engine, error) {
 	if len(config.URL) > 0 {
 		return search.NewESEngine(mm, cm, repo, logger, config)
 	}
+	if mariaDBConfig.Enabled {
+		return search.NewMariaDBEngine(db, mm, cm, repo, logger)
+	}
 	return search.NewNullEngine(), nil
 }
 
@@ -483,6 +547,24 @@ func provideFirebaseCredentialsFilePathString(c *Config) variable.FirebaseCreden
 	return variable.FirebaseCredentialsFilePathString(c.Firebase.ServiceAccount.File)
 }
 
+func provideWebPushConfig(c *Config) webpush.Config {
+	return webpush.Config{
+		VAPIDPrivateKey: c.WebPush.VAPID.PrivateKey,
+		Subject:         c.WebPush.VAPID.Subject,
+	}
+}
+
+func provideMailerConfig(c *Config) mailer.Config {
+	return mailer.Config{
+		Host:     c.SMTP.Host,
+		Port:     c.SMTP.Port,
+		Username: c.SMTP.Username,
+		Password: c.SMTP.Password,
+		From:     c.SMTP.From,
+		TLS:      c.SMTP.TLS,
+	}
+}
+
 func provideESEngineConfig(c *Config) search.ESEngineConfig {
 	return search.ESEngineConfig{
 		URL:      c.ES.URL,
@@ -491,6 +573,12 @@ func provideESEngineConfig(c *Config) search.ESEngineConfig {
 	}
 }
 
+func provideMariaDBEngineConfig(c *Config) search.MariaDBEngineConfig {
+	return search.MariaDBEngineConfig{
+		Enabled: c.MariaDB.FullTextSearch,
+	}
+}
+
 func provideImageProcessorConfig(c *Config) imaging.Config {
 	return imaging.Config{
 		MaxPixels:        c.Imaging.MaxPixels,
@@ -564,21 +652,34 @@ func provideRouterExternalAuthConfig(c *Config) router.ExternalAuthConfig {
 
 func provideRouterConfig(c *Config) *router.Config {
 	return &router.Config{
-		Origin:           c.Origin,
-		Development:      c.DevMode,
-		Version:          Version,
-		Revision:         Revision,
-		AccessLogging:    c.AccessLog.Enabled,
-		Gzipped:          c.Gzip,
-		AllowSignUp:      c.AllowSignUp,
-		AccessTokenExp:   c.OAuth2.AccessTokenExpire,
-		IsRefreshEnabled: c.OAuth2.IsRefreshEnabled,
-		SkyWaySecretKey:  c.SkyWay.SecretKey,
-		LiveKitHost:      c.LiveKit.Host,
-		LiveKitAPIKey:    c.LiveKit.APIKey,
-		LiveKitAPISecret: c.LiveKit.APISecret,
-		ExternalAuth:     provideRouterExternalAuthConfig(c),
+		Origin:                c.Origin,
+		Development:           c.DevMode,
+		Version:               Version,
+		Revision:              Revision,
+		AccessLogging:         c.AccessLog.Enabled,
+		Gzipped:               c.Gzip,
+		AllowSignUp:           c.AllowSignUp,
+		AccessTokenExp:        c.OAuth2.AccessTokenExpire,
+		IsRefreshEnabled:      c.OAuth2.IsRefreshEnabled,
+		SkyWaySecretKey:       c.SkyWay.SecretKey,
+		LiveKitHost:           c.LiveKit.Host,
+		LiveKitAPIKey:         c.LiveKit.APIKey,
+		LiveKitAPISecret:      c.LiveKit.APISecret,
+		ExternalAuth:          provideRouterExternalAuthConfig(c),
+		WebPushVAPIDPublicKey: provideWebPushVAPIDPublicKey(c),
+	}
+}
+
+func provideWebPushVAPIDPublicKey(c *Config) string {
+	config := provideWebPushConfig(c)
+	if !config.Valid() {
+		return ""
+	}
+	key, err := webpush.ParseVAPIDPrivateKey(config.VAPIDPrivateKey)
+	if err != nil {
+		return ""
 	}
+	return key.PublicKeyString()
 }
 
 func provideQallRoomStateManager(c *Config, h *hub.Hub) qall.RoomStateManager {
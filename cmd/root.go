@@ -73,6 +73,8 @@ func init() {
 		confCommand(),
 		fileCommand(),
 		stampCommand(),
+		searchCommand(),
+		webPushCommand(),
 		versionCommand(),
 		healthcheckCommand(),
 	)
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/gorm"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/search"
+	"github.com/traPtitech/traQ/utils/gormzap"
+)
+
+// searchCommand traQ検索インデックス操作コマンド
+func searchCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "search",
+		Short: "manage search index",
+	}
+
+	cmd.AddCommand(
+		searchReindexCommand(),
+		searchVerifyCommand(),
+	)
+
+	return &cmd
+}
+
+// searchReindexCommand 検索インデックス再構築コマンド
+func searchReindexCommand() *cobra.Command {
+	var (
+		batchSize  int
+		interval   time.Duration
+		checkpoint string
+	)
+
+	cmd := cobra.Command{
+		Use:   "reindex",
+		Short: "rebuild search index from all messages",
+		Run: func(_ *cobra.Command, _ []string) {
+			// Logger
+			logger, gormLogger := getCLILoggers()
+			defer logger.Sync()
+
+			repo, idx, closeDB := setupSearchIndexer(logger, gormLogger)
+			defer closeDB()
+
+			// 前回の続きから再開
+			var after uuid.UUID
+			if len(checkpoint) > 0 {
+				b, err := os.ReadFile(checkpoint)
+				if err != nil && !errors.Is(err, os.ErrNotExist) {
+					logger.Fatal("failed to read checkpoint file", zap.Error(err))
+				}
+				if len(b) > 0 {
+					after, err = uuid.FromString(strings.TrimSpace(string(b)))
+					if err != nil {
+						logger.Fatal("invalid checkpoint file", zap.Error(err))
+					}
+					logger.Info(fmt.Sprintf("resuming from message %s", after))
+				}
+			}
+
+			err := search.Reindex(context.Background(), idx, repo, search.ReindexOptions{
+				After:     after,
+				BatchSize: batchSize,
+				Interval:  interval,
+				OnProgress: func(last uuid.UUID, indexed int64, total int64) error {
+					if len(checkpoint) > 0 {
+						if err := os.WriteFile(checkpoint, []byte(last.String()), 0o644); err != nil {
+							return fmt.Errorf("failed to write checkpoint file: %w", err)
+						}
+					}
+					logger.Info(fmt.Sprintf("indexed %d/%d message(s), last %s", indexed, total, last))
+					return nil
+				},
+			})
+			if err != nil {
+				logger.Fatal("failed to reindex", zap.Error(err))
+			}
+
+			if len(checkpoint) > 0 {
+				if err := os.Remove(checkpoint); err != nil && !errors.Is(err, os.ErrNotExist) {
+					logger.Warn("failed to remove checkpoint file", zap.Error(err))
+				}
+			}
+			logger.Info("reindex finished")
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&batchSize, "batch-size", 250, "number of messages indexed at once")
+	flags.DurationVar(&interval, "interval", 0, "wait duration between batches")
+	flags.StringVar(&checkpoint, "checkpoint", "", "file to record progress for resuming")
+
+	return &cmd
+}
+
+// searchVerifyCommand 検索インデックス検証コマンド
+func searchVerifyCommand() *cobra.Command {
+	var repair bool
+
+	cmd := cobra.Command{
+		Use:   "verify",
+		Short: "compare messages between database and search index per channel",
+		Run: func(_ *cobra.Command, _ []string) {
+			// Logger
+			logger, gormLogger := getCLILoggers()
+			defer logger.Sync()
+
+			repo, idx, closeDB := setupSearchIndexer(logger, gormLogger)
+			defer closeDB()
+
+			drifts, err := search.Verify(context.Background(), idx, repo, logger, search.VerifyOptions{
+				Repair: repair,
+				OnDrift: func(drift *search.ChannelDrift) {
+					logger.Info(fmt.Sprintf("channel %s: database %d, index %d, missing %d, extra %d",
+						drift.ChannelID, drift.DBCount, drift.IndexCount, len(drift.Missing), len(drift.Extra)))
+				},
+			})
+			if err != nil {
+				logger.Fatal("failed to verify", zap.Error(err))
+			}
+
+			if len(drifts) == 0 {
+				logger.Info("search index is consistent with database")
+				return
+			}
+			if repair {
+				logger.Info(fmt.Sprintf("repaired %d channel(s)", len(drifts)))
+				return
+			}
+			logger.Fatal(fmt.Sprintf("found drift in %d channel(s). run with --repair to fix", len(drifts)))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&repair, "repair", false, "index missing messages and delete extra messages")
+
+	return &cmd
+}
+
+// setupSearchIndexer 設定に応じた検索インデックスの Indexer を作成します
+func setupSearchIndexer(logger *zap.Logger, gormLogger *gormzap.L) (repository.Repository, search.Indexer, func()) {
+	// Database
+	db, err := c.getDatabase()
+	if err != nil {
+		logger.Fatal("failed to connect database", zap.Error(err))
+	}
+	db.Logger = gormLogger
+	sqlDB, err := db.DB()
+	if err != nil {
+		logger.Fatal("failed to get *sql.DB", zap.Error(err))
+	}
+
+	// Repository
+	repo, _, err := gorm.NewGormRepository(db, hub.New(), logger, false)
+	if err != nil {
+		logger.Fatal("failed to initialize repository", zap.Error(err))
+	}
+
+	// ChannelManager
+	cm, err := channel.InitChannelManager(repo, logger)
+	if err != nil {
+		logger.Fatal("failed to initialize channel manager", zap.Error(err))
+	}
+
+	// Indexer
+	var idx search.Indexer
+	switch {
+	case len(c.ES.URL) > 0:
+		idx, err = search.NewESIndexer(cm, repo, logger, provideESEngineConfig(&c))
+	case c.MariaDB.FullTextSearch:
+		idx, err = search.NewMariaDBIndexer(db, cm, repo, logger)
+	default:
+		logger.Fatal("search engine is not configured")
+	}
+	if err != nil {
+		logger.Fatal("failed to initialize search indexer", zap.Error(err))
+	}
+
+	return repo, idx, func() { _ = sqlDB.Close() }
+}
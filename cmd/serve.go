@@ -209,6 +209,10 @@ func (s *Server) Start(ctx context.Context, address string, gracefulTimeout time
 		}
 	}()
 	s.SS.StampThrottler.Start()
+	s.SS.MessageScheduler.Start()
+	s.SS.PollCloser.Start()
+	s.SS.ReminderScheduler.Start()
+	s.SS.DigestSender.Start()
 
 	if s.routerStopped == nil {
 		s.routerStopped = make(chan struct{})
@@ -264,8 +268,8 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		return err
 	})
 	eg.Go(func() error {
-		s.SS.FCM.Close()
-		s.L.Info("FCM shutdown")
+		s.SS.Push.Close()
+		s.L.Info("Push shutdown")
 		return nil
 	})
 	eg.Go(func() error {
@@ -273,6 +277,26 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		s.L.Info("Channel manager shutdown")
 		return nil
 	})
+	eg.Go(func() error {
+		err := s.SS.MessageScheduler.Shutdown(ctx)
+		s.L.Info("Message scheduler shutdown")
+		return err
+	})
+	eg.Go(func() error {
+		err := s.SS.PollCloser.Shutdown(ctx)
+		s.L.Info("Poll closer shutdown")
+		return err
+	})
+	eg.Go(func() error {
+		err := s.SS.ReminderScheduler.Shutdown(ctx)
+		s.L.Info("Reminder scheduler shutdown")
+		return err
+	})
+	eg.Go(func() error {
+		err := s.SS.DigestSender.Shutdown(ctx)
+		s.L.Info("Digest sender shutdown")
+		return err
+	})
 	eg.Go(func() error {
 		err := s.SS.MessageManager.Wait(ctx)
 		s.L.Info("Message manager shutdown")
@@ -10,21 +10,27 @@ import (
 
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router"
+	"github.com/traPtitech/traQ/router/utils"
 	"github.com/traPtitech/traQ/service"
 	"github.com/traPtitech/traQ/service/bot"
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/digest"
 	"github.com/traPtitech/traQ/service/exevent"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/imaging"
+	"github.com/traPtitech/traQ/service/inbox"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/notification"
 	"github.com/traPtitech/traQ/service/ogp"
 	rbac2 "github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/savedsearch"
+	"github.com/traPtitech/traQ/service/scheduler"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 	"github.com/traPtitech/traQ/service/ws"
+	mutil "github.com/traPtitech/traQ/utils/message"
 	"github.com/traPtitech/traQ/utils/storage"
 )
 
@@ -39,24 +45,38 @@ func newServer(hub *hub.Hub, db *gorm.DB, repo repository.Repository, fs storage
 		counter.NewMessageCounter,
 		counter.NewUserCounter,
 		counter.NewChannelCounter,
+		digest.NewSender,
 		exevent.NewStampThrottler,
 		imaging.NewProcessor,
+		inbox.NewRecorder,
 		notification.NewService,
 		ogp.NewServiceImpl,
 		rbac2.New,
+		savedsearch.NewWatcher,
+		scheduler.NewMessageScheduler,
+		scheduler.NewPollCloser,
+		scheduler.NewReminderScheduler,
 		viewer.NewManager,
 		webrtcv3.NewManager,
 		ws.NewStreamer,
 		botWS.NewStreamer,
 		router.Setup,
+		utils.NewReplaceMapper,
+		mutil.NewReplacer,
 		newFCMClientIfAvailable,
+		newWebPushClientIfAvailable,
+		newMailerIfAvailable,
+		providePushClient,
 		initSearchServiceIfAvailable,
 		provideServerOriginString,
 		provideFirebaseCredentialsFilePathString,
+		provideWebPushConfig,
+		provideMailerConfig,
 		provideImageProcessorConfig,
 		provideOIDCService,
 		provideRouterConfig,
 		provideESEngineConfig,
+		provideMariaDBEngineConfig,
 		provideQallRoomStateManager,
 		provideQallSoundboard,
 		wire.Struct(new(service.Services), "*"),
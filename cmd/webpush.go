@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"github.com/traPtitech/traQ/service/webpush"
+)
+
+// webPushCommand Web Push操作コマンド
+func webPushCommand() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "webpush",
+		Short: "manage Web Push",
+	}
+
+	cmd.AddCommand(
+		webPushKeygenCommand(),
+	)
+
+	return &cmd
+}
+
+// webPushKeygenCommand VAPID鍵ペアを生成するコマンド
+func webPushKeygenCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate a new VAPID key pair",
+		Run: func(_ *cobra.Command, _ []string) {
+			key, err := webpush.GenerateVAPIDKey()
+			if err != nil {
+				log.Fatalf("failed to generate VAPID key: %v", err)
+			}
+			fmt.Printf("privateKey: %s\n", key.PrivateKeyString())
+			fmt.Printf("publicKey: %s\n", key.PublicKeyString())
+		},
+	}
+}
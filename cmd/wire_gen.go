@@ -10,21 +10,27 @@ import (
 	"github.com/leandro-lugaresi/hub"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router"
+	"github.com/traPtitech/traQ/router/utils"
 	"github.com/traPtitech/traQ/service"
 	"github.com/traPtitech/traQ/service/bot"
 	"github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/digest"
 	"github.com/traPtitech/traQ/service/exevent"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/imaging"
+	"github.com/traPtitech/traQ/service/inbox"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/notification"
 	"github.com/traPtitech/traQ/service/ogp"
 	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/savedsearch"
+	"github.com/traPtitech/traQ/service/scheduler"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
 	ws2 "github.com/traPtitech/traQ/service/ws"
+	message2 "github.com/traPtitech/traQ/utils/message"
 	"github.com/traPtitech/traQ/utils/storage"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -42,7 +48,17 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 		return nil, err
 	}
 	webrtcv3Manager := webrtcv3.NewManager(hub2)
-	streamer := ws.NewStreamer(hub2, webrtcv3Manager, logger)
+	messageManager, err := message.NewMessageManager(repo, manager, logger)
+	if err != nil {
+		return nil, err
+	}
+	rbacRBAC, err := rbac.New(repo)
+	if err != nil {
+		return nil, err
+	}
+	replaceMapper := utils.NewReplaceMapper(repo, manager)
+	replacer := message2.NewReplacer(replaceMapper)
+	streamer := ws.NewStreamer(hub2, webrtcv3Manager, repo, manager, messageManager, rbacRBAC, replacer, logger)
 	botService := bot.NewService(repo, manager, hub2, streamer, logger)
 	onlineCounter := counter.NewOnlineCounter(hub2)
 	unreadMessageCounter, err := counter.NewUnreadMessageCounter(db, hub2)
@@ -58,37 +74,47 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 	if err != nil {
 		return nil, err
 	}
-	messageManager, err := message.NewMessageManager(repo, manager, logger)
-	if err != nil {
-		return nil, err
-	}
 	stampThrottler := exevent.NewStampThrottler(hub2, messageManager)
 	firebaseCredentialsFilePathString := provideFirebaseCredentialsFilePathString(c2)
 	client, err := newFCMClientIfAvailable(repo, logger, unreadMessageCounter, firebaseCredentialsFilePathString)
 	if err != nil {
 		return nil, err
 	}
+	webpushConfig := provideWebPushConfig(c2)
+	webpushClient, err := newWebPushClientIfAvailable(repo, logger, unreadMessageCounter, webpushConfig)
+	if err != nil {
+		return nil, err
+	}
+	pushClient := providePushClient(client, webpushClient)
 	config := provideImageProcessorConfig(c2)
 	processor := imaging.NewProcessor(config)
 	fileManager, err := file.InitFileManager(repo, fs, processor, logger)
 	if err != nil {
 		return nil, err
 	}
+	recorder := inbox.NewRecorder(repo, manager, hub2, logger)
+	messageScheduler := scheduler.NewMessageScheduler(repo, messageManager, logger)
+	pollCloser := scheduler.NewPollCloser(repo, messageManager, logger)
+	reminderScheduler := scheduler.NewReminderScheduler(repo, messageManager, recorder, logger)
 	viewerManager := viewer.NewManager(hub2)
 	wsStreamer := ws2.NewStreamer(hub2, viewerManager, webrtcv3Manager, logger)
 	serverOriginString := provideServerOriginString(c2)
-	notificationService := notification.NewService(repo, manager, messageManager, fileManager, hub2, logger, client, wsStreamer, viewerManager, serverOriginString)
-	ogpService, err := ogp.NewServiceImpl(repo, logger)
+	mailerConfig := provideMailerConfig(c2)
+	mailer, err := newMailerIfAvailable(mailerConfig)
 	if err != nil {
 		return nil, err
 	}
-	rbacRBAC, err := rbac.New(repo)
+	sender := digest.NewSender(repo, manager, mailer, logger, serverOriginString)
+	notificationService := notification.NewService(repo, manager, messageManager, fileManager, hub2, logger, pushClient, wsStreamer, viewerManager, onlineCounter, rbacRBAC, serverOriginString)
+	ogpService, err := ogp.NewServiceImpl(repo, logger)
 	if err != nil {
 		return nil, err
 	}
 	oidcService := provideOIDCService(c2, repo, rbacRBAC)
+	watcher := savedsearch.NewWatcher(repo, manager, hub2, logger)
 	esEngineConfig := provideESEngineConfig(c2)
-	engine, err := initSearchServiceIfAvailable(messageManager, manager, repo, logger, esEngineConfig)
+	mariaDBEngineConfig := provideMariaDBEngineConfig(c2)
+	engine, err := initSearchServiceIfAvailable(messageManager, manager, repo, db, logger, esEngineConfig, mariaDBEngineConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -106,14 +132,21 @@ func newServer(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, fs storag
 		UserCounter:          userCounter,
 		ChannelCounter:       channelCounter,
 		StampThrottler:       stampThrottler,
-		FCM:                  client,
+		DigestSender:         sender,
 		FileManager:          fileManager,
 		Imaging:              processor,
+		InboxRecorder:        recorder,
+		Mailer:               mailer,
 		MessageManager:       messageManager,
+		MessageScheduler:     messageScheduler,
+		PollCloser:           pollCloser,
+		ReminderScheduler:    reminderScheduler,
 		Notification:         notificationService,
 		OGP:                  ogpService,
 		OIDC:                 oidcService,
+		Push:                 pushClient,
 		RBAC:                 rbacRBAC,
+		SavedSearchWatcher:   watcher,
 		Search:               engine,
 		ViewerManager:        viewerManager,
 		WebRTCv3:             webrtcv3Manager,
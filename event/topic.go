@@ -143,6 +143,62 @@ const (
 	// 		message: *model.Message
 	// 		cited_ids: []uuid.UUID	引用されたメッセージのIDの配列
 	MessageCited = "message.cited"
+	// MessageReportCreated メッセージが通報された
+	// 	Fields:
+	// 		report_id: uuid.UUID
+	// 		message_id: uuid.UUID
+	// 		reporter_id: uuid.UUID
+	// 		report: *model.MessageReport
+	MessageReportCreated = "message_report.created"
+	// MessageReportStateChanged メッセージ通報の対応状態が変更された
+	// 	Fields:
+	// 		report_id: uuid.UUID
+	// 		resolver_id: uuid.UUID
+	// 		report: *model.MessageReport
+	MessageReportStateChanged = "message_report.state_changed"
+	// PollCreated メッセージに投票が添付された
+	// 	Fields:
+	// 		poll_id: uuid.UUID
+	// 		message_id: uuid.UUID
+	// 		poll: *model.Poll
+	PollCreated = "poll.created"
+	// PollVoted 投票の票が変更された
+	// 	Fields:
+	// 		poll_id: uuid.UUID
+	// 		message_id: uuid.UUID
+	// 		user_id: uuid.UUID	票を変更したユーザーのID
+	PollVoted = "poll.voted"
+	// PollClosed 投票が締め切られた
+	// 	Fields:
+	// 		poll_id: uuid.UUID
+	// 		message_id: uuid.UUID
+	// 		poll: *model.Poll	選択肢と集計結果を含む
+	PollClosed = "poll.closed"
+	// MessageComponentsUpdated メッセージのコンポーネントが変更された
+	// 	Fields:
+	// 		message_id: uuid.UUID
+	// 		components: []model.MessageComponent
+	MessageComponentsUpdated = "message.components.updated"
+	// MessageComponentInteracted メッセージのコンポーネントがユーザーに操作された
+	// 	Fields:
+	// 		message_id: uuid.UUID
+	// 		user_id: uuid.UUID
+	// 		component: *model.MessageComponent
+	// 		value: string	セレクトメニューの場合は選択された値、ボタンの場合は空文字列
+	MessageComponentInteracted = "message.component.interacted"
+	// SavedSearchMatched 保存された検索に新着メッセージが一致した
+	// 	Fields:
+	// 		saved_search_id: uuid.UUID
+	// 		user_id: uuid.UUID	保存した検索の所有者のID
+	// 		saved_search: *model.SavedSearch
+	// 		message_id: uuid.UUID
+	// 		message: *model.Message
+	SavedSearchMatched = "saved_search.matched"
+	// InboxItemCreated 通知受信箱にアイテムが追加された
+	// 	Fields:
+	// 		user_id: uuid.UUID	受信箱の所有者のID
+	// 		inbox_item: *model.InboxItem
+	InboxItemCreated = "inbox_item.created"
 
 	// ChannelCreated チャンネルが作成された
 	// 	Fields:
@@ -250,6 +306,7 @@ const (
 	// BotDeleted Botが削除された
 	// 	Fields:
 	// 		bot_id: uuid.UUID
+	// 		bot_user_id: uuid.UUID
 	BotDeleted = "bot.deleted"
 	// BotStateChanged Botの状態が変化した
 	// 	Fields:
@@ -271,6 +328,23 @@ const (
 	// 		bot_id: uuid.UUID
 	// 		channel_id: uuid.UUID
 	BotLeft = "bot.left"
+	// BotCommandInvoked Botのスラッシュコマンドが呼び出された
+	// 	Fields:
+	// 		invocation: *model.BotCommandInvocation
+	// 		command: *model.BotCommand
+	// 		arguments: map[string]any
+	// 		user_id: uuid.UUID
+	// 		channel_id: uuid.UUID
+	BotCommandInvoked = "bot_command.invoked"
+	// BotCommandEphemeralReplied Botがスラッシュコマンドに呼び出したユーザーにのみ見える返信をした
+	// 	Fields:
+	// 		invocation: *model.BotCommandInvocation
+	// 		user_id: uuid.UUID
+	// 		channel_id: uuid.UUID
+	// 		bot_user_id: uuid.UUID
+	// 		content: string
+	// 		embed: bool
+	BotCommandEphemeralReplied = "bot_command.ephemeral_replied"
 
 	// UserWebRTCv3StateChanged ユーザーのWebRTCの状態が変化した
 	// 	Fields:
@@ -54,6 +54,28 @@ func Migrations() []*gormigrate.Migration {
 		v41(), // ユーザーグループ名受付規則変更に伴う既存ユーザーグループ名の更新
 		v42(), // get_my_stamp_recommendationsパーミッションの追加とmessages_stampsテーブルへの (user_id, updated_at) の複合インデックスの追加
 		v43(), // messages_stampsテーブルのインデックス (user_id, updated_at) を (user_id, updated_at, stamp_id) に変更
+		v44(), // メッセージスレッドの追加
+		v45(), // 予約投稿メッセージの追加
+		v46(), // get_message_historyパーミッションの追加とmessagesテーブルへのedit_countカラムの追加
+		v47(), // メッセージ通報の対応状態の追加、moderatorロールとmanage_message_reportsパーミッションの追加
+		v48(), // 保存された検索の追加
+		v49(), // user_settingsテーブルへのnotify_keywordsカラムの追加
+		v50(), // user_settingsテーブルへのおやすみモード・スヌーズ設定カラムの追加
+		v51(), // Web Push購読の追加
+		v52(), // 通知受信箱の追加
+		v53(), // user_settingsテーブルへのメールダイジェスト設定カラムの追加
+		v54(), // users_subscribe_channelsテーブルへのチャンネル通知の上書き設定カラムの追加
+		v55(), // mention_channelパーミッションの追加とchannelsテーブルへのdisable_channel_mentionsカラムの追加
+		v56(), // メッセージの投票の追加
+		v57(), // メッセージのリマインダーの追加
+		v58(), // BOTのスラッシュコマンドの追加
+		v59(), // メッセージコンポーネントの追加
+		v60(), // BOTイベントの再送用送信箱の追加
+		v61(), // scheduled_messagesテーブルへの投稿状態カラムの追加
+		v62(), // MariaDB全文検索用テーブルの追加
+		v63(), // message_remindersテーブルへの通知状態カラムの追加
+		v64(), // scheduled_messagesテーブルへの投稿処理開始日時カラムの追加
+		v65(), // message_remindersテーブルへの通知処理開始日時カラムの追加
 	}
 }
 
@@ -68,18 +90,31 @@ func AllTables() []interface{} {
 		&model.DMChannelMapping{},
 		&model.ChannelLatestMessage{},
 		&model.BotEventLog{},
+		&model.BotEventDelivery{},
 		&model.BotJoinChannel{},
 		&model.Bot{},
 		&model.OAuth2Client{},
 		&model.OAuth2Authorize{},
 		&model.OAuth2Token{},
 		&model.MessageReport{},
+		&model.PollVote{},
+		&model.PollOption{},
+		&model.Poll{},
+		&model.MessageComponent{},
 		&model.WebhookBot{},
 		&model.Stamp{},
 		&model.UsersTag{},
 		&model.Unread{},
+		&model.MessageThreadSubscription{},
+		&model.ScheduledMessage{},
+		&model.MessageReminder{},
+		&model.BotCommand{},
+		&model.BotCommandInvocation{},
+		&model.SavedSearch{},
+		&model.InboxItem{},
 		&model.Star{},
 		&model.Device{},
+		&model.WebPushSubscription{},
 		&model.Pin{},
 		&model.FileACLEntry{},
 		&model.FileThumbnail{},
@@ -104,5 +139,6 @@ func AllTables() []interface{} {
 		&model.SessionRecord{},
 		&model.OgpCache{},
 		&model.SoundboardItem{},
+		&model.MessageSearchDoc{},
 	}
 }
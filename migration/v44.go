@@ -0,0 +1,76 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v44 メッセージスレッドの追加
+func v44() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "44",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v44Message{}, &v44MessageThreadSubscription{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"message_thread_subscriptions", "message_thread_subscriptions_message_id_messages_id_foreign", "message_id", "messages(id)", "CASCADE", "CASCADE"},
+				{"message_thread_subscriptions", "message_thread_subscriptions_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			if err := db.Migrator().DropTable(&v44MessageThreadSubscription{}); err != nil {
+				return err
+			}
+			if err := db.Migrator().DropIndex(&v44Message{}, "idx_messages_parent_message_id_created_at"); err != nil {
+				return err
+			}
+			for _, c := range []string{"parent_message_id", "reply_count"} {
+				if err := db.Migrator().DropColumn(&v44Message{}, c); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v44Message struct {
+	ID              uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID          uuid.UUID              `gorm:"type:char(36);not null;"`
+	ChannelID       uuid.UUID              `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
+	Text            string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	CreatedAt       time.Time              `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2;index:idx_messages_parent_message_id_created_at,priority:2"`
+	UpdatedAt       time.Time              `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
+	DeletedAt       gorm.DeletedAt         `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1"`
+	ParentMessageID optional.Of[uuid.UUID] `gorm:"type:char(36);index:idx_messages_parent_message_id_created_at,priority:1"` // 追加
+	ReplyCount      int                    `gorm:"type:int;not null;default:0"`                                              // 追加
+}
+
+func (*v44Message) TableName() string {
+	return "messages"
+}
+
+type v44MessageThreadSubscription struct {
+	MessageID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v44MessageThreadSubscription) TableName() string {
+	return "message_thread_subscriptions"
+}
@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v45 予約投稿メッセージの追加
+func v45() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "45",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v45ScheduledMessage{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"scheduled_messages", "scheduled_messages_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+				{"scheduled_messages", "scheduled_messages_channel_id_channels_id_foreign", "channel_id", "channels(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v45ScheduledMessage{})
+		},
+	}
+}
+
+type v45ScheduledMessage struct {
+	ID          uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID      uuid.UUID              `gorm:"type:char(36);not null;index"`
+	ChannelID   uuid.UUID              `gorm:"type:char(36);not null"`
+	DMUserID    optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	Text        string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ScheduledAt time.Time              `gorm:"precision:6;index"`
+	CreatedAt   time.Time              `gorm:"precision:6"`
+	UpdatedAt   time.Time              `gorm:"precision:6"`
+}
+
+func (*v45ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
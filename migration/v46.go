@@ -0,0 +1,81 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v46 get_message_historyパーミッションの追加とmessagesテーブルへのedit_countカラムの追加
+func v46() *gormigrate.Migration {
+	addedRolePermissions := map[string][]string{
+		"user": {
+			"get_message_history",
+		},
+		"bot": {
+			"get_message_history",
+		},
+		"read": {
+			"get_message_history",
+		},
+	}
+
+	return &gormigrate.Migration{
+		ID: "46",
+		Migrate: func(db *gorm.DB) error {
+			for role, perms := range addedRolePermissions {
+				for _, perm := range perms {
+					if err := db.Create(&v46RolePermission{Role: role, Permission: perm}).Error; err != nil {
+						return err
+					}
+				}
+			}
+
+			if err := db.AutoMigrate(&v46Message{}); err != nil {
+				return err
+			}
+			// 既存のアーカイブから編集回数を埋める
+			return db.Exec("UPDATE messages m INNER JOIN (SELECT message_id, COUNT(*) AS c FROM archived_messages GROUP BY message_id) am ON m.id = am.message_id SET m.edit_count = am.c").Error
+		},
+		Rollback: func(db *gorm.DB) error {
+			for role, perms := range addedRolePermissions {
+				for _, perm := range perms {
+					if err := db.Delete(&v46RolePermission{Role: role, Permission: perm}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return db.Migrator().DropColumn(&v46Message{}, "edit_count")
+		},
+	}
+}
+
+type v46RolePermission struct {
+	Role       string `gorm:"type:varchar(30);not null;primaryKey"`
+	Permission string `gorm:"type:varchar(30);not null;primaryKey"`
+}
+
+func (*v46RolePermission) TableName() string {
+	return "user_role_permissions"
+}
+
+type v46Message struct {
+	ID              uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID          uuid.UUID              `gorm:"type:char(36);not null;"`
+	ChannelID       uuid.UUID              `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
+	Text            string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	CreatedAt       time.Time              `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2;index:idx_messages_parent_message_id_created_at,priority:2"`
+	UpdatedAt       time.Time              `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
+	DeletedAt       gorm.DeletedAt         `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1"`
+	ParentMessageID optional.Of[uuid.UUID] `gorm:"type:char(36);index:idx_messages_parent_message_id_created_at,priority:1"`
+	ReplyCount      int                    `gorm:"type:int;not null;default:0"`
+	EditCount       int                    `gorm:"type:int;not null;default:0"` // 追加
+}
+
+func (*v46Message) TableName() string {
+	return "messages"
+}
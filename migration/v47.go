@@ -0,0 +1,90 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v47 メッセージ通報の対応状態の追加、moderatorロールとmanage_message_reportsパーミッションの追加
+func v47() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "47",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v47MessageReport{}); err != nil {
+				return err
+			}
+
+			if err := db.Create(&v47UserRole{Name: "moderator", Oauth2Scope: false, System: true}).Error; err != nil {
+				return err
+			}
+			// moderatorロールはuserロールの全パーミッションを含む
+			if err := db.Exec("INSERT INTO user_role_permissions (role, permission) SELECT 'moderator', permission FROM user_role_permissions WHERE role = 'user'").Error; err != nil {
+				return err
+			}
+			for _, perm := range []string{"get_message_reports", "manage_message_reports"} {
+				if err := db.Create(&v47RolePermission{Role: "moderator", Permission: perm}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			if err := db.Where("role = ?", "moderator").Delete(&v47RolePermission{}).Error; err != nil {
+				return err
+			}
+			if err := db.Delete(&v47UserRole{Name: "moderator"}).Error; err != nil {
+				return err
+			}
+			if err := db.Migrator().DropIndex(&v47MessageReport{}, "idx_message_reports_state"); err != nil {
+				return err
+			}
+			for _, c := range []string{"state", "resolver_id", "note", "resolved_at"} {
+				if err := db.Migrator().DropColumn(&v47MessageReport{}, c); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v47MessageReport struct {
+	ID         uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	MessageID  uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter"`
+	Reporter   uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter"`
+	Reason     string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	State      string                 `gorm:"type:varchar(30);not null;default:'open';index"` // 追加
+	ResolverID optional.Of[uuid.UUID] `gorm:"type:char(36)"`                                  // 追加
+	Note       string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`         // 追加
+	ResolvedAt optional.Of[time.Time] `gorm:"precision:6"`                                    // 追加
+	CreatedAt  time.Time              `gorm:"precision:6;index"`
+	DeletedAt  gorm.DeletedAt         `gorm:"precision:6"`
+}
+
+func (*v47MessageReport) TableName() string {
+	return "message_reports"
+}
+
+type v47UserRole struct {
+	Name        string `gorm:"type:varchar(30);not null;primaryKey"`
+	Oauth2Scope bool   `gorm:"type:boolean;not null;default:false"`
+	System      bool   `gorm:"type:boolean;not null;default:false"`
+}
+
+func (*v47UserRole) TableName() string {
+	return "user_roles"
+}
+
+type v47RolePermission struct {
+	Role       string `gorm:"type:varchar(30);not null;primaryKey"`
+	Permission string `gorm:"type:varchar(30);not null;primaryKey"`
+}
+
+func (*v47RolePermission) TableName() string {
+	return "user_role_permissions"
+}
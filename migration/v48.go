@@ -0,0 +1,50 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v48 保存された検索の追加
+func v48() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "48",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v48SavedSearch{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"saved_searches", "saved_searches_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v48SavedSearch{})
+		},
+	}
+}
+
+type v48SavedSearch struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Query     string    `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	Notify    bool      `gorm:"type:boolean;not null;default:false;index"`
+	CreatedAt time.Time `gorm:"precision:6"`
+	UpdatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v48SavedSearch) TableName() string {
+	return "saved_searches"
+}
@@ -0,0 +1,30 @@
+package migration
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v49 user_settingsテーブルへのnotify_keywordsカラムの追加
+func v49() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "49",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v49UserSettings{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropColumn(&v49UserSettings{}, "notify_keywords")
+		},
+	}
+}
+
+type v49UserSettings struct {
+	UserID         uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	NotifyCitation bool      `gorm:"type:boolean;not null;default:false"`
+	NotifyKeywords string    `gorm:"type:text"` // 追加
+}
+
+func (*v49UserSettings) TableName() string {
+	return "user_settings"
+}
@@ -0,0 +1,43 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v50 user_settingsテーブルへのおやすみモード・スヌーズ設定カラムの追加
+func v50() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "50",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v50UserSettings{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			for _, column := range []string{"dnd_schedules", "dnd_allow_forced", "dnd_allow_mentions", "snooze_until"} {
+				if err := db.Migrator().DropColumn(&v50UserSettings{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v50UserSettings struct {
+	UserID           uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	NotifyCitation   bool                   `gorm:"type:boolean;not null;default:false"`
+	NotifyKeywords   string                 `gorm:"type:text"`
+	DNDSchedules     string                 `gorm:"type:text"`                           // 追加
+	DNDAllowForced   bool                   `gorm:"type:boolean;not null;default:false"` // 追加
+	DNDAllowMentions bool                   `gorm:"type:boolean;not null;default:false"` // 追加
+	SnoozeUntil      optional.Of[time.Time] `gorm:"precision:6"`                         // 追加
+}
+
+func (*v50UserSettings) TableName() string {
+	return "user_settings"
+}
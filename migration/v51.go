@@ -0,0 +1,49 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v51 Web Push購読の追加
+func v51() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "51",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v51WebPushSubscription{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"web_push_subscriptions", "web_push_subscriptions_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v51WebPushSubscription{})
+		},
+	}
+}
+
+type v51WebPushSubscription struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	Endpoint  string    `gorm:"type:varchar(1024);not null;index:idx_web_push_subscriptions_endpoint,length:190"`
+	P256dh    string    `gorm:"type:varchar(128);not null"`
+	Auth      string    `gorm:"type:varchar(64);not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v51WebPushSubscription) TableName() string {
+	return "web_push_subscriptions"
+}
@@ -0,0 +1,55 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v52 通知受信箱の追加
+func v52() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "52",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v52InboxItem{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"inbox_items", "inbox_items_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+				{"inbox_items", "inbox_items_message_id_messages_id_foreign", "message_id", "messages(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v52InboxItem{})
+		},
+	}
+}
+
+type v52InboxItem struct {
+	ID        uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID              `gorm:"type:char(36);not null;index:idx_inbox_items_user_id_is_read,priority:1"`
+	Type      string                 `gorm:"type:varchar(30);not null"`
+	MessageID uuid.UUID              `gorm:"type:char(36);not null;index"`
+	ChannelID uuid.UUID              `gorm:"type:char(36);not null"`
+	ActorID   uuid.UUID              `gorm:"type:char(36);not null"`
+	StampID   optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	IsRead    bool                   `gorm:"type:boolean;not null;default:false;index:idx_inbox_items_user_id_is_read,priority:2"`
+	CreatedAt time.Time              `gorm:"precision:6"`
+}
+
+func (*v52InboxItem) TableName() string {
+	return "inbox_items"
+}
@@ -0,0 +1,48 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v53 user_settingsテーブルへのメールダイジェスト設定カラムの追加
+func v53() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "53",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v53UserSettings{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			for _, column := range []string{"digest_email", "digest_email_verified", "digest_verification_code", "digest_frequency", "digest_checked_at"} {
+				if err := db.Migrator().DropColumn(&v53UserSettings{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v53UserSettings struct {
+	UserID                 uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	NotifyCitation         bool                   `gorm:"type:boolean;not null;default:false"`
+	NotifyKeywords         string                 `gorm:"type:text"`
+	DNDSchedules           string                 `gorm:"type:text"`
+	DNDAllowForced         bool                   `gorm:"type:boolean;not null;default:false"`
+	DNDAllowMentions       bool                   `gorm:"type:boolean;not null;default:false"`
+	SnoozeUntil            optional.Of[time.Time] `gorm:"precision:6"`
+	DigestEmail            string                 `gorm:"type:varchar(254);not null;default:''"`   // 追加
+	DigestEmailVerified    bool                   `gorm:"type:boolean;not null;default:false"`     // 追加
+	DigestVerificationCode string                 `gorm:"type:varchar(16);not null;default:''"`    // 追加
+	DigestFrequency        string                 `gorm:"type:varchar(10);not null;default:'off'"` // 追加
+	DigestCheckedAt        optional.Of[time.Time] `gorm:"precision:6"`                             // 追加
+}
+
+func (*v53UserSettings) TableName() string {
+	return "user_settings"
+}
@@ -0,0 +1,47 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v54 users_subscribe_channelsテーブルへのチャンネル通知の上書き設定カラムの追加
+func v54() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "54",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v54UserSubscribeChannel{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			// 上書き設定のみのレコードは購読していないことを表すので削除
+			if err := db.Where("mark = false AND notify = false").Delete(&v54UserSubscribeChannel{}).Error; err != nil {
+				return err
+			}
+			for _, column := range []string{"notify_mode", "muted_until", "suppress_bot"} {
+				if err := db.Migrator().DropColumn(&v54UserSubscribeChannel{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+type v54UserSubscribeChannel struct {
+	UserID      uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	ChannelID   uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	Mark        bool                   `gorm:"type:boolean;not null;default:false"`
+	Notify      bool                   `gorm:"type:boolean;not null;default:false"`
+	NotifyMode  string                 `gorm:"type:varchar(20);not null;default:'default'"` // 追加
+	MutedUntil  optional.Of[time.Time] `gorm:"precision:6"`                                 // 追加
+	SuppressBot bool                   `gorm:"type:boolean;not null;default:false"`         // 追加
+}
+
+func (*v54UserSubscribeChannel) TableName() string {
+	return "users_subscribe_channels"
+}
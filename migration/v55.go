@@ -0,0 +1,74 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v55 mention_channelパーミッションの追加とchannelsテーブルへのdisable_channel_mentionsカラムの追加
+func v55() *gormigrate.Migration {
+	addedRolePermissions := map[string][]string{
+		"user": {
+			"mention_channel",
+		},
+		"moderator": {
+			"mention_channel",
+		},
+	}
+
+	return &gormigrate.Migration{
+		ID: "55",
+		Migrate: func(db *gorm.DB) error {
+			for role, perms := range addedRolePermissions {
+				for _, perm := range perms {
+					if err := db.Create(&v55RolePermission{Role: role, Permission: perm}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return db.AutoMigrate(&v55Channel{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			for role, perms := range addedRolePermissions {
+				for _, perm := range perms {
+					if err := db.Delete(&v55RolePermission{Role: role, Permission: perm}).Error; err != nil {
+						return err
+					}
+				}
+			}
+			return db.Migrator().DropColumn(&v55Channel{}, "disable_channel_mentions")
+		},
+	}
+}
+
+type v55RolePermission struct {
+	Role       string `gorm:"type:varchar(30);not null;primaryKey"`
+	Permission string `gorm:"type:varchar(30);not null;primaryKey"`
+}
+
+func (*v55RolePermission) TableName() string {
+	return "user_role_permissions"
+}
+
+type v55Channel struct {
+	ID                     uuid.UUID      `gorm:"type:char(36);not null;primaryKey;index:idx_channel_channels_id_is_public_is_forced,priority:1"`
+	Name                   string         `gorm:"type:varchar(20);not null;uniqueIndex:name_parent"`
+	ParentID               uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:name_parent"`
+	Topic                  string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	IsForced               bool           `gorm:"type:boolean;not null;default:false;index:idx_channel_channels_id_is_public_is_forced,priority:3"`
+	IsPublic               bool           `gorm:"type:boolean;not null;default:false;index:idx_channel_channels_id_is_public_is_forced,priority:2"`
+	IsVisible              bool           `gorm:"type:boolean;not null;default:false"`
+	CreatorID              uuid.UUID      `gorm:"type:char(36);not null"`
+	UpdaterID              uuid.UUID      `gorm:"type:char(36);not null"`
+	CreatedAt              time.Time      `gorm:"precision:6"`
+	UpdatedAt              time.Time      `gorm:"precision:6"`
+	DeletedAt              gorm.DeletedAt `gorm:"precision:6"`
+	DisableChannelMentions bool           `gorm:"type:boolean;not null;default:false"` // 追加
+}
+
+func (*v55Channel) TableName() string {
+	return "channels"
+}
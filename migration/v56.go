@@ -0,0 +1,82 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v56 メッセージの投票の追加
+func v56() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "56",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v56Poll{}, &v56PollOption{}, &v56PollVote{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"polls", "polls_message_id_messages_id_foreign", "message_id", "messages(id)", "CASCADE", "CASCADE"},
+				{"polls", "polls_creator_id_users_id_foreign", "creator_id", "users(id)", "CASCADE", "CASCADE"},
+				{"poll_options", "poll_options_poll_id_polls_id_foreign", "poll_id", "polls(id)", "CASCADE", "CASCADE"},
+				{"poll_votes", "poll_votes_poll_id_polls_id_foreign", "poll_id", "polls(id)", "CASCADE", "CASCADE"},
+				{"poll_votes", "poll_votes_option_id_poll_options_id_foreign", "option_id", "poll_options(id)", "CASCADE", "CASCADE"},
+				{"poll_votes", "poll_votes_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v56PollVote{}, &v56PollOption{}, &v56Poll{})
+		},
+	}
+}
+
+type v56Poll struct {
+	ID             uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	MessageID      uuid.UUID              `gorm:"type:char(36);not null;unique"`
+	CreatorID      uuid.UUID              `gorm:"type:char(36);not null"`
+	MultipleChoice bool                   `gorm:"type:boolean;not null;default:false"`
+	Anonymous      bool                   `gorm:"type:boolean;not null;default:false"`
+	Deadline       optional.Of[time.Time] `gorm:"precision:6;index"`
+	ClosedAt       optional.Of[time.Time] `gorm:"precision:6"`
+	VoterCount     int                    `gorm:"type:int;not null;default:0"`
+	CreatedAt      time.Time              `gorm:"precision:6"`
+}
+
+func (*v56Poll) TableName() string {
+	return "polls"
+}
+
+type v56PollOption struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	PollID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	Content   string    `gorm:"type:varchar(100);not null"`
+	Position  int       `gorm:"type:int;not null"`
+	VoteCount int       `gorm:"type:int;not null;default:0"`
+}
+
+func (*v56PollOption) TableName() string {
+	return "poll_options"
+}
+
+type v56PollVote struct {
+	PollID    uuid.UUID `gorm:"type:char(36);not null;index:idx_poll_votes_poll_id_user_id,priority:1"`
+	OptionID  uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey;index:idx_poll_votes_poll_id_user_id,priority:2"`
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v56PollVote) TableName() string {
+	return "poll_votes"
+}
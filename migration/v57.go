@@ -0,0 +1,49 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v57 メッセージのリマインダーの追加
+func v57() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "57",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v57MessageReminder{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"message_reminders", "message_reminders_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+				{"message_reminders", "message_reminders_message_id_messages_id_foreign", "message_id", "messages(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v57MessageReminder{})
+		},
+	}
+}
+
+type v57MessageReminder struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null"`
+	RemindAt  time.Time `gorm:"precision:6;index"`
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v57MessageReminder) TableName() string {
+	return "message_reminders"
+}
@@ -0,0 +1,65 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v58 BOTのスラッシュコマンドの追加
+func v58() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "58",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v58BotCommand{}, &v58BotCommandInvocation{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"bot_commands", "bot_commands_bot_id_bots_id_foreign", "bot_id", "bots(id)", "CASCADE", "CASCADE"},
+				{"bot_command_invocations", "bot_command_invocations_command_id_bot_commands_id_foreign", "command_id", "bot_commands(id)", "CASCADE", "CASCADE"},
+				{"bot_command_invocations", "bot_command_invocations_user_id_users_id_foreign", "user_id", "users(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v58BotCommandInvocation{}, &v58BotCommand{})
+		},
+	}
+}
+
+type v58BotCommand struct {
+	ID          uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BotID       uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_bot_commands_bot_id_name,priority:1"`
+	Name        string    `gorm:"type:varchar(32);not null;uniqueIndex:idx_bot_commands_bot_id_name,priority:2"`
+	Description string    `gorm:"type:varchar(200);not null;default:''"`
+	Arguments   string    `gorm:"type:text"`
+	ChannelIDs  string    `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"precision:6"`
+}
+
+func (*v58BotCommand) TableName() string {
+	return "bot_commands"
+}
+
+type v58BotCommandInvocation struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	CommandID uuid.UUID `gorm:"type:char(36);not null;index"`
+	BotID     uuid.UUID `gorm:"type:char(36);not null"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null"`
+	CreatedAt time.Time `gorm:"precision:6;index"`
+}
+
+func (*v58BotCommandInvocation) TableName() string {
+	return "bot_command_invocations"
+}
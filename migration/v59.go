@@ -0,0 +1,53 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v59 メッセージコンポーネントの追加
+func v59() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "59",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v59MessageComponent{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"message_components", "message_components_message_id_messages_id_foreign", "message_id", "messages(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v59MessageComponent{})
+		},
+	}
+}
+
+type v59MessageComponent struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_message_components_message_id_custom_id,priority:1"`
+	CustomID  string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_message_components_message_id_custom_id,priority:2"`
+	Type      string    `gorm:"type:varchar(10);not null"`
+	Label     string    `gorm:"type:varchar(80);not null;default:''"`
+	Style     string    `gorm:"type:varchar(10);not null;default:'default'"`
+	Options   string    `gorm:"type:text"`
+	Disabled  bool      `gorm:"type:boolean;not null;default:false"`
+	Position  int       `gorm:"type:int;not null"`
+	UpdatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v59MessageComponent) TableName() string {
+	return "message_components"
+}
@@ -0,0 +1,53 @@
+package migration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v60 BOTイベントの再送用送信箱の追加
+func v60() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "60",
+		Migrate: func(db *gorm.DB) error {
+			if err := db.AutoMigrate(&v60BotEventDelivery{}); err != nil {
+				return err
+			}
+
+			foreignKeys := [][6]string{
+				// table name, constraint name, field name, references, on delete, on update
+				{"bot_event_deliveries", "bot_event_deliveries_bot_id_bots_id_foreign", "bot_id", "bots(id)", "CASCADE", "CASCADE"},
+			}
+			for _, c := range foreignKeys {
+				if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE %s", c[0], c[1], c[2], c[3], c[4], c[5])).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v60BotEventDelivery{})
+		},
+	}
+}
+
+type v60BotEventDelivery struct {
+	RequestID     uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	BotID         uuid.UUID `gorm:"type:char(36);not null;index:idx_bot_event_deliveries_bot_id_created_at"`
+	Event         string    `gorm:"type:varchar(30);not null"`
+	Body          string    `gorm:"type:mediumtext;not null"`
+	State         string    `gorm:"type:varchar(10);not null;index:idx_bot_event_deliveries_state_next_attempt_at,priority:1"`
+	Attempts      int       `gorm:"type:int;not null;default:0"`
+	LastError     string    `gorm:"type:text"`
+	NextAttemptAt time.Time `gorm:"precision:6;index:idx_bot_event_deliveries_state_next_attempt_at,priority:2"`
+	CreatedAt     time.Time `gorm:"precision:6;index:idx_bot_event_deliveries_bot_id_created_at"`
+	UpdatedAt     time.Time `gorm:"precision:6"`
+}
+
+func (*v60BotEventDelivery) TableName() string {
+	return "bot_event_deliveries"
+}
@@ -0,0 +1,44 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v61 scheduled_messagesテーブルへの投稿状態カラムの追加
+func v61() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "61",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v61ScheduledMessage{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			if err := db.Migrator().DropColumn(&v61ScheduledMessage{}, "state"); err != nil {
+				return err
+			}
+			return db.Migrator().DropColumn(&v61ScheduledMessage{}, "attempts")
+		},
+	}
+}
+
+type v61ScheduledMessage struct {
+	ID          uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID      uuid.UUID              `gorm:"type:char(36);not null;index"`
+	ChannelID   uuid.UUID              `gorm:"type:char(36);not null"`
+	DMUserID    optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	Text        string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ScheduledAt time.Time              `gorm:"precision:6;index"`
+	State       string                 `gorm:"type:varchar(10);not null;default:'pending'"` // 追加
+	Attempts    int                    `gorm:"type:int;not null;default:0"`                 // 追加
+	CreatedAt   time.Time              `gorm:"precision:6"`
+	UpdatedAt   time.Time              `gorm:"precision:6"`
+}
+
+func (*v61ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
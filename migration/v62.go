@@ -0,0 +1,44 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v62 MariaDB全文検索用テーブルの追加
+func v62() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "62",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v62MessageSearchDoc{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropTable(&v62MessageSearchDoc{})
+		},
+	}
+}
+
+type v62MessageSearchDoc struct {
+	MessageID      uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID         uuid.UUID `gorm:"type:char(36);not null;index"`
+	ChannelID      uuid.UUID `gorm:"type:char(36);not null;index"`
+	IsPublic       bool      `gorm:"type:boolean;not null;default:false"`
+	Bot            bool      `gorm:"type:boolean;not null;default:false"`
+	Ngram          string    `gorm:"type:LONGTEXT;not null;index:idx_message_search_docs_ngram,class:FULLTEXT"`
+	To             string    `gorm:"type:TEXT;not null"`
+	Citation       string    `gorm:"type:TEXT;not null"`
+	HasURL         bool      `gorm:"type:boolean;not null;default:false"`
+	HasAttachments bool      `gorm:"type:boolean;not null;default:false"`
+	HasImage       bool      `gorm:"type:boolean;not null;default:false"`
+	HasVideo       bool      `gorm:"type:boolean;not null;default:false"`
+	HasAudio       bool      `gorm:"type:boolean;not null;default:false"`
+	CreatedAt      time.Time `gorm:"precision:6;index;autoCreateTime:false"`
+	UpdatedAt      time.Time `gorm:"precision:6;index;autoUpdateTime:false"`
+}
+
+func (*v62MessageSearchDoc) TableName() string {
+	return "message_search_docs"
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+)
+
+// v63 message_remindersテーブルへの通知状態カラムの追加
+func v63() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "63",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v63MessageReminder{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			if err := db.Migrator().DropColumn(&v63MessageReminder{}, "state"); err != nil {
+				return err
+			}
+			return db.Migrator().DropColumn(&v63MessageReminder{}, "attempts")
+		},
+	}
+}
+
+type v63MessageReminder struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null"`
+	RemindAt  time.Time `gorm:"precision:6;index"`
+	State     string    `gorm:"type:varchar(10);not null;default:'pending'"` // 追加
+	Attempts  int       `gorm:"type:int;not null;default:0"`                 // 追加
+	CreatedAt time.Time `gorm:"precision:6"`
+}
+
+func (*v63MessageReminder) TableName() string {
+	return "message_reminders"
+}
@@ -0,0 +1,42 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v64 scheduled_messagesテーブルへの投稿処理開始日時カラムの追加
+func v64() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "64",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v64ScheduledMessage{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropColumn(&v64ScheduledMessage{}, "claimed_at")
+		},
+	}
+}
+
+type v64ScheduledMessage struct {
+	ID          uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID      uuid.UUID              `gorm:"type:char(36);not null;index"`
+	ChannelID   uuid.UUID              `gorm:"type:char(36);not null"`
+	DMUserID    optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	Text        string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ScheduledAt time.Time              `gorm:"precision:6;index"`
+	State       string                 `gorm:"type:varchar(10);not null;default:'pending'"`
+	Attempts    int                    `gorm:"type:int;not null;default:0"`
+	ClaimedAt   optional.Of[time.Time] `gorm:"precision:6"` // 追加
+	CreatedAt   time.Time              `gorm:"precision:6"`
+	UpdatedAt   time.Time              `gorm:"precision:6"`
+}
+
+func (*v64ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
@@ -0,0 +1,39 @@
+package migration
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// v65 message_remindersテーブルへの通知処理開始日時カラムの追加
+func v65() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "65",
+		Migrate: func(db *gorm.DB) error {
+			return db.AutoMigrate(&v65MessageReminder{})
+		},
+		Rollback: func(db *gorm.DB) error {
+			return db.Migrator().DropColumn(&v65MessageReminder{}, "claimed_at")
+		},
+	}
+}
+
+type v65MessageReminder struct {
+	ID        uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID              `gorm:"type:char(36);not null;index"`
+	MessageID uuid.UUID              `gorm:"type:char(36);not null"`
+	RemindAt  time.Time              `gorm:"precision:6;index"`
+	State     string                 `gorm:"type:varchar(10);not null;default:'pending'"`
+	Attempts  int                    `gorm:"type:int;not null;default:0"`
+	ClaimedAt optional.Of[time.Time] `gorm:"precision:6"` // 追加
+	CreatedAt time.Time              `gorm:"precision:6"`
+}
+
+func (*v65MessageReminder) TableName() string {
+	return "message_reminders"
+}
@@ -93,6 +93,80 @@ func (*BotEventLog) TableName() string {
 	return "bot_event_logs"
 }
 
+// BotEventDeliveryState Botイベント配送の状態
+type BotEventDeliveryState string
+
+const (
+	// BotEventDeliveryPending 再送待ち
+	BotEventDeliveryPending BotEventDeliveryState = "pending"
+	// BotEventDeliveryDead 再送回数の上限に達し、配送を諦めた
+	BotEventDeliveryDead BotEventDeliveryState = "dead"
+)
+
+const (
+	// BotEventDeliveryMaxAttempts Botイベントの最大送信回数
+	BotEventDeliveryMaxAttempts = 8
+	// botEventDeliveryBaseBackoff 最初の再送までの待機時間
+	botEventDeliveryBaseBackoff = 10 * time.Second
+	// botEventDeliveryMaxBackoff 再送までの待機時間の上限
+	botEventDeliveryMaxBackoff = time.Hour
+)
+
+// BotEventDelivery 配送に失敗したBotイベント (送信箱)
+//
+// 配送に成功したイベントは削除されます
+type BotEventDelivery struct {
+	RequestID     uuid.UUID             `gorm:"type:char(36);not null;primaryKey"`
+	BotID         uuid.UUID             `gorm:"type:char(36);not null;index:idx_bot_event_deliveries_bot_id_created_at"`
+	Event         BotEventType          `gorm:"type:varchar(30);not null"`
+	Body          string                `gorm:"type:mediumtext;not null"`
+	State         BotEventDeliveryState `gorm:"type:varchar(10);not null;index:idx_bot_event_deliveries_state_next_attempt_at,priority:1"`
+	Attempts      int                   `gorm:"type:int;not null;default:0"`
+	LastError     string                `gorm:"type:text"`
+	NextAttemptAt time.Time             `gorm:"precision:6;index:idx_bot_event_deliveries_state_next_attempt_at,priority:2"`
+	CreatedAt     time.Time             `gorm:"precision:6;index:idx_bot_event_deliveries_bot_id_created_at"`
+	UpdatedAt     time.Time             `gorm:"precision:6"`
+
+	Bot *Bot `gorm:"constraint:bot_event_deliveries_bot_id_bots_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName BotEventDeliveryのテーブル名
+func (*BotEventDelivery) TableName() string {
+	return "bot_event_deliveries"
+}
+
+// BotEventDeliveryBackoff attempts回送信に失敗した後、次の再送までの待機時間を返します
+//
+// 10秒から始まり、失敗するごとに2倍 (最大1時間) になります
+func BotEventDeliveryBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	d := botEventDeliveryBaseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= botEventDeliveryMaxBackoff {
+			return botEventDeliveryMaxBackoff
+		}
+	}
+	return d
+}
+
+// RecordFailure 送信の失敗を記録し、次の再送を予約します
+//
+// 最大送信回数に達した場合はBotEventDeliveryDeadになり、trueを返します
+func (d *BotEventDelivery) RecordFailure(now time.Time, reason string) (dead bool) {
+	d.Attempts++
+	d.LastError = reason
+	if d.Attempts >= BotEventDeliveryMaxAttempts {
+		d.State = BotEventDeliveryDead
+		return true
+	}
+	d.State = BotEventDeliveryPending
+	d.NextAttemptAt = now.Add(BotEventDeliveryBackoff(d.Attempts))
+	return false
+}
+
 // BotEventType Botイベントタイプ
 type BotEventType string
 
@@ -0,0 +1,169 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// BotCommandArgumentType BOTのスラッシュコマンドの引数の型
+type BotCommandArgumentType string
+
+const (
+	// BotCommandArgumentTypeString 文字列
+	BotCommandArgumentTypeString BotCommandArgumentType = "string"
+	// BotCommandArgumentTypeInteger 整数
+	BotCommandArgumentTypeInteger BotCommandArgumentType = "integer"
+	// BotCommandArgumentTypeBoolean 真偽値
+	BotCommandArgumentTypeBoolean BotCommandArgumentType = "boolean"
+	// BotCommandArgumentTypeUser ユーザーのUUID
+	BotCommandArgumentTypeUser BotCommandArgumentType = "user"
+	// BotCommandArgumentTypeChannel チャンネルのUUID
+	BotCommandArgumentTypeChannel BotCommandArgumentType = "channel"
+)
+
+// Valid 有効な引数の型かどうかを返します
+func (t BotCommandArgumentType) Valid() bool {
+	switch t {
+	case BotCommandArgumentTypeString, BotCommandArgumentTypeInteger, BotCommandArgumentTypeBoolean, BotCommandArgumentTypeUser, BotCommandArgumentTypeChannel:
+		return true
+	default:
+		return false
+	}
+}
+
+// BotCommandArgument BOTのスラッシュコマンドの引数の定義
+type BotCommandArgument struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Type        BotCommandArgumentType `json:"type"`
+	Required    bool                   `json:"required"`
+}
+
+// validate 値がこの引数の型に合うかどうかを検証します
+func (a *BotCommandArgument) validate(v any) error {
+	ok := false
+	switch a.Type {
+	case BotCommandArgumentTypeString:
+		_, ok = v.(string)
+	case BotCommandArgumentTypeInteger:
+		f, isNumber := v.(float64)
+		ok = isNumber && f == math.Trunc(f)
+	case BotCommandArgumentTypeBoolean:
+		_, ok = v.(bool)
+	case BotCommandArgumentTypeUser, BotCommandArgumentTypeChannel:
+		s, isString := v.(string)
+		ok = isString && uuid.FromStringOrNil(s) != uuid.Nil
+	}
+	if !ok {
+		return fmt.Errorf("argument %s must be %s", a.Name, a.Type)
+	}
+	return nil
+}
+
+// BotCommandArguments BOTのスラッシュコマンドの引数の定義のリスト
+type BotCommandArguments []BotCommandArgument
+
+// Value database/sql/driver.Valuer 実装
+func (as BotCommandArguments) Value() (driver.Value, error) {
+	if as == nil {
+		return json.MarshalToString(BotCommandArguments{})
+	}
+	return json.MarshalToString(as)
+}
+
+// Scan database/sql.Scanner 実装
+func (as *BotCommandArguments) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*as = BotCommandArguments{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), as)
+	case []byte:
+		return json.Unmarshal(s, as)
+	default:
+		return errors.New("failed to scan BotCommandArguments")
+	}
+}
+
+// BotCommand BOTのスラッシュコマンドの構造体
+type BotCommand struct {
+	ID          uuid.UUID           `gorm:"type:char(36);not null;primaryKey"`
+	BotID       uuid.UUID           `gorm:"type:char(36);not null;uniqueIndex:idx_bot_commands_bot_id_name,priority:1"`
+	Name        string              `gorm:"type:varchar(32);not null;uniqueIndex:idx_bot_commands_bot_id_name,priority:2"`
+	Description string              `gorm:"type:varchar(200);not null;default:''"`
+	Arguments   BotCommandArguments `gorm:"type:text"`
+	// ChannelIDs コマンドを使用できるチャンネルのID (空の場合はBOTが参加している全てのチャンネル)
+	ChannelIDs UUIDs     `gorm:"type:text"`
+	CreatedAt  time.Time `gorm:"precision:6"`
+
+	Bot *Bot `gorm:"constraint:bot_commands_bot_id_bots_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName BotCommand構造体のテーブル名
+func (*BotCommand) TableName() string {
+	return "bot_commands"
+}
+
+// AvailableIn 指定したチャンネルでコマンドを使用できるかどうかを返します
+//
+// BOTがチャンネルに参加しているかどうかは考慮しません。
+func (c *BotCommand) AvailableIn(channelID uuid.UUID) bool {
+	return len(c.ChannelIDs) == 0 || slices.Contains(c.ChannelIDs, channelID)
+}
+
+// ValidateArguments コマンドの呼び出し引数を検証します
+//
+// 引数はJSONをデコードした値である必要があります。
+func (c *BotCommand) ValidateArguments(args map[string]any) error {
+	for name := range args {
+		if !slices.ContainsFunc(c.Arguments, func(a BotCommandArgument) bool { return a.Name == name }) {
+			return fmt.Errorf("unknown argument %s", name)
+		}
+	}
+	for _, a := range c.Arguments {
+		v, ok := args[a.Name]
+		if !ok || v == nil {
+			if a.Required {
+				return fmt.Errorf("argument %s is required", a.Name)
+			}
+			continue
+		}
+		if err := a.validate(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BotCommandInvocation BOTのスラッシュコマンドの呼び出しの構造体
+type BotCommandInvocation struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	CommandID uuid.UUID `gorm:"type:char(36);not null;index"`
+	BotID     uuid.UUID `gorm:"type:char(36);not null"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null"`
+	ChannelID uuid.UUID `gorm:"type:char(36);not null"`
+	CreatedAt time.Time `gorm:"precision:6;index"`
+
+	Command *BotCommand `gorm:"constraint:bot_command_invocations_command_id_bot_commands_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:CommandID"`
+	User    *User       `gorm:"constraint:bot_command_invocations_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName BotCommandInvocation構造体のテーブル名
+func (*BotCommandInvocation) TableName() string {
+	return "bot_command_invocations"
+}
+
+// BotCommandReplyTimeLimit BOTがスラッシュコマンドの呼び出しに返信できる期間
+const BotCommandReplyTimeLimit = 15 * time.Minute
+
+// IsExpired BOTが返信できる期間を過ぎているかどうかを返します
+func (i *BotCommandInvocation) IsExpired() bool {
+	return time.Since(i.CreatedAt) > BotCommandReplyTimeLimit
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBotCommand_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "bot_commands", (&BotCommand{}).TableName())
+}
+
+func TestBotCommandInvocation_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "bot_command_invocations", (&BotCommandInvocation{}).TableName())
+}
+
+func TestBotCommandArgumentType_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, BotCommandArgumentTypeString.Valid())
+	assert.True(t, BotCommandArgumentTypeChannel.Valid())
+	assert.False(t, BotCommandArgumentType("").Valid())
+	assert.False(t, BotCommandArgumentType("number").Valid())
+}
+
+func TestBotCommandArguments_Value(t *testing.T) {
+	t.Parallel()
+
+	v, err := BotCommandArguments(nil).Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "[]", v)
+	}
+
+	var as BotCommandArguments
+	if assert.NoError(t, as.Scan(`[{"name":"a","description":"","type":"string","required":true}]`)) {
+		assert.Equal(t, BotCommandArguments{{Name: "a", Type: BotCommandArgumentTypeString, Required: true}}, as)
+	}
+}
+
+func TestBotCommand_AvailableIn(t *testing.T) {
+	t.Parallel()
+
+	ch1 := uuid.Must(uuid.NewV7())
+	ch2 := uuid.Must(uuid.NewV7())
+
+	assert.True(t, (&BotCommand{}).AvailableIn(ch1))
+	assert.True(t, (&BotCommand{ChannelIDs: UUIDs{ch1}}).AvailableIn(ch1))
+	assert.False(t, (&BotCommand{ChannelIDs: UUIDs{ch1}}).AvailableIn(ch2))
+}
+
+func TestBotCommand_ValidateArguments(t *testing.T) {
+	t.Parallel()
+
+	c := &BotCommand{
+		Arguments: BotCommandArguments{
+			{Name: "text", Type: BotCommandArgumentTypeString, Required: true},
+			{Name: "count", Type: BotCommandArgumentTypeInteger},
+			{Name: "flag", Type: BotCommandArgumentTypeBoolean},
+			{Name: "user", Type: BotCommandArgumentTypeUser},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		args  map[string]any
+		valid bool
+	}{
+		{"ok", map[string]any{"text": "a", "count": float64(3), "flag": true, "user": uuid.Must(uuid.NewV7()).String()}, true},
+		{"ok (only required)", map[string]any{"text": "a"}, true},
+		{"missing required", map[string]any{"count": float64(3)}, false},
+		{"unknown argument", map[string]any{"text": "a", "foo": "bar"}, false},
+		{"not integer", map[string]any{"text": "a", "count": 1.5}, false},
+		{"not boolean", map[string]any{"text": "a", "flag": "true"}, false},
+		{"invalid uuid", map[string]any{"text": "a", "user": "foo"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := c.ValidateArguments(tt.args)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestBotCommandInvocation_IsExpired(t *testing.T) {
+	t.Parallel()
+	assert.False(t, (&BotCommandInvocation{CreatedAt: time.Now()}).IsExpired())
+	assert.True(t, (&BotCommandInvocation{CreatedAt: time.Now().Add(-BotCommandReplyTimeLimit - time.Second)}).IsExpired())
+}
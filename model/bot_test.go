@@ -3,6 +3,7 @@ package model
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -48,6 +49,43 @@ func TestBotEventLog_TableName(t *testing.T) {
 	assert.Equal(t, "bot_event_logs", (&BotEventLog{}).TableName())
 }
 
+func TestBotEventDelivery_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "bot_event_deliveries", (&BotEventDelivery{}).TableName())
+}
+
+func TestBotEventDeliveryBackoff(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, time.Duration(0), BotEventDeliveryBackoff(0))
+	assert.Equal(t, 10*time.Second, BotEventDeliveryBackoff(1))
+	assert.Equal(t, 20*time.Second, BotEventDeliveryBackoff(2))
+	assert.Equal(t, 80*time.Second, BotEventDeliveryBackoff(4))
+	assert.Equal(t, time.Hour, BotEventDeliveryBackoff(100))
+}
+
+func TestBotEventDelivery_RecordFailure(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pending", func(t *testing.T) {
+		t.Parallel()
+		now := time.Now()
+		d := &BotEventDelivery{State: BotEventDeliveryPending, Attempts: 1}
+		assert.False(t, d.RecordFailure(now, "ng"))
+		assert.Equal(t, BotEventDeliveryPending, d.State)
+		assert.Equal(t, 2, d.Attempts)
+		assert.Equal(t, "ng", d.LastError)
+		assert.Equal(t, now.Add(20*time.Second), d.NextAttemptAt)
+	})
+
+	t.Run("dead", func(t *testing.T) {
+		t.Parallel()
+		d := &BotEventDelivery{State: BotEventDeliveryPending, Attempts: BotEventDeliveryMaxAttempts - 1}
+		assert.True(t, d.RecordFailure(time.Now(), "ng"))
+		assert.Equal(t, BotEventDeliveryDead, d.State)
+		assert.Equal(t, BotEventDeliveryMaxAttempts, d.Attempts)
+	})
+}
+
 func TestBotEventType_String(t *testing.T) {
 	t.Parallel()
 	assert.Equal(t, "event", BotEventType("event").String())
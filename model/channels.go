@@ -7,6 +7,8 @@ import (
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 const (
@@ -31,6 +33,9 @@ type Channel struct {
 	UpdatedAt time.Time      `gorm:"precision:6"`
 	DeletedAt gorm.DeletedAt `gorm:"precision:6"`
 
+	// DisableChannelMentions チャンネル全体へのメンション(@here, @channel)を無効にするかどうか
+	DisableChannelMentions bool `gorm:"type:boolean;not null;default:false"`
+
 	ChildrenID []uuid.UUID `gorm:"-"`
 }
 
@@ -80,11 +85,19 @@ func (v ChannelSubscribeLevel) Int() int {
 }
 
 // UserSubscribeChannel ユーザー・通知チャンネル対構造体
+//
+// 購読していない(Mark, Notifyが共にfalse)場合でも、通知の上書き設定がされている場合はレコードが存在します
 type UserSubscribeChannel struct {
 	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
 	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
 	Mark      bool      `gorm:"type:boolean;not null;default:false"`
 	Notify    bool      `gorm:"type:boolean;not null;default:false"`
+	// NotifyMode 通知モード
+	NotifyMode ChannelNotifyMode `gorm:"type:varchar(20);not null;default:'default'"`
+	// MutedUntil この日時までプッシュ通知を送らない
+	MutedUntil optional.Of[time.Time] `gorm:"precision:6"`
+	// SuppressBot Botのメッセージではプッシュ通知を送らないかどうか
+	SuppressBot bool `gorm:"type:boolean;not null;default:false"`
 
 	User    User    `gorm:"constraint:users_subscribe_channels_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
 	Channel Channel `gorm:"constraint:users_subscribe_channels_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
@@ -107,6 +120,53 @@ func (usc *UserSubscribeChannel) GetLevel() ChannelSubscribeLevel {
 	}
 }
 
+// HasNotifyOverrides 通知の上書き設定がされているかどうかを返します
+func (usc *UserSubscribeChannel) HasNotifyOverrides() bool {
+	return (usc.NotifyMode != ChannelNotifyModeDefault && usc.NotifyMode != "") || usc.MutedUntil.Valid || usc.SuppressBot
+}
+
+// ShouldPush 通知の上書き設定に基づいて、日時nowに投稿されたメッセージのプッシュ通知を送るかどうかを返します
+//
+// mentionedはユーザーがメッセージでメンション(グループメンションを含む)されたかどうか、fromBotはメッセージの投稿者がBotかどうかです
+func (usc *UserSubscribeChannel) ShouldPush(now time.Time, mentioned, fromBot bool) bool {
+	if usc.MutedUntil.Valid && now.Before(usc.MutedUntil.V) {
+		return false
+	}
+	if usc.SuppressBot && fromBot {
+		return false
+	}
+	switch usc.NotifyMode {
+	case ChannelNotifyModeMute:
+		return false
+	case ChannelNotifyModeMentions:
+		return mentioned
+	default:
+		return true
+	}
+}
+
+// ChannelNotifyMode チャンネルの通知モード
+type ChannelNotifyMode string
+
+const (
+	// ChannelNotifyModeDefault 購読レベルやメンションに従って通知する
+	ChannelNotifyModeDefault ChannelNotifyMode = "default"
+	// ChannelNotifyModeMentions メンションされた場合のみ通知する
+	ChannelNotifyModeMentions ChannelNotifyMode = "mentions"
+	// ChannelNotifyModeMute メンションを含めて通知しない
+	ChannelNotifyModeMute ChannelNotifyMode = "mute"
+)
+
+// Valid 有効な値かどうか
+func (m ChannelNotifyMode) Valid() bool {
+	switch m {
+	case ChannelNotifyModeDefault, ChannelNotifyModeMentions, ChannelNotifyModeMute:
+		return true
+	default:
+		return false
+	}
+}
+
 // DMChannelMapping ダイレクトメッセージチャンネルとユーザーのマッピング
 type DMChannelMapping struct {
 	ChannelID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
@@ -2,9 +2,12 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func TestChannel_TableName(t *testing.T) {
@@ -29,6 +32,54 @@ func TestUserSubscribeChannel_TableName(t *testing.T) {
 	assert.Equal(t, "users_subscribe_channels", (&UserSubscribeChannel{}).TableName())
 }
 
+func TestUserSubscribeChannel_HasNotifyOverrides(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&UserSubscribeChannel{}).HasNotifyOverrides())
+	assert.False(t, (&UserSubscribeChannel{Mark: true, Notify: true, NotifyMode: ChannelNotifyModeDefault}).HasNotifyOverrides())
+	assert.True(t, (&UserSubscribeChannel{NotifyMode: ChannelNotifyModeMentions}).HasNotifyOverrides())
+	assert.True(t, (&UserSubscribeChannel{MutedUntil: optional.From(time.Now())}).HasNotifyOverrides())
+	assert.True(t, (&UserSubscribeChannel{SuppressBot: true}).HasNotifyOverrides())
+}
+
+func TestUserSubscribeChannel_ShouldPush(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name      string
+		usc       UserSubscribeChannel
+		mentioned bool
+		fromBot   bool
+		want      bool
+	}{
+		{"default", UserSubscribeChannel{NotifyMode: ChannelNotifyModeDefault}, false, false, true},
+		{"mentions (not mentioned)", UserSubscribeChannel{NotifyMode: ChannelNotifyModeMentions}, false, false, false},
+		{"mentions (mentioned)", UserSubscribeChannel{NotifyMode: ChannelNotifyModeMentions}, true, false, true},
+		{"mute", UserSubscribeChannel{NotifyMode: ChannelNotifyModeMute}, true, false, false},
+		{"muted until future", UserSubscribeChannel{MutedUntil: optional.From(now.Add(time.Hour))}, true, false, false},
+		{"muted until past", UserSubscribeChannel{MutedUntil: optional.From(now.Add(-time.Hour))}, false, false, true},
+		{"suppress bot", UserSubscribeChannel{SuppressBot: true}, true, true, false},
+		{"suppress bot (not bot)", UserSubscribeChannel{SuppressBot: true}, false, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.usc.ShouldPush(now, tt.mentioned, tt.fromBot))
+		})
+	}
+}
+
+func TestChannelNotifyMode_Valid(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ChannelNotifyModeDefault.Valid())
+	assert.True(t, ChannelNotifyModeMentions.Valid())
+	assert.True(t, ChannelNotifyModeMute.Valid())
+	assert.False(t, ChannelNotifyMode("").Valid())
+	assert.False(t, ChannelNotifyMode("all").Valid())
+}
+
 func TestDMChannelMapping_TableName(t *testing.T) {
 	t.Parallel()
 
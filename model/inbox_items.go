@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// InboxItemType 通知受信箱のアイテムの種類
+type InboxItemType string
+
+const (
+	// InboxItemTypeMention ユーザーへのメンション
+	InboxItemTypeMention InboxItemType = "mention"
+	// InboxItemTypeGroupMention 所属するユーザーグループへのメンション
+	InboxItemTypeGroupMention InboxItemType = "group_mention"
+	// InboxItemTypeCitation 自分のメッセージの引用
+	InboxItemTypeCitation InboxItemType = "citation"
+	// InboxItemTypeStamp 自分のメッセージへのスタンプ
+	InboxItemTypeStamp InboxItemType = "stamp"
+	// InboxItemTypeDM DMの受信
+	InboxItemTypeDM InboxItemType = "dm"
+	// InboxItemTypeReminder 自分で設定したメッセージのリマインダー
+	InboxItemTypeReminder InboxItemType = "reminder"
+)
+
+// InboxItem 通知受信箱のアイテムの構造体
+type InboxItem struct {
+	ID     uuid.UUID     `gorm:"type:char(36);not null;primaryKey"`
+	UserID uuid.UUID     `gorm:"type:char(36);not null;index:idx_inbox_items_user_id_is_read,priority:1"`
+	Type   InboxItemType `gorm:"type:varchar(30);not null"`
+	// MessageID 通知の対象のメッセージのID
+	MessageID uuid.UUID `gorm:"type:char(36);not null;index"`
+	// ChannelID 通知の対象のメッセージのチャンネルのID
+	ChannelID uuid.UUID `gorm:"type:char(36);not null"`
+	// ActorID 通知の原因となったユーザー(メッセージの投稿者・スタンプを押したユーザー・リマインダーを設定したユーザー)のID
+	ActorID uuid.UUID `gorm:"type:char(36);not null"`
+	// StampID 押されたスタンプのID (InboxItemTypeStampの場合のみ)
+	StampID   optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	IsRead    bool                   `gorm:"type:boolean;not null;default:false;index:idx_inbox_items_user_id_is_read,priority:2"`
+	CreatedAt time.Time              `gorm:"precision:6"`
+
+	User    *User    `gorm:"constraint:inbox_items_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Message *Message `gorm:"constraint:inbox_items_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName InboxItem構造体のテーブル名
+func (*InboxItem) TableName() string {
+	return "inbox_items"
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboxItem_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "inbox_items", (&InboxItem{}).TableName())
+}
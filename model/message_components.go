@@ -0,0 +1,103 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MessageComponentType メッセージコンポーネントの種類
+type MessageComponentType string
+
+const (
+	// MessageComponentTypeButton ボタン
+	MessageComponentTypeButton MessageComponentType = "button"
+	// MessageComponentTypeSelect セレクトメニュー
+	MessageComponentTypeSelect MessageComponentType = "select"
+)
+
+// Valid 有効なコンポーネントの種類かどうかを返します
+func (t MessageComponentType) Valid() bool {
+	return t == MessageComponentTypeButton || t == MessageComponentTypeSelect
+}
+
+// MessageComponentStyle ボタンの見た目
+type MessageComponentStyle string
+
+const (
+	// MessageComponentStyleDefault 通常
+	MessageComponentStyleDefault MessageComponentStyle = "default"
+	// MessageComponentStylePrimary 強調
+	MessageComponentStylePrimary MessageComponentStyle = "primary"
+	// MessageComponentStyleDanger 危険
+	MessageComponentStyleDanger MessageComponentStyle = "danger"
+)
+
+// Valid 有効なボタンの見た目かどうかを返します
+func (s MessageComponentStyle) Valid() bool {
+	return s == MessageComponentStyleDefault || s == MessageComponentStylePrimary || s == MessageComponentStyleDanger
+}
+
+// MessageComponentOption セレクトメニューの選択肢
+type MessageComponentOption struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// MessageComponentOptions セレクトメニューの選択肢のリスト
+type MessageComponentOptions []MessageComponentOption
+
+// Value database/sql/driver.Valuer 実装
+func (os MessageComponentOptions) Value() (driver.Value, error) {
+	if os == nil {
+		return json.MarshalToString(MessageComponentOptions{})
+	}
+	return json.MarshalToString(os)
+}
+
+// Scan database/sql.Scanner 実装
+func (os *MessageComponentOptions) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*os = MessageComponentOptions{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), os)
+	case []byte:
+		return json.Unmarshal(s, os)
+	default:
+		return errors.New("failed to scan MessageComponentOptions")
+	}
+}
+
+// MessageComponent BOTがメッセージに添付するインタラクティブなコンポーネントの構造体
+type MessageComponent struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey" json:"id"`
+	MessageID uuid.UUID `gorm:"type:char(36);not null;uniqueIndex:idx_message_components_message_id_custom_id,priority:1" json:"-"`
+	// CustomID BOTが指定するコンポーネントの識別子 (メッセージ内で一意)
+	CustomID  string                  `gorm:"type:varchar(100);not null;uniqueIndex:idx_message_components_message_id_custom_id,priority:2" json:"customId"`
+	Type      MessageComponentType    `gorm:"type:varchar(10);not null" json:"type"`
+	Label     string                  `gorm:"type:varchar(80);not null;default:''" json:"label"`
+	Style     MessageComponentStyle   `gorm:"type:varchar(10);not null;default:'default'" json:"style"`
+	Options   MessageComponentOptions `gorm:"type:text" json:"options"`
+	Disabled  bool                    `gorm:"type:boolean;not null;default:false" json:"disabled"`
+	Position  int                     `gorm:"type:int;not null" json:"-"`
+	UpdatedAt time.Time               `gorm:"precision:6" json:"updatedAt"`
+}
+
+// TableName MessageComponent構造体のテーブル名
+func (*MessageComponent) TableName() string {
+	return "message_components"
+}
+
+// HasOption 指定した値の選択肢がセレクトメニューに含まれるかどうかを返します
+func (c *MessageComponent) HasOption(value string) bool {
+	for _, o := range c.Options {
+		if o.Value == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageComponent_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "message_components", (&MessageComponent{}).TableName())
+}
+
+func TestMessageComponentType_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, MessageComponentTypeButton.Valid())
+	assert.True(t, MessageComponentTypeSelect.Valid())
+	assert.False(t, MessageComponentType("").Valid())
+	assert.False(t, MessageComponentType("checkbox").Valid())
+}
+
+func TestMessageComponentStyle_Valid(t *testing.T) {
+	t.Parallel()
+	assert.True(t, MessageComponentStyleDefault.Valid())
+	assert.True(t, MessageComponentStyleDanger.Valid())
+	assert.False(t, MessageComponentStyle("").Valid())
+}
+
+func TestMessageComponent_HasOption(t *testing.T) {
+	t.Parallel()
+
+	c := &MessageComponent{
+		Type:    MessageComponentTypeSelect,
+		Options: MessageComponentOptions{{Label: "A", Value: "a"}, {Label: "B", Value: "b"}},
+	}
+	assert.True(t, c.HasOption("a"))
+	assert.False(t, c.HasOption("c"))
+	assert.False(t, (&MessageComponent{}).HasOption(""))
+}
+
+func TestMessageComponentOptions_Value(t *testing.T) {
+	t.Parallel()
+
+	v, err := MessageComponentOptions(nil).Value()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "[]", v)
+	}
+
+	var os MessageComponentOptions
+	if assert.NoError(t, os.Scan([]byte(`[{"label":"A","value":"a"}]`))) {
+		assert.Equal(t, MessageComponentOptions{{Label: "A", Value: "a"}}, os)
+	}
+}
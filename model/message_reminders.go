@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// MessageReminderState メッセージのリマインダーの状態
+type MessageReminderState string
+
+const (
+	// MessageReminderPending 通知待ち
+	MessageReminderPending MessageReminderState = "pending"
+	// MessageReminderDelivering 通知処理中
+	MessageReminderDelivering MessageReminderState = "delivering"
+)
+
+// MessageReminderMaxAttempts メッセージのリマインダーの最大通知試行回数
+const MessageReminderMaxAttempts = 5
+
+// MessageReminder メッセージのリマインダーの構造体
+type MessageReminder struct {
+	ID        uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID              `gorm:"type:char(36);not null;index"`
+	MessageID uuid.UUID              `gorm:"type:char(36);not null"`
+	RemindAt  time.Time              `gorm:"precision:6;index"`
+	State     MessageReminderState   `gorm:"type:varchar(10);not null;default:'pending'"`
+	Attempts  int                    `gorm:"type:int;not null;default:0"`
+	ClaimedAt optional.Of[time.Time] `gorm:"precision:6"`
+	CreatedAt time.Time              `gorm:"precision:6"`
+
+	User    *User    `gorm:"constraint:message_reminders_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Message *Message `gorm:"constraint:message_reminders_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName MessageReminder構造体のテーブル名
+func (*MessageReminder) TableName() string {
+	return "message_reminders"
+}
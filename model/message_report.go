@@ -5,16 +5,48 @@ import (
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// MessageReportState メッセージレポートの対応状態
+type MessageReportState string
+
+const (
+	// MessageReportStateOpen 未対応
+	MessageReportStateOpen MessageReportState = "open"
+	// MessageReportStateResolved 対応済み
+	MessageReportStateResolved MessageReportState = "resolved"
+	// MessageReportStateDismissed 却下
+	MessageReportStateDismissed MessageReportState = "dismissed"
 )
 
+func (s MessageReportState) String() string {
+	return string(s)
+}
+
+// Valid 有効な値かどうか
+func (s MessageReportState) Valid() bool {
+	switch s {
+	case MessageReportStateOpen, MessageReportStateResolved, MessageReportStateDismissed:
+		return true
+	default:
+		return false
+	}
+}
+
 // MessageReport メッセージレポート構造体
 type MessageReport struct {
-	ID        uuid.UUID      `gorm:"type:char(36);not null;primaryKey"                   json:"id"`
-	MessageID uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"messageId"`
-	Reporter  uuid.UUID      `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"reporter"`
-	Reason    string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"                json:"reason"`
-	CreatedAt time.Time      `gorm:"precision:6;index"                                    json:"createdAt"`
-	DeletedAt gorm.DeletedAt `gorm:"precision:6"                                          json:"-"`
+	ID         uuid.UUID              `gorm:"type:char(36);not null;primaryKey"                   json:"id"`
+	MessageID  uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"messageId"`
+	Reporter   uuid.UUID              `gorm:"type:char(36);not null;uniqueIndex:message_reporter" json:"reporter"`
+	Reason     string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"                json:"reason"`
+	State      MessageReportState     `gorm:"type:varchar(30);not null;default:'open';index"       json:"state"`
+	ResolverID optional.Of[uuid.UUID] `gorm:"type:char(36)"                                        json:"resolverId"`
+	Note       string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"                json:"note"`
+	ResolvedAt optional.Of[time.Time] `gorm:"precision:6"                                          json:"resolvedAt"`
+	CreatedAt  time.Time              `gorm:"precision:6;index"                                    json:"createdAt"`
+	DeletedAt  gorm.DeletedAt         `gorm:"precision:6"                                          json:"-"`
 }
 
 // TableName MessageReport構造体のテーブル名
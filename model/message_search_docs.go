@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// MessageSearchDoc MariaDBの全文検索用テーブルに入るメッセージの情報
+//
+// messagesテーブルから再構築可能な検索用の非正規化データです。
+type MessageSearchDoc struct {
+	MessageID      uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID         uuid.UUID `gorm:"type:char(36);not null;index"`
+	ChannelID      uuid.UUID `gorm:"type:char(36);not null;index"`
+	IsPublic       bool      `gorm:"type:boolean;not null;default:false"`
+	Bot            bool      `gorm:"type:boolean;not null;default:false"`
+	Ngram          string    `gorm:"type:LONGTEXT;not null;index:idx_message_search_docs_ngram,class:FULLTEXT"`
+	To             string    `gorm:"type:TEXT;not null"`
+	Citation       string    `gorm:"type:TEXT;not null"`
+	HasURL         bool      `gorm:"type:boolean;not null;default:false"`
+	HasAttachments bool      `gorm:"type:boolean;not null;default:false"`
+	HasImage       bool      `gorm:"type:boolean;not null;default:false"`
+	HasVideo       bool      `gorm:"type:boolean;not null;default:false"`
+	HasAudio       bool      `gorm:"type:boolean;not null;default:false"`
+	CreatedAt      time.Time `gorm:"precision:6;index;autoCreateTime:false"`
+	UpdatedAt      time.Time `gorm:"precision:6;index;autoUpdateTime:false"`
+}
+
+// TableName MessageSearchDoc構造体のテーブル名
+func (*MessageSearchDoc) TableName() string {
+	return "message_search_docs"
+}
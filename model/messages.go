@@ -5,22 +5,29 @@ import (
 
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // Message データベースに格納するmessageの構造体
 type Message struct {
-	ID        uuid.UUID      `gorm:"type:char(36);not null;primaryKey"`
-	UserID    uuid.UUID      `gorm:"type:char(36);not null;"`
-	ChannelID uuid.UUID      `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
-	Text      string         `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
-	CreatedAt time.Time      `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2"`
-	UpdatedAt time.Time      `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
-	DeletedAt gorm.DeletedAt `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1"`
-
-	User    *User          `gorm:"constraint:messages_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Channel *Channel       `gorm:"constraint:messages_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Stamps  []MessageStamp `gorm:"constraint:messages_stamps_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignkey:MessageID"`
-	Pin     *Pin           `gorm:"constraint:pins_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID              uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID          uuid.UUID              `gorm:"type:char(36);not null;"`
+	ChannelID       uuid.UUID              `gorm:"type:char(36);not null;index:idx_messages_channel_id_deleted_at_created_at,priority:1"`
+	Text            string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	CreatedAt       time.Time              `gorm:"precision:6;index;index:idx_messages_channel_id_deleted_at_created_at,priority:3;index:idx_messages_deleted_at_created_at,priority:2;index:idx_messages_parent_message_id_created_at,priority:2"`
+	UpdatedAt       time.Time              `gorm:"precision:6;index:idx_messages_deleted_at_updated_at,priority:2"`
+	DeletedAt       gorm.DeletedAt         `gorm:"precision:6;index:idx_messages_channel_id_deleted_at_created_at,priority:2;index:idx_messages_deleted_at_created_at,priority:1;index:idx_messages_deleted_at_updated_at,priority:1"`
+	ParentMessageID optional.Of[uuid.UUID] `gorm:"type:char(36);index:idx_messages_parent_message_id_created_at,priority:1"`
+	ReplyCount      int                    `gorm:"type:int;not null;default:0"`
+	EditCount       int                    `gorm:"type:int;not null;default:0"`
+
+	User       *User              `gorm:"constraint:messages_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Channel    *Channel           `gorm:"constraint:messages_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Stamps     []MessageStamp     `gorm:"constraint:messages_stamps_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignkey:MessageID"`
+	Pin        *Pin               `gorm:"constraint:pins_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Poll       *Poll              `gorm:"constraint:polls_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Components []MessageComponent `gorm:"constraint:message_components_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:MessageID"`
 }
 
 // TableName DBの名前を指定するメソッド
@@ -71,3 +78,18 @@ type ArchivedMessage struct {
 func (am *ArchivedMessage) TableName() string {
 	return "archived_messages"
 }
+
+// MessageThreadSubscription スレッド購読の構造体
+type MessageThreadSubscription struct {
+	MessageID uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey;index"`
+	CreatedAt time.Time `gorm:"precision:6"`
+
+	Message Message `gorm:"constraint:message_thread_subscriptions_message_id_messages_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	User    User    `gorm:"constraint:message_thread_subscriptions_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName MessageThreadSubscription構造体のテーブル名
+func (*MessageThreadSubscription) TableName() string {
+	return "message_thread_subscriptions"
+}
@@ -25,3 +25,8 @@ func TestArchivedMessage_TableName(t *testing.T) {
 	t.Parallel()
 	assert.Equal(t, "archived_messages", (&ArchivedMessage{}).TableName())
 }
+
+func TestMessageThreadSubscription_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "message_thread_subscriptions", (&MessageThreadSubscription{}).TableName())
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// Poll メッセージに添付された投票の構造体
+type Poll struct {
+	ID             uuid.UUID              `gorm:"type:char(36);not null;primaryKey" json:"id"`
+	MessageID      uuid.UUID              `gorm:"type:char(36);not null;unique" json:"-"`
+	CreatorID      uuid.UUID              `gorm:"type:char(36);not null" json:"creatorId"`
+	MultipleChoice bool                   `gorm:"type:boolean;not null;default:false" json:"multipleChoice"`
+	Anonymous      bool                   `gorm:"type:boolean;not null;default:false" json:"anonymous"`
+	Deadline       optional.Of[time.Time] `gorm:"precision:6;index" json:"deadline"`
+	ClosedAt       optional.Of[time.Time] `gorm:"precision:6" json:"closedAt"`
+	VoterCount     int                    `gorm:"type:int;not null;default:0" json:"voterCount"`
+	CreatedAt      time.Time              `gorm:"precision:6" json:"createdAt"`
+
+	Options []PollOption `gorm:"constraint:poll_options_poll_id_polls_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:PollID" json:"options"`
+	Creator *User        `gorm:"constraint:polls_creator_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// TableName Poll構造体のテーブル名
+func (*Poll) TableName() string {
+	return "polls"
+}
+
+// IsClosed 日時tに投票が締め切られているかどうかを返します
+func (p *Poll) IsClosed(t time.Time) bool {
+	return p.ClosedAt.Valid || (p.Deadline.Valid && !t.Before(p.Deadline.V))
+}
+
+// HasOption 指定した選択肢が投票に含まれるかどうかを返します
+func (p *Poll) HasOption(optionID uuid.UUID) bool {
+	for _, o := range p.Options {
+		if o.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
+
+// PollOption 投票の選択肢の構造体
+type PollOption struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey" json:"id"`
+	PollID    uuid.UUID `gorm:"type:char(36);not null;index" json:"-"`
+	Content   string    `gorm:"type:varchar(100);not null" json:"content"`
+	Position  int       `gorm:"type:int;not null" json:"-"`
+	VoteCount int       `gorm:"type:int;not null;default:0" json:"voteCount"`
+}
+
+// TableName PollOption構造体のテーブル名
+func (*PollOption) TableName() string {
+	return "poll_options"
+}
+
+// PollVote 投票の票の構造体
+type PollVote struct {
+	PollID    uuid.UUID `gorm:"type:char(36);not null;index:idx_poll_votes_poll_id_user_id,priority:1" json:"-"`
+	OptionID  uuid.UUID `gorm:"type:char(36);not null;primaryKey" json:"optionId"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;primaryKey;index:idx_poll_votes_poll_id_user_id,priority:2" json:"userId"`
+	CreatedAt time.Time `gorm:"precision:6" json:"createdAt"`
+
+	Poll   *Poll       `gorm:"constraint:poll_votes_poll_id_polls_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Option *PollOption `gorm:"constraint:poll_votes_option_id_poll_options_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE;foreignKey:OptionID" json:"-"`
+	User   *User       `gorm:"constraint:poll_votes_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+// TableName PollVote構造体のテーブル名
+func (*PollVote) TableName() string {
+	return "poll_votes"
+}
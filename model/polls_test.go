@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestPoll_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "polls", (&Poll{}).TableName())
+}
+
+func TestPoll_IsClosed(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	assert.False(t, (&Poll{}).IsClosed(now))
+	assert.False(t, (&Poll{Deadline: optional.From(now.Add(time.Minute))}).IsClosed(now))
+	assert.True(t, (&Poll{Deadline: optional.From(now)}).IsClosed(now))
+	assert.True(t, (&Poll{ClosedAt: optional.From(now.Add(-time.Minute))}).IsClosed(now))
+}
+
+func TestPoll_HasOption(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.Must(uuid.NewV4())
+	p := &Poll{Options: []PollOption{{ID: id}}}
+	assert.True(t, p.HasOption(id))
+	assert.False(t, p.HasOption(uuid.Must(uuid.NewV4())))
+}
+
+func TestPollOption_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "poll_options", (&PollOption{}).TableName())
+}
+
+func TestPollVote_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "poll_votes", (&PollVote{}).TableName())
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// SavedSearch 保存された検索の構造体
+type SavedSearch struct {
+	ID        uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Query     string    `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	Notify    bool      `gorm:"type:boolean;not null;default:false;index"`
+	CreatedAt time.Time `gorm:"precision:6"`
+	UpdatedAt time.Time `gorm:"precision:6"`
+
+	User *User `gorm:"constraint:saved_searches_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName SavedSearch構造体のテーブル名
+func (*SavedSearch) TableName() string {
+	return "saved_searches"
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// ScheduledMessageState 予約投稿メッセージの状態
+type ScheduledMessageState string
+
+const (
+	// ScheduledMessagePending 投稿待ち
+	ScheduledMessagePending ScheduledMessageState = "pending"
+	// ScheduledMessagePosting 投稿処理中
+	ScheduledMessagePosting ScheduledMessageState = "posting"
+	// ScheduledMessageFailed 投稿回数の上限に達し、投稿を諦めた
+	ScheduledMessageFailed ScheduledMessageState = "failed"
+)
+
+// ScheduledMessageMaxAttempts 予約投稿メッセージの最大投稿試行回数
+const ScheduledMessageMaxAttempts = 5
+
+// ScheduledMessage 予約投稿メッセージの構造体
+type ScheduledMessage struct {
+	ID          uuid.UUID              `gorm:"type:char(36);not null;primaryKey"`
+	UserID      uuid.UUID              `gorm:"type:char(36);not null;index"`
+	ChannelID   uuid.UUID              `gorm:"type:char(36);not null"`
+	DMUserID    optional.Of[uuid.UUID] `gorm:"type:char(36)"`
+	Text        string                 `gorm:"type:TEXT COLLATE utf8mb4_bin NOT NULL"`
+	ScheduledAt time.Time              `gorm:"precision:6;index"`
+	State       ScheduledMessageState  `gorm:"type:varchar(10);not null;default:'pending'"`
+	Attempts    int                    `gorm:"type:int;not null;default:0"`
+	ClaimedAt   optional.Of[time.Time] `gorm:"precision:6"`
+	CreatedAt   time.Time              `gorm:"precision:6"`
+	UpdatedAt   time.Time              `gorm:"precision:6"`
+
+	User    *User    `gorm:"constraint:scheduled_messages_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Channel *Channel `gorm:"constraint:scheduled_messages_channel_id_channels_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName ScheduledMessage構造体のテーブル名
+func (*ScheduledMessage) TableName() string {
+	return "scheduled_messages"
+}
+
+// IsDM ダイレクトメッセージとして予約されているかどうか
+func (m *ScheduledMessage) IsDM() bool {
+	return m.DMUserID.Valid
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestScheduledMessage_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "scheduled_messages", (&ScheduledMessage{}).TableName())
+}
+
+func TestScheduledMessage_IsDM(t *testing.T) {
+	t.Parallel()
+	assert.False(t, (&ScheduledMessage{}).IsDM())
+	assert.True(t, (&ScheduledMessage{DMUserID: optional.From(uuid.Must(uuid.NewV4()))}).IsDM())
+}
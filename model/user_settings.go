@@ -1,11 +1,43 @@
 package model
 
-import "github.com/gofrs/uuid"
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
 
 // UserSettings ユーザー設定の構造体
 type UserSettings struct {
-	UserID         uuid.UUID `gorm:"type:char(36);not null;primaryKey;" json:"id"`
-	NotifyCitation bool      `gorm:"type:boolean" json:"notifyCitation"`
+	UserID         uuid.UUID      `gorm:"type:char(36);not null;primaryKey;" json:"id"`
+	NotifyCitation bool           `gorm:"type:boolean" json:"notifyCitation"`
+	NotifyKeywords NotifyKeywords `gorm:"type:text" json:"notifyKeywords"`
+	// DNDSchedules おやすみモード(プッシュ通知を送らない時間帯)
+	DNDSchedules DNDSchedules `gorm:"type:text" json:"dndSchedules"`
+	// DNDAllowForced おやすみモード中も強制通知チャンネルの通知を送るかどうか
+	DNDAllowForced bool `gorm:"type:boolean;not null;default:false" json:"dndAllowForced"`
+	// DNDAllowMentions おやすみモード中もユーザーへの直接のメンションと通知キーワードに一致したメッセージの通知を送るかどうか
+	DNDAllowMentions bool `gorm:"type:boolean;not null;default:false" json:"dndAllowMentions"`
+	// SnoozeUntil この日時までプッシュ通知を送らない
+	SnoozeUntil optional.Of[time.Time] `gorm:"precision:6" json:"snoozeUntil"`
+	// DigestEmail 未読メンションのメールダイジェストの送信先メールアドレス
+	DigestEmail string `gorm:"type:varchar(254);not null;default:''" json:"digestEmail"`
+	// DigestEmailVerified DigestEmailが確認済みかどうか
+	DigestEmailVerified bool `gorm:"type:boolean;not null;default:false" json:"digestEmailVerified"`
+	// DigestVerificationCode DigestEmailの確認コード
+	DigestVerificationCode string `gorm:"type:varchar(16);not null;default:''" json:"-"`
+	// DigestFrequency メールダイジェストの送信頻度
+	DigestFrequency DigestFrequency `gorm:"type:varchar(10);not null;default:'off'" json:"digestFrequency"`
+	// DigestCheckedAt 最後にメールダイジェストの送信を確認した日時
+	DigestCheckedAt optional.Of[time.Time] `gorm:"precision:6" json:"-"`
 
 	User *User `gorm:"constraint:user_settings_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
 }
@@ -19,3 +51,224 @@ func (us *UserSettings) TableName() string {
 func (us *UserSettings) IsNotifyCitationEnabled() bool {
 	return us.NotifyCitation
 }
+
+// IsDoNotDisturb 日時tにプッシュ通知を送らない状態(スヌーズ中またはおやすみモードの時間帯)かどうかを返します
+func (us *UserSettings) IsDoNotDisturb(t time.Time) bool {
+	if us.SnoozeUntil.Valid && t.Before(us.SnoozeUntil.V) {
+		return true
+	}
+	return us.DNDSchedules.Contains(t)
+}
+
+// IsDigestEnabled メールダイジェストを送信するかどうかを返します
+func (us *UserSettings) IsDigestEnabled() bool {
+	return us.DigestFrequency.Interval() > 0 && us.DigestEmailVerified && len(us.DigestEmail) > 0
+}
+
+// DigestFrequency メールダイジェストの送信頻度
+type DigestFrequency string
+
+const (
+	// DigestFrequencyOff 送信しない
+	DigestFrequencyOff DigestFrequency = "off"
+	// DigestFrequencyDaily 1日ごと
+	DigestFrequencyDaily DigestFrequency = "daily"
+	// DigestFrequencyWeekly 1週間ごと
+	DigestFrequencyWeekly DigestFrequency = "weekly"
+)
+
+// Valid 有効な値かどうか
+func (f DigestFrequency) Valid() bool {
+	switch f {
+	case DigestFrequencyOff, DigestFrequencyDaily, DigestFrequencyWeekly:
+		return true
+	default:
+		return false
+	}
+}
+
+// Interval 送信間隔を返します 送信しない場合は0を返します
+func (f DigestFrequency) Interval() time.Duration {
+	switch f {
+	case DigestFrequencyDaily:
+		return 24 * time.Hour
+	case DigestFrequencyWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// NotifyKeyword 通知キーワード
+type NotifyKeyword struct {
+	// Keyword キーワード
+	Keyword string `json:"keyword"`
+	// WholeWord 単語単位で一致した場合のみ通知するかどうか
+	WholeWord bool `json:"wholeWord"`
+	// CaseSensitive 大文字小文字を区別するかどうか
+	CaseSensitive bool `json:"caseSensitive"`
+}
+
+// Match textがキーワードを含むかどうかを返します
+//
+// WholeWordがtrueの場合、キーワードの前後が文字・数字・アンダースコアでない場合のみ一致とします
+func (k NotifyKeyword) Match(text string) bool {
+	keyword := k.Keyword
+	if len(keyword) == 0 {
+		return false
+	}
+	if !k.CaseSensitive {
+		text = strings.ToLower(text)
+		keyword = strings.ToLower(keyword)
+	}
+	if !k.WholeWord {
+		return strings.Contains(text, keyword)
+	}
+
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], keyword)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(keyword)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !isWordRune(before) && !isWordRune(after) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+	return false
+}
+
+func isWordRune(r rune) bool {
+	if r == utf8.RuneError {
+		return false
+	}
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// NotifyKeywords 通知キーワードのリスト
+type NotifyKeywords []NotifyKeyword
+
+// Match textがいずれかのキーワードを含むかどうかを返します
+func (ks NotifyKeywords) Match(text string) bool {
+	for _, k := range ks {
+		if k.Match(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value database/sql/driver.Valuer 実装
+func (ks NotifyKeywords) Value() (driver.Value, error) {
+	if ks == nil {
+		return json.MarshalToString(NotifyKeywords{})
+	}
+	return json.MarshalToString(ks)
+}
+
+// Scan database/sql.Scanner 実装
+func (ks *NotifyKeywords) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*ks = NotifyKeywords{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), ks)
+	case []byte:
+		return json.Unmarshal(s, ks)
+	default:
+		return errors.New("failed to scan NotifyKeywords")
+	}
+}
+
+// DNDSchedule おやすみモードの時間帯
+//
+// StartがEndより後の場合は日をまたぐ時間帯とし、Weekdaysは開始時刻の曜日として扱います
+type DNDSchedule struct {
+	// Weekdays 有効な曜日 (0: 日曜日 ~ 6: 土曜日)
+	Weekdays []time.Weekday `json:"weekdays"`
+	// Start 開始時刻 (HH:MM)
+	Start string `json:"start"`
+	// End 終了時刻 (HH:MM)
+	End string `json:"end"`
+	// Timezone タイムゾーン (IANA Time Zone database名)
+	Timezone string `json:"timezone"`
+}
+
+// ParseDNDClock HH:MM形式の時刻を0時からの経過分に変換します
+func ParseDNDClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock: %s", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Contains 日時tが時間帯に含まれるかどうかを返します
+//
+// 時刻やタイムゾーンが不正な場合はfalseを返します
+func (s DNDSchedule) Contains(t time.Time) bool {
+	start, err := ParseDNDClock(s.Start)
+	if err != nil {
+		return false
+	}
+	end, err := ParseDNDClock(s.End)
+	if err != nil {
+		return false
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return false
+	}
+
+	lt := t.In(loc)
+	now := lt.Hour()*60 + lt.Minute()
+	today := slices.Contains(s.Weekdays, lt.Weekday())
+	if start <= end {
+		return today && start <= now && now < end
+	}
+	// 日をまたぐ場合
+	yesterday := slices.Contains(s.Weekdays, (lt.Weekday()+6)%7)
+	return (today && start <= now) || (yesterday && now < end)
+}
+
+// DNDSchedules おやすみモードの時間帯のリスト
+type DNDSchedules []DNDSchedule
+
+// Contains 日時tがいずれかの時間帯に含まれるかどうかを返します
+func (ss DNDSchedules) Contains(t time.Time) bool {
+	for _, s := range ss {
+		if s.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value database/sql/driver.Valuer 実装
+func (ss DNDSchedules) Value() (driver.Value, error) {
+	if ss == nil {
+		return json.MarshalToString(DNDSchedules{})
+	}
+	return json.MarshalToString(ss)
+}
+
+// Scan database/sql.Scanner 実装
+func (ss *DNDSchedules) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		*ss = DNDSchedules{}
+		return nil
+	case string:
+		return json.Unmarshal([]byte(s), ss)
+	case []byte:
+		return json.Unmarshal(s, ss)
+	default:
+		return errors.New("failed to scan DNDSchedules")
+	}
+}
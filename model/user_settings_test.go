@@ -0,0 +1,181 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestUserSettings_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "user_settings", (&UserSettings{}).TableName())
+}
+
+func TestNotifyKeyword_Match(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		keyword NotifyKeyword
+		text    string
+		want    bool
+	}{
+		{"empty keyword", NotifyKeyword{Keyword: ""}, "traQ", false},
+		{"contains", NotifyKeyword{Keyword: "traq"}, "I love traQ!", true},
+		{"not contains", NotifyKeyword{Keyword: "traq"}, "I love trap!", false},
+		{"case sensitive", NotifyKeyword{Keyword: "traq", CaseSensitive: true}, "I love traQ!", false},
+		{"case sensitive match", NotifyKeyword{Keyword: "traQ", CaseSensitive: true}, "I love traQ!", true},
+		{"partial", NotifyKeyword{Keyword: "go"}, "golang", true},
+		{"whole word partial", NotifyKeyword{Keyword: "go", WholeWord: true}, "golang", false},
+		{"whole word", NotifyKeyword{Keyword: "go", WholeWord: true}, "let's go!", true},
+		{"whole word second occurrence", NotifyKeyword{Keyword: "go", WholeWord: true}, "golang go", true},
+		{"whole word underscore", NotifyKeyword{Keyword: "go", WholeWord: true}, "go_lang", false},
+		{"whole word multibyte", NotifyKeyword{Keyword: "通知", WholeWord: true}, "「通知」", true},
+		{"whole word multibyte partial", NotifyKeyword{Keyword: "通知", WholeWord: true}, "通知音", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.keyword.Match(tt.text))
+		})
+	}
+}
+
+func TestNotifyKeywords_Match(t *testing.T) {
+	t.Parallel()
+	ks := NotifyKeywords{{Keyword: "foo"}, {Keyword: "bar", WholeWord: true}}
+	assert.True(t, ks.Match("FOO"))
+	assert.True(t, ks.Match("a bar"))
+	assert.False(t, ks.Match("barbaz"))
+	assert.False(t, NotifyKeywords{}.Match("foo"))
+}
+
+func TestNotifyKeywords_Value(t *testing.T) {
+	t.Parallel()
+
+	v, err := NotifyKeywords(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "[]", v)
+
+	v, err = NotifyKeywords{{Keyword: "foo", WholeWord: true}}.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"keyword":"foo","wholeWord":true,"caseSensitive":false}]`, v.(string))
+}
+
+func TestNotifyKeywords_Scan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+		var ks NotifyKeywords
+		require.NoError(t, ks.Scan(nil))
+		assert.Len(t, ks, 0)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+		var ks NotifyKeywords
+		require.NoError(t, ks.Scan(`[{"keyword":"foo","wholeWord":true,"caseSensitive":false}]`))
+		assert.Equal(t, NotifyKeywords{{Keyword: "foo", WholeWord: true}}, ks)
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		t.Parallel()
+		var ks NotifyKeywords
+		require.NoError(t, ks.Scan([]byte(`[{"keyword":"bar","wholeWord":false,"caseSensitive":true}]`)))
+		assert.Equal(t, NotifyKeywords{{Keyword: "bar", CaseSensitive: true}}, ks)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		var ks NotifyKeywords
+		assert.Error(t, ks.Scan(1))
+	})
+}
+
+func TestUserSettings_IsDoNotDisturb(t *testing.T) {
+	t.Parallel()
+
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, jst) // 月曜日
+
+	assert.False(t, (&UserSettings{}).IsDoNotDisturb(now))
+	assert.True(t, (&UserSettings{SnoozeUntil: optional.From(now.Add(time.Minute))}).IsDoNotDisturb(now))
+	assert.False(t, (&UserSettings{SnoozeUntil: optional.From(now)}).IsDoNotDisturb(now))
+	assert.True(t, (&UserSettings{DNDSchedules: DNDSchedules{
+		{Weekdays: []time.Weekday{time.Monday}, Start: "11:00", End: "13:00", Timezone: "Asia/Tokyo"},
+	}}).IsDoNotDisturb(now))
+}
+
+func TestUserSettings_IsDigestEnabled(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, (&UserSettings{}).IsDigestEnabled())
+	assert.False(t, (&UserSettings{DigestEmail: "user@example.com", DigestFrequency: DigestFrequencyDaily}).IsDigestEnabled())
+	assert.False(t, (&UserSettings{DigestEmail: "user@example.com", DigestEmailVerified: true, DigestFrequency: DigestFrequencyOff}).IsDigestEnabled())
+	assert.True(t, (&UserSettings{DigestEmail: "user@example.com", DigestEmailVerified: true, DigestFrequency: DigestFrequencyWeekly}).IsDigestEnabled())
+}
+
+func TestDigestFrequency(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, DigestFrequencyOff.Valid())
+	assert.True(t, DigestFrequencyDaily.Valid())
+	assert.True(t, DigestFrequencyWeekly.Valid())
+	assert.False(t, DigestFrequency("monthly").Valid())
+	assert.False(t, DigestFrequency("").Valid())
+
+	assert.Equal(t, time.Duration(0), DigestFrequencyOff.Interval())
+	assert.Equal(t, 24*time.Hour, DigestFrequencyDaily.Interval())
+	assert.Equal(t, 7*24*time.Hour, DigestFrequencyWeekly.Interval())
+}
+
+func TestDNDSchedule_Contains(t *testing.T) {
+	t.Parallel()
+
+	jst, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+	tests := []struct {
+		name     string
+		schedule DNDSchedule
+		t        time.Time
+		want     bool
+	}{
+		{"in range", DNDSchedule{Weekdays: weekdays, Start: "09:00", End: "18:00", Timezone: "Asia/Tokyo"}, time.Date(2026, 1, 5, 9, 0, 0, 0, jst), true},
+		{"end is exclusive", DNDSchedule{Weekdays: weekdays, Start: "09:00", End: "18:00", Timezone: "Asia/Tokyo"}, time.Date(2026, 1, 5, 18, 0, 0, 0, jst), false},
+		{"other weekday", DNDSchedule{Weekdays: weekdays, Start: "09:00", End: "18:00", Timezone: "Asia/Tokyo"}, time.Date(2026, 1, 4, 12, 0, 0, 0, jst), false},
+		{"timezone", DNDSchedule{Weekdays: weekdays, Start: "09:00", End: "18:00", Timezone: "Asia/Tokyo"}, time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC), true},
+		{"overnight before midnight", DNDSchedule{Weekdays: []time.Weekday{time.Sunday}, Start: "23:00", End: "07:00", Timezone: "Asia/Tokyo"}, time.Date(2026, 1, 4, 23, 30, 0, 0, jst), true},
+		{"overnight after midnight", DNDSchedule{Weekdays: []time.Weekday{time.Sunday}, Start: "23:00", End: "07:00", Timezone: "Asia/Tokyo"}, time.Date(2026, 1, 5, 6, 59, 0, 0, jst), true},
+		{"overnight after midnight of other weekday", DNDSchedule{Weekdays: []time.Weekday{time.Monday}, Start: "23:00", End: "07:00", Timezone: "Asia/Tokyo"}, time.Date(2026, 1, 5, 6, 59, 0, 0, jst), false},
+		{"overnight saturday to sunday", DNDSchedule{Weekdays: []time.Weekday{time.Saturday}, Start: "23:00", End: "07:00", Timezone: "Asia/Tokyo"}, time.Date(2026, 1, 4, 1, 0, 0, 0, jst), true},
+		{"invalid clock", DNDSchedule{Weekdays: weekdays, Start: "9", End: "18:00", Timezone: "Asia/Tokyo"}, time.Date(2026, 1, 5, 12, 0, 0, 0, jst), false},
+		{"invalid timezone", DNDSchedule{Weekdays: weekdays, Start: "09:00", End: "18:00", Timezone: "Invalid/Zone"}, time.Date(2026, 1, 5, 12, 0, 0, 0, jst), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.schedule.Contains(tt.t))
+		})
+	}
+}
+
+func TestDNDSchedules_Scan(t *testing.T) {
+	t.Parallel()
+
+	var ss DNDSchedules
+	require.NoError(t, ss.Scan(nil))
+	assert.Len(t, ss, 0)
+
+	require.NoError(t, ss.Scan(`[{"weekdays":[0,6],"start":"00:00","end":"23:59","timezone":"UTC"}]`))
+	assert.Equal(t, DNDSchedules{{Weekdays: []time.Weekday{time.Sunday, time.Saturday}, Start: "00:00", End: "23:59", Timezone: "UTC"}}, ss)
+
+	assert.Error(t, ss.Scan(1))
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// WebPushSubscription Web Push購読の構造体
+type WebPushSubscription struct {
+	ID       uuid.UUID `gorm:"type:char(36);not null;primaryKey"`
+	UserID   uuid.UUID `gorm:"type:char(36);not null;index"`
+	Endpoint string    `gorm:"type:varchar(1024);not null;index:idx_web_push_subscriptions_endpoint,length:190"`
+	// P256dh ユーザーエージェントのECDH公開鍵 (base64url)
+	P256dh string `gorm:"type:varchar(128);not null"`
+	// Auth 認証シークレット (base64url)
+	Auth      string    `gorm:"type:varchar(64);not null"`
+	CreatedAt time.Time `gorm:"precision:6"`
+
+	User *User `gorm:"constraint:web_push_subscriptions_user_id_users_id_foreign,OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+// TableName WebPushSubscription構造体のテーブル名
+func (*WebPushSubscription) TableName() string {
+	return "web_push_subscriptions"
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebPushSubscription_TableName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "web_push_subscriptions", (&WebPushSubscription{}).TableName())
+}
@@ -161,6 +161,7 @@ type BotRepository interface {
 	GetParticipatingChannelIDsByBot(ctx context.Context, botID uuid.UUID) ([]uuid.UUID, error)
 	// WriteBotEventLog Botイベントログを書き込みます
 	//
+	// 同じリクエストIDのログが既に存在する場合は上書きします。
 	// 成功した場合、nilを返します。
 	// DBによるエラーを返すことがあります。
 	WriteBotEventLog(ctx context.Context, log *model.BotEventLog) error
@@ -175,4 +176,43 @@ type BotRepository interface {
 	// 成功した場合、nilを返します。
 	// DBによるエラーを返すことがあります。
 	PurgeBotEventLogs(ctx context.Context, before time.Time) error
+	// CreateBotEventDelivery 配送に失敗したBotイベントを送信箱に追加します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	CreateBotEventDelivery(ctx context.Context, delivery *model.BotEventDelivery) error
+	// GetBotEventDelivery 指定したリクエストIDの送信箱のBotイベントを取得します
+	//
+	// 成功した場合、Botイベントとnilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotEventDelivery(ctx context.Context, requestID uuid.UUID) (*model.BotEventDelivery, error)
+	// GetBotEventDeliveries 指定したBotの送信箱のBotイベントを新しい順に取得します
+	//
+	// 成功した場合、Botイベントの配列とnilを返します。負のoffset, limitは無視されます。
+	// stateが有効な場合、その状態のBotイベントのみを返します。
+	// 存在しないBotを指定した場合、空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotEventDeliveries(ctx context.Context, botID uuid.UUID, state optional.Of[model.BotEventDeliveryState], limit, offset int) ([]*model.BotEventDelivery, error)
+	// GetDueBotEventDeliveries 再送予定時刻を過ぎた、有効なBotへの再送待ちのBotイベントを古い順に取得します
+	//
+	// 成功した場合、Botイベントの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetDueBotEventDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.BotEventDelivery, error)
+	// UpdateBotEventDelivery 送信箱のBotイベントの状態・送信回数・エラー・再送予定時刻を更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateBotEventDelivery(ctx context.Context, delivery *model.BotEventDelivery) error
+	// DeleteBotEventDelivery 指定したリクエストIDのBotイベントを送信箱から削除します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteBotEventDelivery(ctx context.Context, requestID uuid.UUID) error
+	// PurgeBotEventDeliveries 指定した時間以前に作成された送信箱のBotイベントを全て消去します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	PurgeBotEventDeliveries(ctx context.Context, before time.Time) error
 }
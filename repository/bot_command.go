@@ -0,0 +1,65 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// SetBotCommandArgs BOTのスラッシュコマンド設定引数
+type SetBotCommandArgs struct {
+	Name        string
+	Description string
+	Arguments   model.BotCommandArguments
+	ChannelIDs  []uuid.UUID
+}
+
+// BotCommandRepository BOTのスラッシュコマンドリポジトリ
+type BotCommandRepository interface {
+	// SetBotCommands 指定したBOTのスラッシュコマンドを置き換えます
+	//
+	// 既存のコマンドと同じ名前のコマンドは更新され、IDが維持されます。
+	// 成功した場合、名前の昇順のコマンドの配列とnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetBotCommands(ctx context.Context, botID uuid.UUID, args []SetBotCommandArgs) ([]*model.BotCommand, error)
+	// GetBotCommands 指定したBOTのスラッシュコマンドを名前の昇順で取得します
+	//
+	// 成功した場合、コマンドの配列とnilを返します。
+	// 存在しないBOTを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotCommands(ctx context.Context, botID uuid.UUID) ([]*model.BotCommand, error)
+	// GetBotCommand 指定したスラッシュコマンドを取得します
+	//
+	// 成功した場合、コマンドとnilを返します。
+	// 存在しないコマンドを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotCommand(ctx context.Context, id uuid.UUID) (*model.BotCommand, error)
+	// GetChannelBotCommands 指定したチャンネルで使用できるスラッシュコマンドを取得します
+	//
+	// チャンネルに参加している有効なBOTのコマンドのうち、チャンネルの制限に合うものを返します。
+	// 成功した場合、コマンドの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetChannelBotCommands(ctx context.Context, channelID uuid.UUID) ([]*model.BotCommand, error)
+	// CreateBotCommandInvocation スラッシュコマンドの呼び出しを記録します
+	//
+	// 成功した場合、呼び出しとnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateBotCommandInvocation(ctx context.Context, command *model.BotCommand, userID, channelID uuid.UUID) (*model.BotCommandInvocation, error)
+	// GetBotCommandInvocation 指定したスラッシュコマンドの呼び出しを取得します
+	//
+	// 成功した場合、呼び出しとnilを返します。
+	// 存在しない呼び出しを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetBotCommandInvocation(ctx context.Context, id uuid.UUID) (*model.BotCommandInvocation, error)
+	// PurgeBotCommandInvocations 指定した時間以前のスラッシュコマンドの呼び出しを全て消去します
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	PurgeBotCommandInvocations(ctx context.Context, before time.Time) error
+}
@@ -20,12 +20,13 @@ type ChangeChannelSubscriptionArgs struct {
 
 // UpdateChannelArgs チャンネル情報更新引数
 type UpdateChannelArgs struct {
-	UpdaterID          uuid.UUID
-	Name               optional.Of[string]
-	Topic              optional.Of[string]
-	Visibility         optional.Of[bool]
-	ForcedNotification optional.Of[bool]
-	Parent             optional.Of[uuid.UUID]
+	UpdaterID              uuid.UUID
+	Name                   optional.Of[string]
+	Topic                  optional.Of[string]
+	Visibility             optional.Of[bool]
+	ForcedNotification     optional.Of[bool]
+	Parent                 optional.Of[uuid.UUID]
+	DisableChannelMentions optional.Of[bool]
 }
 
 // ChannelEventsQuery GetChannelEvents用クエリ
@@ -39,11 +40,20 @@ type ChannelEventsQuery struct {
 	Asc       bool
 }
 
+// UpdateChannelNotifyOverridesArgs チャンネル通知の上書き設定更新引数
+type UpdateChannelNotifyOverridesArgs struct {
+	NotifyMode  model.ChannelNotifyMode
+	MutedUntil  optional.Of[time.Time]
+	SuppressBot bool
+}
+
 // ChannelSubscriptionQuery GetChannelSubscriptions用クエリ
 type ChannelSubscriptionQuery struct {
 	UserID    optional.Of[uuid.UUID]
 	ChannelID optional.Of[uuid.UUID]
 	Level     model.ChannelSubscribeLevel
+	// WithNotifyOverrides 購読していなくても通知の上書き設定がされているものを含めるかどうか (Levelが指定されていない場合のみ有効)
+	WithNotifyOverrides bool
 }
 
 func (q ChannelSubscriptionQuery) SetUser(id uuid.UUID) ChannelSubscriptionQuery {
@@ -61,6 +71,11 @@ func (q ChannelSubscriptionQuery) SetLevel(level model.ChannelSubscribeLevel) Ch
 	return q
 }
 
+func (q ChannelSubscriptionQuery) IncludeNotifyOverrides() ChannelSubscriptionQuery {
+	q.WithNotifyOverrides = true
+	return q
+}
+
 // ChannelStats チャンネル統計情報
 type ChannelStats struct {
 	TotalMessageCount int64 `json:"totalMessageCount"`
@@ -106,7 +121,15 @@ type ChannelRepository interface {
 	//
 	// channelIDにuuid.Nilを指定した場合、ErrNilIDを返します。
 	// 存在しないユーザーを指定した場合は無視されます。
+	// 購読をオフにしても、通知の上書き設定は保持されます。
 	ChangeChannelSubscription(ctx context.Context, channelID uuid.UUID, args ChangeChannelSubscriptionArgs) (on []uuid.UUID, off []uuid.UUID, err error)
+	// UpdateChannelNotifyOverrides ユーザーのチャンネル通知の上書き設定を変更します
+	//
+	// 成功した場合、nilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// 存在しないユーザー・チャンネルを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateChannelNotifyOverrides(ctx context.Context, userID, channelID uuid.UUID, args UpdateChannelNotifyOverridesArgs) error
 	// GetChannelSubscriptions 指定したクエリに基づいてチャンネル購読情報を取得します
 	GetChannelSubscriptions(ctx context.Context, query ChannelSubscriptionQuery) ([]*model.UserSubscribeChannel, error)
 	// GetChannelEvents 指定したクエリでチャンネルイベントを取得します
@@ -13,15 +13,17 @@ import (
 
 // FilesQuery GetFiles用クエリ
 type FilesQuery struct {
-	UploaderID optional.Of[uuid.UUID]
-	ChannelID  optional.Of[uuid.UUID]
-	Since      optional.Of[time.Time]
-	Until      optional.Of[time.Time]
-	Inclusive  bool
-	Limit      int
-	Offset     int
-	Asc        bool
-	Type       model.FileType
+	UploaderID   optional.Of[uuid.UUID]
+	ChannelID    optional.Of[uuid.UUID]
+	Since        optional.Of[time.Time]
+	Until        optional.Of[time.Time]
+	Inclusive    bool
+	Limit        int
+	Offset       int
+	Asc          bool
+	Type         model.FileType
+	Word         optional.Of[string]
+	AccessibleBy optional.Of[uuid.UUID]
 }
 
 // FileRepository ファイルリポジトリ
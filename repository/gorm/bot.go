@@ -8,12 +8,14 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/leandro-lugaresi/hub"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/rbac/role"
 	"github.com/traPtitech/traQ/utils/gormutil"
+	"github.com/traPtitech/traQ/utils/optional"
 	"github.com/traPtitech/traQ/utils/random"
 )
 
@@ -347,8 +349,8 @@ func (repo *Repository) DeleteBot(ctx context.Context, id uuid.UUID) error {
 	if id == uuid.Nil {
 		return repository.ErrNilID
 	}
+	var b model.Bot
 	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		var b model.Bot
 		if err := tx.First(&b, &model.Bot{ID: id}).Error; err != nil {
 			return convertError(err)
 		}
@@ -374,7 +376,8 @@ func (repo *Repository) DeleteBot(ctx context.Context, id uuid.UUID) error {
 	repo.hub.Publish(hub.Message{
 		Name: event.BotDeleted,
 		Fields: hub.Fields{
-			"bot_id": id,
+			"bot_id":      id,
+			"bot_user_id": b.BotUserID,
 		},
 	})
 	return nil
@@ -435,7 +438,8 @@ func (repo *Repository) WriteBotEventLog(ctx context.Context, log *model.BotEven
 	if log == nil || log.RequestID == uuid.Nil {
 		return nil
 	}
-	return repo.db.WithContext(ctx).Create(log).Error
+	// 再送時は同じリクエストIDでログを書き込むため、最新の結果で上書きする
+	return repo.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(log).Error
 }
 
 // GetBotEventLogs implements BotRepository interface.
@@ -455,3 +459,88 @@ func (repo *Repository) GetBotEventLogs(ctx context.Context, botID uuid.UUID, li
 func (repo *Repository) PurgeBotEventLogs(ctx context.Context, before time.Time) error {
 	return repo.db.WithContext(ctx).Delete(&model.BotEventLog{}, "date_time < ?", before).Error
 }
+
+// CreateBotEventDelivery implements BotRepository interface.
+func (repo *Repository) CreateBotEventDelivery(ctx context.Context, delivery *model.BotEventDelivery) error {
+	if delivery == nil || delivery.RequestID == uuid.Nil || delivery.BotID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.WithContext(ctx).Create(delivery).Error
+}
+
+// GetBotEventDelivery implements BotRepository interface.
+func (repo *Repository) GetBotEventDelivery(ctx context.Context, requestID uuid.UUID) (*model.BotEventDelivery, error) {
+	if requestID == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var delivery model.BotEventDelivery
+	if err := repo.db.WithContext(ctx).First(&delivery, &model.BotEventDelivery{RequestID: requestID}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &delivery, nil
+}
+
+// GetBotEventDeliveries implements BotRepository interface.
+func (repo *Repository) GetBotEventDeliveries(ctx context.Context, botID uuid.UUID, state optional.Of[model.BotEventDeliveryState], limit, offset int) ([]*model.BotEventDelivery, error) {
+	deliveries := make([]*model.BotEventDelivery, 0)
+	if botID == uuid.Nil {
+		return deliveries, nil
+	}
+	tx := repo.db.WithContext(ctx).Where(&model.BotEventDelivery{BotID: botID})
+	if state.Valid {
+		tx = tx.Where("state = ?", state.V)
+	}
+	return deliveries, tx.
+		Order("created_at DESC").
+		Scopes(gormutil.LimitAndOffset(limit, offset)).
+		Find(&deliveries).
+		Error
+}
+
+// GetDueBotEventDeliveries implements BotRepository interface.
+func (repo *Repository) GetDueBotEventDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.BotEventDelivery, error) {
+	deliveries := make([]*model.BotEventDelivery, 0)
+	return deliveries, repo.db.WithContext(ctx).
+		Joins("INNER JOIN bots ON bots.id = bot_event_deliveries.bot_id AND bots.state = ? AND bots.deleted_at IS NULL", model.BotActive).
+		Where("bot_event_deliveries.state = ? AND bot_event_deliveries.next_attempt_at <= ?", model.BotEventDeliveryPending, now).
+		Order("bot_event_deliveries.next_attempt_at").
+		Scopes(gormutil.LimitAndOffset(limit, 0)).
+		Find(&deliveries).
+		Error
+}
+
+// UpdateBotEventDelivery implements BotRepository interface.
+func (repo *Repository) UpdateBotEventDelivery(ctx context.Context, delivery *model.BotEventDelivery) error {
+	if delivery == nil || delivery.RequestID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.WithContext(ctx).
+		Model(&model.BotEventDelivery{RequestID: delivery.RequestID}).
+		Updates(map[string]interface{}{
+			"state":           delivery.State,
+			"attempts":        delivery.Attempts,
+			"last_error":      delivery.LastError,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"updated_at":      time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteBotEventDelivery implements BotRepository interface.
+func (repo *Repository) DeleteBotEventDelivery(ctx context.Context, requestID uuid.UUID) error {
+	if requestID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.WithContext(ctx).Delete(&model.BotEventDelivery{}, &model.BotEventDelivery{RequestID: requestID}).Error
+}
+
+// PurgeBotEventDeliveries implements BotRepository interface.
+func (repo *Repository) PurgeBotEventDeliveries(ctx context.Context, before time.Time) error {
+	return repo.db.WithContext(ctx).Delete(&model.BotEventDelivery{}, "created_at < ?", before).Error
+}
@@ -0,0 +1,162 @@
+package gorm
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// SetBotCommands implements BotCommandRepository interface.
+func (repo *Repository) SetBotCommands(ctx context.Context, botID uuid.UUID, args []repository.SetBotCommandArgs) ([]*model.BotCommand, error) {
+	if botID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	commands := make([]*model.BotCommand, 0, len(args))
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current []*model.BotCommand
+		if err := tx.Where(&model.BotCommand{BotID: botID}).Find(&current).Error; err != nil {
+			return err
+		}
+		byName := make(map[string]*model.BotCommand, len(current))
+		for _, c := range current {
+			byName[c.Name] = c
+		}
+
+		for _, a := range args {
+			if c, ok := byName[a.Name]; ok {
+				delete(byName, a.Name)
+				c.Description = a.Description
+				c.Arguments = a.Arguments
+				c.ChannelIDs = a.ChannelIDs
+				if err := tx.Model(c).Updates(map[string]interface{}{
+					"description": c.Description,
+					"arguments":   c.Arguments,
+					"channel_ids": c.ChannelIDs,
+				}).Error; err != nil {
+					return err
+				}
+				commands = append(commands, c)
+				continue
+			}
+
+			c := &model.BotCommand{
+				ID:          uuid.Must(uuid.NewV7()),
+				BotID:       botID,
+				Name:        a.Name,
+				Description: a.Description,
+				Arguments:   a.Arguments,
+				ChannelIDs:  a.ChannelIDs,
+			}
+			if err := tx.Create(c).Error; err != nil {
+				return err
+			}
+			commands = append(commands, c)
+		}
+
+		// 指定されなかったコマンドは削除
+		for _, c := range byName {
+			if err := tx.Delete(c).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(commands, func(a, b *model.BotCommand) int { return strings.Compare(a.Name, b.Name) })
+	return commands, nil
+}
+
+// GetBotCommands implements BotCommandRepository interface.
+func (repo *Repository) GetBotCommands(ctx context.Context, botID uuid.UUID) ([]*model.BotCommand, error) {
+	arr := make([]*model.BotCommand, 0)
+	if botID == uuid.Nil {
+		return arr, nil
+	}
+	err := repo.db.WithContext(ctx).Where(&model.BotCommand{BotID: botID}).Order("name").Find(&arr).Error
+	return arr, err
+}
+
+// GetBotCommand implements BotCommandRepository interface.
+func (repo *Repository) GetBotCommand(ctx context.Context, id uuid.UUID) (*model.BotCommand, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var c model.BotCommand
+	if err := repo.db.WithContext(ctx).First(&c, &model.BotCommand{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &c, nil
+}
+
+// GetChannelBotCommands implements BotCommandRepository interface.
+func (repo *Repository) GetChannelBotCommands(ctx context.Context, channelID uuid.UUID) ([]*model.BotCommand, error) {
+	arr := make([]*model.BotCommand, 0)
+	if channelID == uuid.Nil {
+		return arr, nil
+	}
+	err := repo.db.
+		WithContext(ctx).
+		Joins("INNER JOIN bots ON bots.id = bot_commands.bot_id AND bots.state = ? AND bots.deleted_at IS NULL", model.BotActive).
+		Joins("INNER JOIN bot_join_channels ON bot_join_channels.bot_id = bot_commands.bot_id AND bot_join_channels.channel_id = ?", channelID).
+		Order("bot_commands.name").
+		Find(&arr).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	// MEMO ChannelIDsを正規化したほうがいいかもしれない
+	result := make([]*model.BotCommand, 0, len(arr))
+	for _, c := range arr {
+		if c.AvailableIn(channelID) {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// CreateBotCommandInvocation implements BotCommandRepository interface.
+func (repo *Repository) CreateBotCommandInvocation(ctx context.Context, command *model.BotCommand, userID, channelID uuid.UUID) (*model.BotCommandInvocation, error) {
+	if command.ID == uuid.Nil || userID == uuid.Nil || channelID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	i := &model.BotCommandInvocation{
+		ID:        uuid.Must(uuid.NewV7()),
+		CommandID: command.ID,
+		BotID:     command.BotID,
+		UserID:    userID,
+		ChannelID: channelID,
+	}
+	if err := repo.db.WithContext(ctx).Create(i).Error; err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// GetBotCommandInvocation implements BotCommandRepository interface.
+func (repo *Repository) GetBotCommandInvocation(ctx context.Context, id uuid.UUID) (*model.BotCommandInvocation, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var i model.BotCommandInvocation
+	if err := repo.db.WithContext(ctx).First(&i, &model.BotCommandInvocation{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &i, nil
+}
+
+// PurgeBotCommandInvocations implements BotCommandRepository interface.
+func (repo *Repository) PurgeBotCommandInvocations(ctx context.Context, before time.Time) error {
+	return repo.db.WithContext(ctx).Delete(&model.BotCommandInvocation{}, "created_at < ?", before).Error
+}
@@ -0,0 +1,131 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func mustMakeBot(t *testing.T, repo repository.Repository, creatorID uuid.UUID, state model.BotState) *model.Bot {
+	t.Helper()
+	b, err := repo.CreateBot(context.TODO(), random.AlphaNumeric(16), "bot", "", uuid.Must(uuid.NewV7()), creatorID, model.BotModeHTTP, state, "https://example.com")
+	require.NoError(t, err)
+	return b
+}
+
+func TestRepositoryImpl_SetBotCommands(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.SetBotCommands(context.TODO(), uuid.Nil, nil)
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID(), model.BotActive)
+
+		cs, err := repo.SetBotCommands(context.TODO(), b.ID, []repository.SetBotCommandArgs{
+			{Name: "foo", Description: "foo"},
+			{Name: "bar", Arguments: model.BotCommandArguments{{Name: "a", Type: model.BotCommandArgumentTypeString}}},
+		})
+		require.NoError(err)
+		require.Len(cs, 2)
+		assert.Equal("bar", cs[0].Name)
+		assert.Equal("foo", cs[1].Name)
+		fooID := cs[1].ID
+
+		cs, err = repo.SetBotCommands(context.TODO(), b.ID, []repository.SetBotCommandArgs{
+			{Name: "foo", Description: "updated"},
+			{Name: "baz"},
+		})
+		require.NoError(err)
+		require.Len(cs, 2)
+
+		cs, err = repo.GetBotCommands(context.TODO(), b.ID)
+		require.NoError(err)
+		if assert.Len(cs, 2) {
+			assert.Equal("baz", cs[0].Name)
+			assert.Equal("foo", cs[1].Name)
+			assert.Equal(fooID, cs[1].ID)
+			assert.Equal("updated", cs[1].Description)
+		}
+	})
+}
+
+func TestRepositoryImpl_GetChannelBotCommands(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+	other := mustMakeChannel(t, repo, rand)
+
+	active := mustMakeBot(t, repo, user.GetID(), model.BotActive)
+	inactive := mustMakeBot(t, repo, user.GetID(), model.BotInactive)
+	notJoined := mustMakeBot(t, repo, user.GetID(), model.BotActive)
+	require.NoError(t, repo.AddBotToChannel(context.TODO(), active.ID, channel.ID))
+	require.NoError(t, repo.AddBotToChannel(context.TODO(), inactive.ID, channel.ID))
+
+	_, err := repo.SetBotCommands(context.TODO(), active.ID, []repository.SetBotCommandArgs{
+		{Name: "all"},
+		{Name: "scoped", ChannelIDs: []uuid.UUID{channel.ID}},
+		{Name: "other", ChannelIDs: []uuid.UUID{other.ID}},
+	})
+	require.NoError(t, err)
+	_, err = repo.SetBotCommands(context.TODO(), inactive.ID, []repository.SetBotCommandArgs{{Name: "inactive"}})
+	require.NoError(t, err)
+	_, err = repo.SetBotCommands(context.TODO(), notJoined.ID, []repository.SetBotCommandArgs{{Name: "notjoined"}})
+	require.NoError(t, err)
+
+	cs, err := repo.GetChannelBotCommands(context.TODO(), channel.ID)
+	if assert.NoError(t, err) && assert.Len(t, cs, 2) {
+		assert.Equal(t, "all", cs[0].Name)
+		assert.Equal(t, "scoped", cs[1].Name)
+	}
+}
+
+func TestRepositoryImpl_CreateBotCommandInvocation(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	b := mustMakeBot(t, repo, user.GetID(), model.BotActive)
+	cs, err := repo.SetBotCommands(context.TODO(), b.ID, []repository.SetBotCommandArgs{{Name: "foo"}})
+	require.NoError(t, err)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateBotCommandInvocation(context.TODO(), cs[0], uuid.Nil, channel.ID)
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		i, err := repo.CreateBotCommandInvocation(context.TODO(), cs[0], user.GetID(), channel.ID)
+		if assert.NoError(err) {
+			assert.Equal(cs[0].ID, i.CommandID)
+			assert.Equal(b.ID, i.BotID)
+		}
+
+		i2, err := repo.GetBotCommandInvocation(context.TODO(), i.ID)
+		if assert.NoError(err) {
+			assert.Equal(i.ID, i2.ID)
+		}
+
+		require.NoError(t, repo.PurgeBotCommandInvocations(context.TODO(), time.Now().Add(time.Minute)))
+		_, err = repo.GetBotCommandInvocation(context.TODO(), i.ID)
+		assert.ErrorIs(err, repository.ErrNotFound)
+	})
+}
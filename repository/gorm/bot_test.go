@@ -0,0 +1,97 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func mustMakeBotEventDelivery(t *testing.T, repo repository.Repository, botID uuid.UUID, nextAttemptAt time.Time) *model.BotEventDelivery {
+	t.Helper()
+	d := &model.BotEventDelivery{
+		RequestID:     uuid.Must(uuid.NewV7()),
+		BotID:         botID,
+		Event:         "MESSAGE_CREATED",
+		Body:          "{}",
+		State:         model.BotEventDeliveryPending,
+		Attempts:      1,
+		NextAttemptAt: nextAttemptAt,
+	}
+	require.NoError(t, repo.CreateBotEventDelivery(context.TODO(), d))
+	return d
+}
+
+func TestRepositoryImpl_GetDueBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	active := mustMakeBot(t, repo, user.GetID(), model.BotActive)
+	paused := mustMakeBot(t, repo, user.GetID(), model.BotPaused)
+	now := time.Now()
+	due := mustMakeBotEventDelivery(t, repo, active.ID, now.Add(-time.Minute))
+	mustMakeBotEventDelivery(t, repo, active.ID, now.Add(time.Minute))
+	mustMakeBotEventDelivery(t, repo, paused.ID, now.Add(-time.Minute))
+
+	ds, err := repo.GetDueBotEventDeliveries(context.TODO(), now, 0)
+	if assert.NoError(t, err) {
+		ids := make([]uuid.UUID, 0, len(ds))
+		for _, d := range ds {
+			if d.BotID == active.ID || d.BotID == paused.ID {
+				ids = append(ids, d.RequestID)
+			}
+		}
+		assert.ElementsMatch(t, []uuid.UUID{due.RequestID}, ids)
+	}
+}
+
+func TestRepositoryImpl_UpdateBotEventDelivery(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		err := repo.UpdateBotEventDelivery(context.TODO(), &model.BotEventDelivery{RequestID: uuid.Must(uuid.NewV7())})
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		b := mustMakeBot(t, repo, user.GetID(), model.BotActive)
+		d := mustMakeBotEventDelivery(t, repo, b.ID, time.Now())
+
+		d.RecordFailure(time.Now(), "ng")
+		require.NoError(repo.UpdateBotEventDelivery(context.TODO(), d))
+
+		got, err := repo.GetBotEventDelivery(context.TODO(), d.RequestID)
+		require.NoError(err)
+		assert.Equal(2, got.Attempts)
+		assert.Equal("ng", got.LastError)
+
+		ds, err := repo.GetBotEventDeliveries(context.TODO(), b.ID, optional.From(model.BotEventDeliveryDead), 0, 0)
+		require.NoError(err)
+		assert.Len(ds, 0)
+	})
+}
+
+func TestRepositoryImpl_DeleteBotEventDelivery(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	b := mustMakeBot(t, repo, user.GetID(), model.BotActive)
+	d := mustMakeBotEventDelivery(t, repo, b.ID, time.Now())
+
+	if assert.NoError(t, repo.DeleteBotEventDelivery(context.TODO(), d.RequestID)) {
+		_, err := repo.GetBotEventDelivery(context.TODO(), d.RequestID)
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	}
+}
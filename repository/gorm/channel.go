@@ -3,6 +3,7 @@ package gorm
 import (
 	"bytes"
 	"context"
+	"errors"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -118,6 +119,9 @@ func (repo *Repository) UpdateChannel(ctx context.Context, channelID uuid.UUID,
 		if args.Parent.Valid {
 			data["parent_id"] = args.Parent.V
 		}
+		if args.DisableChannelMentions.Valid {
+			data["disable_channel_mentions"] = args.DisableChannelMentions.V
+		}
 
 		if err := tx.Model(&ch).Updates(data).Error; err != nil {
 			return err
@@ -283,8 +287,10 @@ func (repo *Repository) ChangeChannelSubscription(ctx context.Context, channelID
 			return err
 		}
 		current := make(map[uuid.UUID]model.ChannelSubscribeLevel, len(_current))
+		overridden := make(map[uuid.UUID]bool, len(_current))
 		for _, s := range _current {
 			current[s.UserID] = s.GetLevel()
+			overridden[s.UserID] = s.HasNotifyOverrides()
 		}
 
 		for uid, level := range args.Subscription {
@@ -304,8 +310,18 @@ func (repo *Repository) ChangeChannelSubscription(ctx context.Context, channelID
 					}
 				}
 
-				if err := tx.Delete(&model.UserSubscribeChannel{}, &model.UserSubscribeChannel{UserID: uid, ChannelID: channelID}).Error; err != nil {
-					return err
+				if overridden[uid] {
+					// 通知の上書き設定は保持する
+					if err := tx.Model(model.UserSubscribeChannel{}).
+						Where(&model.UserSubscribeChannel{UserID: uid, ChannelID: channelID}).
+						Updates(map[string]interface{}{"mark": false, "notify": false}).
+						Error; err != nil {
+						return err
+					}
+				} else {
+					if err := tx.Delete(&model.UserSubscribeChannel{}, &model.UserSubscribeChannel{UserID: uid, ChannelID: channelID}).Error; err != nil {
+						return err
+					}
 				}
 				if current[uid] == model.ChannelSubscribeLevelMarkAndNotify {
 					off = append(off, uid)
@@ -384,7 +400,11 @@ func (repo *Repository) GetChannelSubscriptions(ctx context.Context, query repos
 	case model.ChannelSubscribeLevelMarkAndNotify:
 		tx = tx.Where("mark = true AND notify = true")
 	default:
-		tx = tx.Where("mark = true OR notify = true")
+		if query.WithNotifyOverrides {
+			tx = tx.Where("mark = true OR notify = true OR notify_mode <> ? OR muted_until IS NOT NULL OR suppress_bot = true", model.ChannelNotifyModeDefault)
+		} else {
+			tx = tx.Where("mark = true OR notify = true")
+		}
 	}
 
 	result := make([]*model.UserSubscribeChannel, 0)
@@ -392,6 +412,52 @@ func (repo *Repository) GetChannelSubscriptions(ctx context.Context, query repos
 	return result, err
 }
 
+// UpdateChannelNotifyOverrides implements ChannelRepository interface.
+func (repo *Repository) UpdateChannelNotifyOverrides(ctx context.Context, userID, channelID uuid.UUID, args repository.UpdateChannelNotifyOverridesArgs) error {
+	if userID == uuid.Nil || channelID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	s := model.UserSubscribeChannel{
+		UserID:      userID,
+		ChannelID:   channelID,
+		NotifyMode:  args.NotifyMode,
+		MutedUntil:  args.MutedUntil,
+		SuppressBot: args.SuppressBot,
+	}
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.UserSubscribeChannel
+		if err := tx.
+			Where(&model.UserSubscribeChannel{UserID: userID, ChannelID: channelID}).
+			First(&current).
+			Error; err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if !s.HasNotifyOverrides() {
+				return nil // 購読も上書き設定も無い
+			}
+			if err := tx.Create(&s).Error; err != nil {
+				if gormutil.IsMySQLForeignKeyConstraintFailsError(err) {
+					return repository.ErrNotFound
+				}
+				return err
+			}
+			return nil
+		}
+
+		if current.GetLevel() == model.ChannelSubscribeLevelNone && !s.HasNotifyOverrides() {
+			// 購読も上書き設定も無くなったので削除
+			return tx.Delete(&current).Error
+		}
+		return tx.Model(&current).Updates(map[string]interface{}{
+			"notify_mode":  args.NotifyMode,
+			"muted_until":  args.MutedUntil,
+			"suppress_bot": args.SuppressBot,
+		}).Error
+	})
+}
+
 // GetChannelEvents implements ChannelRepository interface.
 func (repo *Repository) GetChannelEvents(ctx context.Context, query repository.ChannelEventsQuery) (events []*model.ChannelEvent, more bool, err error) {
 	events = make([]*model.ChannelEvent, 0)
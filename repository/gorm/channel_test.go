@@ -167,6 +167,68 @@ func TestGormRepository_ChangeChannelSubscription(t *testing.T) {
 	})
 }
 
+func TestGormRepository_UpdateChannelNotifyOverrides(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common)
+
+	t.Run("Nil ID", func(t *testing.T) {
+		t.Parallel()
+		assert.ErrorIs(t, repo.UpdateChannelNotifyOverrides(context.TODO(), uuid.Nil, uuid.Nil, repository.UpdateChannelNotifyOverridesArgs{}), repository.ErrNilID)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		ch := mustMakeChannel(t, repo, rand)
+		err := repo.UpdateChannelNotifyOverrides(context.TODO(), uuid.Must(uuid.NewV7()), ch.ID, repository.UpdateChannelNotifyOverridesArgs{NotifyMode: model.ChannelNotifyModeMute})
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+		ch := mustMakeChannel(t, repo, rand)
+		user := mustMakeUser(t, repo, rand, false)
+		q := repository.ChannelSubscriptionQuery{}.SetUser(user.GetID()).SetChannel(ch.ID)
+
+		// 購読していなくても上書き設定は保存される
+		require.NoError(t, repo.UpdateChannelNotifyOverrides(context.TODO(), user.GetID(), ch.ID, repository.UpdateChannelNotifyOverridesArgs{
+			NotifyMode:  model.ChannelNotifyModeMute,
+			SuppressBot: true,
+		}))
+		subs, err := repo.GetChannelSubscriptions(context.TODO(), q)
+		require.NoError(t, err)
+		assert.Len(t, subs, 0)
+		subs, err = repo.GetChannelSubscriptions(context.TODO(), q.IncludeNotifyOverrides())
+		require.NoError(t, err)
+		if assert.Len(t, subs, 1) {
+			assert.Equal(t, model.ChannelSubscribeLevelNone, subs[0].GetLevel())
+			assert.Equal(t, model.ChannelNotifyModeMute, subs[0].NotifyMode)
+			assert.True(t, subs[0].SuppressBot)
+		}
+
+		// 購読を変更しても上書き設定は保持される
+		_, _, err = repo.ChangeChannelSubscription(context.TODO(), ch.ID, repository.ChangeChannelSubscriptionArgs{
+			Subscription: map[uuid.UUID]model.ChannelSubscribeLevel{user.GetID(): model.ChannelSubscribeLevelMarkAndNotify},
+		})
+		require.NoError(t, err)
+		_, _, err = repo.ChangeChannelSubscription(context.TODO(), ch.ID, repository.ChangeChannelSubscriptionArgs{
+			Subscription: map[uuid.UUID]model.ChannelSubscribeLevel{user.GetID(): model.ChannelSubscribeLevelNone},
+		})
+		require.NoError(t, err)
+		subs, err = repo.GetChannelSubscriptions(context.TODO(), q.IncludeNotifyOverrides())
+		require.NoError(t, err)
+		if assert.Len(t, subs, 1) {
+			assert.Equal(t, model.ChannelSubscribeLevelNone, subs[0].GetLevel())
+			assert.Equal(t, model.ChannelNotifyModeMute, subs[0].NotifyMode)
+		}
+
+		// 上書き設定を既定に戻すとレコードは削除される
+		require.NoError(t, repo.UpdateChannelNotifyOverrides(context.TODO(), user.GetID(), ch.ID, repository.UpdateChannelNotifyOverridesArgs{
+			NotifyMode: model.ChannelNotifyModeDefault,
+		}))
+		assert.Equal(t, 0, count(t, getDB(repo).Model(model.UserSubscribeChannel{}).Where(&model.UserSubscribeChannel{ChannelID: ch.ID})))
+	})
+}
+
 func TestGormRepository_GetChannelStats(t *testing.T) {
 	t.Parallel()
 	repo, _, _ := setup(t, common)
@@ -293,5 +293,9 @@ func (repo *Repository) GetMessageClips(ctx context.Context, userID, messageID u
 }
 
 func clipPreloads(db *gorm.DB) *gorm.DB {
-	return db.Preload("Message").Preload("Message.Stamps").Preload("Message.Pin")
+	return db.Preload("Message").Preload("Message.Stamps").Preload("Message.Pin").Preload("Message.Poll.Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Message.Components", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	})
 }
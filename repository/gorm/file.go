@@ -8,6 +8,7 @@ import (
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormutil"
 )
 
 // GetFileMetas implements FileRepository interface.
@@ -32,6 +33,17 @@ func (repo *Repository) GetFileMetas(ctx context.Context, q repository.FilesQuer
 		}
 	}
 
+	if q.Word.Valid {
+		pattern := gormutil.LikeContains(q.Word.V)
+		tx = tx.Where("files.name LIKE ? OR files.mime LIKE ?", pattern, pattern)
+	}
+	if q.AccessibleBy.Valid {
+		// IsFileAccessibleと同じ判定
+		users := []uuid.UUID{q.AccessibleBy.V, uuid.Nil}
+		tx = tx.Where("EXISTS (SELECT 1 FROM files_acl WHERE files_acl.file_id = files.id AND files_acl.user_id IN (?) AND files_acl.allow = TRUE)", users).
+			Where("NOT EXISTS (SELECT 1 FROM files_acl WHERE files_acl.file_id = files.id AND files_acl.user_id IN (?) AND files_acl.allow = FALSE)", users)
+	}
+
 	if q.Inclusive {
 		if q.Since.Valid {
 			tx = tx.Where("files.created_at >= ?", q.Since.V)
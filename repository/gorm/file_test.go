@@ -11,6 +11,9 @@ import (
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/random"
 )
 
 func TestGormRepository_SaveFileMeta(t *testing.T) {
@@ -126,6 +129,62 @@ func TestGormRepository_DeleteFileMeta(t *testing.T) {
 	})
 }
 
+func TestGormRepository_GetFileMetas_Search(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common3, false)
+
+	word := random.AlphaNumeric(12)
+	saveFile := func(name, mime string, acl []*model.FileACLEntry) *model.FileMeta {
+		meta := &model.FileMeta{
+			ID:   uuid.Must(uuid.NewV7()),
+			Name: name,
+			Mime: mime,
+			Size: 10,
+			Hash: "d41d8cd98f00b204e9800998ecf8427e",
+			Type: model.FileTypeUserFile,
+		}
+		require.NoError(t, repo.SaveFileMeta(context.TODO(), meta, acl))
+		return meta
+	}
+	everyone := []*model.FileACLEntry{{UserID: uuid.Nil, Allow: true}}
+
+	byName := saveFile("a"+word+".txt", "text/plain", everyone)
+	byMime := saveFile("file.bin", "application/x-"+word, everyone)
+	onlyUser := saveFile(word+".png", "image/png", []*model.FileACLEntry{{UserID: user.GetID(), Allow: true}})
+	denied := saveFile(word+".jpg", "image/jpeg", []*model.FileACLEntry{{UserID: uuid.Nil, Allow: true}, {UserID: user.GetID(), Allow: false}})
+	saveFile("other.txt", "text/plain", everyone)
+
+	t.Run("word", func(t *testing.T) {
+		t.Parallel()
+
+		files, _, err := repo.GetFileMetas(context.TODO(), repository.FilesQuery{Type: model.FileTypeUserFile, Word: optional.From(word)})
+		if assert.NoError(t, err) {
+			ids := utils.Map(files, func(f *model.FileMeta) uuid.UUID { return f.ID })
+			assert.ElementsMatch(t, []uuid.UUID{byName.ID, byMime.ID, onlyUser.ID, denied.ID}, ids)
+		}
+	})
+
+	t.Run("accessible by user", func(t *testing.T) {
+		t.Parallel()
+
+		files, _, err := repo.GetFileMetas(context.TODO(), repository.FilesQuery{Type: model.FileTypeUserFile, Word: optional.From(word), AccessibleBy: optional.From(user.GetID())})
+		if assert.NoError(t, err) {
+			ids := utils.Map(files, func(f *model.FileMeta) uuid.UUID { return f.ID })
+			assert.ElementsMatch(t, []uuid.UUID{byName.ID, byMime.ID, onlyUser.ID}, ids)
+		}
+	})
+
+	t.Run("accessible by other user", func(t *testing.T) {
+		t.Parallel()
+
+		files, _, err := repo.GetFileMetas(context.TODO(), repository.FilesQuery{Type: model.FileTypeUserFile, Word: optional.From(word), AccessibleBy: optional.From(uuid.Must(uuid.NewV7()))})
+		if assert.NoError(t, err) {
+			ids := utils.Map(files, func(f *model.FileMeta) uuid.UUID { return f.ID })
+			assert.ElementsMatch(t, []uuid.UUID{byName.ID, byMime.ID, denied.ID}, ids)
+		}
+	})
+}
+
 func TestGormRepository_IsFileAccessible(t *testing.T) {
 	t.Parallel()
 	repo, _, _, user := setupWithUser(t, common, false)
@@ -0,0 +1,112 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// CreateInboxItems implements InboxItemRepository interface.
+func (repo *Repository) CreateInboxItems(ctx context.Context, args []repository.CreateInboxItemArgs) ([]*model.InboxItem, error) {
+	items := make([]*model.InboxItem, len(args))
+	for i, a := range args {
+		if a.UserID == uuid.Nil || a.MessageID == uuid.Nil || a.ChannelID == uuid.Nil || a.ActorID == uuid.Nil {
+			return nil, repository.ErrNilID
+		}
+		items[i] = &model.InboxItem{
+			ID:        uuid.Must(uuid.NewV7()),
+			UserID:    a.UserID,
+			Type:      a.Type,
+			MessageID: a.MessageID,
+			ChannelID: a.ChannelID,
+			ActorID:   a.ActorID,
+			StampID:   a.StampID,
+		}
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+	if err := repo.db.WithContext(ctx).Create(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetInboxItems implements InboxItemRepository interface.
+func (repo *Repository) GetInboxItems(ctx context.Context, query repository.InboxItemsQuery) (items []*model.InboxItem, more bool, err error) {
+	items = make([]*model.InboxItem, 0)
+	if query.UserID == uuid.Nil {
+		return items, false, nil
+	}
+
+	tx := repo.db.WithContext(ctx).
+		Scopes(inboxItemsOf(query.UserID)).
+		Order("inbox_items.id DESC")
+	if query.Cursor.Valid {
+		tx = tx.Where("inbox_items.id < ?", query.Cursor.V)
+	}
+	if query.UnreadOnly {
+		tx = tx.Where("inbox_items.is_read = FALSE")
+	}
+
+	if query.Limit > 0 {
+		err = tx.Limit(query.Limit + 1).Find(&items).Error
+		if len(items) > query.Limit {
+			return items[:len(items)-1], true, err
+		}
+	} else {
+		err = tx.Find(&items).Error
+	}
+	return items, false, err
+}
+
+// GetUnreadInboxItemCount implements InboxItemRepository interface.
+func (repo *Repository) GetUnreadInboxItemCount(ctx context.Context, userID uuid.UUID) (count int64, err error) {
+	if userID == uuid.Nil {
+		return 0, nil
+	}
+	err = repo.db.WithContext(ctx).
+		Model(&model.InboxItem{}).
+		Scopes(inboxItemsOf(userID)).
+		Where("inbox_items.is_read = FALSE").
+		Count(&count).
+		Error
+	return count, err
+}
+
+// MarkInboxItemsAsRead implements InboxItemRepository interface.
+func (repo *Repository) MarkInboxItemsAsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	if userID == uuid.Nil || len(ids) == 0 {
+		return nil
+	}
+	return repo.db.WithContext(ctx).
+		Model(&model.InboxItem{}).
+		Where("user_id = ? AND id IN ? AND is_read = FALSE", userID, ids).
+		Update("is_read", true).
+		Error
+}
+
+// MarkAllInboxItemsAsRead implements InboxItemRepository interface.
+func (repo *Repository) MarkAllInboxItemsAsRead(ctx context.Context, userID uuid.UUID) error {
+	if userID == uuid.Nil {
+		return nil
+	}
+	return repo.db.WithContext(ctx).
+		Model(&model.InboxItem{}).
+		Where("user_id = ? AND is_read = FALSE", userID).
+		Update("is_read", true).
+		Error
+}
+
+// inboxItemsOf 指定したユーザーの削除されていないメッセージの通知受信箱のアイテム
+func inboxItemsOf(userID uuid.UUID) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.
+			Joins("INNER JOIN messages ON messages.id = inbox_items.message_id AND messages.deleted_at IS NULL").
+			Where("inbox_items.user_id = ?", userID)
+	}
+}
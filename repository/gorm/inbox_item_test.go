@@ -0,0 +1,180 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateInboxItems(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateInboxItems(context.TODO(), []repository.CreateInboxItemArgs{{
+			UserID:    uuid.Nil,
+			Type:      model.InboxItemTypeMention,
+			MessageID: m.ID,
+			ChannelID: m.ChannelID,
+			ActorID:   m.UserID,
+		}})
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		items, err := repo.CreateInboxItems(context.TODO(), nil)
+		if assert.NoError(t, err) {
+			assert.Len(t, items, 0)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		user2 := mustMakeUser(t, repo, rand, false)
+		stamp := mustMakeStamp(t, repo, rand, uuid.Nil)
+
+		items, err := repo.CreateInboxItems(context.TODO(), []repository.CreateInboxItemArgs{
+			{UserID: user2.GetID(), Type: model.InboxItemTypeMention, MessageID: m.ID, ChannelID: m.ChannelID, ActorID: m.UserID},
+			{UserID: user.GetID(), Type: model.InboxItemTypeStamp, MessageID: m.ID, ChannelID: m.ChannelID, ActorID: user2.GetID(), StampID: optional.From(stamp.ID)},
+		})
+		if assert.NoError(err) && assert.Len(items, 2) {
+			assert.NotEmpty(items[0].ID)
+			assert.Equal(user2.GetID(), items[0].UserID)
+			assert.Equal(model.InboxItemTypeMention, items[0].Type)
+			assert.False(items[0].IsRead)
+			assert.Equal(optional.From(stamp.ID), items[1].StampID)
+		}
+	})
+}
+
+func TestRepositoryImpl_GetInboxItems(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+	user2 := mustMakeUser(t, repo, rand, false)
+
+	m1 := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	m2 := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	m3 := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	deleted := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	i1 := mustMakeInboxItem(t, repo, user2.GetID(), m1)
+	i2 := mustMakeInboxItem(t, repo, user2.GetID(), m2)
+	i3 := mustMakeInboxItem(t, repo, user2.GetID(), m3)
+	mustMakeInboxItem(t, repo, user2.GetID(), deleted)
+	mustMakeInboxItem(t, repo, user.GetID(), m1)
+	assert.NoError(t, repo.DeleteMessage(context.TODO(), deleted.ID))
+	assert.NoError(t, repo.MarkInboxItemsAsRead(context.TODO(), user2.GetID(), []uuid.UUID{i2.ID}))
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		items, more, err := repo.GetInboxItems(context.TODO(), repository.InboxItemsQuery{UserID: uuid.Nil})
+		if assert.NoError(t, err) {
+			assert.Len(t, items, 0)
+			assert.False(t, more)
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		items, more, err := repo.GetInboxItems(context.TODO(), repository.InboxItemsQuery{UserID: user2.GetID()})
+		if assert.NoError(err) && assert.Len(items, 3) {
+			assert.False(more)
+			assert.Equal(i3.ID, items[0].ID)
+			assert.Equal(i2.ID, items[1].ID)
+			assert.True(items[1].IsRead)
+			assert.Equal(i1.ID, items[2].ID)
+		}
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		items, more, err := repo.GetInboxItems(context.TODO(), repository.InboxItemsQuery{UserID: user2.GetID(), Limit: 1})
+		if assert.NoError(err) && assert.Len(items, 1) {
+			assert.True(more)
+			assert.Equal(i3.ID, items[0].ID)
+		}
+
+		items, more, err = repo.GetInboxItems(context.TODO(), repository.InboxItemsQuery{UserID: user2.GetID(), Cursor: optional.From(i3.ID), Limit: 2})
+		if assert.NoError(err) && assert.Len(items, 2) {
+			assert.False(more)
+			assert.Equal(i2.ID, items[0].ID)
+			assert.Equal(i1.ID, items[1].ID)
+		}
+	})
+
+	t.Run("unread only", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		items, _, err := repo.GetInboxItems(context.TODO(), repository.InboxItemsQuery{UserID: user2.GetID(), UnreadOnly: true})
+		if assert.NoError(err) && assert.Len(items, 2) {
+			assert.Equal(i3.ID, items[0].ID)
+			assert.Equal(i1.ID, items[1].ID)
+		}
+	})
+
+	t.Run("unread count", func(t *testing.T) {
+		t.Parallel()
+
+		count, err := repo.GetUnreadInboxItemCount(context.TODO(), user2.GetID())
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 2, count)
+		}
+	})
+}
+
+func TestRepositoryImpl_MarkInboxItemsAsRead(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+	user2 := mustMakeUser(t, repo, rand, false)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	i1 := mustMakeInboxItem(t, repo, user2.GetID(), m)
+	mustMakeInboxItem(t, repo, user2.GetID(), m)
+	other := mustMakeInboxItem(t, repo, user.GetID(), m)
+
+	// 他人のアイテムは既読にならない
+	assert.NoError(t, repo.MarkInboxItemsAsRead(context.TODO(), user2.GetID(), []uuid.UUID{i1.ID, other.ID}))
+
+	count, err := repo.GetUnreadInboxItemCount(context.TODO(), user2.GetID())
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 1, count)
+	}
+	count, err = repo.GetUnreadInboxItemCount(context.TODO(), user.GetID())
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 1, count)
+	}
+}
+
+func TestRepositoryImpl_MarkAllInboxItemsAsRead(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+	user2 := mustMakeUser(t, repo, rand, false)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	mustMakeInboxItem(t, repo, user2.GetID(), m)
+	mustMakeInboxItem(t, repo, user2.GetID(), m)
+
+	assert.NoError(t, repo.MarkAllInboxItemsAsRead(context.TODO(), user2.GetID()))
+
+	count, err := repo.GetUnreadInboxItemCount(context.TODO(), user2.GetID())
+	if assert.NoError(t, err) {
+		assert.EqualValues(t, 0, count)
+	}
+}
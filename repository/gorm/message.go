@@ -14,7 +14,9 @@ import (
 	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormutil"
 	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // CreateMessage implements MessageRepository interface.
@@ -34,23 +36,85 @@ func (repo *Repository) CreateMessage(ctx context.Context, userID, channelID uui
 		if err := tx.Create(m).Error; err != nil {
 			return err
 		}
+		return updateChannelLatestMessage(tx, m)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		clm := &model.ChannelLatestMessage{
-			ChannelID: m.ChannelID,
-			MessageID: m.ID,
-			DateTime:  m.CreatedAt,
+	repo.publishMessageCreated(m)
+	return m, nil
+}
+
+// CreateReplyMessage implements MessageRepository interface.
+func (repo *Repository) CreateReplyMessage(ctx context.Context, userID, parentID uuid.UUID, text string) (*model.Message, error) {
+	if userID == uuid.Nil || parentID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	var m *model.Message
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var parent model.Message
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&parent, &model.Message{ID: parentID}).Error; err != nil {
+			return convertError(err)
+		}
+
+		m = &model.Message{
+			ID:              uuid.Must(uuid.NewV7()),
+			UserID:          userID,
+			ChannelID:       parent.ChannelID,
+			Text:            text,
+			ParentMessageID: optional.From(parent.ID),
+			Stamps:          []model.MessageStamp{},
+		}
+		if err := tx.Create(m).Error; err != nil {
+			return err
 		}
 
-		return tx.
-			Clauses(clause.OnConflict{UpdateAll: true}).
-			Create(clm).
-			Error
+		// 返信数を更新 (updated_atは更新しない)
+		if err := tx.Model(&parent).UpdateColumn("reply_count", gorm.Expr("reply_count + 1")).Error; err != nil {
+			return err
+		}
+
+		// 返信したユーザーと、最初の返信の場合は親メッセージの投稿者をスレッドの購読者に追加
+		subscribers := []uuid.UUID{userID}
+		if parent.ReplyCount == 0 && parent.UserID != userID {
+			subscribers = append(subscribers, parent.UserID)
+		}
+		for _, uid := range subscribers {
+			if err := tx.
+				Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&model.MessageThreadSubscription{MessageID: parent.ID, UserID: uid}).
+				Error; err != nil {
+				return err
+			}
+		}
+
+		return updateChannelLatestMessage(tx, m)
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	parseResult := message.Parse(text)
+	repo.publishMessageCreated(m)
+	return m, nil
+}
+
+func updateChannelLatestMessage(tx *gorm.DB, m *model.Message) error {
+	clm := &model.ChannelLatestMessage{
+		ChannelID: m.ChannelID,
+		MessageID: m.ID,
+		DateTime:  m.CreatedAt,
+	}
+
+	return tx.
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(clm).
+		Error
+}
+
+func (repo *Repository) publishMessageCreated(m *model.Message) {
+	parseResult := message.Parse(m.Text)
 	repo.hub.Publish(hub.Message{
 		Name: event.MessageCreated,
 		Fields: hub.Fields{
@@ -69,7 +133,6 @@ func (repo *Repository) CreateMessage(ctx context.Context, userID, channelID uui
 			},
 		})
 	}
-	return m, nil
 }
 
 // UpdateMessage implements MessageRepository interface.
@@ -99,7 +162,7 @@ func (repo *Repository) UpdateMessage(ctx context.Context, messageID uuid.UUID,
 		}
 
 		// update
-		if err := tx.Model(&oldMes).Update("text", text).Error; err != nil {
+		if err := tx.Model(&oldMes).Updates(map[string]interface{}{"text": text, "edit_count": gorm.Expr("edit_count + 1")}).Error; err != nil {
 			return err
 		}
 
@@ -150,6 +213,16 @@ func (repo *Repository) DeleteMessage(ctx context.Context, messageID uuid.UUID)
 		if err := tx.Delete(model.ClipFolderMessage{}, &model.ClipFolderMessage{MessageID: messageID}).Error; err != nil {
 			return err
 		}
+		if m.ParentMessageID.Valid {
+			// 親メッセージの返信数を更新 (updated_atは更新しない)
+			if err := tx.
+				Model(&model.Message{}).
+				Where("id = ? AND reply_count > 0", m.ParentMessageID.V).
+				UpdateColumn("reply_count", gorm.Expr("reply_count - 1")).
+				Error; err != nil {
+				return err
+			}
+		}
 
 		var mes []model.Message
 		if err := tx.
@@ -194,6 +267,16 @@ func (repo *Repository) GetMessageByID(ctx context.Context, messageID uuid.UUID)
 	return message, nil
 }
 
+// GetArchivedMessagesByID implements MessageRepository interface.
+func (repo *Repository) GetArchivedMessagesByID(ctx context.Context, messageID uuid.UUID) ([]*model.ArchivedMessage, error) {
+	arr := make([]*model.ArchivedMessage, 0)
+	if messageID == uuid.Nil {
+		return arr, nil
+	}
+	err := repo.db.WithContext(ctx).Where(&model.ArchivedMessage{MessageID: messageID}).Order("date_time").Find(&arr).Error
+	return arr, err
+}
+
 // GetMessages implements MessageRepository interface.
 func (repo *Repository) GetMessages(ctx context.Context, query repository.MessagesQuery) (messages []*model.Message, more bool, err error) {
 	messages = make([]*model.Message, 0)
@@ -225,11 +308,14 @@ func (repo *Repository) GetMessages(ctx context.Context, query repository.Messag
 	if query.Channel != uuid.Nil {
 		tx = tx.Where("messages.channel_id = ?", query.Channel)
 	}
+	if query.Parent != uuid.Nil {
+		tx = tx.Where("messages.parent_message_id = ?", query.Parent)
+	}
 	if query.User != uuid.Nil {
 		tx = tx.Where("messages.user_id = ?", query.User)
 	}
 	if query.ChannelsSubscribedByUser != uuid.Nil {
-		tx = tx.Where("channels.is_forced = TRUE OR channels.id IN (SELECT s.channel_id FROM users_subscribe_channels s WHERE s.user_id = ?)", query.ChannelsSubscribedByUser)
+		tx = tx.Where("channels.is_forced = TRUE OR channels.id IN (SELECT s.channel_id FROM users_subscribe_channels s WHERE s.user_id = ? AND (s.mark = TRUE OR s.notify = TRUE))", query.ChannelsSubscribedByUser)
 	}
 
 	if query.Inclusive {
@@ -293,6 +379,59 @@ func (repo *Repository) GetDeletedMessagesAfter(ctx context.Context, after time.
 	return
 }
 
+// GetMessagesAfterID implements MessageRepository interface.
+func (repo *Repository) GetMessagesAfterID(ctx context.Context, after uuid.UUID, limit int) (messages []*model.Message, more bool, err error) {
+	messages = make([]*model.Message, 0, limit)
+	err = repo.db.
+		WithContext(ctx).
+		Where("id > ?", after).
+		Order("id").
+		Limit(limit + 1).
+		Find(&messages).
+		Error
+
+	if len(messages) > limit {
+		more = true
+		messages = messages[:limit]
+	}
+	return
+}
+
+// GetMessageCountsByChannel implements MessageRepository interface.
+func (repo *Repository) GetMessageCountsByChannel(ctx context.Context) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		ChannelID uuid.UUID
+		Count     int64
+	}
+	if err := repo.db.
+		WithContext(ctx).
+		Model(&model.Message{}).
+		Select("channel_id, COUNT(*) AS count").
+		Group("channel_id").
+		Scan(&rows).
+		Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ChannelID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetMessageIDsByChannel implements MessageRepository interface.
+func (repo *Repository) GetMessageIDsByChannel(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	err := repo.db.
+		WithContext(ctx).
+		Model(&model.Message{}).
+		Where("channel_id = ?", channelID).
+		Pluck("id", &ids).
+		Error
+	return ids, err
+}
+
 // SetMessageUnreads implements MessageRepository interface.
 func (repo *Repository) SetMessageUnreads(ctx context.Context, userNoticeableMap map[uuid.UUID]bool, messageID uuid.UUID) error {
 	if messageID == uuid.Nil {
@@ -469,7 +608,7 @@ func (repo *Repository) GetChannelLatestMessages(ctx context.Context, query repo
 	tx := repo.db.
 		WithContext(ctx).
 		Unscoped().
-		Select("m.id, m.user_id, m.channel_id, m.text, m.created_at, m.updated_at, m.deleted_at").
+		Select("m.id, m.user_id, m.channel_id, m.text, m.created_at, m.updated_at, m.deleted_at, m.parent_message_id, m.reply_count, m.edit_count").
 		Table("channel_latest_messages clm").
 		Joins("INNER JOIN messages m ON clm.message_id = m.id").
 		Joins("INNER JOIN channels c ON clm.channel_id = c.id").
@@ -477,7 +616,7 @@ func (repo *Repository) GetChannelLatestMessages(ctx context.Context, query repo
 		Order("clm.date_time DESC")
 
 	if query.SubscribedByUser.Valid {
-		tx = tx.Where("c.is_forced = TRUE OR c.id IN (?)", repo.db.WithContext(ctx).Table("users_subscribe_channels").Select("channel_id").Where("user_id", query.SubscribedByUser.V))
+		tx = tx.Where("c.is_forced = TRUE OR c.id IN (?)", repo.db.WithContext(ctx).Table("users_subscribe_channels").Select("channel_id").Where("user_id", query.SubscribedByUser.V).Where("mark = TRUE OR notify = TRUE"))
 	}
 
 	if query.Limit > 0 {
@@ -551,8 +690,45 @@ func (repo *Repository) RemoveStampFromMessage(ctx context.Context, messageID, s
 	return nil
 }
 
+// SubscribeMessageThread implements MessageRepository interface.
+func (repo *Repository) SubscribeMessageThread(ctx context.Context, messageID, userID uuid.UUID) error {
+	if messageID == uuid.Nil || userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.MessageThreadSubscription{MessageID: messageID, UserID: userID}).
+		Error
+}
+
+// UnsubscribeMessageThread implements MessageRepository interface.
+func (repo *Repository) UnsubscribeMessageThread(ctx context.Context, messageID, userID uuid.UUID) error {
+	if messageID == uuid.Nil || userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+	return repo.db.
+		WithContext(ctx).
+		Delete(&model.MessageThreadSubscription{}, &model.MessageThreadSubscription{MessageID: messageID, UserID: userID}).
+		Error
+}
+
+// IsMessageThreadSubscribed implements MessageRepository interface.
+func (repo *Repository) IsMessageThreadSubscribed(ctx context.Context, messageID, userID uuid.UUID) (bool, error) {
+	if messageID == uuid.Nil || userID == uuid.Nil {
+		return false, nil
+	}
+	return gormutil.RecordExists(repo.db.WithContext(ctx), &model.MessageThreadSubscription{MessageID: messageID, UserID: userID})
+}
+
 func messagePreloads(db *gorm.DB) *gorm.DB {
 	return db.
 		Preload("Stamps").
-		Preload("Pin")
+		Preload("Pin").
+		Preload("Poll.Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Components", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		})
 }
@@ -0,0 +1,145 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// SetMessageComponents implements MessageComponentRepository interface.
+func (repo *Repository) SetMessageComponents(ctx context.Context, messageID uuid.UUID, args []repository.SetMessageComponentArgs) ([]model.MessageComponent, error) {
+	if messageID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	components := make([]model.MessageComponent, 0, len(args))
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current []model.MessageComponent
+		if err := tx.Where(&model.MessageComponent{MessageID: messageID}).Find(&current).Error; err != nil {
+			return err
+		}
+		byCustomID := make(map[string]model.MessageComponent, len(current))
+		for _, c := range current {
+			byCustomID[c.CustomID] = c
+		}
+
+		for i, a := range args {
+			c := model.MessageComponent{
+				ID:        uuid.Must(uuid.NewV7()),
+				MessageID: messageID,
+				CustomID:  a.CustomID,
+				Type:      a.Type,
+				Label:     a.Label,
+				Style:     a.Style,
+				Options:   a.Options,
+				Disabled:  a.Disabled,
+				Position:  i,
+			}
+			if old, ok := byCustomID[a.CustomID]; ok {
+				delete(byCustomID, a.CustomID)
+				c.ID = old.ID
+				if err := tx.Model(&old).Updates(map[string]interface{}{
+					"type":     c.Type,
+					"label":    c.Label,
+					"style":    c.Style,
+					"options":  c.Options,
+					"disabled": c.Disabled,
+					"position": c.Position,
+				}).Error; err != nil {
+					return err
+				}
+				c.UpdatedAt = old.UpdatedAt
+			} else if err := tx.Create(&c).Error; err != nil {
+				return err
+			}
+			components = append(components, c)
+		}
+
+		// 指定されなかったコンポーネントは削除
+		for _, c := range byCustomID {
+			if err := tx.Delete(&c).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageComponentsUpdated,
+		Fields: hub.Fields{
+			"message_id": messageID,
+			"components": components,
+		},
+	})
+	return components, nil
+}
+
+// GetMessageComponents implements MessageComponentRepository interface.
+func (repo *Repository) GetMessageComponents(ctx context.Context, messageID uuid.UUID) ([]model.MessageComponent, error) {
+	arr := make([]model.MessageComponent, 0)
+	if messageID == uuid.Nil {
+		return arr, nil
+	}
+	err := repo.db.WithContext(ctx).Where(&model.MessageComponent{MessageID: messageID}).Order("position").Find(&arr).Error
+	return arr, err
+}
+
+// UpdateMessageComponent implements MessageComponentRepository interface.
+func (repo *Repository) UpdateMessageComponent(ctx context.Context, messageID uuid.UUID, customID string, args repository.UpdateMessageComponentArgs) (*model.MessageComponent, error) {
+	if messageID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	var (
+		c          model.MessageComponent
+		components []model.MessageComponent
+	)
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&c, &model.MessageComponent{MessageID: messageID, CustomID: customID}).Error; err != nil {
+			return convertError(err)
+		}
+
+		changes := map[string]interface{}{}
+		if args.Label.Valid {
+			changes["label"] = args.Label.V
+		}
+		if args.Style.Valid {
+			changes["style"] = args.Style.V
+		}
+		if args.Options.Valid {
+			changes["options"] = args.Options.V
+		}
+		if args.Disabled.Valid {
+			changes["disabled"] = args.Disabled.V
+		}
+		if len(changes) > 0 {
+			if err := tx.Model(&c).Updates(changes).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.First(&c, &model.MessageComponent{ID: c.ID}).Error; err != nil {
+			return err
+		}
+		return tx.Where(&model.MessageComponent{MessageID: messageID}).Order("position").Find(&components).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageComponentsUpdated,
+		Fields: hub.Fields{
+			"message_id": messageID,
+			"components": components,
+		},
+	})
+	return &c, nil
+}
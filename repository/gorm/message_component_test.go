@@ -0,0 +1,95 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_SetMessageComponents(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.SetMessageComponents(context.TODO(), uuid.Nil, nil)
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert, require := assertAndRequire(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+		cs, err := repo.SetMessageComponents(context.TODO(), m.ID, []repository.SetMessageComponentArgs{
+			{CustomID: "approve", Type: model.MessageComponentTypeButton, Label: "Approve", Style: model.MessageComponentStylePrimary},
+			{CustomID: "reject", Type: model.MessageComponentTypeButton, Label: "Reject", Style: model.MessageComponentStyleDanger},
+		})
+		require.NoError(err)
+		require.Len(cs, 2)
+		approveID := cs[0].ID
+
+		cs, err = repo.SetMessageComponents(context.TODO(), m.ID, []repository.SetMessageComponentArgs{
+			{CustomID: "menu", Type: model.MessageComponentTypeSelect, Style: model.MessageComponentStyleDefault, Options: model.MessageComponentOptions{{Label: "A", Value: "a"}}},
+			{CustomID: "approve", Type: model.MessageComponentTypeButton, Label: "Approved", Style: model.MessageComponentStylePrimary, Disabled: true},
+		})
+		require.NoError(err)
+		require.Len(cs, 2)
+
+		cs, err = repo.GetMessageComponents(context.TODO(), m.ID)
+		require.NoError(err)
+		if assert.Len(cs, 2) {
+			assert.Equal("menu", cs[0].CustomID)
+			assert.Equal(model.MessageComponentOptions{{Label: "A", Value: "a"}}, cs[0].Options)
+			assert.Equal("approve", cs[1].CustomID)
+			assert.Equal(approveID, cs[1].ID)
+			assert.Equal("Approved", cs[1].Label)
+			assert.True(cs[1].Disabled)
+		}
+
+		msg, err := repo.GetMessageByID(context.TODO(), m.ID)
+		require.NoError(err)
+		assert.Len(msg.Components, 2)
+	})
+}
+
+func TestRepositoryImpl_UpdateMessageComponent(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	_, err := repo.SetMessageComponents(context.TODO(), m.ID, []repository.SetMessageComponentArgs{
+		{CustomID: "approve", Type: model.MessageComponentTypeButton, Label: "Approve", Style: model.MessageComponentStylePrimary},
+	})
+	require.NoError(t, err)
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.UpdateMessageComponent(context.TODO(), m.ID, "reject", repository.UpdateMessageComponentArgs{})
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		c, err := repo.UpdateMessageComponent(context.TODO(), m.ID, "approve", repository.UpdateMessageComponentArgs{
+			Label:    optional.From("Approved"),
+			Disabled: optional.From(true),
+		})
+		if assert.NoError(err) {
+			assert.Equal("Approved", c.Label)
+			assert.True(c.Disabled)
+			assert.Equal(model.MessageComponentStylePrimary, c.Style)
+		}
+	})
+}
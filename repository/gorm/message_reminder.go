@@ -0,0 +1,157 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormutil"
+)
+
+// CreateMessageReminder implements MessageReminderRepository interface.
+func (repo *Repository) CreateMessageReminder(ctx context.Context, userID, messageID uuid.UUID, remindAt time.Time) (*model.MessageReminder, error) {
+	if userID == uuid.Nil || messageID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	r := &model.MessageReminder{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    userID,
+		MessageID: messageID,
+		RemindAt:  remindAt,
+		State:     model.MessageReminderPending,
+	}
+	if err := repo.db.WithContext(ctx).Create(r).Error; err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetMessageReminder implements MessageReminderRepository interface.
+func (repo *Repository) GetMessageReminder(ctx context.Context, id uuid.UUID) (*model.MessageReminder, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var r model.MessageReminder
+	if err := repo.db.WithContext(ctx).First(&r, &model.MessageReminder{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &r, nil
+}
+
+// GetMessageRemindersByUserID implements MessageReminderRepository interface.
+func (repo *Repository) GetMessageRemindersByUserID(ctx context.Context, userID uuid.UUID) ([]*model.MessageReminder, error) {
+	arr := make([]*model.MessageReminder, 0)
+	if userID == uuid.Nil {
+		return arr, nil
+	}
+	err := repo.db.WithContext(ctx).Where(&model.MessageReminder{UserID: userID}).Order("remind_at").Find(&arr).Error
+	return arr, err
+}
+
+// GetDueMessageReminders implements MessageReminderRepository interface.
+func (repo *Repository) GetDueMessageReminders(ctx context.Context, until time.Time, limit int) ([]*model.MessageReminder, error) {
+	arr := make([]*model.MessageReminder, 0)
+	err := repo.db.
+		WithContext(ctx).
+		Where("state = ? AND remind_at <= ?", model.MessageReminderPending, until).
+		Order("remind_at").
+		Scopes(gormutil.LimitAndOffset(limit, 0)).
+		Find(&arr).
+		Error
+	return arr, err
+}
+
+// ClaimMessageReminder implements MessageReminderRepository interface.
+func (repo *Repository) ClaimMessageReminder(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.
+		WithContext(ctx).
+		Model(&model.MessageReminder{}).
+		Where("id = ? AND state = ?", id, model.MessageReminderPending).
+		Updates(map[string]interface{}{
+			"state":      model.MessageReminderDelivering,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"claimed_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// ReleaseMessageReminder implements MessageReminderRepository interface.
+func (repo *Repository) ReleaseMessageReminder(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.
+		WithContext(ctx).
+		Model(&model.MessageReminder{}).
+		Where("id = ? AND state = ?", id, model.MessageReminderDelivering).
+		Update("state", model.MessageReminderPending)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// RecoverMessageReminders implements MessageReminderRepository interface.
+func (repo *Repository) RecoverMessageReminders(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	var n int64
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// claimed_atが無いものはカラム追加前に通知処理中になったもの
+		stuck := func() *gorm.DB {
+			return tx.
+				Where("state = ? AND (claimed_at IS NULL OR claimed_at <= ?)", model.MessageReminderDelivering, claimedBefore)
+		}
+
+		result := stuck().
+			Where("attempts >= ?", model.MessageReminderMaxAttempts).
+			Delete(&model.MessageReminder{})
+		if result.Error != nil {
+			return result.Error
+		}
+		n += result.RowsAffected
+
+		result = stuck().
+			Model(&model.MessageReminder{}).
+			Update("state", model.MessageReminderPending)
+		if result.Error != nil {
+			return result.Error
+		}
+		n += result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// DeleteMessageReminder implements MessageReminderRepository interface.
+func (repo *Repository) DeleteMessageReminder(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.WithContext(ctx).Delete(&model.MessageReminder{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
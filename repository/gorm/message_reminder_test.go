@@ -0,0 +1,222 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+func TestRepositoryImpl_CreateMessageReminder(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), uuid.Nil, time.Now())
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+		at := time.Now().Add(time.Hour).Truncate(time.Second)
+		r, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, at)
+		if assert.NoError(err) {
+			assert.NotEmpty(r.ID)
+			assert.Equal(user.GetID(), r.UserID)
+			assert.Equal(m.ID, r.MessageID)
+			assert.True(at.Equal(r.RemindAt))
+		}
+
+		r2, err := repo.GetMessageReminder(context.TODO(), r.ID)
+		if assert.NoError(err) {
+			assert.Equal(r.ID, r2.ID)
+		}
+	})
+}
+
+func TestRepositoryImpl_GetMessageRemindersByUserID(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	later, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	sooner, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	rs, err := repo.GetMessageRemindersByUserID(context.TODO(), user.GetID())
+	if assert.NoError(t, err) && assert.Len(t, rs, 2) {
+		assert.Equal(t, sooner.ID, rs[0].ID)
+		assert.Equal(t, later.ID, rs[1].ID)
+	}
+}
+
+func TestRepositoryImpl_DeleteMessageReminder(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.DeleteMessageReminder(context.TODO(), uuid.Nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.DeleteMessageReminder(context.TODO(), uuid.Must(uuid.NewV7())), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		r, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, time.Now())
+		require.NoError(t, err)
+
+		assert.NoError(t, repo.DeleteMessageReminder(context.TODO(), r.ID))
+		_, err = repo.GetMessageReminder(context.TODO(), r.ID)
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+}
+
+func TestRepositoryImpl_GetDueMessageReminders(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	due, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	notDue, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	arr, err := repo.GetDueMessageReminders(context.TODO(), time.Now(), 0)
+	if assert.NoError(t, err) {
+		ids := make([]uuid.UUID, len(arr))
+		for i, r := range arr {
+			ids[i] = r.ID
+		}
+		assert.Contains(t, ids, due.ID)
+		assert.NotContains(t, ids, notDue.ID)
+	}
+}
+
+func TestRepositoryImpl_ClaimMessageReminder(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.ClaimMessageReminder(context.TODO(), uuid.Nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.ClaimMessageReminder(context.TODO(), uuid.Must(uuid.NewV7())), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		r, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, time.Now().Add(-time.Minute))
+		require.NoError(t, err)
+
+		if assert.NoError(repo.ClaimMessageReminder(context.TODO(), r.ID)) {
+			r, err := repo.GetMessageReminder(context.TODO(), r.ID)
+			if assert.NoError(err) {
+				assert.Equal(model.MessageReminderDelivering, r.State)
+				assert.Equal(1, r.Attempts)
+			}
+		}
+		// 通知処理中のものは再度処理できず、通知対象にも含まれない
+		assert.EqualError(repo.ClaimMessageReminder(context.TODO(), r.ID), repository.ErrNotFound.Error())
+		arr, err := repo.GetDueMessageReminders(context.TODO(), time.Now(), 0)
+		if assert.NoError(err) {
+			for _, due := range arr {
+				assert.NotEqual(r.ID, due.ID)
+			}
+		}
+	})
+}
+
+func TestRepositoryImpl_ReleaseMessageReminder(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.ReleaseMessageReminder(context.TODO(), uuid.Nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("not delivering", func(t *testing.T) {
+		t.Parallel()
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		r, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, time.Now())
+		require.NoError(t, err)
+
+		assert.EqualError(t, repo.ReleaseMessageReminder(context.TODO(), r.ID), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		r, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.ClaimMessageReminder(context.TODO(), r.ID))
+
+		if assert.NoError(repo.ReleaseMessageReminder(context.TODO(), r.ID)) {
+			r, err := repo.GetMessageReminder(context.TODO(), r.ID)
+			if assert.NoError(err) {
+				assert.Equal(model.MessageReminderPending, r.State)
+				assert.Equal(1, r.Attempts)
+			}
+		}
+	})
+}
+
+func TestRepositoryImpl_RecoverMessageReminders(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+	assert, require := assertAndRequire(t)
+
+	claim := func(attempts int, claimedAt time.Time) *model.MessageReminder {
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		r, err := repo.CreateMessageReminder(context.TODO(), user.GetID(), m.ID, time.Now())
+		require.NoError(err)
+		require.NoError(getDB(repo).Model(r).Update("attempts", attempts-1).Error)
+		require.NoError(repo.ClaimMessageReminder(context.TODO(), r.ID))
+		require.NoError(getDB(repo).Model(r).Update("claimed_at", claimedAt).Error)
+		return r
+	}
+	stuck := claim(1, time.Now().Add(-time.Hour))
+	exhausted := claim(model.MessageReminderMaxAttempts, time.Now().Add(-time.Hour))
+	delivering := claim(1, time.Now())
+
+	n, err := repo.RecoverMessageReminders(context.TODO(), time.Now().Add(-30*time.Minute))
+	require.NoError(err)
+	assert.EqualValues(2, n)
+
+	if r, err := repo.GetMessageReminder(context.TODO(), stuck.ID); assert.NoError(err) {
+		assert.Equal(model.MessageReminderPending, r.State)
+	}
+	_, err = repo.GetMessageReminder(context.TODO(), exhausted.ID)
+	assert.EqualError(err, repository.ErrNotFound.Error())
+	if r, err := repo.GetMessageReminder(context.TODO(), delivering.ID); assert.NoError(err) {
+		assert.Equal(model.MessageReminderDelivering, r.State)
+	}
+}
@@ -2,9 +2,13 @@ package gorm
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"gorm.io/gorm"
 
+	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/gormutil"
@@ -23,6 +27,7 @@ func (repo *Repository) CreateMessageReport(ctx context.Context, messageID, repo
 		MessageID: messageID,
 		Reporter:  reporterID,
 		Reason:    reason,
+		State:     model.MessageReportStateOpen,
 	}
 	if err := repo.db.WithContext(ctx).Create(r).Error; err != nil {
 		if gormutil.IsMySQLDuplicatedRecordErr(err) {
@@ -30,6 +35,15 @@ func (repo *Repository) CreateMessageReport(ctx context.Context, messageID, repo
 		}
 		return err
 	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageReportCreated,
+		Fields: hub.Fields{
+			"report_id":   r.ID,
+			"message_id":  messageID,
+			"reporter_id": reporterID,
+			"report":      r,
+		},
+	})
 	return nil
 }
 
@@ -40,6 +54,71 @@ func (repo *Repository) GetMessageReports(ctx context.Context, offset, limit int
 	return arr, err
 }
 
+// GetMessageReportsByQuery implements MessageReportRepository interface.
+func (repo *Repository) GetMessageReportsByQuery(ctx context.Context, query repository.MessageReportsQuery) (arr []*model.MessageReport, err error) {
+	arr = make([]*model.MessageReport, 0)
+	tx := repo.db.WithContext(ctx)
+	if query.State.Valid {
+		tx = tx.Where(&model.MessageReport{State: query.State.V})
+	}
+	err = tx.Scopes(gormutil.LimitAndOffset(query.Limit, query.Offset)).Order("created_at").Find(&arr).Error
+	return arr, err
+}
+
+// GetMessageReport implements MessageReportRepository interface.
+func (repo *Repository) GetMessageReport(ctx context.Context, reportID uuid.UUID) (*model.MessageReport, error) {
+	if reportID == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var r model.MessageReport
+	if err := repo.db.WithContext(ctx).First(&r, &model.MessageReport{ID: reportID}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &r, nil
+}
+
+// UpdateMessageReportState implements MessageReportRepository interface.
+func (repo *Repository) UpdateMessageReportState(ctx context.Context, reportID uuid.UUID, args repository.UpdateMessageReportStateArgs) error {
+	if reportID == uuid.Nil || args.ResolverID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	var r model.MessageReport
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&r, &model.MessageReport{ID: reportID}).Error; err != nil {
+			return convertError(err)
+		}
+
+		changes := map[string]interface{}{
+			"state":       args.State,
+			"resolver_id": args.ResolverID,
+			"note":        args.Note,
+			"resolved_at": time.Now(),
+		}
+		if args.State == model.MessageReportStateOpen {
+			// 未対応に戻す場合は対応者情報を消す
+			changes["resolver_id"] = nil
+			changes["resolved_at"] = nil
+		}
+		if err := tx.Model(&r).Updates(changes).Error; err != nil {
+			return err
+		}
+		return tx.First(&r, &model.MessageReport{ID: reportID}).Error
+	})
+	if err != nil {
+		return err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.MessageReportStateChanged,
+		Fields: hub.Fields{
+			"report_id":   reportID,
+			"resolver_id": args.ResolverID,
+			"report":      &r,
+		},
+	})
+	return nil
+}
+
 // GetMessageReportsByMessageID implements MessageReportRepository interface.
 func (repo *Repository) GetMessageReportsByMessageID(ctx context.Context, messageID uuid.UUID) (arr []*model.MessageReport, err error) {
 	arr = make([]*model.MessageReport, 0)
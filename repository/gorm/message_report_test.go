@@ -0,0 +1,122 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateMessageReport(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.CreateMessageReport(context.TODO(), uuid.Nil, user.GetID(), "a"), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+		if assert.NoError(repo.CreateMessageReport(context.TODO(), m.ID, user.GetID(), "spam")) {
+			reports, err := repo.GetMessageReportsByMessageID(context.TODO(), m.ID)
+			if assert.NoError(err) && assert.Len(reports, 1) {
+				assert.Equal("spam", reports[0].Reason)
+				assert.Equal(model.MessageReportStateOpen, reports[0].State)
+			}
+		}
+		assert.EqualError(repo.CreateMessageReport(context.TODO(), m.ID, user.GetID(), "spam"), repository.ErrAlreadyExists.Error())
+	})
+}
+
+func TestRepositoryImpl_GetMessageReport(t *testing.T) {
+	t.Parallel()
+	repo, _, require, user, channel := setupWithUserAndChannel(t, common3, false)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	require.NoError(repo.CreateMessageReport(context.TODO(), m.ID, user.GetID(), "spam"))
+	reports, err := repo.GetMessageReportsByMessageID(context.TODO(), m.ID)
+	require.NoError(err)
+	require.Len(reports, 1)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetMessageReport(context.TODO(), uuid.Nil)
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetMessageReport(context.TODO(), uuid.Must(uuid.NewV7()))
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		r, err := repo.GetMessageReport(context.TODO(), reports[0].ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, m.ID, r.MessageID)
+		}
+	})
+}
+
+func TestRepositoryImpl_UpdateMessageReportState(t *testing.T) {
+	t.Parallel()
+	repo, _, require, user, channel := setupWithUserAndChannel(t, common3, false)
+	moderator := mustMakeUser(t, repo, rand, false)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	require.NoError(repo.CreateMessageReport(context.TODO(), m.ID, user.GetID(), "spam"))
+	reports, err := repo.GetMessageReportsByMessageID(context.TODO(), m.ID)
+	require.NoError(err)
+	require.Len(reports, 1)
+	reportID := reports[0].ID
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateMessageReportState(context.TODO(), uuid.Nil, repository.UpdateMessageReportStateArgs{State: model.MessageReportStateResolved, ResolverID: moderator.GetID()}), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateMessageReportState(context.TODO(), uuid.Must(uuid.NewV7()), repository.UpdateMessageReportStateArgs{State: model.MessageReportStateResolved, ResolverID: moderator.GetID()}), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		if assert.NoError(repo.UpdateMessageReportState(context.TODO(), reportID, repository.UpdateMessageReportStateArgs{State: model.MessageReportStateResolved, ResolverID: moderator.GetID(), Note: "deleted"})) {
+			r, err := repo.GetMessageReport(context.TODO(), reportID)
+			if assert.NoError(err) {
+				assert.Equal(model.MessageReportStateResolved, r.State)
+				assert.Equal(optional.From(moderator.GetID()), r.ResolverID)
+				assert.Equal("deleted", r.Note)
+				assert.True(r.ResolvedAt.Valid)
+			}
+
+			reports, err := repo.GetMessageReportsByQuery(context.TODO(), repository.MessageReportsQuery{State: optional.From(model.MessageReportStateResolved)})
+			if assert.NoError(err) {
+				ids := make([]uuid.UUID, len(reports))
+				for i, r := range reports {
+					ids[i] = r.ID
+				}
+				assert.Contains(ids, reportID)
+			}
+		}
+	})
+}
@@ -75,6 +75,62 @@ func TestRepositoryImpl_CreateMessage(t *testing.T) {
 	})
 }
 
+func TestRepositoryImpl_CreateReplyMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common3, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateReplyMessage(context.TODO(), user.GetID(), uuid.Nil, "a")
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateReplyMessage(context.TODO(), user.GetID(), uuid.Must(uuid.NewV7()), "a")
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		parent := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		replier := mustMakeUser(t, repo, rand, false)
+
+		m, err := repo.CreateReplyMessage(context.TODO(), replier.GetID(), parent.ID, "reply")
+		if assert.NoError(err) {
+			assert.NotZero(m.ID)
+			assert.Equal(replier.GetID(), m.UserID)
+			assert.Equal(channel.ID, m.ChannelID)
+			assert.Equal("reply", m.Text)
+			assert.Equal(optional.From(parent.ID), m.ParentMessageID)
+		}
+
+		p, err := repo.GetMessageByID(context.TODO(), parent.ID)
+		if assert.NoError(err) {
+			assert.Equal(1, p.ReplyCount)
+		}
+
+		// 返信したユーザーと親メッセージの投稿者が購読している
+		for _, uid := range []uuid.UUID{user.GetID(), replier.GetID()} {
+			ok, err := repo.IsMessageThreadSubscribed(context.TODO(), parent.ID, uid)
+			if assert.NoError(err) {
+				assert.True(ok)
+			}
+		}
+
+		if assert.NoError(repo.DeleteMessage(context.TODO(), m.ID)) {
+			p, err := repo.GetMessageByID(context.TODO(), parent.ID)
+			if assert.NoError(err) {
+				assert.Equal(0, p.ReplyCount)
+			}
+		}
+	})
+}
+
 func TestRepositoryImpl_UpdateMessage(t *testing.T) {
 	t.Parallel()
 	repo, assert, _, user, channel := setupWithUserAndChannel(t, common3, false)
@@ -90,6 +146,7 @@ func TestRepositoryImpl_UpdateMessage(t *testing.T) {
 	m, err := repo.GetMessageByID(context.TODO(), m.ID)
 	if assert.NoError(err) {
 		assert.Equal("new message", m.Text)
+		assert.Equal(1, m.EditCount)
 		assert.Equal(1, count(t, getDB(repo).Model(&model.ArchivedMessage{}).Where(&model.ArchivedMessage{MessageID: m.ID, Text: originalText})))
 	}
 }
@@ -109,6 +166,28 @@ func TestRepositoryImpl_DeleteMessage(t *testing.T) {
 	assert.EqualError(repo.DeleteMessage(context.TODO(), m.ID), repository.ErrNotFound.Error())
 }
 
+func TestRepositoryImpl_GetArchivedMessagesByID(t *testing.T) {
+	t.Parallel()
+	repo, assert, require, user, channel := setupWithUserAndChannel(t, common3, false)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+	originalText := m.Text
+	require.NoError(repo.UpdateMessage(context.TODO(), m.ID, "edit 1"))
+	require.NoError(repo.UpdateMessage(context.TODO(), m.ID, "edit 2"))
+
+	ams, err := repo.GetArchivedMessagesByID(context.TODO(), m.ID)
+	if assert.NoError(err) && assert.Len(ams, 2) {
+		assert.Equal(originalText, ams[0].Text)
+		assert.Equal("edit 1", ams[1].Text)
+		assert.Equal(user.GetID(), ams[0].UserID)
+	}
+
+	ams, err = repo.GetArchivedMessagesByID(context.TODO(), uuid.Nil)
+	if assert.NoError(err) {
+		assert.Empty(ams)
+	}
+}
+
 func TestRepositoryImpl_GetMessageByID(t *testing.T) {
 	t.Parallel()
 	repo, assert, _, user, channel := setupWithUserAndChannel(t, common3, false)
@@ -480,3 +559,35 @@ func TestRepositoryImpl_RemoveStampFromMessage(t *testing.T) {
 		}
 	})
 }
+
+func TestRepositoryImpl_SubscribeMessageThread(t *testing.T) {
+	t.Parallel()
+	repo, assert, _, user, channel := setupWithUserAndChannel(t, common3, false)
+
+	m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+	assert.EqualError(repo.SubscribeMessageThread(context.TODO(), uuid.Nil, user.GetID()), repository.ErrNilID.Error())
+	assert.EqualError(repo.SubscribeMessageThread(context.TODO(), m.ID, uuid.Nil), repository.ErrNilID.Error())
+
+	if assert.NoError(repo.SubscribeMessageThread(context.TODO(), m.ID, user.GetID())) {
+		ok, err := repo.IsMessageThreadSubscribed(context.TODO(), m.ID, user.GetID())
+		if assert.NoError(err) {
+			assert.True(ok)
+		}
+		ids, err := repo.GetUserIDs(context.TODO(), repository.UsersQuery{}.ThreadSubscriberOf(m.ID))
+		if assert.NoError(err) {
+			assert.ElementsMatch([]uuid.UUID{user.GetID()}, ids)
+		}
+	}
+	// 既に購読している
+	assert.NoError(repo.SubscribeMessageThread(context.TODO(), m.ID, user.GetID()))
+
+	if assert.NoError(repo.UnsubscribeMessageThread(context.TODO(), m.ID, user.GetID())) {
+		ok, err := repo.IsMessageThreadSubscribed(context.TODO(), m.ID, user.GetID())
+		if assert.NoError(err) {
+			assert.False(ok)
+		}
+	}
+	// 既に購読していない
+	assert.NoError(repo.UnsubscribeMessageThread(context.TODO(), m.ID, user.GetID()))
+}
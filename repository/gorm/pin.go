@@ -92,5 +92,11 @@ func (repo *Repository) GetPinnedMessageByChannelID(ctx context.Context, channel
 func pinPreloads(db *gorm.DB) *gorm.DB {
 	return db.
 		Preload("Message").
-		Preload("Message.Stamps")
+		Preload("Message.Stamps").
+		Preload("Message.Poll.Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Preload("Message.Components", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		})
 }
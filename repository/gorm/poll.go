@@ -0,0 +1,179 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormutil"
+)
+
+// CreatePoll implements PollRepository interface.
+func (repo *Repository) CreatePoll(ctx context.Context, args repository.CreatePollArgs) (*model.Poll, error) {
+	if args.MessageID == uuid.Nil || args.CreatorID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	p := &model.Poll{
+		ID:             uuid.Must(uuid.NewV7()),
+		MessageID:      args.MessageID,
+		CreatorID:      args.CreatorID,
+		MultipleChoice: args.MultipleChoice,
+		Anonymous:      args.Anonymous,
+		Deadline:       args.Deadline,
+		Options:        make([]model.PollOption, len(args.Options)),
+	}
+	for i, content := range args.Options {
+		p.Options[i] = model.PollOption{
+			ID:       uuid.Must(uuid.NewV7()),
+			PollID:   p.ID,
+			Content:  content,
+			Position: i,
+		}
+	}
+	if err := repo.db.WithContext(ctx).Create(p).Error; err != nil {
+		if gormutil.IsMySQLDuplicatedRecordErr(err) {
+			return nil, repository.ErrAlreadyExists
+		}
+		return nil, err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.PollCreated,
+		Fields: hub.Fields{
+			"poll_id":    p.ID,
+			"message_id": p.MessageID,
+			"poll":       p,
+		},
+	})
+	return p, nil
+}
+
+// GetPollByMessageID implements PollRepository interface.
+func (repo *Repository) GetPollByMessageID(ctx context.Context, messageID uuid.UUID) (*model.Poll, error) {
+	if messageID == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var p model.Poll
+	if err := repo.db.WithContext(ctx).Scopes(pollPreloads).Where(&model.Poll{MessageID: messageID}).First(&p).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &p, nil
+}
+
+// GetPollVotes implements PollRepository interface.
+func (repo *Repository) GetPollVotes(ctx context.Context, pollID uuid.UUID) ([]*model.PollVote, error) {
+	arr := make([]*model.PollVote, 0)
+	if pollID == uuid.Nil {
+		return arr, nil
+	}
+	err := repo.db.WithContext(ctx).Where(&model.PollVote{PollID: pollID}).Order("created_at").Find(&arr).Error
+	return arr, err
+}
+
+// SetPollVotes implements PollRepository interface.
+func (repo *Repository) SetPollVotes(ctx context.Context, pollID, userID uuid.UUID, optionIDs []uuid.UUID) error {
+	if pollID == uuid.Nil || userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	var p model.Poll
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 締め切りとの競合や同じユーザーの同時投票を防ぐため、投票をロックする
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&p, &model.Poll{ID: pollID}).Error; err != nil {
+			return convertError(err)
+		}
+		if p.IsClosed(time.Now()) {
+			return repository.ErrForbidden
+		}
+
+		if err := tx.Where(&model.PollVote{PollID: pollID, UserID: userID}).Delete(&model.PollVote{}).Error; err != nil {
+			return err
+		}
+		if len(optionIDs) > 0 {
+			votes := make([]*model.PollVote, len(optionIDs))
+			for i, optionID := range optionIDs {
+				votes[i] = &model.PollVote{PollID: pollID, OptionID: optionID, UserID: userID}
+			}
+			if err := tx.Create(&votes).Error; err != nil {
+				return err
+			}
+		}
+
+		// 集計値を更新
+		if err := tx.Exec("UPDATE poll_options SET vote_count = (SELECT COUNT(*) FROM poll_votes WHERE poll_votes.option_id = poll_options.id) WHERE poll_id = ?", pollID).Error; err != nil {
+			return err
+		}
+		return tx.Exec("UPDATE polls SET voter_count = (SELECT COUNT(DISTINCT user_id) FROM poll_votes WHERE poll_votes.poll_id = polls.id) WHERE id = ?", pollID).Error
+	})
+	if err != nil {
+		return err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.PollVoted,
+		Fields: hub.Fields{
+			"poll_id":    pollID,
+			"message_id": p.MessageID,
+			"user_id":    userID,
+		},
+	})
+	return nil
+}
+
+// ClosePoll implements PollRepository interface.
+func (repo *Repository) ClosePoll(ctx context.Context, pollID uuid.UUID, closedAt time.Time) (*model.Poll, error) {
+	if pollID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	var p model.Poll
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&p, &model.Poll{ID: pollID}).Error; err != nil {
+			return convertError(err)
+		}
+		if p.ClosedAt.Valid {
+			return repository.ErrAlreadyExists
+		}
+		if err := tx.Model(&p).Update("closed_at", closedAt).Error; err != nil {
+			return err
+		}
+		return tx.Scopes(pollPreloads).First(&p, &model.Poll{ID: pollID}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	repo.hub.Publish(hub.Message{
+		Name: event.PollClosed,
+		Fields: hub.Fields{
+			"poll_id":    p.ID,
+			"message_id": p.MessageID,
+			"poll":       &p,
+		},
+	})
+	return &p, nil
+}
+
+// GetExpiredPolls implements PollRepository interface.
+func (repo *Repository) GetExpiredPolls(ctx context.Context, until time.Time, limit int) ([]*model.Poll, error) {
+	arr := make([]*model.Poll, 0)
+	err := repo.db.
+		WithContext(ctx).
+		Where("deadline <= ? AND closed_at IS NULL", until).
+		Order("deadline").
+		Scopes(gormutil.LimitAndOffset(limit, 0)).
+		Find(&arr).
+		Error
+	return arr, err
+}
+
+func pollPreloads(db *gorm.DB) *gorm.DB {
+	return db.Preload("Options", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	})
+}
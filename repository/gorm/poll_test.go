@@ -0,0 +1,233 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func mustMakePoll(t *testing.T, repo repository.Repository, messageID, creatorID uuid.UUID, multipleChoice bool, deadline optional.Of[time.Time]) *model.Poll {
+	t.Helper()
+	p, err := repo.CreatePoll(context.TODO(), repository.CreatePollArgs{
+		MessageID:      messageID,
+		CreatorID:      creatorID,
+		Options:        []string{"a", "b", "c"},
+		MultipleChoice: multipleChoice,
+		Deadline:       deadline,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestRepositoryImpl_CreatePoll(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreatePoll(context.TODO(), repository.CreatePollArgs{MessageID: uuid.Nil, CreatorID: user.GetID(), Options: []string{"a", "b"}})
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+
+		p, err := repo.CreatePoll(context.TODO(), repository.CreatePollArgs{
+			MessageID: m.ID,
+			CreatorID: user.GetID(),
+			Options:   []string{"a", "b"},
+			Anonymous: true,
+		})
+		if assert.NoError(err) {
+			assert.NotEmpty(p.ID)
+			assert.Equal(m.ID, p.MessageID)
+			assert.True(p.Anonymous)
+			if assert.Len(p.Options, 2) {
+				assert.Equal("a", p.Options[0].Content)
+				assert.Equal("b", p.Options[1].Content)
+			}
+		}
+
+		_, err = repo.CreatePoll(context.TODO(), repository.CreatePollArgs{
+			MessageID: m.ID,
+			CreatorID: user.GetID(),
+			Options:   []string{"a", "b"},
+		})
+		assert.EqualError(err, repository.ErrAlreadyExists.Error())
+	})
+}
+
+func TestRepositoryImpl_GetPollByMessageID(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetPollByMessageID(context.TODO(), uuid.Must(uuid.NewV7()))
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		p := mustMakePoll(t, repo, m.ID, user.GetID(), false, optional.Of[time.Time]{})
+
+		poll, err := repo.GetPollByMessageID(context.TODO(), m.ID)
+		if assert.NoError(err) {
+			assert.Equal(p.ID, poll.ID)
+			if assert.Len(poll.Options, 3) {
+				assert.Equal("a", poll.Options[0].Content)
+				assert.Equal("c", poll.Options[2].Content)
+			}
+		}
+
+		msg, err := repo.GetMessageByID(context.TODO(), m.ID)
+		if assert.NoError(err) && assert.NotNil(msg.Poll) {
+			assert.Equal(p.ID, msg.Poll.ID)
+			assert.Len(msg.Poll.Options, 3)
+		}
+	})
+}
+
+func TestRepositoryImpl_SetPollVotes(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.SetPollVotes(context.TODO(), uuid.Nil, user.GetID(), nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.SetPollVotes(context.TODO(), uuid.Must(uuid.NewV7()), user.GetID(), nil), repository.ErrNotFound.Error())
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		p := mustMakePoll(t, repo, m.ID, user.GetID(), false, optional.Of[time.Time]{})
+		_, err := repo.ClosePoll(context.TODO(), p.ID, time.Now())
+		require.NoError(t, err)
+
+		assert.EqualError(t, repo.SetPollVotes(context.TODO(), p.ID, user.GetID(), []uuid.UUID{p.Options[0].ID}), repository.ErrForbidden.Error())
+	})
+
+	t.Run("deadline passed", func(t *testing.T) {
+		t.Parallel()
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		p := mustMakePoll(t, repo, m.ID, user.GetID(), false, optional.From(time.Now().Add(-time.Minute)))
+
+		assert.EqualError(t, repo.SetPollVotes(context.TODO(), p.ID, user.GetID(), []uuid.UUID{p.Options[0].ID}), repository.ErrForbidden.Error())
+		votes, err := repo.GetPollVotes(context.TODO(), p.ID)
+		if assert.NoError(t, err) {
+			assert.Empty(t, votes)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		user2 := mustMakeUser(t, repo, rand, false)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		p := mustMakePoll(t, repo, m.ID, user.GetID(), true, optional.Of[time.Time]{})
+
+		require.NoError(t, repo.SetPollVotes(context.TODO(), p.ID, user.GetID(), []uuid.UUID{p.Options[0].ID, p.Options[1].ID}))
+		require.NoError(t, repo.SetPollVotes(context.TODO(), p.ID, user2.GetID(), []uuid.UUID{p.Options[0].ID}))
+
+		poll, err := repo.GetPollByMessageID(context.TODO(), m.ID)
+		if assert.NoError(err) {
+			assert.Equal(2, poll.VoterCount)
+			assert.Equal(2, poll.Options[0].VoteCount)
+			assert.Equal(1, poll.Options[1].VoteCount)
+			assert.Equal(0, poll.Options[2].VoteCount)
+		}
+
+		// 票の置き換え
+		require.NoError(t, repo.SetPollVotes(context.TODO(), p.ID, user.GetID(), []uuid.UUID{p.Options[2].ID}))
+		// 取り消し
+		require.NoError(t, repo.SetPollVotes(context.TODO(), p.ID, user2.GetID(), nil))
+
+		poll, err = repo.GetPollByMessageID(context.TODO(), m.ID)
+		if assert.NoError(err) {
+			assert.Equal(1, poll.VoterCount)
+			assert.Equal(0, poll.Options[0].VoteCount)
+			assert.Equal(0, poll.Options[1].VoteCount)
+			assert.Equal(1, poll.Options[2].VoteCount)
+		}
+
+		votes, err := repo.GetPollVotes(context.TODO(), p.ID)
+		if assert.NoError(err) && assert.Len(votes, 1) {
+			assert.Equal(user.GetID(), votes[0].UserID)
+			assert.Equal(p.Options[2].ID, votes[0].OptionID)
+		}
+	})
+}
+
+func TestRepositoryImpl_ClosePoll(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.ClosePoll(context.TODO(), uuid.Nil, time.Now())
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.ClosePoll(context.TODO(), uuid.Must(uuid.NewV7()), time.Now())
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		m := mustMakeMessage(t, repo, user.GetID(), channel.ID)
+		p := mustMakePoll(t, repo, m.ID, user.GetID(), false, optional.Of[time.Time]{})
+
+		poll, err := repo.ClosePoll(context.TODO(), p.ID, time.Now())
+		if assert.NoError(err) {
+			assert.True(poll.ClosedAt.Valid)
+			assert.Len(poll.Options, 3)
+		}
+
+		_, err = repo.ClosePoll(context.TODO(), p.ID, time.Now())
+		assert.EqualError(err, repository.ErrAlreadyExists.Error())
+	})
+}
+
+func TestRepositoryImpl_GetExpiredPolls(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	now := time.Now()
+	expired := mustMakePoll(t, repo, mustMakeMessage(t, repo, user.GetID(), channel.ID).ID, user.GetID(), false, optional.From(now.Add(-time.Minute)))
+	closed := mustMakePoll(t, repo, mustMakeMessage(t, repo, user.GetID(), channel.ID).ID, user.GetID(), false, optional.From(now.Add(-time.Minute)))
+	_, err := repo.ClosePoll(context.TODO(), closed.ID, now)
+	require.NoError(t, err)
+	mustMakePoll(t, repo, mustMakeMessage(t, repo, user.GetID(), channel.ID).ID, user.GetID(), false, optional.From(now.Add(time.Hour)))
+	mustMakePoll(t, repo, mustMakeMessage(t, repo, user.GetID(), channel.ID).ID, user.GetID(), false, optional.Of[time.Time]{})
+
+	polls, err := repo.GetExpiredPolls(context.TODO(), now, 10)
+	if assert.NoError(t, err) && assert.Len(t, polls, 1) {
+		assert.Equal(t, expired.ID, polls[0].ID)
+	}
+}
@@ -1,11 +1,15 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/leandro-lugaresi/hub"
+	"github.com/motoki317/sc"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 
 	"github.com/traPtitech/traQ/migration"
+	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 )
 
@@ -16,18 +20,23 @@ type Repository struct {
 	logger *zap.Logger
 	repository.StampRepository
 	repository.UserRepository
+
+	// notifyKeywordSettings 通知キーワードが設定されているユーザー設定のキャッシュ
+	notifyKeywordSettings *sc.Cache[struct{}, []*model.UserSettings]
 }
 
 // NewGormRepository リポジトリ実装を初期化して生成します。
 // スキーマが初期化された場合、init: true を返します。
 func NewGormRepository(db *gorm.DB, hub *hub.Hub, logger *zap.Logger, doMigration bool) (repo repository.Repository, init bool, err error) {
-	repo = &Repository{
+	r := &Repository{
 		db:              db,
 		hub:             hub,
 		logger:          logger.Named("repository"),
 		StampRepository: makeStampRepository(db, hub),
 		UserRepository:  makeUserRepository(db, hub),
 	}
+	r.notifyKeywordSettings = sc.NewMust(r.loadUserSettingsWithNotifyKeywords, 1*time.Hour, 1*time.Hour)
+	repo = r
 	if doMigration {
 		if init, err = migration.Migrate(db); err != nil {
 			return nil, false, err
@@ -331,3 +331,40 @@ func count(t *testing.T, where *gorm.DB) int {
 	require.NoError(t, where.Count(&c).Error)
 	return int(c)
 }
+
+func mustMakeScheduledMessage(t *testing.T, repo repository.Repository, userID, channelID uuid.UUID, scheduledAt time.Time) *model.ScheduledMessage {
+	t.Helper()
+	sm, err := repo.CreateScheduledMessage(context.TODO(), repository.CreateScheduledMessageArgs{
+		UserID:      userID,
+		ChannelID:   channelID,
+		Text:        "scheduled message",
+		ScheduledAt: scheduledAt,
+	})
+	require.NoError(t, err)
+	return sm
+}
+
+func mustMakeSavedSearch(t *testing.T, repo repository.Repository, userID uuid.UUID, query string, notify bool) *model.SavedSearch {
+	t.Helper()
+	s, err := repo.CreateSavedSearch(context.TODO(), repository.CreateSavedSearchArgs{
+		UserID: userID,
+		Name:   "saved search",
+		Query:  query,
+		Notify: notify,
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func mustMakeInboxItem(t *testing.T, repo repository.Repository, userID uuid.UUID, m *model.Message) *model.InboxItem {
+	t.Helper()
+	items, err := repo.CreateInboxItems(context.TODO(), []repository.CreateInboxItemArgs{{
+		UserID:    userID,
+		Type:      model.InboxItemTypeMention,
+		MessageID: m.ID,
+		ChannelID: m.ChannelID,
+		ActorID:   m.UserID,
+	}})
+	require.NoError(t, err)
+	return items[0]
+}
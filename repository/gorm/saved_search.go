@@ -0,0 +1,103 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+)
+
+// CreateSavedSearch implements SavedSearchRepository interface.
+func (repo *Repository) CreateSavedSearch(ctx context.Context, args repository.CreateSavedSearchArgs) (*model.SavedSearch, error) {
+	if args.UserID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	s := &model.SavedSearch{
+		ID:     uuid.Must(uuid.NewV7()),
+		UserID: args.UserID,
+		Name:   args.Name,
+		Query:  args.Query,
+		Notify: args.Notify,
+	}
+	if err := repo.db.WithContext(ctx).Create(s).Error; err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// UpdateSavedSearch implements SavedSearchRepository interface.
+func (repo *Repository) UpdateSavedSearch(ctx context.Context, id uuid.UUID, args repository.UpdateSavedSearchArgs) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	changes := map[string]interface{}{}
+	if args.Name.Valid {
+		changes["name"] = args.Name.V
+	}
+	if args.Query.Valid {
+		changes["query"] = args.Query.V
+	}
+	if args.Notify.Valid {
+		changes["notify"] = args.Notify.V
+	}
+
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var s model.SavedSearch
+		if err := tx.First(&s, &model.SavedSearch{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		return tx.Model(&s).Updates(changes).Error
+	})
+}
+
+// GetSavedSearch implements SavedSearchRepository interface.
+func (repo *Repository) GetSavedSearch(ctx context.Context, id uuid.UUID) (*model.SavedSearch, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var s model.SavedSearch
+	if err := repo.db.WithContext(ctx).First(&s, &model.SavedSearch{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &s, nil
+}
+
+// GetSavedSearchesByUserID implements SavedSearchRepository interface.
+func (repo *Repository) GetSavedSearchesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.SavedSearch, error) {
+	arr := make([]*model.SavedSearch, 0)
+	if userID == uuid.Nil {
+		return arr, nil
+	}
+	err := repo.db.WithContext(ctx).Where(&model.SavedSearch{UserID: userID}).Order("created_at").Find(&arr).Error
+	return arr, err
+}
+
+// GetNotifyingSavedSearches implements SavedSearchRepository interface.
+func (repo *Repository) GetNotifyingSavedSearches(ctx context.Context) ([]*model.SavedSearch, error) {
+	arr := make([]*model.SavedSearch, 0)
+	err := repo.db.WithContext(ctx).Where("notify = ?", true).Find(&arr).Error
+	return arr, err
+}
+
+// DeleteSavedSearch implements SavedSearchRepository interface.
+func (repo *Repository) DeleteSavedSearch(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.WithContext(ctx).Delete(&model.SavedSearch{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateSavedSearch(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateSavedSearch(context.TODO(), repository.CreateSavedSearchArgs{
+			UserID: uuid.Nil,
+			Name:   "a",
+			Query:  "a",
+		})
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		s, err := repo.CreateSavedSearch(context.TODO(), repository.CreateSavedSearchArgs{
+			UserID: user.GetID(),
+			Name:   "deploy errors",
+			Query:  "error from:@deploy-bot",
+			Notify: true,
+		})
+		if assert.NoError(err) {
+			assert.NotEmpty(s.ID)
+			assert.Equal(user.GetID(), s.UserID)
+			assert.Equal("deploy errors", s.Name)
+			assert.Equal("error from:@deploy-bot", s.Query)
+			assert.True(s.Notify)
+		}
+	})
+}
+
+func TestRepositoryImpl_UpdateSavedSearch(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateSavedSearch(context.TODO(), uuid.Nil, repository.UpdateSavedSearchArgs{}), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateSavedSearch(context.TODO(), uuid.Must(uuid.NewV7()), repository.UpdateSavedSearchArgs{}), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		s := mustMakeSavedSearch(t, repo, user.GetID(), "a", false)
+
+		if assert.NoError(repo.UpdateSavedSearch(context.TODO(), s.ID, repository.UpdateSavedSearchArgs{
+			Name:   optional.From("updated"),
+			Query:  optional.From("b"),
+			Notify: optional.From(true),
+		})) {
+			s, err := repo.GetSavedSearch(context.TODO(), s.ID)
+			if assert.NoError(err) {
+				assert.Equal("updated", s.Name)
+				assert.Equal("b", s.Query)
+				assert.True(s.Notify)
+			}
+		}
+	})
+}
+
+func TestRepositoryImpl_GetSavedSearch(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetSavedSearch(context.TODO(), uuid.Nil)
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetSavedSearch(context.TODO(), uuid.Must(uuid.NewV7()))
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s := mustMakeSavedSearch(t, repo, user.GetID(), "a", false)
+
+		r, err := repo.GetSavedSearch(context.TODO(), s.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, s.ID, r.ID)
+			assert.Equal(t, s.Query, r.Query)
+		}
+	})
+}
+
+func TestRepositoryImpl_GetSavedSearchesByUserID(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	s1 := mustMakeSavedSearch(t, repo, user.GetID(), "a", false)
+	s2 := mustMakeSavedSearch(t, repo, user.GetID(), "b", true)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		arr, err := repo.GetSavedSearchesByUserID(context.TODO(), uuid.Nil)
+		if assert.NoError(t, err) {
+			assert.Empty(t, arr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		arr, err := repo.GetSavedSearchesByUserID(context.TODO(), user.GetID())
+		if assert.NoError(t, err) && assert.Len(t, arr, 2) {
+			assert.Equal(t, s1.ID, arr[0].ID)
+			assert.Equal(t, s2.ID, arr[1].ID)
+		}
+	})
+}
+
+func TestRepositoryImpl_GetNotifyingSavedSearches(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	notifying := mustMakeSavedSearch(t, repo, user.GetID(), "a", true)
+	notNotifying := mustMakeSavedSearch(t, repo, user.GetID(), "b", false)
+
+	arr, err := repo.GetNotifyingSavedSearches(context.TODO())
+	if assert.NoError(t, err) {
+		ids := make([]uuid.UUID, len(arr))
+		for i, s := range arr {
+			ids[i] = s.ID
+		}
+		assert.Contains(t, ids, notifying.ID)
+		assert.NotContains(t, ids, notNotifying.ID)
+	}
+}
+
+func TestRepositoryImpl_DeleteSavedSearch(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user := setupWithUser(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.DeleteSavedSearch(context.TODO(), uuid.Nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.DeleteSavedSearch(context.TODO(), uuid.Must(uuid.NewV7())), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s := mustMakeSavedSearch(t, repo, user.GetID(), "a", false)
+
+		if assert.NoError(t, repo.DeleteSavedSearch(context.TODO(), s.ID)) {
+			_, err := repo.GetSavedSearch(context.TODO(), s.ID)
+			assert.EqualError(t, err, repository.ErrNotFound.Error())
+		}
+	})
+}
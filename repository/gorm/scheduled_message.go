@@ -0,0 +1,175 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/gormutil"
+)
+
+// CreateScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) CreateScheduledMessage(ctx context.Context, args repository.CreateScheduledMessageArgs) (*model.ScheduledMessage, error) {
+	if args.UserID == uuid.Nil || args.ChannelID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+
+	m := &model.ScheduledMessage{
+		ID:          uuid.Must(uuid.NewV7()),
+		UserID:      args.UserID,
+		ChannelID:   args.ChannelID,
+		DMUserID:    args.DMUserID,
+		Text:        args.Text,
+		ScheduledAt: args.ScheduledAt,
+		State:       model.ScheduledMessagePending,
+	}
+	if err := repo.db.WithContext(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// UpdateScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) UpdateScheduledMessage(ctx context.Context, id uuid.UUID, args repository.UpdateScheduledMessageArgs) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	changes := map[string]interface{}{}
+	if args.Text.Valid {
+		changes["text"] = args.Text.V
+	}
+	if args.ScheduledAt.Valid {
+		changes["scheduled_at"] = args.ScheduledAt.V
+	}
+
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var m model.ScheduledMessage
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&m, &model.ScheduledMessage{ID: id}).Error; err != nil {
+			return convertError(err)
+		}
+		if m.State == model.ScheduledMessagePosting {
+			return repository.ErrForbidden
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+		// 投稿に失敗していた場合も変更後の内容で再度投稿する
+		changes["state"] = model.ScheduledMessagePending
+		changes["attempts"] = 0
+		return tx.Model(&m).Updates(changes).Error
+	})
+}
+
+// GetScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) GetScheduledMessage(ctx context.Context, id uuid.UUID) (*model.ScheduledMessage, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var m model.ScheduledMessage
+	if err := repo.db.WithContext(ctx).First(&m, &model.ScheduledMessage{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &m, nil
+}
+
+// GetScheduledMessagesByUserID implements ScheduledMessageRepository interface.
+func (repo *Repository) GetScheduledMessagesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.ScheduledMessage, error) {
+	arr := make([]*model.ScheduledMessage, 0)
+	if userID == uuid.Nil {
+		return arr, nil
+	}
+	err := repo.db.WithContext(ctx).Where(&model.ScheduledMessage{UserID: userID}).Order("scheduled_at").Find(&arr).Error
+	return arr, err
+}
+
+// GetDueScheduledMessages implements ScheduledMessageRepository interface.
+func (repo *Repository) GetDueScheduledMessages(ctx context.Context, until time.Time, limit int) ([]*model.ScheduledMessage, error) {
+	arr := make([]*model.ScheduledMessage, 0)
+	err := repo.db.
+		WithContext(ctx).
+		Where("state = ? AND scheduled_at <= ?", model.ScheduledMessagePending, until).
+		Order("scheduled_at").
+		Scopes(gormutil.LimitAndOffset(limit, 0)).
+		Find(&arr).
+		Error
+	return arr, err
+}
+
+// ClaimScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) ClaimScheduledMessage(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.
+		WithContext(ctx).
+		Model(&model.ScheduledMessage{}).
+		Where("id = ? AND state = ?", id, model.ScheduledMessagePending).
+		Updates(map[string]interface{}{
+			"state":      model.ScheduledMessagePosting,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"claimed_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// RecoverScheduledMessages implements ScheduledMessageRepository interface.
+func (repo *Repository) RecoverScheduledMessages(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	result := repo.db.
+		WithContext(ctx).
+		Model(&model.ScheduledMessage{}).
+		// claimed_atが無いものはカラム追加前に投稿処理中になったもの
+		Where("state = ? AND (claimed_at IS NULL OR claimed_at <= ?)", model.ScheduledMessagePosting, claimedBefore).
+		Update("state", gorm.Expr(
+			"CASE WHEN attempts >= ? THEN ? ELSE ? END",
+			model.ScheduledMessageMaxAttempts,
+			model.ScheduledMessageFailed,
+			model.ScheduledMessagePending,
+		))
+	return result.RowsAffected, result.Error
+}
+
+// UpdateScheduledMessageState implements ScheduledMessageRepository interface.
+func (repo *Repository) UpdateScheduledMessageState(ctx context.Context, id uuid.UUID, state model.ScheduledMessageState) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.
+		WithContext(ctx).
+		Model(&model.ScheduledMessage{}).
+		Where("id = ?", id).
+		Update("state", state)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteScheduledMessage implements ScheduledMessageRepository interface.
+func (repo *Repository) DeleteScheduledMessage(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.WithContext(ctx).Delete(&model.ScheduledMessage{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
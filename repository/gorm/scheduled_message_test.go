@@ -0,0 +1,316 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_CreateScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.CreateScheduledMessage(context.TODO(), repository.CreateScheduledMessageArgs{
+			UserID:      user.GetID(),
+			ChannelID:   uuid.Nil,
+			Text:        "a",
+			ScheduledAt: time.Now().Add(time.Hour),
+		})
+		assert.EqualError(t, err, repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+
+		at := time.Now().Add(time.Hour).Truncate(time.Second)
+		sm, err := repo.CreateScheduledMessage(context.TODO(), repository.CreateScheduledMessageArgs{
+			UserID:      user.GetID(),
+			ChannelID:   channel.ID,
+			Text:        "scheduled",
+			ScheduledAt: at,
+		})
+		if assert.NoError(err) {
+			assert.NotEmpty(sm.ID)
+			assert.Equal(user.GetID(), sm.UserID)
+			assert.Equal(channel.ID, sm.ChannelID)
+			assert.False(sm.IsDM())
+			assert.Equal("scheduled", sm.Text)
+			assert.True(at.Equal(sm.ScheduledAt))
+			assert.Equal(model.ScheduledMessagePending, sm.State)
+		}
+	})
+}
+
+func TestRepositoryImpl_UpdateScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateScheduledMessage(context.TODO(), uuid.Nil, repository.UpdateScheduledMessageArgs{}), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateScheduledMessage(context.TODO(), uuid.Must(uuid.NewV7()), repository.UpdateScheduledMessageArgs{}), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(time.Hour))
+
+		at := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+		if assert.NoError(repo.UpdateScheduledMessage(context.TODO(), sm.ID, repository.UpdateScheduledMessageArgs{
+			Text:        optional.From("updated"),
+			ScheduledAt: optional.From(at),
+		})) {
+			sm, err := repo.GetScheduledMessage(context.TODO(), sm.ID)
+			if assert.NoError(err) {
+				assert.Equal("updated", sm.Text)
+				assert.True(at.Equal(sm.ScheduledAt))
+			}
+		}
+	})
+
+	t.Run("posting", func(t *testing.T) {
+		t.Parallel()
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(-time.Minute))
+		require.NoError(t, repo.ClaimScheduledMessage(context.TODO(), sm.ID))
+
+		assert.EqualError(t, repo.UpdateScheduledMessage(context.TODO(), sm.ID, repository.UpdateScheduledMessageArgs{Text: optional.From("updated")}), repository.ErrForbidden.Error())
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(-time.Minute))
+		require.NoError(t, repo.ClaimScheduledMessage(context.TODO(), sm.ID))
+		require.NoError(t, repo.UpdateScheduledMessageState(context.TODO(), sm.ID, model.ScheduledMessageFailed))
+
+		// 編集すると再度投稿待ちになる
+		if assert.NoError(repo.UpdateScheduledMessage(context.TODO(), sm.ID, repository.UpdateScheduledMessageArgs{Text: optional.From("updated")})) {
+			sm, err := repo.GetScheduledMessage(context.TODO(), sm.ID)
+			if assert.NoError(err) {
+				assert.Equal(model.ScheduledMessagePending, sm.State)
+				assert.Equal(0, sm.Attempts)
+			}
+		}
+	})
+}
+
+func TestRepositoryImpl_GetScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetScheduledMessage(context.TODO(), uuid.Nil)
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := repo.GetScheduledMessage(context.TODO(), uuid.Must(uuid.NewV7()))
+		assert.EqualError(t, err, repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(time.Hour))
+
+		r, err := repo.GetScheduledMessage(context.TODO(), sm.ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, sm.ID, r.ID)
+			assert.Equal(t, sm.Text, r.Text)
+		}
+	})
+}
+
+func TestRepositoryImpl_GetScheduledMessagesByUserID(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	sm2 := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(2*time.Hour))
+	sm1 := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(time.Hour))
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		arr, err := repo.GetScheduledMessagesByUserID(context.TODO(), uuid.Nil)
+		if assert.NoError(t, err) {
+			assert.Empty(t, arr)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+
+		arr, err := repo.GetScheduledMessagesByUserID(context.TODO(), user.GetID())
+		if assert.NoError(t, err) && assert.Len(t, arr, 2) {
+			assert.Equal(t, sm1.ID, arr[0].ID)
+			assert.Equal(t, sm2.ID, arr[1].ID)
+		}
+	})
+}
+
+func TestRepositoryImpl_GetDueScheduledMessages(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	due := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(-time.Minute))
+	notDue := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(time.Hour))
+	claimed := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(-time.Minute))
+	require.NoError(t, repo.ClaimScheduledMessage(context.TODO(), claimed.ID))
+
+	arr, err := repo.GetDueScheduledMessages(context.TODO(), time.Now(), 0)
+	if assert.NoError(t, err) {
+		ids := make([]uuid.UUID, len(arr))
+		for i, sm := range arr {
+			ids[i] = sm.ID
+		}
+		assert.Contains(t, ids, due.ID)
+		assert.NotContains(t, ids, notDue.ID)
+		assert.NotContains(t, ids, claimed.ID)
+	}
+}
+
+func TestRepositoryImpl_ClaimScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.ClaimScheduledMessage(context.TODO(), uuid.Nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.ClaimScheduledMessage(context.TODO(), uuid.Must(uuid.NewV7())), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(-time.Minute))
+
+		if assert.NoError(repo.ClaimScheduledMessage(context.TODO(), sm.ID)) {
+			sm, err := repo.GetScheduledMessage(context.TODO(), sm.ID)
+			if assert.NoError(err) {
+				assert.Equal(model.ScheduledMessagePosting, sm.State)
+				assert.Equal(1, sm.Attempts)
+			}
+		}
+		// 投稿処理中のものは再度処理できない
+		assert.EqualError(repo.ClaimScheduledMessage(context.TODO(), sm.ID), repository.ErrNotFound.Error())
+	})
+}
+
+func TestRepositoryImpl_RecoverScheduledMessages(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+	assert, require := assertAndRequire(t)
+
+	claim := func(attempts int, claimedAt time.Time) *model.ScheduledMessage {
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(-time.Minute))
+		require.NoError(getDB(repo).Model(sm).Update("attempts", attempts-1).Error)
+		require.NoError(repo.ClaimScheduledMessage(context.TODO(), sm.ID))
+		require.NoError(getDB(repo).Model(sm).Update("claimed_at", claimedAt).Error)
+		return sm
+	}
+	stuck := claim(1, time.Now().Add(-time.Hour))
+	exhausted := claim(model.ScheduledMessageMaxAttempts, time.Now().Add(-time.Hour))
+	posting := claim(1, time.Now())
+
+	n, err := repo.RecoverScheduledMessages(context.TODO(), time.Now().Add(-30*time.Minute))
+	require.NoError(err)
+	assert.EqualValues(2, n)
+
+	for sm, state := range map[*model.ScheduledMessage]model.ScheduledMessageState{
+		stuck:     model.ScheduledMessagePending,
+		exhausted: model.ScheduledMessageFailed,
+		posting:   model.ScheduledMessagePosting,
+	} {
+		sm, err := repo.GetScheduledMessage(context.TODO(), sm.ID)
+		if assert.NoError(err) {
+			assert.Equal(state, sm.State)
+		}
+	}
+}
+
+func TestRepositoryImpl_UpdateScheduledMessageState(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateScheduledMessageState(context.TODO(), uuid.Nil, model.ScheduledMessagePending), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateScheduledMessageState(context.TODO(), uuid.Must(uuid.NewV7()), model.ScheduledMessagePending), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		assert := assert.New(t)
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(-time.Minute))
+		require.NoError(t, repo.ClaimScheduledMessage(context.TODO(), sm.ID))
+
+		if assert.NoError(repo.UpdateScheduledMessageState(context.TODO(), sm.ID, model.ScheduledMessagePending)) {
+			sm, err := repo.GetScheduledMessage(context.TODO(), sm.ID)
+			if assert.NoError(err) {
+				assert.Equal(model.ScheduledMessagePending, sm.State)
+				assert.Equal(1, sm.Attempts)
+			}
+		}
+	})
+}
+
+func TestRepositoryImpl_DeleteScheduledMessage(t *testing.T) {
+	t.Parallel()
+	repo, _, _, user, channel := setupWithUserAndChannel(t, common2, false)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.DeleteScheduledMessage(context.TODO(), uuid.Nil), repository.ErrNilID.Error())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.DeleteScheduledMessage(context.TODO(), uuid.Must(uuid.NewV7())), repository.ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		sm := mustMakeScheduledMessage(t, repo, user.GetID(), channel.ID, time.Now().Add(time.Hour))
+
+		if assert.NoError(t, repo.DeleteScheduledMessage(context.TODO(), sm.ID)) {
+			_, err := repo.GetScheduledMessage(context.TODO(), sm.ID)
+			assert.EqualError(t, err, repository.ErrNotFound.Error())
+		}
+	})
+}
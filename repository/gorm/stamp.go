@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sort"
+	"strings"
 	"time"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
@@ -315,6 +316,46 @@ func (r *stampRepository) GetAllStampsWithThumbnail(ctx context.Context, stampTy
 	return r.perType.Get(ctx, stampType)
 }
 
+// SearchStamps implements StampRepository interface.
+func (r *stampRepository) SearchStamps(ctx context.Context, word string, limit int) ([]*model.StampWithThumbnail, error) {
+	stamps, err := r.perType.Get(ctx, repository.StampTypeAll)
+	if err != nil {
+		return nil, err
+	}
+
+	// 一致度 (小さいほど上位)
+	word = strings.ToLower(word)
+	rank := func(name string) int {
+		name = strings.ToLower(name)
+		switch {
+		case name == word:
+			return 0
+		case strings.HasPrefix(name, word):
+			return 1
+		default:
+			return 2
+		}
+	}
+
+	arr := make([]*model.StampWithThumbnail, 0)
+	for _, s := range stamps.Value() {
+		if strings.Contains(strings.ToLower(s.Name), word) {
+			arr = append(arr, s)
+		}
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		ri, rj := rank(arr[i].Name), rank(arr[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return arr[i].Name < arr[j].Name
+	})
+	if limit > 0 && len(arr) > limit {
+		arr = arr[:limit]
+	}
+	return arr, nil
+}
+
 // StampExists implements StampRepository interface.
 func (r *stampRepository) StampExists(ctx context.Context, id uuid.UUID) (bool, error) {
 	if id == uuid.Nil {
@@ -2,6 +2,7 @@ package gorm
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -278,6 +279,41 @@ func TestRepositoryImpl_GetAllStampsWithThumbnail(t *testing.T) {
 	})
 }
 
+func TestRepositoryImpl_SearchStamps(t *testing.T) {
+	t.Parallel()
+	repo, assert, _ := setup(t, common3)
+
+	word := strings.ToLower(random2.AlphaNumeric(10))
+	contains := mustMakeStamp(t, repo, "a"+word, uuid.Nil)
+	prefix := mustMakeStamp(t, repo, word+"b", uuid.Nil)
+	exact := mustMakeStamp(t, repo, word, uuid.Nil)
+	mustMakeStamp(t, repo, rand, uuid.Nil)
+
+	ids := func(stamps []*model.StampWithThumbnail) []uuid.UUID {
+		res := make([]uuid.UUID, len(stamps))
+		for i, s := range stamps {
+			res[i] = s.ID
+		}
+		return res
+	}
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+		stamps, err := repo.SearchStamps(context.TODO(), strings.ToUpper(word), 0)
+		if assert.NoError(err) {
+			assert.Equal([]uuid.UUID{exact.ID, prefix.ID, contains.ID}, ids(stamps))
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		t.Parallel()
+		stamps, err := repo.SearchStamps(context.TODO(), word, 2)
+		if assert.NoError(err) {
+			assert.Equal([]uuid.UUID{exact.ID, prefix.ID}, ids(stamps))
+		}
+	})
+}
+
 func TestRepositoryImpl_StampExists(t *testing.T) {
 	t.Parallel()
 	repo, _, _ := setup(t, common2)
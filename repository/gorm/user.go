@@ -9,6 +9,7 @@ import (
 	"github.com/leandro-lugaresi/hub"
 	"github.com/motoki317/sc"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
@@ -191,12 +192,36 @@ func (r *userRepository) makeGetUsersTx(ctx context.Context, query repository.Us
 	if query.IsSubscriberAtNotifyLevelOf.Valid {
 		tx = tx.Joins("INNER JOIN users_subscribe_channels ON users_subscribe_channels.user_id = users.id AND users_subscribe_channels.channel_id = ? AND users_subscribe_channels.notify = true", query.IsSubscriberAtNotifyLevelOf.V)
 	}
+	if query.IsThreadSubscriberOf.Valid {
+		tx = tx.Joins("INNER JOIN message_thread_subscriptions ON message_thread_subscriptions.user_id = users.id AND message_thread_subscriptions.message_id = ?", query.IsThreadSubscriberOf.V)
+	}
 	if query.IsCMemberOf.Valid {
 		tx = tx.Joins("INNER JOIN users_private_channels ON users_private_channels.user_id = users.id AND users_private_channels.channel_id = ?", query.IsCMemberOf.V)
 	}
 	if query.IsGMemberOf.Valid {
 		tx = tx.Joins("INNER JOIN user_group_members ON user_group_members.user_id = users.id AND user_group_members.group_id = ?", query.IsGMemberOf.V)
 	}
+	if query.Word.Valid {
+		pattern := gormutil.LikeContains(query.Word.V)
+		tx = tx.Where("users.name LIKE ? OR users.display_name LIKE ? OR "+
+			"EXISTS (SELECT 1 FROM user_profiles WHERE user_profiles.user_id = users.id AND user_profiles.bio LIKE ?) OR "+
+			"EXISTS (SELECT 1 FROM users_tags INNER JOIN tags ON tags.id = users_tags.tag_id WHERE users_tags.user_id = users.id AND tags.name LIKE ?)",
+			pattern, pattern, pattern, pattern)
+		if query.Limit > 0 {
+			// 一致度の高いものから取得する
+			prefix := gormutil.LikePrefix(query.Word.V)
+			tx = tx.Order(clause.OrderBy{Expression: clause.Expr{
+				SQL: "CASE WHEN users.name = ? OR users.display_name = ? THEN 0 " +
+					"WHEN users.name LIKE ? OR users.display_name LIKE ? THEN 1 " +
+					"WHEN users.name LIKE ? OR users.display_name LIKE ? THEN 2 " +
+					"ELSE 3 END, users.name",
+				Vars: []interface{}{query.Word.V, query.Word.V, prefix, prefix, pattern, pattern},
+			}})
+		}
+	}
+	if query.Limit > 0 {
+		tx = tx.Limit(query.Limit)
+	}
 	if query.EnableProfileLoading {
 		tx = tx.Preload("Profile")
 	}
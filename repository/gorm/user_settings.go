@@ -2,11 +2,15 @@ package gorm
 
 import (
 	"context"
+	"crypto/subtle"
+	"time"
 
 	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
 
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 const defaultNotifyCitation = false
@@ -19,6 +23,7 @@ func (repo *Repository) UpdateNotifyCitation(ctx context.Context, userID uuid.UU
 
 	settings := model.UserSettings{}
 
+	defer repo.purgeUserSettingsCache()
 	if err := repo.db.WithContext(ctx).First(&settings, "user_id=?", userID).Error; err != nil {
 		err = convertError(err)
 		if err == repository.ErrNotFound {
@@ -68,8 +73,11 @@ func (repo *Repository) GetUserSettings(ctx context.Context, userID uuid.UUID) (
 	if err := repo.db.WithContext(ctx).First(&settings, "user_id=?", userID).Error; err != nil {
 		err = convertError(err)
 		dus := &model.UserSettings{
-			UserID:         userID,
-			NotifyCitation: defaultNotifyCitation,
+			UserID:          userID,
+			NotifyCitation:  defaultNotifyCitation,
+			NotifyKeywords:  model.NotifyKeywords{},
+			DNDSchedules:    model.DNDSchedules{},
+			DigestFrequency: model.DigestFrequencyOff,
 		}
 		if err == repository.ErrNotFound {
 			return dus, nil
@@ -79,3 +87,146 @@ func (repo *Repository) GetUserSettings(ctx context.Context, userID uuid.UUID) (
 
 	return &settings, nil
 }
+
+// UpdateNotifyKeywords implements UserSettingsRepository interface
+func (repo *Repository) UpdateNotifyKeywords(ctx context.Context, userID uuid.UUID, keywords model.NotifyKeywords) error {
+	if keywords == nil {
+		keywords = model.NotifyKeywords{}
+	}
+	return repo.updateUserSettings(ctx, userID, map[string]interface{}{
+		"notify_keywords": keywords,
+	})
+}
+
+// GetUserSettingsWithNotifyKeywords implements UserSettingsRepository interface
+func (repo *Repository) GetUserSettingsWithNotifyKeywords(ctx context.Context) ([]*model.UserSettings, error) {
+	return repo.notifyKeywordSettings.Get(ctx, struct{}{})
+}
+
+func (repo *Repository) loadUserSettingsWithNotifyKeywords(ctx context.Context, _ struct{}) ([]*model.UserSettings, error) {
+	settings := make([]*model.UserSettings, 0)
+	return settings, repo.db.WithContext(ctx).
+		Where("notify_keywords IS NOT NULL AND notify_keywords <> ?", "[]").
+		Find(&settings).
+		Error
+}
+
+// purgeUserSettingsCache ユーザー設定のキャッシュを破棄します
+func (repo *Repository) purgeUserSettingsCache() {
+	repo.notifyKeywordSettings.Purge()
+}
+
+// GetUserSettingsByUserIDs implements UserSettingsRepository interface
+func (repo *Repository) GetUserSettingsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*model.UserSettings, error) {
+	settings := make([]*model.UserSettings, 0)
+	if len(userIDs) == 0 {
+		return settings, nil
+	}
+	return settings, repo.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&settings).Error
+}
+
+// UpdateDoNotDisturb implements UserSettingsRepository interface
+func (repo *Repository) UpdateDoNotDisturb(ctx context.Context, userID uuid.UUID, args repository.UpdateDoNotDisturbArgs) error {
+	schedules := args.Schedules
+	if schedules == nil {
+		schedules = model.DNDSchedules{}
+	}
+	return repo.updateUserSettings(ctx, userID, map[string]interface{}{
+		"dnd_schedules":      schedules,
+		"dnd_allow_forced":   args.AllowForced,
+		"dnd_allow_mentions": args.AllowMentions,
+	})
+}
+
+// UpdateSnoozeUntil implements UserSettingsRepository interface
+func (repo *Repository) UpdateSnoozeUntil(ctx context.Context, userID uuid.UUID, until optional.Of[time.Time]) error {
+	return repo.updateUserSettings(ctx, userID, map[string]interface{}{
+		"snooze_until": until,
+	})
+}
+
+// SetDigestEmail implements UserSettingsRepository interface
+func (repo *Repository) SetDigestEmail(ctx context.Context, userID uuid.UUID, email string, verificationCode string) error {
+	return repo.updateUserSettings(ctx, userID, map[string]interface{}{
+		"digest_email":             email,
+		"digest_email_verified":    false,
+		"digest_verification_code": verificationCode,
+	})
+}
+
+// VerifyDigestEmail implements UserSettingsRepository interface
+func (repo *Repository) VerifyDigestEmail(ctx context.Context, userID uuid.UUID, verificationCode string) error {
+	if userID == uuid.Nil {
+		return repository.ErrNotFound
+	}
+
+	defer repo.purgeUserSettingsCache()
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var settings model.UserSettings
+		if err := tx.First(&settings, "user_id=?", userID).Error; err != nil {
+			return convertError(err)
+		}
+		if len(settings.DigestEmail) == 0 || len(settings.DigestVerificationCode) == 0 {
+			return repository.ErrNotFound
+		}
+		if subtle.ConstantTimeCompare([]byte(settings.DigestVerificationCode), []byte(verificationCode)) != 1 {
+			return repository.ErrForbidden
+		}
+		return tx.Model(&settings).Updates(map[string]interface{}{
+			"digest_email_verified":    true,
+			"digest_verification_code": "",
+		}).Error
+	})
+}
+
+// UpdateDigestFrequency implements UserSettingsRepository interface
+func (repo *Repository) UpdateDigestFrequency(ctx context.Context, userID uuid.UUID, frequency model.DigestFrequency) error {
+	return repo.updateUserSettings(ctx, userID, map[string]interface{}{
+		"digest_frequency": frequency,
+	})
+}
+
+// GetUserSettingsWithDigestEnabled implements UserSettingsRepository interface
+func (repo *Repository) GetUserSettingsWithDigestEnabled(ctx context.Context) ([]*model.UserSettings, error) {
+	settings := make([]*model.UserSettings, 0)
+	return settings, repo.db.WithContext(ctx).
+		Where("digest_email_verified = TRUE AND digest_email <> '' AND digest_frequency <> ?", model.DigestFrequencyOff).
+		Find(&settings).
+		Error
+}
+
+// UpdateDigestCheckedAt implements UserSettingsRepository interface
+func (repo *Repository) UpdateDigestCheckedAt(ctx context.Context, userID uuid.UUID, checkedAt time.Time) error {
+	return repo.updateUserSettings(ctx, userID, map[string]interface{}{
+		"digest_checked_at": checkedAt,
+	})
+}
+
+// updateUserSettings ユーザー設定を更新します。ユーザー設定が存在しない場合は作成してから更新します
+func (repo *Repository) updateUserSettings(ctx context.Context, userID uuid.UUID, changes map[string]interface{}) error {
+	if userID == uuid.Nil {
+		return repository.ErrNilID
+	}
+
+	defer repo.purgeUserSettingsCache()
+	return repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		settings := model.UserSettings{}
+		if err := tx.First(&settings, "user_id=?", userID).Error; err != nil {
+			err = convertError(err)
+			if err != repository.ErrNotFound {
+				return err
+			}
+			settings = model.UserSettings{
+				UserID:          userID,
+				NotifyCitation:  defaultNotifyCitation,
+				NotifyKeywords:  model.NotifyKeywords{},
+				DNDSchedules:    model.DNDSchedules{},
+				DigestFrequency: model.DigestFrequencyOff,
+			}
+			if err := tx.Create(&settings).Error; err != nil {
+				return convertError(err)
+			}
+		}
+		return tx.Model(&settings).Updates(changes).Error
+	})
+}
@@ -0,0 +1,155 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestRepositoryImpl_UpdateNotifyKeywords(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common2)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateNotifyKeywords(context.TODO(), uuid.Nil, model.NotifyKeywords{}), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		user := mustMakeUser(t, repo, rand, false)
+		assert := assert.New(t)
+
+		keywords := model.NotifyKeywords{{Keyword: "traQ", CaseSensitive: true}}
+		if assert.NoError(repo.UpdateNotifyKeywords(context.TODO(), user.GetID(), keywords)) {
+			us, err := repo.GetUserSettings(context.TODO(), user.GetID())
+			if assert.NoError(err) {
+				assert.Equal(keywords, us.NotifyKeywords)
+			}
+		}
+
+		keywords = model.NotifyKeywords{{Keyword: "go", WholeWord: true}}
+		if assert.NoError(repo.UpdateNotifyKeywords(context.TODO(), user.GetID(), keywords)) {
+			us, err := repo.GetUserSettings(context.TODO(), user.GetID())
+			if assert.NoError(err) {
+				assert.Equal(keywords, us.NotifyKeywords)
+			}
+		}
+	})
+}
+
+func TestRepositoryImpl_GetUserSettingsWithNotifyKeywords(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, ex1)
+
+	user1 := mustMakeUser(t, repo, rand, false)
+	user2 := mustMakeUser(t, repo, rand, false)
+	user3 := mustMakeUser(t, repo, rand, false)
+	require.NoError(repo.UpdateNotifyKeywords(context.TODO(), user1.GetID(), model.NotifyKeywords{{Keyword: "foo"}}))
+	require.NoError(repo.UpdateNotifyKeywords(context.TODO(), user2.GetID(), model.NotifyKeywords{}))
+	require.NoError(repo.UpdateNotifyCitation(context.TODO(), user3.GetID(), true))
+
+	settings, err := repo.GetUserSettingsWithNotifyKeywords(context.TODO())
+	if assert.NoError(err) && assert.Len(settings, 1) {
+		assert.Equal(user1.GetID(), settings[0].UserID)
+		assert.Equal(model.NotifyKeywords{{Keyword: "foo"}}, settings[0].NotifyKeywords)
+	}
+
+	// 更新するとキャッシュが破棄される
+	require.NoError(repo.UpdateNotifyKeywords(context.TODO(), user1.GetID(), model.NotifyKeywords{}))
+	settings, err = repo.GetUserSettingsWithNotifyKeywords(context.TODO())
+	if assert.NoError(err) {
+		assert.Empty(settings)
+	}
+}
+
+func TestRepositoryImpl_UpdateDoNotDisturb(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common2)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateDoNotDisturb(context.TODO(), uuid.Nil, repository.UpdateDoNotDisturbArgs{}), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		user := mustMakeUser(t, repo, rand, false)
+		assert := assert.New(t)
+
+		args := repository.UpdateDoNotDisturbArgs{
+			Schedules: model.DNDSchedules{
+				{Weekdays: []time.Weekday{time.Monday}, Start: "23:00", End: "07:00", Timezone: "Asia/Tokyo"},
+			},
+			AllowForced: true,
+		}
+		if assert.NoError(repo.UpdateDoNotDisturb(context.TODO(), user.GetID(), args)) {
+			us, err := repo.GetUserSettings(context.TODO(), user.GetID())
+			if assert.NoError(err) {
+				assert.Equal(args.Schedules, us.DNDSchedules)
+				assert.True(us.DNDAllowForced)
+				assert.False(us.DNDAllowMentions)
+			}
+		}
+	})
+}
+
+func TestRepositoryImpl_UpdateSnoozeUntil(t *testing.T) {
+	t.Parallel()
+	repo, _, _ := setup(t, common2)
+
+	t.Run("nil id", func(t *testing.T) {
+		t.Parallel()
+
+		assert.EqualError(t, repo.UpdateSnoozeUntil(context.TODO(), uuid.Nil, optional.Of[time.Time]{}), repository.ErrNilID.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		user := mustMakeUser(t, repo, rand, false)
+		assert := assert.New(t)
+
+		until := time.Now().Add(time.Hour)
+		if assert.NoError(repo.UpdateSnoozeUntil(context.TODO(), user.GetID(), optional.From(until))) {
+			us, err := repo.GetUserSettings(context.TODO(), user.GetID())
+			if assert.NoError(err) && assert.True(us.SnoozeUntil.Valid) {
+				assert.WithinDuration(until, us.SnoozeUntil.V, time.Millisecond)
+			}
+		}
+
+		if assert.NoError(repo.UpdateSnoozeUntil(context.TODO(), user.GetID(), optional.Of[time.Time]{})) {
+			us, err := repo.GetUserSettings(context.TODO(), user.GetID())
+			if assert.NoError(err) {
+				assert.False(us.SnoozeUntil.Valid)
+			}
+		}
+	})
+}
+
+func TestRepositoryImpl_GetUserSettingsByUserIDs(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common2)
+
+	user1 := mustMakeUser(t, repo, rand, false)
+	user2 := mustMakeUser(t, repo, rand, false)
+	require.NoError(repo.UpdateNotifyCitation(context.TODO(), user1.GetID(), true))
+
+	settings, err := repo.GetUserSettingsByUserIDs(context.TODO(), []uuid.UUID{user1.GetID(), user2.GetID()})
+	if assert.NoError(err) && assert.Len(settings, 1) {
+		assert.Equal(user1.GetID(), settings[0].UserID)
+	}
+
+	settings, err = repo.GetUserSettingsByUserIDs(context.TODO(), nil)
+	if assert.NoError(err) {
+		assert.Len(settings, 0)
+	}
+}
@@ -124,6 +124,40 @@ func TestRepositoryImpl_GetUsers(t *testing.T) {
 	})
 }
 
+func TestRepositoryImpl_GetUsers_Contains(t *testing.T) {
+	t.Parallel()
+	repo, assert, require := setup(t, common3)
+
+	word := random2.AlphaNumeric(12)
+	byName := mustMakeUser(t, repo, "u"+word, false)
+	byDisplayName := mustMakeUser(t, repo, rand, false)
+	require.NoError(repo.UpdateUser(context.TODO(), byDisplayName.GetID(), repository.UpdateUserArgs{DisplayName: optional.From("表示" + word)}))
+	byBio := mustMakeUser(t, repo, rand, false)
+	require.NoError(repo.UpdateUser(context.TODO(), byBio.GetID(), repository.UpdateUserArgs{Bio: optional.From("よろしく " + word + " です")}))
+	byTag := mustMakeUser(t, repo, rand, false)
+	mustAddTagToUser(t, repo, byTag.GetID(), mustMakeTag(t, repo, "t"+word).ID)
+	mustMakeUser(t, repo, rand, false)
+
+	ids, err := repo.GetUserIDs(context.TODO(), repository.UsersQuery{}.Contains(word))
+	if assert.NoError(err) {
+		assert.ElementsMatch([]uuid.UUID{byName.GetID(), byDisplayName.GetID(), byBio.GetID(), byTag.GetID()}, ids)
+	}
+
+	// ワイルドカードはエスケープされる
+	ids, err = repo.GetUserIDs(context.TODO(), repository.UsersQuery{}.Contains(word[:4]+"_"+word[5:]))
+	if assert.NoError(err) {
+		assert.Empty(ids)
+	}
+
+	// 一致度の高いものから最大limit件取得する
+	prefix := mustMakeUser(t, repo, word+"x", false)
+	exact := mustMakeUser(t, repo, word, false)
+	ids, err = repo.GetUserIDs(context.TODO(), repository.UsersQuery{}.Contains(word).LimitOf(2))
+	if assert.NoError(err) {
+		assert.Equal([]uuid.UUID{exact.GetID(), prefix.GetID()}, ids)
+	}
+}
+
 func TestRepositoryImpl_GetUser(t *testing.T) {
 	t.Parallel()
 	repo, assert, _, user := setupWithUser(t, common2, false)
@@ -0,0 +1,102 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// RegisterWebPushSubscription implements WebPushSubscriptionRepository interface.
+func (repo *Repository) RegisterWebPushSubscription(ctx context.Context, args repository.RegisterWebPushSubscriptionArgs) (*model.WebPushSubscription, error) {
+	if args.UserID == uuid.Nil {
+		return nil, repository.ErrNilID
+	}
+	if len(args.Endpoint) == 0 {
+		return nil, repository.ArgError("Endpoint", "endpoint is empty")
+	}
+
+	s := &model.WebPushSubscription{
+		ID:       uuid.Must(uuid.NewV7()),
+		UserID:   args.UserID,
+		Endpoint: args.Endpoint,
+		P256dh:   args.P256dh,
+		Auth:     args.Auth,
+	}
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("endpoint = ?", args.Endpoint).Delete(&model.WebPushSubscription{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(s).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetWebPushSubscription implements WebPushSubscriptionRepository interface.
+func (repo *Repository) GetWebPushSubscription(ctx context.Context, id uuid.UUID) (*model.WebPushSubscription, error) {
+	if id == uuid.Nil {
+		return nil, repository.ErrNotFound
+	}
+	var s model.WebPushSubscription
+	if err := repo.db.WithContext(ctx).First(&s, &model.WebPushSubscription{ID: id}).Error; err != nil {
+		return nil, convertError(err)
+	}
+	return &s, nil
+}
+
+// GetWebPushSubscriptionsByUserID implements WebPushSubscriptionRepository interface.
+func (repo *Repository) GetWebPushSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.WebPushSubscription, error) {
+	subscriptions := make([]*model.WebPushSubscription, 0)
+	if userID == uuid.Nil {
+		return subscriptions, nil
+	}
+	return subscriptions, repo.db.WithContext(ctx).
+		Where(&model.WebPushSubscription{UserID: userID}).
+		Order("created_at").
+		Find(&subscriptions).
+		Error
+}
+
+// GetWebPushSubscriptions implements WebPushSubscriptionRepository interface.
+func (repo *Repository) GetWebPushSubscriptions(ctx context.Context, userIDs set.UUID) (map[uuid.UUID][]*model.WebPushSubscription, error) {
+	var tmp []*model.WebPushSubscription
+	if err := repo.db.WithContext(ctx).Where("user_id IN (?)", userIDs.StringArray()).Find(&tmp).Error; err != nil {
+		return nil, err
+	}
+
+	subscriptions := make(map[uuid.UUID][]*model.WebPushSubscription, len(userIDs))
+	for _, s := range tmp {
+		subscriptions[s.UserID] = append(subscriptions[s.UserID], s)
+	}
+	return subscriptions, nil
+}
+
+// DeleteWebPushSubscription implements WebPushSubscriptionRepository interface.
+func (repo *Repository) DeleteWebPushSubscription(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return repository.ErrNilID
+	}
+	result := repo.db.WithContext(ctx).Delete(&model.WebPushSubscription{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// DeleteWebPushSubscriptionsByEndpoints implements WebPushSubscriptionRepository interface.
+func (repo *Repository) DeleteWebPushSubscriptionsByEndpoints(ctx context.Context, endpoints []string) error {
+	if len(endpoints) == 0 {
+		return nil
+	}
+	return repo.db.WithContext(ctx).Where("endpoint IN (?)", endpoints).Delete(&model.WebPushSubscription{}).Error
+}
@@ -0,0 +1,64 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// CreateInboxItemArgs 通知受信箱のアイテム作成引数
+type CreateInboxItemArgs struct {
+	UserID    uuid.UUID
+	Type      model.InboxItemType
+	MessageID uuid.UUID
+	ChannelID uuid.UUID
+	ActorID   uuid.UUID
+	StampID   optional.Of[uuid.UUID]
+}
+
+// InboxItemsQuery GetInboxItems用クエリ
+type InboxItemsQuery struct {
+	UserID uuid.UUID
+	// Cursor 指定したIDのアイテムより古いアイテムを取得します
+	Cursor optional.Of[uuid.UUID]
+	// UnreadOnly 未読のアイテムのみを取得します
+	UnreadOnly bool
+	Limit      int
+}
+
+// InboxItemRepository 通知受信箱リポジトリ
+type InboxItemRepository interface {
+	// CreateInboxItems 通知受信箱のアイテムを作成します
+	//
+	// 成功した場合、作成したアイテムの配列とnilを返します。
+	// 引数にuuid.Nilを含む場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateInboxItems(ctx context.Context, args []CreateInboxItemArgs) ([]*model.InboxItem, error)
+	// GetInboxItems 指定したユーザーの通知受信箱のアイテムを新しい順に取得します
+	//
+	// 削除されたメッセージのアイテムは含まれません。
+	// 成功した場合、アイテムの配列と続きのアイテムが存在するかどうかとnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetInboxItems(ctx context.Context, query InboxItemsQuery) (items []*model.InboxItem, more bool, err error)
+	// GetUnreadInboxItemCount 指定したユーザーの通知受信箱の未読アイテム数を取得します
+	//
+	// 削除されたメッセージのアイテムは含まれません。
+	// 成功した場合、未読アイテム数とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetUnreadInboxItemCount(ctx context.Context, userID uuid.UUID) (int64, error)
+	// MarkInboxItemsAsRead 指定したユーザーの通知受信箱のアイテムを既読にします
+	//
+	// 他のユーザーのアイテムのIDや存在しないIDは無視されます。
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	MarkInboxItemsAsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error
+	// MarkAllInboxItemsAsRead 指定したユーザーの通知受信箱の全てのアイテムを既読にします
+	//
+	// 成功した場合、nilを返します。
+	// DBによるエラーを返すことがあります。
+	MarkAllInboxItemsAsRead(ctx context.Context, userID uuid.UUID) error
+}
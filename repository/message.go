@@ -17,6 +17,7 @@ type MessagesQuery struct {
 	IDIn    optional.Of[[]uuid.UUID]
 	User    uuid.UUID
 	Channel uuid.UUID
+	Parent  uuid.UUID
 	// ChannelsSubscribedByUser 指定したユーザーが購読しているチャンネルのメッセージを指定
 	ChannelsSubscribedByUser uuid.UUID
 	Since                    optional.Of[time.Time]
@@ -45,6 +46,15 @@ type MessageRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	CreateMessage(ctx context.Context, userID, channelID uuid.UUID, text string) (*model.Message, error)
+	// CreateReplyMessage 指定したメッセージのスレッドへの返信メッセージを作成します
+	//
+	// 成功した場合、メッセージとnilを返します。
+	// 親メッセージの返信数を更新し、投稿ユーザーをスレッドの購読者に追加します。
+	// 最初の返信の場合、親メッセージの投稿ユーザーもスレッドの購読者に追加します。
+	// 存在しない親メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateReplyMessage(ctx context.Context, userID, parentID uuid.UUID, text string) (*model.Message, error)
 	// UpdateMessage 指定したメッセージを更新します
 	//
 	// 成功した場合、nilを返します。
@@ -65,6 +75,11 @@ type MessageRepository interface {
 	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
 	// DBによるエラーを返すことがあります。
 	GetMessageByID(ctx context.Context, messageID uuid.UUID) (*model.Message, error)
+	// GetArchivedMessagesByID 指定したメッセージの編集前のアーカイブを取得します
+	//
+	// 成功した場合、DateTimeで昇順ソートされたアーカイブの配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetArchivedMessagesByID(ctx context.Context, messageID uuid.UUID) ([]*model.ArchivedMessage, error)
 	// GetMessages 指定したクエリでメッセージを取得します
 	//
 	// 成功した場合、メッセージの配列を返します。負のoffset, limitは無視されます。
@@ -83,6 +98,23 @@ type MessageRepository interface {
 	// 指定した範囲内にlimitを超えてメッセージが存在していた場合、trueを返します。
 	// DBによるエラーを返すことがあります。
 	GetDeletedMessagesAfter(ctx context.Context, after time.Time, limit int) (messages []*model.Message, more bool, err error)
+	// GetMessagesAfterID 指定したIDより大きいIDの削除されていないメッセージを取得します
+	//
+	// 成功した場合、IDで昇順ソートされたメッセージの配列を返します。
+	// afterにuuid.Nilを指定した場合は先頭から取得します。
+	// 指定した範囲内にlimitを超えてメッセージが存在していた場合、trueを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessagesAfterID(ctx context.Context, after uuid.UUID, limit int) (messages []*model.Message, more bool, err error)
+	// GetMessageCountsByChannel チャンネルごとの削除されていないメッセージの数を取得します
+	//
+	// 成功した場合、チャンネルIDをキーとしたメッセージ数のマップを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageCountsByChannel(ctx context.Context) (map[uuid.UUID]int64, error)
+	// GetMessageIDsByChannel 指定したチャンネルの削除されていないメッセージのIDを全て取得します
+	//
+	// 成功した場合、メッセージIDの配列を返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageIDsByChannel(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error)
 	// SetMessageUnreads ユーザーの集合について、指定したメッセージを未読にします
 	//
 	// 成功した場合、nilを返します。
@@ -125,6 +157,23 @@ type MessageRepository interface {
 	// 引数にuuid.Nilを指定するとErrNilIDを返します。
 	// DBによるエラーを返すことがあります。
 	RemoveStampFromMessage(ctx context.Context, messageID, stampID, userID uuid.UUID) (err error)
+	// SubscribeMessageThread 指定したユーザーに指定したメッセージのスレッドを購読させます
+	//
+	// 成功した、或いは既に購読していた場合、nilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SubscribeMessageThread(ctx context.Context, messageID, userID uuid.UUID) error
+	// UnsubscribeMessageThread 指定したユーザーの指定したメッセージのスレッドの購読を解除します
+	//
+	// 成功した、或いは既に購読していなかった場合、nilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UnsubscribeMessageThread(ctx context.Context, messageID, userID uuid.UUID) error
+	// IsMessageThreadSubscribed 指定したユーザーが指定したメッセージのスレッドを購読しているかどうかを返します
+	//
+	// 購読している場合、trueとnilを返します。
+	// DBによるエラーを返すことがあります。
+	IsMessageThreadSubscribed(ctx context.Context, messageID, userID uuid.UUID) (bool, error)
 }
 
 // UserUnreadChannel ユーザーの未読チャンネル構造体
@@ -0,0 +1,54 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// SetMessageComponentArgs メッセージコンポーネント設定引数
+type SetMessageComponentArgs struct {
+	CustomID string
+	Type     model.MessageComponentType
+	Label    string
+	Style    model.MessageComponentStyle
+	Options  model.MessageComponentOptions
+	Disabled bool
+}
+
+// UpdateMessageComponentArgs メッセージコンポーネント更新引数
+type UpdateMessageComponentArgs struct {
+	Label    optional.Of[string]
+	Style    optional.Of[model.MessageComponentStyle]
+	Options  optional.Of[model.MessageComponentOptions]
+	Disabled optional.Of[bool]
+}
+
+// MessageComponentRepository メッセージコンポーネントリポジトリ
+type MessageComponentRepository interface {
+	// SetMessageComponents 指定したメッセージのコンポーネントを置き換えます
+	//
+	// 成功した場合、表示順に並んだコンポーネントの配列とnilを返します。
+	// 既存のコンポーネントと同じCustomIDのコンポーネントは更新され、IDが維持されます。
+	// argsに含まれないコンポーネントは削除されます。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetMessageComponents(ctx context.Context, messageID uuid.UUID, args []SetMessageComponentArgs) ([]model.MessageComponent, error)
+	// GetMessageComponents 指定したメッセージのコンポーネントを表示順に取得します
+	//
+	// 成功した場合、コンポーネントの配列とnilを返します。
+	// 存在しないメッセージを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageComponents(ctx context.Context, messageID uuid.UUID) ([]model.MessageComponent, error)
+	// UpdateMessageComponent 指定したメッセージの指定したCustomIDのコンポーネントを更新します
+	//
+	// 成功した場合、更新後のコンポーネントとnilを返します。
+	// 存在しないコンポーネントを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateMessageComponent(ctx context.Context, messageID uuid.UUID, customID string, args UpdateMessageComponentArgs) (*model.MessageComponent, error)
+}
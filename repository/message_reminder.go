@@ -0,0 +1,68 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// MessageReminderRepository メッセージのリマインダーリポジトリ
+type MessageReminderRepository interface {
+	// CreateMessageReminder メッセージのリマインダーを作成します
+	//
+	// 成功した場合、リマインダーとnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateMessageReminder(ctx context.Context, userID, messageID uuid.UUID, remindAt time.Time) (*model.MessageReminder, error)
+	// GetMessageReminder 指定したリマインダーを取得します
+	//
+	// 成功した場合、リマインダーとnilを返します。
+	// 存在しないリマインダーを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageReminder(ctx context.Context, id uuid.UUID) (*model.MessageReminder, error)
+	// GetMessageRemindersByUserID 指定したユーザーのリマインダーを通知日時の昇順で全て取得します
+	//
+	// 成功した場合、リマインダーの配列とnilを返します。
+	// 存在しないユーザーを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageRemindersByUserID(ctx context.Context, userID uuid.UUID) ([]*model.MessageReminder, error)
+	// GetDueMessageReminders 通知日時がuntil以前の通知待ちのリマインダーを通知日時の昇順で取得します
+	//
+	// 成功した場合、リマインダーの配列とnilを返します。負のlimitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetDueMessageReminders(ctx context.Context, until time.Time, limit int) ([]*model.MessageReminder, error)
+	// ClaimMessageReminder 指定した通知待ちのリマインダーを通知処理中にし、通知試行回数を1増やします
+	//
+	// 複数のワーカーが同じリマインダーを通知しないよう、状態の確認と変更はアトミックに行われます。
+	// 通知処理の開始日時が記録され、RecoverMessageRemindersによる回復の判定に用いられます。
+	// 成功した場合、nilを返します。
+	// 存在しないか通知待ちでないリマインダーを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	ClaimMessageReminder(ctx context.Context, id uuid.UUID) error
+	// ReleaseMessageReminder 指定した通知処理中のリマインダーを通知待ちに戻します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しないか通知処理中でないリマインダーを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	ReleaseMessageReminder(ctx context.Context, id uuid.UUID) error
+	// RecoverMessageReminders 通知処理の開始日時がclaimedBefore以前のまま通知処理中になっているリマインダーを通知待ちに戻します
+	//
+	// 通知処理中にサーバーが停止したリマインダーを回復するためのものです。
+	// 通知試行回数が上限に達しているものは削除します。
+	// 成功した場合、通知待ちに戻したか削除したリマインダーの数とnilを返します。
+	// DBによるエラーを返すことがあります。
+	RecoverMessageReminders(ctx context.Context, claimedBefore time.Time) (int64, error)
+	// DeleteMessageReminder 指定したリマインダーを削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しないリマインダーを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteMessageReminder(ctx context.Context, id uuid.UUID) error
+}
@@ -6,8 +6,23 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
+// MessageReportsQuery メッセージ通報取得用クエリ
+type MessageReportsQuery struct {
+	State  optional.Of[model.MessageReportState]
+	Offset int
+	Limit  int
+}
+
+// UpdateMessageReportStateArgs メッセージ通報の対応状態更新引数
+type UpdateMessageReportStateArgs struct {
+	State      model.MessageReportState
+	ResolverID uuid.UUID
+	Note       string
+}
+
 // MessageReportRepository メッセージ通報リポジトリ
 type MessageReportRepository interface {
 	// CreateMessageReport 指定したユーザーによる指定したメッセージの通報を登録します
@@ -22,6 +37,24 @@ type MessageReportRepository interface {
 	// 成功した場合、メッセージ通報の配列とnilを返します。負のoffset, limitは無視されます。
 	// DBによるエラーを返すことがあります。
 	GetMessageReports(ctx context.Context, offset, limit int) ([]*model.MessageReport, error)
+	// GetMessageReportsByQuery 指定したクエリでメッセージ通報を通報日時の昇順で取得します
+	//
+	// 成功した場合、メッセージ通報の配列とnilを返します。負のoffset, limitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetMessageReportsByQuery(ctx context.Context, query MessageReportsQuery) ([]*model.MessageReport, error)
+	// GetMessageReport 指定したメッセージ通報を取得します
+	//
+	// 成功した場合、メッセージ通報とnilを返します。
+	// 存在しないメッセージ通報を指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetMessageReport(ctx context.Context, reportID uuid.UUID) (*model.MessageReport, error)
+	// UpdateMessageReportState 指定したメッセージ通報の対応状態を更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しないメッセージ通報を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateMessageReportState(ctx context.Context, reportID uuid.UUID, args UpdateMessageReportStateArgs) error
 	// GetMessageReportsByMessageID 指定したメッセージのメッセージ通報を全て取得します
 	//
 	// 成功した場合、メッセージ通報の配列とnilを返します。
@@ -13,6 +13,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	model "github.com/traPtitech/traQ/model"
 	repository "github.com/traPtitech/traQ/repository"
+	optional "github.com/traPtitech/traQ/utils/optional"
 )
 
 // MockBotRepository is a mock of BotRepository interface.
@@ -81,6 +82,20 @@ func (mr *MockBotRepositoryMockRecorder) CreateBot(ctx, name, displayName, descr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBot", reflect.TypeOf((*MockBotRepository)(nil).CreateBot), ctx, name, displayName, description, iconFileID, creatorID, mode, state, webhookURL)
 }
 
+// CreateBotEventDelivery mocks base method.
+func (m *MockBotRepository) CreateBotEventDelivery(ctx context.Context, delivery *model.BotEventDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBotEventDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBotEventDelivery indicates an expected call of CreateBotEventDelivery.
+func (mr *MockBotRepositoryMockRecorder) CreateBotEventDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBotEventDelivery", reflect.TypeOf((*MockBotRepository)(nil).CreateBotEventDelivery), ctx, delivery)
+}
+
 // DeleteBot mocks base method.
 func (m *MockBotRepository) DeleteBot(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -95,6 +110,20 @@ func (mr *MockBotRepositoryMockRecorder) DeleteBot(ctx, id interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBot", reflect.TypeOf((*MockBotRepository)(nil).DeleteBot), ctx, id)
 }
 
+// DeleteBotEventDelivery mocks base method.
+func (m *MockBotRepository) DeleteBotEventDelivery(ctx context.Context, requestID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBotEventDelivery", ctx, requestID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBotEventDelivery indicates an expected call of DeleteBotEventDelivery.
+func (mr *MockBotRepositoryMockRecorder) DeleteBotEventDelivery(ctx, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBotEventDelivery", reflect.TypeOf((*MockBotRepository)(nil).DeleteBotEventDelivery), ctx, requestID)
+}
+
 // GetBotByBotUserID mocks base method.
 func (m *MockBotRepository) GetBotByBotUserID(ctx context.Context, id uuid.UUID) (*model.Bot, error) {
 	m.ctrl.T.Helper()
@@ -140,6 +169,36 @@ func (mr *MockBotRepositoryMockRecorder) GetBotByID(ctx, id interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotByID", reflect.TypeOf((*MockBotRepository)(nil).GetBotByID), ctx, id)
 }
 
+// GetBotEventDeliveries mocks base method.
+func (m *MockBotRepository) GetBotEventDeliveries(ctx context.Context, botID uuid.UUID, state optional.Of[model.BotEventDeliveryState], limit, offset int) ([]*model.BotEventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotEventDeliveries", ctx, botID, state, limit, offset)
+	ret0, _ := ret[0].([]*model.BotEventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotEventDeliveries indicates an expected call of GetBotEventDeliveries.
+func (mr *MockBotRepositoryMockRecorder) GetBotEventDeliveries(ctx, botID, state, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotEventDeliveries", reflect.TypeOf((*MockBotRepository)(nil).GetBotEventDeliveries), ctx, botID, state, limit, offset)
+}
+
+// GetBotEventDelivery mocks base method.
+func (m *MockBotRepository) GetBotEventDelivery(ctx context.Context, requestID uuid.UUID) (*model.BotEventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBotEventDelivery", ctx, requestID)
+	ret0, _ := ret[0].(*model.BotEventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBotEventDelivery indicates an expected call of GetBotEventDelivery.
+func (mr *MockBotRepositoryMockRecorder) GetBotEventDelivery(ctx, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBotEventDelivery", reflect.TypeOf((*MockBotRepository)(nil).GetBotEventDelivery), ctx, requestID)
+}
+
 // GetBotEventLogs mocks base method.
 func (m *MockBotRepository) GetBotEventLogs(ctx context.Context, botID uuid.UUID, limit, offset int) ([]*model.BotEventLog, error) {
 	m.ctrl.T.Helper()
@@ -170,6 +229,21 @@ func (mr *MockBotRepositoryMockRecorder) GetBots(ctx, query interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBots", reflect.TypeOf((*MockBotRepository)(nil).GetBots), ctx, query)
 }
 
+// GetDueBotEventDeliveries mocks base method.
+func (m *MockBotRepository) GetDueBotEventDeliveries(ctx context.Context, now time.Time, limit int) ([]*model.BotEventDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueBotEventDeliveries", ctx, now, limit)
+	ret0, _ := ret[0].([]*model.BotEventDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueBotEventDeliveries indicates an expected call of GetDueBotEventDeliveries.
+func (mr *MockBotRepositoryMockRecorder) GetDueBotEventDeliveries(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueBotEventDeliveries", reflect.TypeOf((*MockBotRepository)(nil).GetDueBotEventDeliveries), ctx, now, limit)
+}
+
 // GetParticipatingChannelIDsByBot mocks base method.
 func (m *MockBotRepository) GetParticipatingChannelIDsByBot(ctx context.Context, botID uuid.UUID) ([]uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -185,6 +259,20 @@ func (mr *MockBotRepositoryMockRecorder) GetParticipatingChannelIDsByBot(ctx, bo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParticipatingChannelIDsByBot", reflect.TypeOf((*MockBotRepository)(nil).GetParticipatingChannelIDsByBot), ctx, botID)
 }
 
+// PurgeBotEventDeliveries mocks base method.
+func (m *MockBotRepository) PurgeBotEventDeliveries(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeBotEventDeliveries", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeBotEventDeliveries indicates an expected call of PurgeBotEventDeliveries.
+func (mr *MockBotRepositoryMockRecorder) PurgeBotEventDeliveries(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeBotEventDeliveries", reflect.TypeOf((*MockBotRepository)(nil).PurgeBotEventDeliveries), ctx, before)
+}
+
 // PurgeBotEventLogs mocks base method.
 func (m *MockBotRepository) PurgeBotEventLogs(ctx context.Context, before time.Time) error {
 	m.ctrl.T.Helper()
@@ -242,6 +330,20 @@ func (mr *MockBotRepositoryMockRecorder) UpdateBot(ctx, id, args interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBot", reflect.TypeOf((*MockBotRepository)(nil).UpdateBot), ctx, id, args)
 }
 
+// UpdateBotEventDelivery mocks base method.
+func (m *MockBotRepository) UpdateBotEventDelivery(ctx context.Context, delivery *model.BotEventDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBotEventDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBotEventDelivery indicates an expected call of UpdateBotEventDelivery.
+func (mr *MockBotRepositoryMockRecorder) UpdateBotEventDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBotEventDelivery", reflect.TypeOf((*MockBotRepository)(nil).UpdateBotEventDelivery), ctx, delivery)
+}
+
 // WriteBotEventLog mocks base method.
 func (m *MockBotRepository) WriteBotEventLog(ctx context.Context, log *model.BotEventLog) error {
 	m.ctrl.T.Helper()
@@ -234,3 +234,17 @@ func (mr *MockChannelRepositoryMockRecorder) UpdateChannel(ctx, channelID, args
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannel", reflect.TypeOf((*MockChannelRepository)(nil).UpdateChannel), ctx, channelID, args)
 }
+
+// UpdateChannelNotifyOverrides mocks base method.
+func (m *MockChannelRepository) UpdateChannelNotifyOverrides(ctx context.Context, userID, channelID uuid.UUID, args repository.UpdateChannelNotifyOverridesArgs) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelNotifyOverrides", ctx, userID, channelID, args)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateChannelNotifyOverrides indicates an expected call of UpdateChannelNotifyOverrides.
+func (mr *MockChannelRepositoryMockRecorder) UpdateChannelNotifyOverrides(ctx, userID, channelID, args interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelNotifyOverrides", reflect.TypeOf((*MockChannelRepository)(nil).UpdateChannelNotifyOverrides), ctx, userID, channelID, args)
+}
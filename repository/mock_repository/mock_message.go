@@ -68,6 +68,21 @@ func (mr *MockMessageRepositoryMockRecorder) CreateMessage(ctx, userID, channelI
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockMessageRepository)(nil).CreateMessage), ctx, userID, channelID, text)
 }
 
+// CreateReplyMessage mocks base method.
+func (m *MockMessageRepository) CreateReplyMessage(ctx context.Context, userID, parentID uuid.UUID, text string) (*model.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReplyMessage", ctx, userID, parentID, text)
+	ret0, _ := ret[0].(*model.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReplyMessage indicates an expected call of CreateReplyMessage.
+func (mr *MockMessageRepositoryMockRecorder) CreateReplyMessage(ctx, userID, parentID, text interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReplyMessage", reflect.TypeOf((*MockMessageRepository)(nil).CreateReplyMessage), ctx, userID, parentID, text)
+}
+
 // DeleteMessage mocks base method.
 func (m *MockMessageRepository) DeleteMessage(ctx context.Context, messageID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -96,6 +111,21 @@ func (mr *MockMessageRepositoryMockRecorder) DeleteUnreadsByChannelID(ctx, chann
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnreadsByChannelID", reflect.TypeOf((*MockMessageRepository)(nil).DeleteUnreadsByChannelID), ctx, channelID, userID)
 }
 
+// GetArchivedMessagesByID mocks base method.
+func (m *MockMessageRepository) GetArchivedMessagesByID(ctx context.Context, messageID uuid.UUID) ([]*model.ArchivedMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedMessagesByID", ctx, messageID)
+	ret0, _ := ret[0].([]*model.ArchivedMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedMessagesByID indicates an expected call of GetArchivedMessagesByID.
+func (mr *MockMessageRepositoryMockRecorder) GetArchivedMessagesByID(ctx, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedMessagesByID", reflect.TypeOf((*MockMessageRepository)(nil).GetArchivedMessagesByID), ctx, messageID)
+}
+
 // GetChannelLatestMessages mocks base method.
 func (m *MockMessageRepository) GetChannelLatestMessages(ctx context.Context, query repository.ChannelLatestMessagesQuery) ([]*model.Message, error) {
 	m.ctrl.T.Helper()
@@ -142,6 +172,36 @@ func (mr *MockMessageRepositoryMockRecorder) GetMessageByID(ctx, messageID inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageByID", reflect.TypeOf((*MockMessageRepository)(nil).GetMessageByID), ctx, messageID)
 }
 
+// GetMessageCountsByChannel mocks base method.
+func (m *MockMessageRepository) GetMessageCountsByChannel(ctx context.Context) (map[uuid.UUID]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageCountsByChannel", ctx)
+	ret0, _ := ret[0].(map[uuid.UUID]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageCountsByChannel indicates an expected call of GetMessageCountsByChannel.
+func (mr *MockMessageRepositoryMockRecorder) GetMessageCountsByChannel(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageCountsByChannel", reflect.TypeOf((*MockMessageRepository)(nil).GetMessageCountsByChannel), ctx)
+}
+
+// GetMessageIDsByChannel mocks base method.
+func (m *MockMessageRepository) GetMessageIDsByChannel(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageIDsByChannel", ctx, channelID)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageIDsByChannel indicates an expected call of GetMessageIDsByChannel.
+func (mr *MockMessageRepositoryMockRecorder) GetMessageIDsByChannel(ctx, channelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageIDsByChannel", reflect.TypeOf((*MockMessageRepository)(nil).GetMessageIDsByChannel), ctx, channelID)
+}
+
 // GetMessages mocks base method.
 func (m *MockMessageRepository) GetMessages(ctx context.Context, query repository.MessagesQuery) ([]*model.Message, bool, error) {
 	m.ctrl.T.Helper()
@@ -158,6 +218,22 @@ func (mr *MockMessageRepositoryMockRecorder) GetMessages(ctx, query interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessages", reflect.TypeOf((*MockMessageRepository)(nil).GetMessages), ctx, query)
 }
 
+// GetMessagesAfterID mocks base method.
+func (m *MockMessageRepository) GetMessagesAfterID(ctx context.Context, after uuid.UUID, limit int) ([]*model.Message, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessagesAfterID", ctx, after, limit)
+	ret0, _ := ret[0].([]*model.Message)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMessagesAfterID indicates an expected call of GetMessagesAfterID.
+func (mr *MockMessageRepositoryMockRecorder) GetMessagesAfterID(ctx, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessagesAfterID", reflect.TypeOf((*MockMessageRepository)(nil).GetMessagesAfterID), ctx, after, limit)
+}
+
 // GetUnreadMessagesByUserID mocks base method.
 func (m *MockMessageRepository) GetUnreadMessagesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.Message, error) {
 	m.ctrl.T.Helper()
@@ -204,6 +280,21 @@ func (mr *MockMessageRepositoryMockRecorder) GetUserUnreadChannels(ctx, userID i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserUnreadChannels", reflect.TypeOf((*MockMessageRepository)(nil).GetUserUnreadChannels), ctx, userID)
 }
 
+// IsMessageThreadSubscribed mocks base method.
+func (m *MockMessageRepository) IsMessageThreadSubscribed(ctx context.Context, messageID, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMessageThreadSubscribed", ctx, messageID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMessageThreadSubscribed indicates an expected call of IsMessageThreadSubscribed.
+func (mr *MockMessageRepositoryMockRecorder) IsMessageThreadSubscribed(ctx, messageID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMessageThreadSubscribed", reflect.TypeOf((*MockMessageRepository)(nil).IsMessageThreadSubscribed), ctx, messageID, userID)
+}
+
 // RemoveStampFromMessage mocks base method.
 func (m *MockMessageRepository) RemoveStampFromMessage(ctx context.Context, messageID, stampID, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -232,6 +323,34 @@ func (mr *MockMessageRepositoryMockRecorder) SetMessageUnreads(ctx, userNoticeab
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMessageUnreads", reflect.TypeOf((*MockMessageRepository)(nil).SetMessageUnreads), ctx, userNoticeableMap, messageID)
 }
 
+// SubscribeMessageThread mocks base method.
+func (m *MockMessageRepository) SubscribeMessageThread(ctx context.Context, messageID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribeMessageThread", ctx, messageID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribeMessageThread indicates an expected call of SubscribeMessageThread.
+func (mr *MockMessageRepositoryMockRecorder) SubscribeMessageThread(ctx, messageID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeMessageThread", reflect.TypeOf((*MockMessageRepository)(nil).SubscribeMessageThread), ctx, messageID, userID)
+}
+
+// UnsubscribeMessageThread mocks base method.
+func (m *MockMessageRepository) UnsubscribeMessageThread(ctx context.Context, messageID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsubscribeMessageThread", ctx, messageID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsubscribeMessageThread indicates an expected call of UnsubscribeMessageThread.
+func (mr *MockMessageRepositoryMockRecorder) UnsubscribeMessageThread(ctx, messageID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsubscribeMessageThread", reflect.TypeOf((*MockMessageRepository)(nil).UnsubscribeMessageThread), ctx, messageID, userID)
+}
+
 // UpdateMessage mocks base method.
 func (m *MockMessageRepository) UpdateMessage(ctx context.Context, messageID uuid.UUID, text string) error {
 	m.ctrl.T.Helper()
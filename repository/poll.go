@@ -0,0 +1,66 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// CreatePollArgs 投票作成引数
+type CreatePollArgs struct {
+	MessageID      uuid.UUID
+	CreatorID      uuid.UUID
+	Options        []string
+	MultipleChoice bool
+	Anonymous      bool
+	Deadline       optional.Of[time.Time]
+}
+
+// PollRepository 投票リポジトリ
+type PollRepository interface {
+	// CreatePoll 投票を作成します
+	//
+	// 成功した場合、選択肢を含む投票とnilを返します。
+	// 既にメッセージに投票が添付されている場合、ErrAlreadyExistsを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreatePoll(ctx context.Context, args CreatePollArgs) (*model.Poll, error)
+	// GetPollByMessageID 指定したメッセージに添付された投票を選択肢を含めて取得します
+	//
+	// 成功した場合、投票とnilを返します。
+	// 存在しない場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetPollByMessageID(ctx context.Context, messageID uuid.UUID) (*model.Poll, error)
+	// GetPollVotes 指定した投票の票を全て取得します
+	//
+	// 成功した場合、票の配列とnilを返します。
+	// 存在しない投票を指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetPollVotes(ctx context.Context, pollID uuid.UUID) ([]*model.PollVote, error)
+	// SetPollVotes 指定したユーザーの投票の票を指定した選択肢に置き換え、選択肢ごとの得票数と投票者数を更新します
+	//
+	// 成功した場合、nilを返します。optionIDsが空の場合、ユーザーの票を取り消します。
+	// 投票が締め切られている場合、ErrForbiddenを返します。
+	// 存在しない投票を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	SetPollVotes(ctx context.Context, pollID, userID uuid.UUID, optionIDs []uuid.UUID) error
+	// ClosePoll 指定した投票を締め切ります
+	//
+	// 成功した場合、締め切った投票とnilを返します。
+	// 既に締め切られている場合、ErrAlreadyExistsを返します。
+	// 存在しない投票を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	ClosePoll(ctx context.Context, pollID uuid.UUID, closedAt time.Time) (*model.Poll, error)
+	// GetExpiredPolls 締め切り日時がuntil以前で、まだ締め切られていない投票を締め切り日時の昇順で取得します
+	//
+	// 成功した場合、投票の配列とnilを返します。負のlimitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetExpiredPolls(ctx context.Context, until time.Time, limit int) ([]*model.Poll, error)
+}
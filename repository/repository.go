@@ -22,4 +22,12 @@ type Repository interface {
 	ClipRepository
 	OgpCacheRepository
 	SoundboardRepository
+	ScheduledMessageRepository
+	SavedSearchRepository
+	WebPushSubscriptionRepository
+	InboxItemRepository
+	PollRepository
+	MessageReminderRepository
+	BotCommandRepository
+	MessageComponentRepository
 }
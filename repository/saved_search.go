@@ -0,0 +1,67 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// CreateSavedSearchArgs 保存された検索作成引数
+type CreateSavedSearchArgs struct {
+	UserID uuid.UUID
+	Name   string
+	Query  string
+	Notify bool
+}
+
+// UpdateSavedSearchArgs 保存された検索更新引数
+type UpdateSavedSearchArgs struct {
+	Name   optional.Of[string]
+	Query  optional.Of[string]
+	Notify optional.Of[bool]
+}
+
+// SavedSearchRepository 保存された検索リポジトリ
+type SavedSearchRepository interface {
+	// CreateSavedSearch 検索を保存します
+	//
+	// 成功した場合、保存された検索とnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateSavedSearch(ctx context.Context, args CreateSavedSearchArgs) (*model.SavedSearch, error)
+	// UpdateSavedSearch 指定した保存された検索を更新します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない保存された検索を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateSavedSearch(ctx context.Context, id uuid.UUID, args UpdateSavedSearchArgs) error
+	// GetSavedSearch 指定した保存された検索を取得します
+	//
+	// 成功した場合、保存された検索とnilを返します。
+	// 存在しない保存された検索を指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetSavedSearch(ctx context.Context, id uuid.UUID) (*model.SavedSearch, error)
+	// GetSavedSearchesByUserID 指定したユーザーの保存された検索を作成日時の昇順で全て取得します
+	//
+	// 成功した場合、保存された検索の配列とnilを返します。
+	// 存在しないユーザーを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetSavedSearchesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.SavedSearch, error)
+	// GetNotifyingSavedSearches 新着メッセージの通知が有効な保存された検索を全て取得します
+	//
+	// 成功した場合、保存された検索の配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetNotifyingSavedSearches(ctx context.Context) ([]*model.SavedSearch, error)
+	// DeleteSavedSearch 指定した保存された検索を削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない保存された検索を指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteSavedSearch(ctx context.Context, id uuid.UUID) error
+}
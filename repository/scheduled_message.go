@@ -0,0 +1,94 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// CreateScheduledMessageArgs 予約投稿メッセージ作成引数
+type CreateScheduledMessageArgs struct {
+	UserID      uuid.UUID
+	ChannelID   uuid.UUID
+	DMUserID    optional.Of[uuid.UUID]
+	Text        string
+	ScheduledAt time.Time
+}
+
+// UpdateScheduledMessageArgs 予約投稿メッセージ更新引数
+type UpdateScheduledMessageArgs struct {
+	Text        optional.Of[string]
+	ScheduledAt optional.Of[time.Time]
+}
+
+// ScheduledMessageRepository 予約投稿メッセージリポジトリ
+type ScheduledMessageRepository interface {
+	// CreateScheduledMessage 予約投稿メッセージを作成します
+	//
+	// 成功した場合、予約投稿メッセージとnilを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	CreateScheduledMessage(ctx context.Context, args CreateScheduledMessageArgs) (*model.ScheduledMessage, error)
+	// UpdateScheduledMessage 指定した予約投稿メッセージを更新します
+	//
+	// 変更があった場合、予約投稿メッセージは投稿待ちに戻り、投稿試行回数はリセットされます。
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 投稿処理中の予約投稿メッセージを指定した場合、ErrForbiddenを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateScheduledMessage(ctx context.Context, id uuid.UUID, args UpdateScheduledMessageArgs) error
+	// GetScheduledMessage 指定した予約投稿メッセージを取得します
+	//
+	// 成功した場合、予約投稿メッセージとnilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetScheduledMessage(ctx context.Context, id uuid.UUID) (*model.ScheduledMessage, error)
+	// GetScheduledMessagesByUserID 指定したユーザーの予約投稿メッセージを投稿予定日時の昇順で全て取得します
+	//
+	// 成功した場合、予約投稿メッセージの配列とnilを返します。
+	// 存在しないユーザーを指定した場合は空配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetScheduledMessagesByUserID(ctx context.Context, userID uuid.UUID) ([]*model.ScheduledMessage, error)
+	// GetDueScheduledMessages 投稿予定日時がuntil以前の投稿待ちの予約投稿メッセージを投稿予定日時の昇順で取得します
+	//
+	// 成功した場合、予約投稿メッセージの配列とnilを返します。負のlimitは無視されます。
+	// DBによるエラーを返すことがあります。
+	GetDueScheduledMessages(ctx context.Context, until time.Time, limit int) ([]*model.ScheduledMessage, error)
+	// ClaimScheduledMessage 指定した投稿待ちの予約投稿メッセージを投稿処理中にし、投稿試行回数を1増やします
+	//
+	// 投稿処理の開始日時が記録され、RecoverScheduledMessagesによる回復の判定に用いられます。
+	//
+	// 複数のワーカーが同じ予約投稿メッセージを投稿しないよう、状態の確認と変更はアトミックに行われます。
+	// 成功した場合、nilを返します。
+	// 存在しないか投稿待ちでない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	ClaimScheduledMessage(ctx context.Context, id uuid.UUID) error
+	// RecoverScheduledMessages 投稿処理の開始日時がclaimedBefore以前のまま投稿処理中になっている予約投稿メッセージを投稿待ちに戻します
+	//
+	// 投稿処理中にサーバーが停止した予約投稿メッセージを回復するためのものです。
+	// 投稿試行回数が上限に達しているものは投稿失敗にします。
+	// 成功した場合、状態を変更した予約投稿メッセージの数とnilを返します。
+	// DBによるエラーを返すことがあります。
+	RecoverScheduledMessages(ctx context.Context, claimedBefore time.Time) (int64, error)
+	// UpdateScheduledMessageState 指定した予約投稿メッセージの状態を変更します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateScheduledMessageState(ctx context.Context, id uuid.UUID, state model.ScheduledMessageState) error
+	// DeleteScheduledMessage 指定した予約投稿メッセージを削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しない予約投稿メッセージを指定した場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定するとErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteScheduledMessage(ctx context.Context, id uuid.UUID) error
+}
@@ -98,6 +98,13 @@ type StampRepository interface {
 	// 成功した場合、スタンプのIDでソートされた配列とnilを返します。
 	// DBによるエラーを返すことがあります。
 	GetAllStampsWithThumbnail(ctx context.Context, stampType StampType) (stampsWithETag *etag.Entity[[]*model.StampWithThumbnail], err error)
+	// SearchStamps 名前にwordを含むスタンプとサムネイルの有無を最大limit件取得します
+	//
+	// 大文字小文字は区別しません。
+	// 成功した場合、名前がwordに完全一致・前方一致・部分一致するものの順、同順位では名前順でソートされた配列とnilを返します。
+	// limitに0以下の値を指定した場合、全て取得します。
+	// DBによるエラーを返すことがあります。
+	SearchStamps(ctx context.Context, word string, limit int) ([]*model.StampWithThumbnail, error)
 	// StampExists 指定したIDのスタンプが存在するかどうかを返します
 	//
 	// 存在する場合、trueとnilを返します。
@@ -50,7 +50,10 @@ type UsersQuery struct {
 	IsGMemberOf                 optional.Of[uuid.UUID]
 	IsSubscriberAtMarkLevelOf   optional.Of[uuid.UUID]
 	IsSubscriberAtNotifyLevelOf optional.Of[uuid.UUID]
+	IsThreadSubscriberOf        optional.Of[uuid.UUID]
+	Word                        optional.Of[string]
 	EnableProfileLoading        bool
+	Limit                       int
 }
 
 // NotBot Botでない
@@ -95,6 +98,26 @@ func (q UsersQuery) SubscriberAtNotifyLevelOf(channelID uuid.UUID) UsersQuery {
 	return q
 }
 
+// ThreadSubscriberOf messageIDメッセージのスレッドの購読ユーザーである
+func (q UsersQuery) ThreadSubscriberOf(messageID uuid.UUID) UsersQuery {
+	q.IsThreadSubscriberOf = optional.From(messageID)
+	return q
+}
+
+// Contains ユーザー名・表示名・自己紹介・タグのいずれかにwordを含む
+func (q UsersQuery) Contains(word string) UsersQuery {
+	q.Word = optional.From(word)
+	return q
+}
+
+// LimitOf 最大limit件取得する
+//
+// Containsと併用した場合、ユーザー名・表示名がwordに完全一致・前方一致・部分一致するものから順に取得します
+func (q UsersQuery) LimitOf(limit int) UsersQuery {
+	q.Limit = limit
+	return q
+}
+
 // LoadProfile ユーザーの追加プロファイル情報を読み込むかどうか
 func (q UsersQuery) LoadProfile() UsersQuery {
 	q.EnableProfileLoading = true
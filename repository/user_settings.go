@@ -2,12 +2,21 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
+// UpdateDoNotDisturbArgs おやすみモード設定更新引数
+type UpdateDoNotDisturbArgs struct {
+	Schedules     model.DNDSchedules
+	AllowForced   bool
+	AllowMentions bool
+}
+
 // UserSettingsRepository ユーザセッティングレポジトリ
 type UserSettingsRepository interface {
 	// UpdateNotifyCitation メッセージ引用通知を設定します
@@ -25,4 +34,56 @@ type UserSettingsRepository interface {
 	// GetUserSettings ユーザー設定を返します
 	// DBによるエラーを返すことがあります
 	GetUserSettings(ctx context.Context, userID uuid.UUID) (*model.UserSettings, error)
+	// UpdateNotifyKeywords 通知キーワードを設定します
+	//
+	// 既存の通知キーワードは全て置き換えられます
+	// DBによるエラーを返すことがあります
+	UpdateNotifyKeywords(ctx context.Context, userID uuid.UUID, keywords model.NotifyKeywords) error
+	// GetUserSettingsWithNotifyKeywords 通知キーワードが1つ以上設定されているユーザー設定を全て取得します
+	//
+	// メッセージ投稿毎に呼ばれるため結果はキャッシュされ、ユーザー設定の更新時に破棄されます。
+	// 返り値のユーザー設定は変更してはいけません。
+	// 成功した場合、ユーザー設定の配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetUserSettingsWithNotifyKeywords(ctx context.Context) ([]*model.UserSettings, error)
+	// GetUserSettingsByUserIDs 指定したユーザーのユーザー設定を取得します
+	//
+	// 成功した場合、ユーザー設定の配列とnilを返します。設定を一度も変更していないユーザーの設定は含まれません。
+	// DBによるエラーを返すことがあります。
+	GetUserSettingsByUserIDs(ctx context.Context, userIDs []uuid.UUID) ([]*model.UserSettings, error)
+	// UpdateDoNotDisturb おやすみモードを設定します
+	//
+	// 既存の時間帯は全て置き換えられます
+	// DBによるエラーを返すことがあります
+	UpdateDoNotDisturb(ctx context.Context, userID uuid.UUID, args UpdateDoNotDisturbArgs) error
+	// UpdateSnoozeUntil 通知のスヌーズ期限を設定します
+	//
+	// untilが無効値の場合、スヌーズを解除します
+	// DBによるエラーを返すことがあります
+	UpdateSnoozeUntil(ctx context.Context, userID uuid.UUID, until optional.Of[time.Time]) error
+	// SetDigestEmail メールダイジェストの送信先メールアドレスを設定します
+	//
+	// 送信先は未確認状態になり、確認コードverificationCodeが設定されます
+	// DBによるエラーを返すことがあります
+	SetDigestEmail(ctx context.Context, userID uuid.UUID, email string, verificationCode string) error
+	// VerifyDigestEmail メールダイジェストの送信先メールアドレスを確認済みにします
+	//
+	// 成功した場合、nilを返します。
+	// 確認待ちの送信先が存在しない場合、ErrNotFoundを返します。
+	// 確認コードが一致しない場合、ErrForbiddenを返します。
+	// DBによるエラーを返すことがあります。
+	VerifyDigestEmail(ctx context.Context, userID uuid.UUID, verificationCode string) error
+	// UpdateDigestFrequency メールダイジェストの送信頻度を設定します
+	//
+	// DBによるエラーを返すことがあります
+	UpdateDigestFrequency(ctx context.Context, userID uuid.UUID, frequency model.DigestFrequency) error
+	// GetUserSettingsWithDigestEnabled メールダイジェストが有効(送信先が確認済みかつ送信頻度がoff以外)なユーザー設定を全て取得します
+	//
+	// 成功した場合、ユーザー設定の配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetUserSettingsWithDigestEnabled(ctx context.Context) ([]*model.UserSettings, error)
+	// UpdateDigestCheckedAt 最後にメールダイジェストの送信を確認した日時を設定します
+	//
+	// DBによるエラーを返すことがあります
+	UpdateDigestCheckedAt(ctx context.Context, userID uuid.UUID, checkedAt time.Time) error
 }
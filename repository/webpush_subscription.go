@@ -0,0 +1,60 @@
+//go:generate mockgen -source=$GOFILE -destination=mock_$GOPACKAGE/mock_$GOFILE
+package repository
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// RegisterWebPushSubscriptionArgs Web Push購読登録引数
+type RegisterWebPushSubscriptionArgs struct {
+	UserID   uuid.UUID
+	Endpoint string
+	P256dh   string
+	Auth     string
+}
+
+// WebPushSubscriptionRepository Web Push購読リポジトリ
+type WebPushSubscriptionRepository interface {
+	// RegisterWebPushSubscription Web Push購読を登録します
+	//
+	// 同じエンドポイントの購読が既に存在する場合は置き換えます。
+	// 成功した場合、購読とnilを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// Endpointが空文字列の場合、ArgumentErrorを返します。
+	// DBによるエラーを返すことがあります。
+	RegisterWebPushSubscription(ctx context.Context, args RegisterWebPushSubscriptionArgs) (*model.WebPushSubscription, error)
+	// GetWebPushSubscription 指定したWeb Push購読を取得します
+	//
+	// 成功した場合、購読とnilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetWebPushSubscription(ctx context.Context, id uuid.UUID) (*model.WebPushSubscription, error)
+	// GetWebPushSubscriptionsByUserID 指定したユーザーのWeb Push購読を登録日時の昇順で全て取得します
+	//
+	// 成功した場合、購読の配列とnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetWebPushSubscriptionsByUserID(ctx context.Context, userID uuid.UUID) ([]*model.WebPushSubscription, error)
+	// GetWebPushSubscriptions 指定したユーザーの全Web Push購読を取得します
+	//
+	// 成功した場合、ユーザーIDをキーとした購読の配列のマップとnilを返します。
+	// DBによるエラーを返すことがあります。
+	GetWebPushSubscriptions(ctx context.Context, userIDs set.UUID) (map[uuid.UUID][]*model.WebPushSubscription, error)
+	// DeleteWebPushSubscription 指定したWeb Push購読を削除します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しなかった場合、ErrNotFoundを返します。
+	// 引数にuuid.Nilを指定した場合、ErrNilIDを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteWebPushSubscription(ctx context.Context, id uuid.UUID) error
+	// DeleteWebPushSubscriptionsByEndpoints 指定したエンドポイントのWeb Push購読を削除します
+	//
+	// 成功した、或いは既に削除されていた場合にnilを返します。
+	// DBによるエラーを返すことがあります。
+	DeleteWebPushSubscriptionsByEndpoints(ctx context.Context, endpoints []string) error
+}
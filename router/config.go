@@ -34,6 +34,8 @@ type Config struct {
 	LiveKitAPIKey string
 	// LiveKitAPISecret LiveKit APIシークレット
 	LiveKitAPISecret string
+	// WebPushVAPIDPublicKey Web PushのVAPID公開鍵 (base64url)
+	WebPushVAPIDPublicKey string
 	// ExternalAuth 外部認証設定
 	ExternalAuth ExternalAuthConfig
 }
@@ -88,6 +90,7 @@ func provideV3Config(c *Config) v3.Config {
 		LiveKitHost:                     c.LiveKitHost,
 		LiveKitAPIKey:                   c.LiveKitAPIKey,
 		LiveKitAPISecret:                c.LiveKitAPISecret,
+		WebPushVAPIDPublicKey:           c.WebPushVAPIDPublicKey,
 		AllowSignUp:                     c.AllowSignUp,
 		EnabledExternalAccountProviders: c.ExternalAuth.ValidProviders(),
 	}
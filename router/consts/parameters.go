@@ -17,5 +17,13 @@ const (
 	ParamBotID          = "botID"
 	ParamClientID       = "clientID"
 	ParamClipFolderID   = "folderID"
+	ParamScheduleID     = "scheduleID"
+	ParamReminderID     = "reminderID"
+	ParamInvocationID   = "invocationID"
+	ParamRequestID      = "requestID"
+	ParamCustomID       = "customID"
+	ParamSavedSearchID  = "savedSearchID"
+	ParamReportID       = "reportID"
+	ParamSubscriptionID = "subscriptionID"
 	ParamURL            = "url"
 )
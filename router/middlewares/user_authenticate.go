@@ -19,7 +19,7 @@ const authScheme = "Bearer"
 func UserAuthenticate(repo repository.Repository, sessStore session.Store) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c *echo.Context) error {
-			var uid uuid.UUID
+			var uid, tokenID uuid.UUID
 
 			if ah := c.Request().Header.Get(echo.HeaderAuthorization); len(ah) > 0 {
 				// AuthorizationヘッダーがあるためOAuth2で検証
@@ -54,6 +54,7 @@ func UserAuthenticate(repo repository.Repository, sessStore session.Store) echo.
 					return next(c)
 				}
 				uid = token.UserID
+				tokenID = token.ID
 			} else {
 				// Authorizationヘッダーがないためセッションを確認する
 				sess, err := sessStore.GetSession(c)
@@ -80,7 +81,11 @@ func UserAuthenticate(repo repository.Repository, sessStore session.Store) echo.
 
 			c.Set(consts.KeyUser, user)
 			c.Set(consts.KeyUserID, user.GetID())
-			c.SetRequest(c.Request().WithContext(context.WithValue(c.Request().Context(), ctxkey.UserID, user.GetID()))) // SSEストリーマーで使う
+			ctx := context.WithValue(c.Request().Context(), ctxkey.UserID, user.GetID()) // SSEストリーマーで使う
+			if tokenID != uuid.Nil {
+				ctx = context.WithValue(ctx, ctxkey.AccessTokenID, tokenID) // BOTストリーマーで使う
+			}
+			c.SetRequest(c.Request().WithContext(ctx))
 			return next(c)
 		}
 	}
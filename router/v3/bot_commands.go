@@ -0,0 +1,272 @@
+package v3
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v5"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// GetBotCommands GET /bots/:botID/commands
+func (h *Handlers) GetBotCommands(c *echo.Context) error {
+	b := getParamBot(c)
+
+	cmds, err := h.Repo.GetBotCommands(c.Request().Context(), b.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatBotCommands(cmds))
+}
+
+// PutBotCommandRequest BOTのスラッシュコマンドの定義
+type PutBotCommandRequest struct {
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Arguments   model.BotCommandArguments `json:"arguments"`
+	ChannelIDs  []uuid.UUID               `json:"channelIds"`
+}
+
+func (r PutBotCommandRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, validator.BotCommandNameRuleRequired...),
+		vd.Field(&r.Description, vd.RuneLength(0, 200)),
+		vd.Field(&r.Arguments, vd.By(validateBotCommandArguments)),
+		vd.Field(&r.ChannelIDs, vd.Length(0, 100), vd.Each(validator.NotNilUUID)),
+	)
+}
+
+// validateBotCommandArguments 引数の定義を検証します
+//
+// model.BotCommandArgumentsはdriver.Valuerを実装しているため、vd.Lengthなどは使えない
+func validateBotCommandArguments(value any) error {
+	args, _ := value.(model.BotCommandArguments)
+	if len(args) > 10 {
+		return errors.New("the number of arguments must be no more than 10")
+	}
+	names := make(map[string]struct{}, len(args))
+	for _, a := range args {
+		if err := vd.Validate(a.Name, validator.BotCommandNameRuleRequired...); err != nil {
+			return errors.New("argument name " + err.Error())
+		}
+		if _, ok := names[a.Name]; ok {
+			return errors.New("argument names must be unique")
+		}
+		names[a.Name] = struct{}{}
+		if err := vd.Validate(a.Description, vd.RuneLength(0, 200)); err != nil {
+			return errors.New("argument description " + err.Error())
+		}
+		if !a.Type.Valid() {
+			return errors.New("argument type must be one of string, integer, boolean, user, channel")
+		}
+	}
+	return nil
+}
+
+// PutBotCommandsRequest PUT /bots/:botID/commands リクエストボディ
+type PutBotCommandsRequest struct {
+	Commands []PutBotCommandRequest `json:"commands"`
+}
+
+func (r PutBotCommandsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Commands, vd.NotNil, vd.Length(0, 50), vd.By(func(value any) error {
+			names := make(map[string]struct{}, len(r.Commands))
+			for _, cmd := range r.Commands {
+				if _, ok := names[cmd.Name]; ok {
+					return errors.New("command names must be unique")
+				}
+				names[cmd.Name] = struct{}{}
+			}
+			return nil
+		})),
+	)
+}
+
+// SetBotCommands PUT /bots/:botID/commands
+func (h *Handlers) SetBotCommands(c *echo.Context) error {
+	b := getParamBot(c)
+
+	var req PutBotCommandsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	args := make([]repository.SetBotCommandArgs, len(req.Commands))
+	for i, cmd := range req.Commands {
+		args[i] = repository.SetBotCommandArgs{
+			Name:        cmd.Name,
+			Description: cmd.Description,
+			Arguments:   cmd.Arguments,
+			ChannelIDs:  cmd.ChannelIDs,
+		}
+	}
+
+	cmds, err := h.Repo.SetBotCommands(c.Request().Context(), b.ID, args)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatBotCommands(cmds))
+}
+
+// GetChannelBotCommands GET /channels/:channelID/bot-commands
+func (h *Handlers) GetChannelBotCommands(c *echo.Context) error {
+	ch := getParamChannel(c)
+
+	cmds, err := h.Repo.GetChannelBotCommands(c.Request().Context(), ch.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatBotCommands(cmds))
+}
+
+// PostBotCommandInvocationRequest POST /channels/:channelID/bot-commands/invoke リクエストボディ
+type PostBotCommandInvocationRequest struct {
+	CommandID uuid.UUID      `json:"commandId"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+func (r PostBotCommandInvocationRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.CommandID, vd.Required, validator.NotNilUUID),
+	)
+}
+
+// InvokeBotCommand POST /channels/:channelID/bot-commands/invoke
+func (h *Handlers) InvokeBotCommand(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+	ch := getParamChannel(c)
+
+	var req PostBotCommandInvocationRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// BOTが参加していて、このチャンネルで使用できるコマンドのみ呼び出せる
+	cmds, err := h.Repo.GetChannelBotCommands(ctx, ch.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	idx := slices.IndexFunc(cmds, func(cmd *model.BotCommand) bool { return cmd.ID == req.CommandID })
+	if idx < 0 {
+		return herror.BadRequest("this command is not available in this channel")
+	}
+	cmd := cmds[idx]
+
+	if req.Arguments == nil {
+		req.Arguments = map[string]any{}
+	}
+	if err := cmd.ValidateArguments(req.Arguments); err != nil {
+		return herror.BadRequest(err)
+	}
+
+	inv, err := h.Repo.CreateBotCommandInvocation(ctx, cmd, userID, ch.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	h.Hub.Publish(hub.Message{
+		Name: event.BotCommandInvoked,
+		Fields: hub.Fields{
+			"invocation": inv,
+			"command":    cmd,
+			"arguments":  req.Arguments,
+			"user_id":    userID,
+			"channel_id": ch.ID,
+		},
+	})
+	return c.JSON(http.StatusAccepted, map[string]any{"invocationId": inv.ID})
+}
+
+// PostBotCommandReplyRequest POST /bot-command-invocations/:invocationID/reply リクエストボディ
+type PostBotCommandReplyRequest struct {
+	Content   string `json:"content"`
+	Embed     bool   `json:"embed"`
+	Ephemeral bool   `json:"ephemeral"`
+}
+
+func (r PostBotCommandReplyRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Content, vd.Required, vd.RuneLength(1, 10000)),
+	)
+}
+
+// ReplyBotCommandInvocation POST /bot-command-invocations/:invocationID/reply
+func (h *Handlers) ReplyBotCommandInvocation(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+	invocationID := getParamAsUUID(c, consts.ParamInvocationID)
+
+	var req PostBotCommandReplyRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	inv, err := h.Repo.GetBotCommandInvocation(ctx, invocationID)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	// 他のBOTへの呼び出しは存在しないものとして扱う
+	b, err := h.Repo.GetBotByBotUserID(ctx, userID)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if b.ID != inv.BotID {
+		return herror.NotFound()
+	}
+	if inv.IsExpired() {
+		return herror.BadRequest("this invocation has expired")
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	if req.Ephemeral {
+		h.Hub.Publish(hub.Message{
+			Name: event.BotCommandEphemeralReplied,
+			Fields: hub.Fields{
+				"invocation":  inv,
+				"user_id":     inv.UserID,
+				"channel_id":  inv.ChannelID,
+				"bot_user_id": userID,
+				"content":     req.Content,
+				"embed":       req.Embed,
+			},
+		})
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	m, err := h.MessageManager.Create(ctx, inv.ChannelID, userID, req.Content)
+	if err != nil {
+		switch err {
+		case message.ErrChannelArchived:
+			return herror.BadRequest("this channel has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, m)
+}
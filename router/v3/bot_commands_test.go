@@ -0,0 +1,253 @@
+package v3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func TestHandlers_SetBotCommands(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/bots/{botId}/commands"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot.ID).
+			WithJSON(&PutBotCommandsRequest{Commands: []PutBotCommandRequest{}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot.ID).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PutBotCommandsRequest{Commands: []PutBotCommandRequest{}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (invalid name)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutBotCommandsRequest{Commands: []PutBotCommandRequest{{Name: "Foo Bar"}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (duplicated name)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutBotCommandsRequest{Commands: []PutBotCommandRequest{{Name: "foo"}, {Name: "foo"}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid argument type)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, bot.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutBotCommandsRequest{Commands: []PutBotCommandRequest{{
+				Name:      "foo",
+				Arguments: model.BotCommandArguments{{Name: "a", Type: "number"}},
+			}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		arr := e.PUT(path, bot.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutBotCommandsRequest{Commands: []PutBotCommandRequest{
+				{Name: "foo", Description: "foo command", Arguments: model.BotCommandArguments{{Name: "a", Type: model.BotCommandArgumentTypeString, Required: true}}},
+				{Name: "bar"},
+			}}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		arr.Length().IsEqual(2)
+		arr.Value(0).Object().Value("name").String().IsEqual("bar")
+		arr.Value(1).Object().Value("name").String().IsEqual("foo")
+		arr.Value(1).Object().Value("arguments").Array().Length().IsEqual(1)
+
+		e.GET(path, bot.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			Length().
+			IsEqual(2)
+	})
+}
+
+func TestHandlers_InvokeBotCommand(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/channels/{channelId}/bot-commands/invoke"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	ch2 := env.CreateChannel(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(context.TODO(), bot.ID, model.BotActive))
+	require.NoError(t, env.Repository.AddBotToChannel(context.TODO(), bot.ID, ch.ID))
+	cmds, err := env.Repository.SetBotCommands(context.TODO(), bot.ID, []repository.SetBotCommandArgs{
+		{Name: "echo", Arguments: model.BotCommandArguments{{Name: "text", Type: model.BotCommandArgumentTypeString, Required: true}}},
+	})
+	require.NoError(t, err)
+	cmd := cmds[0]
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithJSON(&PostBotCommandInvocationRequest{CommandID: cmd.ID, Arguments: map[string]any{"text": "a"}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (not available)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch2.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostBotCommandInvocationRequest{CommandID: cmd.ID, Arguments: map[string]any{"text": "a"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid arguments)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostBotCommandInvocationRequest{CommandID: cmd.ID, Arguments: map[string]any{"text": 1}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostBotCommandInvocationRequest{CommandID: cmd.ID, Arguments: map[string]any{"text": "a"}}).
+			Expect().
+			Status(http.StatusAccepted).
+			JSON().
+			Object().
+			Value("invocationId").
+			String().
+			NotEmpty()
+	})
+}
+
+func TestHandlers_ReplyBotCommandInvocation(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/bot-command-invocations/{invocationId}/reply"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	bot2 := env.CreateBot(t, rand, user.GetID())
+	cmds, err := env.Repository.SetBotCommands(context.TODO(), bot.ID, []repository.SetBotCommandArgs{{Name: "echo"}})
+	require.NoError(t, err)
+	inv, err := env.Repository.CreateBotCommandInvocation(context.TODO(), cmds[0], user.GetID(), ch.ID)
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+	botSession := env.S(t, bot.BotUserID)
+	bot2Session := env.S(t, bot2.BotUserID)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inv.ID).
+			WithJSON(&PostBotCommandReplyRequest{Content: "a"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden (not bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inv.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostBotCommandReplyRequest{Content: "a"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found (other bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inv.ID).
+			WithCookie(session.CookieName, bot2Session).
+			WithJSON(&PostBotCommandReplyRequest{Content: "a"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV7())).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostBotCommandReplyRequest{Content: "a"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success (ephemeral)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, inv.ID).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostBotCommandReplyRequest{Content: "a", Ephemeral: true}).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, inv.ID).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostBotCommandReplyRequest{Content: "hello"}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("channelId").String().IsEqual(ch.ID.String())
+		obj.Value("userId").String().IsEqual(bot.BotUserID.String())
+		obj.Value("content").String().IsEqual("hello")
+	})
+}
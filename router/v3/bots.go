@@ -3,6 +3,7 @@ package v3
 import (
 	"context"
 	"net/http"
+	"time"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -253,6 +254,77 @@ func (h *Handlers) GetBotLogs(c *echo.Context) error {
 	return c.JSON(http.StatusOK, formatBotEventLogs(logs))
 }
 
+// GetBotEventDeliveriesRequest GET /bots/:botID/events リクエストクエリ
+type GetBotEventDeliveriesRequest struct {
+	State  string `query:"state"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+func (r *GetBotEventDeliveriesRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 30
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.State, vd.In(string(model.BotEventDeliveryPending), string(model.BotEventDeliveryDead))),
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetBotEventDeliveries GET /bots/:botID/events
+func (h *Handlers) GetBotEventDeliveries(c *echo.Context) error {
+	b := getParamBot(c)
+
+	var req GetBotEventDeliveriesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	var state optional.Of[model.BotEventDeliveryState]
+	if req.State != "" {
+		state = optional.From(model.BotEventDeliveryState(req.State))
+	}
+	ds, err := h.Repo.GetBotEventDeliveries(c.Request().Context(), b.ID, state, req.Limit, req.Offset)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, formatBotEventDeliveries(ds))
+}
+
+// RedeliverBotEvent POST /bots/:botID/events/:requestID/redeliver
+func (h *Handlers) RedeliverBotEvent(c *echo.Context) error {
+	b := getParamBot(c)
+	requestID := getParamAsUUID(c, consts.ParamRequestID)
+
+	d, err := h.Repo.GetBotEventDelivery(c.Request().Context(), requestID)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	if d.BotID != b.ID {
+		return herror.NotFound()
+	}
+	// 一時停止・無効化されたBOTへは再送されないため、先に有効化してもらう
+	if b.State != model.BotActive {
+		return herror.BadRequest("this bot is not active")
+	}
+
+	// 送信回数をリセットして、次の再送処理で送信する
+	d.State = model.BotEventDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = time.Now()
+	if err := h.Repo.UpdateBotEventDelivery(c.Request().Context(), d); err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.NoContent(http.StatusAccepted)
+}
+
 // GetChannelBots GET /channels/:channelID/bots
 func (h *Handlers) GetChannelBots(c *echo.Context) error {
 	channelID := getParamAsUUID(c, consts.ParamChannelID)
@@ -779,6 +779,189 @@ func TestHandlers_GetBotLogs(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetBotEventDeliveries(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/events"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	commonSession := env.S(t, user1.GetID())
+	bot1 := env.CreateBot(t, rand, user1.GetID())
+	bot2 := env.CreateBot(t, rand, user2.GetID())
+
+	pending := &model.BotEventDelivery{
+		RequestID:     uuid.Must(uuid.NewV7()),
+		BotID:         bot1.ID,
+		Event:         event.MessageCreated,
+		Body:          "{}",
+		State:         model.BotEventDeliveryPending,
+		Attempts:      1,
+		LastError:     "unexpected status code: 500",
+		NextAttemptAt: time.Now().Add(time.Minute),
+	}
+	require.NoError(t, env.Repository.CreateBotEventDelivery(context.TODO(), pending))
+	dead := &model.BotEventDelivery{
+		RequestID:     uuid.Must(uuid.NewV7()),
+		BotID:         bot1.ID,
+		Event:         event.MessageCreated,
+		Body:          "{}",
+		State:         model.BotEventDeliveryDead,
+		Attempts:      model.BotEventDeliveryMaxAttempts,
+		NextAttemptAt: time.Now(),
+	}
+	require.NoError(t, env.Repository.CreateBotEventDelivery(context.TODO(), dead))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot1.ID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid state)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithQuery("state", "ok").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot2.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			Length().
+			IsEqual(2)
+	})
+
+	t.Run("success (dead)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, bot1.ID.String()).
+			WithCookie(session.CookieName, commonSession).
+			WithQuery("state", "dead").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		first := obj.Value(0).Object()
+		first.Value("requestId").String().IsEqual(dead.RequestID.String())
+		first.Value("state").String().IsEqual("dead")
+		first.Value("attempts").Number().IsEqual(model.BotEventDeliveryMaxAttempts)
+	})
+}
+
+func TestHandlers_RedeliverBotEvent(t *testing.T) {
+	t.Parallel()
+	path := "/api/v3/bots/{botId}/events/{requestId}/redeliver"
+	env := Setup(t, common1)
+	user1 := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	commonSession := env.S(t, user1.GetID())
+	activeBot := env.CreateBot(t, rand, user1.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(context.TODO(), activeBot.ID, model.BotActive))
+	pausedBot := env.CreateBot(t, rand, user1.GetID())
+	require.NoError(t, env.Repository.ChangeBotState(context.TODO(), pausedBot.ID, model.BotPaused))
+	otherBot := env.CreateBot(t, rand, user2.GetID())
+
+	mustMakeDeadDelivery := func(t *testing.T, botID uuid.UUID) *model.BotEventDelivery {
+		t.Helper()
+		d := &model.BotEventDelivery{
+			RequestID:     uuid.Must(uuid.NewV7()),
+			BotID:         botID,
+			Event:         event.MessageCreated,
+			Body:          "{}",
+			State:         model.BotEventDeliveryDead,
+			Attempts:      model.BotEventDeliveryMaxAttempts,
+			NextAttemptAt: time.Now(),
+		}
+		require.NoError(t, env.Repository.CreateBotEventDelivery(context.TODO(), d))
+		return d
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		d := mustMakeDeadDelivery(t, activeBot.ID)
+		e := env.R(t)
+		e.POST(path, activeBot.ID.String(), d.RequestID.String()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		d := mustMakeDeadDelivery(t, otherBot.ID)
+		e := env.R(t)
+		e.POST(path, otherBot.ID.String(), d.RequestID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, activeBot.ID.String(), uuid.Must(uuid.NewV7()).String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (other bot's event)", func(t *testing.T) {
+		t.Parallel()
+		d := mustMakeDeadDelivery(t, pausedBot.ID)
+		e := env.R(t)
+		e.POST(path, activeBot.ID.String(), d.RequestID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request (paused bot)", func(t *testing.T) {
+		t.Parallel()
+		d := mustMakeDeadDelivery(t, pausedBot.ID)
+		e := env.R(t)
+		e.POST(path, pausedBot.ID.String(), d.RequestID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		d := mustMakeDeadDelivery(t, activeBot.ID)
+		e := env.R(t)
+		e.POST(path, activeBot.ID.String(), d.RequestID.String()).
+			WithCookie(session.CookieName, commonSession).
+			Expect().
+			Status(http.StatusAccepted)
+
+		got, err := env.Repository.GetBotEventDelivery(context.TODO(), d.RequestID)
+		require.NoError(t, err)
+		require.Equal(t, model.BotEventDeliveryPending, got.State)
+		require.Equal(t, 0, got.Attempts)
+	})
+}
+
 func TestHandlers_GetChannelBots(t *testing.T) {
 	t.Parallel()
 	path := "/api/v3/channels/{channelId}/bots"
@@ -113,10 +113,11 @@ func (h *Handlers) GetChannel(c *echo.Context) error {
 
 // PatchChannelRequest PATCH /channels/:channelID リクエストボディ
 type PatchChannelRequest struct {
-	Name     optional.Of[string]    `json:"name"`
-	Archived optional.Of[bool]      `json:"archived"`
-	Force    optional.Of[bool]      `json:"force"`
-	Parent   optional.Of[uuid.UUID] `json:"parent"`
+	Name                   optional.Of[string]    `json:"name"`
+	Archived               optional.Of[bool]      `json:"archived"`
+	Force                  optional.Of[bool]      `json:"force"`
+	Parent                 optional.Of[uuid.UUID] `json:"parent"`
+	DisableChannelMentions optional.Of[bool]      `json:"disableChannelMentions"`
 }
 
 func (r PatchChannelRequest) Validate() error {
@@ -158,10 +159,11 @@ func (h *Handlers) EditChannel(c *echo.Context) error {
 	}
 
 	args := repository.UpdateChannelArgs{
-		UpdaterID:          getRequestUserID(c),
-		Name:               req.Name,
-		ForcedNotification: req.Force,
-		Parent:             req.Parent,
+		UpdaterID:              getRequestUserID(c),
+		Name:                   req.Name,
+		ForcedNotification:     req.Force,
+		Parent:                 req.Parent,
+		DisableChannelMentions: req.DisableChannelMentions,
 	}
 	if err := h.ChannelManager.UpdateChannel(ctx, channelID, args); err != nil {
 		switch err {
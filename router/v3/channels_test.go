@@ -58,6 +58,7 @@ func channelEquals(t *testing.T, expect *model.Channel, actual *httpexpect.Objec
 	}
 	actual.Value("archived").Boolean().IsEqual(expect.IsArchived())
 	actual.Value("force").Boolean().IsEqual(expect.IsForced)
+	actual.Value("disableChannelMentions").Boolean().IsEqual(expect.DisableChannelMentions)
 	actual.Value("topic").String().IsEqual(expect.Topic)
 	actual.Value("name").String().IsEqual(expect.Name)
 	childIDs := make([]interface{}, 0, len(expect.ChildrenID))
@@ -544,6 +545,21 @@ func TestHandlers_EditChannel(t *testing.T) {
 		assert.EqualValues(t, newName, ch.Name)
 		assert.EqualValues(t, parent.ID, ch.ParentID)
 	})
+
+	t.Run("success (disable channel mentions)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		ch := env.CreateChannel(t, rand)
+		e.PATCH(path, ch.ID).
+			WithCookie(session.CookieName, adminSession).
+			WithJSON(&PatchChannelRequest{DisableChannelMentions: optional.From(true)}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		ch, err := env.CM.GetChannel(context.TODO(), ch.ID)
+		require.NoError(t, err)
+		assert.True(t, ch.DisableChannelMentions)
+	})
 }
 
 func TestHandlers_GetChannelStats(t *testing.T) {
@@ -0,0 +1,97 @@
+package v3
+
+import (
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v5"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// GetMyInboxRequest GET /users/me/notifications 用リクエストクエリ
+type GetMyInboxRequest struct {
+	Limit  int                    `query:"limit"`
+	Cursor optional.Of[uuid.UUID] `query:"cursor"`
+	Unread bool                   `query:"unread"`
+}
+
+func (q *GetMyInboxRequest) Validate() error {
+	if q.Limit == 0 {
+		q.Limit = 20
+	}
+	return vd.ValidateStruct(q,
+		vd.Field(&q.Limit, vd.Min(1), vd.Max(100)),
+	)
+}
+
+// GetMyInbox GET /users/me/notifications
+func (h *Handlers) GetMyInbox(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+
+	var req GetMyInboxRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	items, more, err := h.Repo.GetInboxItems(ctx, repository.InboxItemsQuery{
+		UserID:     userID,
+		Cursor:     req.Cursor,
+		UnreadOnly: req.Unread,
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	unreadCount, err := h.Repo.GetUnreadInboxItemCount(ctx, userID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	res := &Inbox{
+		Items:       formatInboxItems(items),
+		UnreadCount: unreadCount,
+	}
+	if more {
+		res.NextCursor = optional.From(items[len(items)-1].ID)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// PostMyInboxReadRequest POST /users/me/notifications/read リクエストボディ
+type PostMyInboxReadRequest struct {
+	IDs []uuid.UUID `json:"ids"`
+	All bool        `json:"all"`
+}
+
+func (r PostMyInboxReadRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.IDs, vd.When(!r.All, vd.Required), vd.Length(0, 200)),
+	)
+}
+
+// ReadMyInboxItems POST /users/me/notifications/read
+func (h *Handlers) ReadMyInboxItems(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+
+	var req PostMyInboxReadRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.All {
+		if err := h.Repo.MarkAllInboxItemsAsRead(ctx, userID); err != nil {
+			return herror.InternalServerError(err)
+		}
+	} else {
+		if err := h.Repo.MarkInboxItemsAsRead(ctx, userID, req.IDs); err != nil {
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
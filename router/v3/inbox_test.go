@@ -0,0 +1,156 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func TestHandlers_GetMyInbox(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/notifications"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m1 := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+	m2 := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+	i1 := env.CreateInboxItem(t, user.GetID(), m1)
+	i2 := env.CreateInboxItem(t, user.GetID(), m2)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid cursor)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, s).
+			WithQuery("cursor", "invalid").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			WithQuery("limit", 1).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("unreadCount").Number().IsEqual(2)
+		obj.Value("nextCursor").String().IsEqual(i2.ID.String())
+		items := obj.Value("items").Array()
+		items.Length().IsEqual(1)
+		first := items.Value(0).Object()
+		first.Value("id").String().IsEqual(i2.ID.String())
+		first.Value("type").String().IsEqual("mention")
+		first.Value("messageId").String().IsEqual(m2.GetID().String())
+		first.Value("actorId").String().IsEqual(user2.GetID().String())
+		first.Value("isRead").Boolean().IsFalse()
+
+		obj = e.GET(path).
+			WithCookie(session.CookieName, s).
+			WithQuery("limit", 1).
+			WithQuery("cursor", i2.ID.String()).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("nextCursor").IsNull()
+		items = obj.Value("items").Array()
+		items.Length().IsEqual(1)
+		items.Value(0).Object().Value("id").String().IsEqual(i1.ID.String())
+	})
+}
+
+func TestHandlers_ReadMyInboxItems(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/notifications/read"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user2.GetID(), ch.ID, rand)
+	i1 := env.CreateInboxItem(t, user.GetID(), m)
+	env.CreateInboxItem(t, user.GetID(), m)
+	env.CreateInboxItem(t, user2.GetID(), m)
+	env.CreateInboxItem(t, user2.GetID(), m)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostMyInboxReadRequest{All: true}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (empty)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyInboxReadRequest{}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success (ids)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyInboxReadRequest{IDs: []uuid.UUID{i1.ID}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.GET("/api/v3/users/me/notifications").
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("unreadCount").
+			Number().
+			IsEqual(1)
+	})
+
+	t.Run("success (all)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PostMyInboxReadRequest{All: true}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.GET("/api/v3/users/me/notifications").
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("unreadCount").
+			Number().
+			IsEqual(0)
+	})
+}
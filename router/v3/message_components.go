@@ -0,0 +1,252 @@
+package v3
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// validateMessageComponentOptions セレクトメニューの選択肢を検証します
+//
+// model.MessageComponentOptionsはdriver.Valuerを実装しているため、vd.Lengthなどは使えない
+func validateMessageComponentOptions(value any) error {
+	options, _ := value.(model.MessageComponentOptions)
+	if len(options) > 25 {
+		return errors.New("the number of options must be no more than 25")
+	}
+	values := make(map[string]struct{}, len(options))
+	for _, o := range options {
+		if err := vd.Validate(o.Label, vd.Required, vd.RuneLength(1, 80)); err != nil {
+			return errors.New("option label " + err.Error())
+		}
+		if err := vd.Validate(o.Value, vd.Required, vd.RuneLength(1, 100)); err != nil {
+			return errors.New("option value " + err.Error())
+		}
+		if _, ok := values[o.Value]; ok {
+			return errors.New("option values must be unique")
+		}
+		values[o.Value] = struct{}{}
+	}
+	return nil
+}
+
+// PutMessageComponentRequest メッセージコンポーネントの定義
+type PutMessageComponentRequest struct {
+	CustomID string                        `json:"customId"`
+	Type     model.MessageComponentType    `json:"type"`
+	Label    string                        `json:"label"`
+	Style    model.MessageComponentStyle   `json:"style"`
+	Options  model.MessageComponentOptions `json:"options"`
+	Disabled bool                          `json:"disabled"`
+}
+
+func (r PutMessageComponentRequest) Validate() error {
+	isSelect := r.Type == model.MessageComponentTypeSelect
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.CustomID, vd.Required, vd.RuneLength(1, 100)),
+		vd.Field(&r.Type, vd.Required, vd.In(model.MessageComponentTypeButton, model.MessageComponentTypeSelect)),
+		vd.Field(&r.Label, vd.When(!isSelect, vd.Required), vd.RuneLength(0, 80)),
+		vd.Field(&r.Style, vd.In(model.MessageComponentStyleDefault, model.MessageComponentStylePrimary, model.MessageComponentStyleDanger)),
+		vd.Field(&r.Options, vd.By(func(value any) error {
+			if isSelect && len(r.Options) == 0 {
+				return errors.New("options are required for select")
+			}
+			if !isSelect && len(r.Options) > 0 {
+				return errors.New("options must be empty for button")
+			}
+			return validateMessageComponentOptions(value)
+		})),
+	)
+}
+
+// PutMessageComponentsRequest PUT /messages/:messageID/components リクエストボディ
+type PutMessageComponentsRequest struct {
+	Components []PutMessageComponentRequest `json:"components"`
+}
+
+func (r PutMessageComponentsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Components, vd.NotNil, vd.Length(0, 10), vd.By(func(value any) error {
+			ids := make(map[string]struct{}, len(r.Components))
+			for _, c := range r.Components {
+				if _, ok := ids[c.CustomID]; ok {
+					return errors.New("custom ids must be unique")
+				}
+				ids[c.CustomID] = struct{}{}
+			}
+			return nil
+		})),
+	)
+}
+
+// PatchMessageComponentRequest PATCH /messages/:messageID/components/:customID リクエストボディ
+type PatchMessageComponentRequest struct {
+	Label    optional.Of[string]           `json:"label"`
+	Style    optional.Of[string]           `json:"style"`
+	Options  model.MessageComponentOptions `json:"options"`
+	Disabled optional.Of[bool]             `json:"disabled"`
+}
+
+func (r PatchMessageComponentRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Label, vd.RuneLength(0, 80)),
+		vd.Field(&r.Style, validator.RequiredIfValid, vd.In(string(model.MessageComponentStyleDefault), string(model.MessageComponentStylePrimary), string(model.MessageComponentStyleDanger))),
+		vd.Field(&r.Options, vd.By(validateMessageComponentOptions)),
+	)
+}
+
+// PostMessageInteractionRequest POST /messages/:messageID/interactions リクエストボディ
+type PostMessageInteractionRequest struct {
+	CustomID string `json:"customId"`
+	Value    string `json:"value"`
+}
+
+func (r PostMessageInteractionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.CustomID, vd.Required, vd.RuneLength(1, 100)),
+		vd.Field(&r.Value, vd.RuneLength(0, 100)),
+	)
+}
+
+// SetMessageComponents PUT /messages/:messageID/components
+func (h *Handlers) SetMessageComponents(c *echo.Context) error {
+	m := getParamMessage(c)
+
+	var req PutMessageComponentsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// 他人のメッセージにコンポーネントは添付できない
+	if getRequestUserID(c) != m.GetUserID() {
+		return herror.Forbidden("This is not your message")
+	}
+
+	args := make([]repository.SetMessageComponentArgs, len(req.Components))
+	for i, component := range req.Components {
+		style := component.Style
+		if style == "" {
+			style = model.MessageComponentStyleDefault
+		}
+		args[i] = repository.SetMessageComponentArgs{
+			CustomID: component.CustomID,
+			Type:     component.Type,
+			Label:    component.Label,
+			Style:    style,
+			Options:  component.Options,
+			Disabled: component.Disabled,
+		}
+	}
+
+	components, err := h.MessageManager.SetComponents(c.Request().Context(), m.GetID(), args)
+	if err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound()
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusOK, components)
+}
+
+// EditMessageComponent PATCH /messages/:messageID/components/:customID
+func (h *Handlers) EditMessageComponent(c *echo.Context) error {
+	m := getParamMessage(c)
+	customID := c.Param(consts.ParamCustomID)
+
+	var req PatchMessageComponentRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// 他人のメッセージのコンポーネントは変更できない
+	if getRequestUserID(c) != m.GetUserID() {
+		return herror.Forbidden("This is not your message")
+	}
+
+	idx := slices.IndexFunc(m.GetComponents(), func(mc model.MessageComponent) bool { return mc.CustomID == customID })
+	if idx < 0 {
+		return herror.NotFound("component was not found")
+	}
+	if req.Options != nil && (m.GetComponents()[idx].Type != model.MessageComponentTypeSelect || len(req.Options) == 0) {
+		return herror.BadRequest("options can be set only for select and must not be empty")
+	}
+
+	args := repository.UpdateMessageComponentArgs{
+		Label:    req.Label,
+		Disabled: req.Disabled,
+	}
+	if req.Style.Valid {
+		args.Style = optional.From(model.MessageComponentStyle(req.Style.V))
+	}
+	if req.Options != nil {
+		args.Options = optional.From(req.Options)
+	}
+
+	component, err := h.MessageManager.UpdateComponent(c.Request().Context(), m.GetID(), customID, args)
+	if err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound("component was not found")
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusOK, component)
+}
+
+// InteractMessageComponent POST /messages/:messageID/interactions
+func (h *Handlers) InteractMessageComponent(c *echo.Context) error {
+	m := getParamMessage(c)
+
+	var req PostMessageInteractionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	idx := slices.IndexFunc(m.GetComponents(), func(mc model.MessageComponent) bool { return mc.CustomID == req.CustomID })
+	if idx < 0 {
+		return herror.NotFound("component was not found")
+	}
+	component := m.GetComponents()[idx]
+	if component.Disabled {
+		return herror.BadRequest("this component is disabled")
+	}
+	switch component.Type {
+	case model.MessageComponentTypeSelect:
+		if !component.HasOption(req.Value) {
+			return herror.BadRequest("invalid value")
+		}
+	default:
+		req.Value = ""
+	}
+
+	h.Hub.Publish(hub.Message{
+		Name: event.MessageComponentInteracted,
+		Fields: hub.Fields{
+			"message_id": m.GetID(),
+			"user_id":    getRequestUserID(c),
+			"component":  &component,
+			"value":      req.Value,
+		},
+	})
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,257 @@
+package v3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestHandlers_SetMessageComponents(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/components"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	bot2 := env.CreateBot(t, rand, user.GetID())
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, bot.BotUserID, ch.ID, rand)
+	s := env.S(t, user.GetID())
+	botSession := env.S(t, bot.BotUserID)
+	bot2Session := env.S(t, bot2.BotUserID)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithJSON(&PutMessageComponentsRequest{Components: []PutMessageComponentRequest{}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden (not bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMessageComponentsRequest{Components: []PutMessageComponentRequest{}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("forbidden (other bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithCookie(session.CookieName, bot2Session).
+			WithJSON(&PutMessageComponentsRequest{Components: []PutMessageComponentRequest{}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request (select without options)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PutMessageComponentsRequest{Components: []PutMessageComponentRequest{{
+				CustomID: "choose",
+				Type:     model.MessageComponentTypeSelect,
+			}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (duplicated custom id)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, m.GetID()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PutMessageComponentsRequest{Components: []PutMessageComponentRequest{
+				{CustomID: "ok", Type: model.MessageComponentTypeButton, Label: "OK"},
+				{CustomID: "ok", Type: model.MessageComponentTypeButton, Label: "OK"},
+			}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, bot.BotUserID, ch.ID, rand)
+		e := env.R(t)
+		arr := e.PUT(path, m.GetID()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PutMessageComponentsRequest{Components: []PutMessageComponentRequest{
+				{CustomID: "ok", Type: model.MessageComponentTypeButton, Label: "OK", Style: model.MessageComponentStylePrimary},
+				{CustomID: "choose", Type: model.MessageComponentTypeSelect, Options: model.MessageComponentOptions{{Label: "A", Value: "a"}}},
+			}}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		arr.Length().IsEqual(2)
+		arr.Value(0).Object().Value("customId").String().IsEqual("ok")
+		arr.Value(0).Object().Value("style").String().IsEqual("primary")
+		arr.Value(1).Object().Value("customId").String().IsEqual("choose")
+		arr.Value(1).Object().Value("style").String().IsEqual("default")
+		arr.Value(1).Object().Value("options").Array().Length().IsEqual(1)
+
+		e.GET("/api/v3/messages/{messageId}", m.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("components").
+			Array().
+			Length().
+			IsEqual(2)
+	})
+}
+
+func TestHandlers_EditMessageComponent(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/components/{customId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, bot.BotUserID, ch.ID, rand)
+	_, err := env.MM.SetComponents(context.TODO(), m.GetID(), []repository.SetMessageComponentArgs{
+		{CustomID: "ok", Type: model.MessageComponentTypeButton, Label: "OK", Style: model.MessageComponentStyleDefault},
+	})
+	require.NoError(t, err)
+	botSession := env.S(t, bot.BotUserID)
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, m.GetID(), "unknown").
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PatchMessageComponentRequest{Disabled: optional.From(true)}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request (options for button)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, m.GetID(), "ok").
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PatchMessageComponentRequest{Options: model.MessageComponentOptions{{Label: "A", Value: "a"}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.PATCH(path, m.GetID(), "ok").
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PatchMessageComponentRequest{Label: optional.From("Done"), Disabled: optional.From(true)}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("label").String().IsEqual("Done")
+		obj.Value("disabled").Boolean().IsTrue()
+	})
+}
+
+func TestHandlers_InteractMessageComponent(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/interactions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	bot := env.CreateBot(t, rand, user.GetID())
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, bot.BotUserID, ch.ID, rand)
+	_, err := env.MM.SetComponents(context.TODO(), m.GetID(), []repository.SetMessageComponentArgs{
+		{CustomID: "ok", Type: model.MessageComponentTypeButton, Label: "OK", Style: model.MessageComponentStyleDefault},
+		{CustomID: "off", Type: model.MessageComponentTypeButton, Label: "Off", Style: model.MessageComponentStyleDefault, Disabled: true},
+		{CustomID: "choose", Type: model.MessageComponentTypeSelect, Style: model.MessageComponentStyleDefault, Options: model.MessageComponentOptions{{Label: "A", Value: "a"}}},
+	})
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+	botSession := env.S(t, bot.BotUserID)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "ok"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden (bot)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, botSession).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "ok"}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "unknown"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request (disabled)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "off"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid value)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "choose", Value: "b"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success (button)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "ok"}).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+
+	t.Run("success (select)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageInteractionRequest{CustomID: "choose", Value: "a"}).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,93 @@
+package v3
+
+import (
+	"net/http"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+)
+
+// maxMessageRemindersPerUser ユーザーあたりのリマインダーの最大数
+const maxMessageRemindersPerUser = 100
+
+// PostMessageReminderRequest POST /messages/:messageID/reminders リクエストボディ
+type PostMessageReminderRequest struct {
+	RemindAt time.Time `json:"remindAt"`
+}
+
+func (r PostMessageReminderRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.RemindAt, vd.Required, vd.Min(time.Now()).Error("must be a future time")),
+	)
+}
+
+// CreateMessageReminder POST /messages/:messageID/reminders
+func (h *Handlers) CreateMessageReminder(c *echo.Context) error {
+	m := getParamMessage(c)
+
+	var req PostMessageReminderRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+
+	rs, err := h.Repo.GetMessageRemindersByUserID(ctx, userID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if len(rs) >= maxMessageRemindersPerUser {
+		return herror.BadRequest("too many reminders")
+	}
+
+	r, err := h.Repo.CreateMessageReminder(ctx, userID, m.GetID(), req.RemindAt)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusCreated, formatMessageReminder(r))
+}
+
+// GetMyMessageReminders GET /users/me/reminders
+func (h *Handlers) GetMyMessageReminders(c *echo.Context) error {
+	rs, err := h.Repo.GetMessageRemindersByUserID(c.Request().Context(), getRequestUserID(c))
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatMessageReminders(rs))
+}
+
+// DeleteMyMessageReminder DELETE /users/me/reminders/:reminderID
+func (h *Handlers) DeleteMyMessageReminder(c *echo.Context) error {
+	ctx := c.Request().Context()
+	id := getParamAsUUID(c, consts.ParamReminderID)
+
+	r, err := h.Repo.GetMessageReminder(ctx, id)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	// 他人のリマインダーは存在しないものとして扱う
+	if r.UserID != getRequestUserID(c) {
+		return herror.NotFound()
+	}
+
+	if err := h.Repo.DeleteMessageReminder(ctx, r.ID); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
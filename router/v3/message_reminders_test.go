@@ -0,0 +1,170 @@
+package v3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func TestHandlers_CreateMessageReminder(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/reminders"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(&PostMessageReminderRequest{RemindAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (past)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReminderRequest{RemindAt: time.Now().Add(-time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV7())).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReminderRequest{RemindAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReminderRequest{RemindAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("id").String().NotEmpty()
+		obj.Value("messageId").String().IsEqual(m.GetID().String())
+	})
+
+	t.Run("too many reminders", func(t *testing.T) {
+		t.Parallel()
+		user := env.CreateUser(t, rand)
+		s := env.S(t, user.GetID())
+		for range maxMessageRemindersPerUser {
+			_, err := env.Repository.CreateMessageReminder(context.TODO(), user.GetID(), m.GetID(), time.Now().Add(time.Hour))
+			require.NoError(t, err)
+		}
+
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReminderRequest{RemindAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+}
+
+func TestHandlers_GetMyMessageReminders(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/reminders"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	r, err := env.Repository.CreateMessageReminder(context.TODO(), user.GetID(), m.GetID(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		first := obj.Value(0).Object()
+		first.Value("id").String().IsEqual(r.ID.String())
+		first.Value("messageId").String().IsEqual(m.GetID().String())
+	})
+}
+
+func TestHandlers_DeleteMyMessageReminder(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/reminders/{reminderId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	r, err := env.Repository.CreateMessageReminder(context.TODO(), user.GetID(), m.GetID(), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, r.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("other's reminder", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, r.ID).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		r, err := env.Repository.CreateMessageReminder(context.TODO(), user.GetID(), m.GetID(), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		e := env.R(t)
+		e.DELETE(path, r.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.DELETE(path, r.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+}
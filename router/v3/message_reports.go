@@ -0,0 +1,153 @@
+package v3
+
+import (
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// PostMessageReportRequest POST /messages/:messageID/report リクエストボディ
+type PostMessageReportRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (r PostMessageReportRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Reason, vd.Required, vd.RuneLength(1, 1000)),
+	)
+}
+
+// ReportMessage POST /messages/:messageID/report
+func (h *Handlers) ReportMessage(c *echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessageReportRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if m.GetUserID() == userID {
+		return herror.BadRequest("you cannot report your own message")
+	}
+
+	if err := h.Repo.CreateMessageReport(c.Request().Context(), m.GetID(), userID, req.Reason); err != nil {
+		switch err {
+		case repository.ErrAlreadyExists:
+			return herror.Conflict("you have already reported this message")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetMessageReportsRequest GET /message-reports リクエストクエリ
+type GetMessageReportsRequest struct {
+	State  string `query:"state"`
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+func (r *GetMessageReportsRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 50
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.State, vd.In(model.MessageReportStateOpen.String(), model.MessageReportStateResolved.String(), model.MessageReportStateDismissed.String())),
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(200)),
+		vd.Field(&r.Offset, vd.Min(0)),
+	)
+}
+
+// GetMessageReports GET /message-reports
+func (h *Handlers) GetMessageReports(c *echo.Context) error {
+	var req GetMessageReportsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	q := repository.MessageReportsQuery{
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	}
+	if len(req.State) > 0 {
+		q.State = optional.From(model.MessageReportState(req.State))
+	}
+	reports, err := h.Repo.GetMessageReportsByQuery(c.Request().Context(), q)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatMessageReports(reports))
+}
+
+// GetMessageReport GET /message-reports/:reportID
+func (h *Handlers) GetMessageReport(c *echo.Context) error {
+	r, err := h.getMessageReport(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, formatMessageReport(r))
+}
+
+// PatchMessageReportRequest PATCH /message-reports/:reportID リクエストボディ
+type PatchMessageReportRequest struct {
+	State string `json:"state"`
+	Note  string `json:"note"`
+}
+
+func (r PatchMessageReportRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.State, vd.Required, vd.In(model.MessageReportStateOpen.String(), model.MessageReportStateResolved.String(), model.MessageReportStateDismissed.String())),
+		vd.Field(&r.Note, vd.RuneLength(0, 1000)),
+	)
+}
+
+// EditMessageReport PATCH /message-reports/:reportID
+func (h *Handlers) EditMessageReport(c *echo.Context) error {
+	r, err := h.getMessageReport(c)
+	if err != nil {
+		return err
+	}
+
+	var req PatchMessageReportRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	args := repository.UpdateMessageReportStateArgs{
+		State:      model.MessageReportState(req.State),
+		ResolverID: getRequestUserID(c),
+		Note:       req.Note,
+	}
+	if err := h.Repo.UpdateMessageReportState(c.Request().Context(), r.ID, args); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getMessageReport パスパラメータのメッセージ通報を取得
+func (h *Handlers) getMessageReport(c *echo.Context) (*model.MessageReport, error) {
+	r, err := h.Repo.GetMessageReport(c.Request().Context(), getParamAsUUID(c, consts.ParamReportID))
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, herror.NotFound()
+		default:
+			return nil, herror.InternalServerError(err)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,199 @@
+package v3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/service/rbac/role"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestHandlers_ReportMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/report"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (own message)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (empty reason)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PostMessageReportRequest{Reason: ""}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PostMessageReportRequest{Reason: "spam"}).
+			Expect().
+			Status(http.StatusConflict)
+	})
+}
+
+func TestHandlers_GetMessageReports(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	moderator := env.CreateUser(t, rand)
+	require.NoError(t, env.Repository.UpdateUser(context.TODO(), moderator.GetID(), repository.UpdateUserArgs{Role: optional.From(role.Moderator)}))
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, moderator.GetID(), ch.ID, rand)
+	require.NoError(t, env.Repository.CreateMessageReport(context.TODO(), m.GetID(), user.GetID(), "spam"))
+	s := env.S(t, user.GetID())
+	modSession := env.S(t, moderator.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, modSession).
+			WithQuery("state", "unknown").
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, modSession).
+			WithQuery("state", model.MessageReportStateOpen.String()).
+			WithQuery("limit", 200).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			NotEmpty()
+	})
+}
+
+func TestHandlers_EditMessageReport(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/message-reports/{reportId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	moderator := env.CreateUser(t, rand)
+	require.NoError(t, env.Repository.UpdateUser(context.TODO(), moderator.GetID(), repository.UpdateUserArgs{Role: optional.From(role.Moderator)}))
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, moderator.GetID(), ch.ID, rand)
+	require.NoError(t, env.Repository.CreateMessageReport(context.TODO(), m.GetID(), user.GetID(), "spam"))
+	reports, err := env.Repository.GetMessageReportsByMessageID(context.TODO(), m.GetID())
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	r := reports[0]
+	s := env.S(t, user.GetID())
+	modSession := env.S(t, moderator.GetID())
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateResolved.String()}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, uuid.Must(uuid.NewV7())).
+			WithCookie(session.CookieName, modSession).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateResolved.String()}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r.ID).
+			WithCookie(session.CookieName, modSession).
+			WithJSON(&PatchMessageReportRequest{State: "closed"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, r.ID).
+			WithCookie(session.CookieName, modSession).
+			WithJSON(&PatchMessageReportRequest{State: model.MessageReportStateDismissed.String(), Note: "not a problem"}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		obj := e.GET(path, r.ID).
+			WithCookie(session.CookieName, modSession).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+		obj.Value("state").String().IsEqual(model.MessageReportStateDismissed.String())
+		obj.Value("note").String().IsEqual("not a problem")
+		obj.Value("resolverId").String().IsEqual(moderator.GetID().String())
+	})
+}
@@ -1,10 +1,14 @@
 package v3
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v5"
 
 	"github.com/traPtitech/traQ/model"
@@ -13,6 +17,7 @@ import (
 	"github.com/traPtitech/traQ/router/extension/herror"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/search"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // GetMyUnreadChannels GET /users/me/unread
@@ -51,6 +56,17 @@ func (h *Handlers) SearchMessages(c *echo.Context) error {
 		return err
 	}
 
+	// インライン検索クエリ
+	if qs := c.QueryParam("q"); len(qs) > 0 {
+		qe, err := h.parseSearchQuery(ctx, qs, &q)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		if qe != nil {
+			return c.JSON(http.StatusBadRequest, qe)
+		}
+	}
+
 	if q.In.Valid {
 		// ユーザーが該当チャンネルへのアクセス権限があるかを確認
 		ok, err := h.ChannelManager.IsChannelAccessibleToUser(ctx, getRequestUserID(c), q.In.V)
@@ -69,21 +85,58 @@ func (h *Handlers) SearchMessages(c *echo.Context) error {
 	}
 
 	type res struct {
-		TotalHits int64             `json:"totalHits"`
-		Hits      []message.Message `json:"hits"`
+		TotalHits  int64                  `json:"totalHits"`
+		Hits       []message.Message      `json:"hits"`
+		NextCursor optional.Of[string]    `json:"nextCursor"`
+		Highlights map[uuid.UUID][]string `json:"highlights"`
+		Facets     MessageSearchFacets    `json:"facets"`
 	}
 	response := res{
-		TotalHits: r.TotalHits(),
-		Hits:      r.Hits(),
+		TotalHits:  r.TotalHits(),
+		Hits:       r.Hits(),
+		NextCursor: r.NextCursor(),
+		Highlights: r.Highlights(),
+		Facets:     formatMessageSearchFacets(r.Facets()),
 	}
 	return c.JSON(http.StatusOK, response)
 }
 
+// parseSearchQuery インライン検索クエリを解析してqに反映します
+//
+// クエリが不正な場合は解析エラーのレスポンスを返します
+func (h *Handlers) parseSearchQuery(ctx context.Context, s string, q *search.Query) (*MessageSearchQueryError, error) {
+	err := search.NewQueryParser(h.Repo, h.ChannelManager).Parse(ctx, s, q)
+	if err == nil {
+		return nil, nil
+	}
+	var pe *search.QueryParseError
+	if errors.As(err, &pe) {
+		return &MessageSearchQueryError{
+			Message:  pe.Error(),
+			Code:     string(pe.Code),
+			Position: pe.Position,
+			Token:    pe.Token,
+		}, nil
+	}
+	return nil, err
+}
+
 // GetMessage GET /messages/:messageID
 func (h *Handlers) GetMessage(c *echo.Context) error {
 	return c.JSON(http.StatusOK, getParamMessage(c))
 }
 
+// GetMessageHistory GET /messages/:messageID/history
+func (h *Handlers) GetMessageHistory(c *echo.Context) error {
+	m := getParamMessage(c)
+
+	ams, err := h.Repo.GetArchivedMessagesByID(c.Request().Context(), m.GetID())
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatMessageRevisions(m, ams))
+}
+
 // PostMessageRequest POST /channels/:channelID/messages等リクエストボディ
 type PostMessageRequest struct {
 	Content string `json:"content"`
@@ -316,6 +369,106 @@ func (h *Handlers) GetMessageClips(c *echo.Context) error {
 	return c.JSON(http.StatusOK, formatMessageClips(clips))
 }
 
+// GetMessageReplies GET /messages/:messageID/replies
+func (h *Handlers) GetMessageReplies(c *echo.Context) error {
+	m := getParamMessage(c)
+
+	var req MessagesQuery
+	if err := req.bind(c); err != nil {
+		return err
+	}
+
+	timeline, err := h.MessageManager.GetReplies(c.Request().Context(), m.GetID(), req.convert())
+	if err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	c.Response().Header().Set(consts.HeaderMore, strconv.FormatBool(timeline.HasMore()))
+	return c.JSON(http.StatusOK, timeline.Records())
+}
+
+// PostMessageReply POST /messages/:messageID/replies
+func (h *Handlers) PostMessageReply(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+	parent := getParamMessage(c)
+
+	var req PostMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.Nonce != "" {
+		if !h.NonceManager.NonceChecker(req.Nonce) {
+			return herror.BadRequest("this nonce is duplicated")
+		}
+	}
+
+	if req.Embed {
+		req.Content = h.Replacer.Replace(req.Content)
+	}
+
+	m, err := h.MessageManager.CreateReply(ctx, parent.GetID(), userID, req.Content)
+	if err != nil {
+		switch err {
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		case message.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, m)
+}
+
+// GetMessageThreadSubscription GET /messages/:messageID/thread-subscription
+func (h *Handlers) GetMessageThreadSubscription(c *echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	subscribed, err := h.MessageManager.IsThreadSubscribed(c.Request().Context(), m.GetID(), userID)
+	if err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusOK, map[string]any{"subscribed": subscribed})
+}
+
+// PutMessageThreadSubscriptionRequest PUT /messages/:messageID/thread-subscription リクエストボディ
+type PutMessageThreadSubscriptionRequest struct {
+	Subscribed bool `json:"subscribed"`
+}
+
+// PutMessageThreadSubscription PUT /messages/:messageID/thread-subscription
+func (h *Handlers) PutMessageThreadSubscription(c *echo.Context) error {
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PutMessageThreadSubscriptionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.MessageManager.SubscribeThread(c.Request().Context(), m.GetID(), userID, req.Subscribed); err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
 // GetMessages GET /channels/:channelID/messages
 func (h *Handlers) GetMessages(c *echo.Context) error {
 	channelID := getParamAsUUID(c, consts.ParamChannelID)
@@ -142,6 +142,53 @@ func TestHandlers_GetMessage(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetMessageHistory(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/history"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, "original")
+	require.NoError(t, env.MM.Edit(context.TODO(), m.GetID(), "edited"))
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, m.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV7())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(2)
+		first := obj.Value(0).Object()
+		first.Value("userId").String().IsEqual(user.GetID().String())
+		first.Value("content").String().IsEqual("original")
+		first.Value("createdAt").String().NotEmpty()
+		obj.Value(1).Object().Value("content").String().IsEqual("edited")
+	})
+}
+
 func TestPostMessageRequest_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -951,6 +998,162 @@ func TestHandlers_GetMessageClips(t *testing.T) {
 	})
 }
 
+func TestHandlers_GetMessageReplies(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/replies"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	r1, err := env.MM.CreateReply(context.TODO(), m.GetID(), user.GetID(), "reply1")
+	require.NoError(t, err)
+	r2, err := env.MM.CreateReply(context.TODO(), m.GetID(), user.GetID(), "reply2")
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, m.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithQuery("limit", -1).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(2)
+
+		messageEquals(t, r2, obj.Value(0).Object())
+		messageEquals(t, r1, obj.Value(1).Object())
+		obj.Value(0).Object().Value("threadId").String().IsEqual(m.GetID().String())
+	})
+
+	t.Run("success (from reply)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, r1.GetID()).
+			WithCookie(session.CookieName, s).
+			WithQuery("order", "asc").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(2)
+
+		messageEquals(t, r1, obj.Value(0).Object())
+		messageEquals(t, r2, obj.Value(1).Object())
+	})
+}
+
+func TestHandlers_PostMessageReply(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/replies"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	archived := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	archivedM := env.CreateMessage(t, user.GetID(), archived.ID, rand)
+	require.NoError(t, env.CM.ArchiveChannel(context.TODO(), archived.ID, user.GetID()))
+	s := env.S(t, user.GetID())
+
+	req := &PostMessageRequest{
+		Content: "Hello, traP",
+	}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("archived", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, archivedM.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessageRequest{Content: ""}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(req).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("id").String().NotEmpty()
+		obj.Value("userId").String().IsEqual(user.GetID().String())
+		obj.Value("channelId").String().IsEqual(ch.ID.String())
+		obj.Value("content").String().IsEqual("Hello, traP")
+		obj.Value("threadId").String().IsEqual(m.GetID().String())
+
+		subscribed, err := env.MM.IsThreadSubscribed(context.TODO(), m.GetID(), user.GetID())
+		if assert.NoError(t, err) {
+			assert.True(t, subscribed)
+		}
+	})
+}
+
 func TestHandlers_GetMessages(t *testing.T) {
 	t.Parallel()
 
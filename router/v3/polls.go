@@ -0,0 +1,171 @@
+package v3
+
+import (
+	"net/http"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v5"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// PostMessagePollRequest POST /messages/:messageID/poll リクエストボディ
+type PostMessagePollRequest struct {
+	Options        []string               `json:"options"`
+	MultipleChoice bool                   `json:"multipleChoice"`
+	Anonymous      bool                   `json:"anonymous"`
+	Deadline       optional.Of[time.Time] `json:"deadline"`
+}
+
+func (r PostMessagePollRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Options, vd.Required, vd.Length(2, 10), vd.Each(vd.Required, vd.RuneLength(1, 100))),
+		vd.Field(&r.Deadline, vd.Min(time.Now()).Error("must be a future time")),
+	)
+}
+
+// PostMessagePollVotesRequest POST /messages/:messageID/poll/votes リクエストボディ
+type PostMessagePollVotesRequest struct {
+	OptionIDs []uuid.UUID `json:"optionIds"`
+}
+
+func (r PostMessagePollVotesRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.OptionIDs, vd.Required, vd.Each(validator.NotNilUUID)),
+	)
+}
+
+// GetMessagePoll GET /messages/:messageID/poll
+func (h *Handlers) GetMessagePoll(c *echo.Context) error {
+	ctx := c.Request().Context()
+	m := getParamMessage(c)
+
+	poll, err := h.Repo.GetPollByMessageID(ctx, m.GetID())
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("poll was not found")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	votes, err := h.Repo.GetPollVotes(ctx, poll.ID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatMessagePoll(poll, votes, getRequestUserID(c)))
+}
+
+// CreateMessagePoll POST /messages/:messageID/poll
+func (h *Handlers) CreateMessagePoll(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+	m := getParamMessage(c)
+
+	var req PostMessagePollRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// 他人のメッセージに投票は添付できない
+	if userID != m.GetUserID() {
+		return herror.Forbidden("This is not your message")
+	}
+
+	poll, err := h.MessageManager.CreatePoll(ctx, repository.CreatePollArgs{
+		MessageID:      m.GetID(),
+		CreatorID:      userID,
+		Options:        req.Options,
+		MultipleChoice: req.MultipleChoice,
+		Anonymous:      req.Anonymous,
+		Deadline:       req.Deadline,
+	})
+	if err != nil {
+		switch err {
+		case message.ErrAlreadyExists:
+			return herror.Conflict("this message already has a poll")
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, formatMessagePoll(poll, nil, userID))
+}
+
+// VoteMessagePoll POST /messages/:messageID/poll/votes
+func (h *Handlers) VoteMessagePoll(c *echo.Context) error {
+	m := getParamMessage(c)
+
+	var req PostMessagePollVotesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.MessageManager.VotePoll(c.Request().Context(), m.GetID(), getRequestUserID(c), req.OptionIDs); err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound("poll was not found")
+		case message.ErrInvalidPollVote:
+			return herror.BadRequest("invalid options")
+		case message.ErrPollClosed:
+			return herror.BadRequest("this poll has been closed")
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RetractMessagePollVote DELETE /messages/:messageID/poll/votes
+func (h *Handlers) RetractMessagePollVote(c *echo.Context) error {
+	m := getParamMessage(c)
+
+	if err := h.MessageManager.RetractPollVote(c.Request().Context(), m.GetID(), getRequestUserID(c)); err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound("poll was not found")
+		case message.ErrPollClosed:
+			return herror.BadRequest("this poll has been closed")
+		case message.ErrChannelArchived:
+			return herror.BadRequest("the channel of this message has been archived")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CloseMessagePoll POST /messages/:messageID/poll/close
+func (h *Handlers) CloseMessagePoll(c *echo.Context) error {
+	m := getParamMessage(c)
+
+	poll := m.GetPoll()
+	if poll == nil {
+		return herror.NotFound("poll was not found")
+	}
+	// 他人が作成した投票は締め切れない
+	if poll.CreatorID != getRequestUserID(c) {
+		return herror.Forbidden("This is not your poll")
+	}
+
+	if _, err := h.MessageManager.ClosePoll(c.Request().Context(), m.GetID()); err != nil {
+		switch err {
+		case message.ErrNotFound:
+			return herror.NotFound("poll was not found")
+		case message.ErrPollClosed:
+			return herror.BadRequest("this poll has already been closed")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
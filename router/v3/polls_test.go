@@ -0,0 +1,231 @@
+package v3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestHandlers_CreateMessagePoll(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/poll"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(&PostMessagePollRequest{Options: []string{"a", "b"}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (too few options)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessagePollRequest{Options: []string{"a"}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (past deadline)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessagePollRequest{Options: []string{"a", "b"}, Deadline: optional.From(time.Now().Add(-time.Hour))}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden (other's message)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PostMessagePollRequest{Options: []string{"a", "b"}}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+		e := env.R(t)
+		obj := e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessagePollRequest{Options: []string{"a", "b", "c"}, MultipleChoice: true}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("creatorId").String().IsEqual(user.GetID().String())
+		obj.Value("multipleChoice").Boolean().IsTrue()
+		obj.Value("anonymous").Boolean().IsFalse()
+		obj.Value("voterCount").Number().IsEqual(0)
+		options := obj.Value("options").Array()
+		options.Length().IsEqual(3)
+		options.Value(0).Object().Value("content").String().IsEqual("a")
+		obj.Value("myVotes").Array().Length().IsEqual(0)
+
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessagePollRequest{Options: []string{"a", "b"}}).
+			Expect().
+			Status(http.StatusConflict)
+	})
+}
+
+func TestHandlers_VoteMessagePoll(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/poll/votes"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	noPoll := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	poll, err := env.MM.CreatePoll(context.TODO(), repository.CreatePollArgs{
+		MessageID: m.GetID(),
+		CreatorID: user.GetID(),
+		Options:   []string{"a", "b"},
+		Anonymous: true,
+	})
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithJSON(&PostMessagePollVotesRequest{OptionIDs: []uuid.UUID{poll.Options[0].ID}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, noPoll.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessagePollVotesRequest{OptionIDs: []uuid.UUID{poll.Options[0].ID}}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("bad request (multiple options for single choice)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessagePollVotesRequest{OptionIDs: []uuid.UUID{poll.Options[0].ID, poll.Options[1].ID}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (unknown option)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMessagePollVotesRequest{OptionIDs: []uuid.UUID{uuid.Must(uuid.NewV4())}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s2).
+			WithJSON(&PostMessagePollVotesRequest{OptionIDs: []uuid.UUID{poll.Options[1].ID}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		obj := e.GET("/api/v3/messages/{messageId}/poll", m.GetID()).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+		obj.Value("voterCount").Number().IsEqual(1)
+		obj.Value("options").Array().Value(1).Object().Value("voteCount").Number().IsEqual(1)
+		obj.Value("myVotes").Array().ContainsOnly(poll.Options[1].ID.String())
+		obj.Value("votes").IsNull()
+
+		e.DELETE(path, m.GetID()).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+}
+
+func TestHandlers_CloseMessagePoll(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/messages/{messageId}/poll/close"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	m := env.CreateMessage(t, user.GetID(), ch.ID, rand)
+	_, err := env.MM.CreatePoll(context.TODO(), repository.CreatePollArgs{
+		MessageID: m.GetID(),
+		CreatorID: user.GetID(),
+		Options:   []string{"a", "b"},
+	})
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.POST(path, m.GetID()).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+}
@@ -23,8 +23,9 @@ func (h *Handlers) GetVersion(c *echo.Context) error {
 		"version":  h.Version,
 		"revision": h.Revision,
 		"flags": map[string]any{
-			"externalLogin": extLogins,
-			"signUpAllowed": h.AllowSignUp,
+			"externalLogin":         extLogins,
+			"signUpAllowed":         h.AllowSignUp,
+			"webPushVapidPublicKey": h.WebPushVAPIDPublicKey,
 		},
 	})
 }
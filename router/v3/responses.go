@@ -5,30 +5,34 @@ import (
 	"time"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/search"
 	"github.com/traPtitech/traQ/utils/optional"
 
 	"github.com/gofrs/uuid"
 )
 
 type Channel struct {
-	ID       uuid.UUID              `json:"id"`
-	Name     string                 `json:"name"`
-	ParentID optional.Of[uuid.UUID] `json:"parentId"`
-	Topic    string                 `json:"topic"`
-	Children []uuid.UUID            `json:"children"`
-	Archived bool                   `json:"archived"`
-	Force    bool                   `json:"force"`
+	ID                     uuid.UUID              `json:"id"`
+	Name                   string                 `json:"name"`
+	ParentID               optional.Of[uuid.UUID] `json:"parentId"`
+	Topic                  string                 `json:"topic"`
+	Children               []uuid.UUID            `json:"children"`
+	Archived               bool                   `json:"archived"`
+	Force                  bool                   `json:"force"`
+	DisableChannelMentions bool                   `json:"disableChannelMentions"`
 }
 
 func formatChannel(channel *model.Channel, childrenID []uuid.UUID) *Channel {
 	return &Channel{
-		ID:       channel.ID,
-		Name:     channel.Name,
-		ParentID: optional.New(channel.ParentID, channel.ParentID != uuid.Nil),
-		Topic:    channel.Topic,
-		Children: childrenID,
-		Archived: channel.IsArchived(),
-		Force:    channel.IsForced,
+		ID:                     channel.ID,
+		Name:                   channel.Name,
+		ParentID:               optional.New(channel.ParentID, channel.ParentID != uuid.Nil),
+		Topic:                  channel.Topic,
+		Children:               childrenID,
+		Archived:               channel.IsArchived(),
+		Force:                  channel.IsForced,
+		DisableChannelMentions: channel.DisableChannelMentions,
 	}
 }
 
@@ -281,6 +285,38 @@ func formatBotEventLogs(logs []*model.BotEventLog) []*botEventLogResponse {
 	return res
 }
 
+type botEventDeliveryResponse struct {
+	RequestID     uuid.UUID                   `json:"requestId"`
+	BotID         uuid.UUID                   `json:"botId"`
+	Event         model.BotEventType          `json:"event"`
+	State         model.BotEventDeliveryState `json:"state"`
+	Attempts      int                         `json:"attempts"`
+	LastError     string                      `json:"lastError"`
+	NextAttemptAt time.Time                   `json:"nextAttemptAt"`
+	CreatedAt     time.Time                   `json:"createdAt"`
+}
+
+func formatBotEventDelivery(d *model.BotEventDelivery) *botEventDeliveryResponse {
+	return &botEventDeliveryResponse{
+		RequestID:     d.RequestID,
+		BotID:         d.BotID,
+		Event:         d.Event,
+		State:         d.State,
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		NextAttemptAt: d.NextAttemptAt,
+		CreatedAt:     d.CreatedAt,
+	}
+}
+
+func formatBotEventDeliveries(ds []*model.BotEventDelivery) []*botEventDeliveryResponse {
+	res := make([]*botEventDeliveryResponse, len(ds))
+	for i, d := range ds {
+		res[i] = formatBotEventDelivery(d)
+	}
+	return res
+}
+
 type Message struct {
 	ID        uuid.UUID              `json:"id"`
 	UserID    uuid.UUID              `json:"userId"`
@@ -291,6 +327,7 @@ type Message struct {
 	Pinned    bool                   `json:"pinned"`
 	Stamps    []model.MessageStamp   `json:"stamps"`
 	ThreadID  optional.Of[uuid.UUID] `json:"threadId"` // TODO
+	Poll      *model.Poll            `json:"poll"`
 }
 
 func formatMessage(m *model.Message) *Message {
@@ -303,6 +340,7 @@ func formatMessage(m *model.Message) *Message {
 		UpdatedAt: m.UpdatedAt,
 		Pinned:    m.Pin != nil,
 		Stamps:    m.Stamps,
+		Poll:      m.Poll,
 	}
 }
 
@@ -342,6 +380,29 @@ func formatMessagePin(pin *model.Pin) *MessagePin {
 	}
 }
 
+type MessagePoll struct {
+	*model.Poll
+	MyVotes []uuid.UUID       `json:"myVotes"`
+	Votes   []*model.PollVote `json:"votes"`
+}
+
+func formatMessagePoll(poll *model.Poll, votes []*model.PollVote, userID uuid.UUID) *MessagePoll {
+	res := &MessagePoll{
+		Poll:    poll,
+		MyVotes: make([]uuid.UUID, 0),
+	}
+	for _, v := range votes {
+		if v.UserID == userID {
+			res.MyVotes = append(res.MyVotes, v.OptionID)
+		}
+	}
+	// 匿名投票では誰がどの選択肢に投票したかを返さない
+	if !poll.Anonymous {
+		res.Votes = votes
+	}
+	return res
+}
+
 type MessageClip struct {
 	FolderID  uuid.UUID `json:"folderId"`
 	ClippedAt time.Time `json:"clippedAt"`
@@ -362,6 +423,30 @@ func formatMessageClips(cfms []*model.ClipFolderMessage) []*MessageClip {
 	return res
 }
 
+type MessageRevision struct {
+	UserID    uuid.UUID `json:"userId"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// formatMessageRevisions 編集前のアーカイブに現在のメッセージを加え、古い順に並べたものを返す
+func formatMessageRevisions(m message.Message, ams []*model.ArchivedMessage) []*MessageRevision {
+	res := make([]*MessageRevision, 0, len(ams)+1)
+	for _, am := range ams {
+		res = append(res, &MessageRevision{
+			UserID:    am.UserID,
+			Content:   am.Text,
+			CreatedAt: am.DateTime,
+		})
+	}
+	res = append(res, &MessageRevision{
+		UserID:    m.GetUserID(),
+		Content:   m.GetText(),
+		CreatedAt: m.GetUpdatedAt(),
+	})
+	return res
+}
+
 type UserGroupMember struct {
 	ID   uuid.UUID `json:"id"`
 	Role string    `json:"role"`
@@ -633,3 +718,254 @@ func formatStampPalettes(cfs []*model.StampPalette) []*StampPalette {
 	sort.Slice(res, func(i, j int) bool { return res[i].ID.String() < res[j].ID.String() })
 	return res
 }
+
+type ScheduledMessage struct {
+	ID          uuid.UUID                   `json:"id"`
+	UserID      uuid.UUID                   `json:"userId"`
+	ChannelID   uuid.UUID                   `json:"channelId"`
+	DMUserID    optional.Of[uuid.UUID]      `json:"dmUserId"`
+	Content     string                      `json:"content"`
+	ScheduledAt time.Time                   `json:"scheduledAt"`
+	State       model.ScheduledMessageState `json:"state"`
+	CreatedAt   time.Time                   `json:"createdAt"`
+	UpdatedAt   time.Time                   `json:"updatedAt"`
+}
+
+func formatScheduledMessage(sm *model.ScheduledMessage) *ScheduledMessage {
+	return &ScheduledMessage{
+		ID:          sm.ID,
+		UserID:      sm.UserID,
+		ChannelID:   sm.ChannelID,
+		DMUserID:    sm.DMUserID,
+		Content:     sm.Text,
+		ScheduledAt: sm.ScheduledAt,
+		State:       sm.State,
+		CreatedAt:   sm.CreatedAt,
+		UpdatedAt:   sm.UpdatedAt,
+	}
+}
+
+func formatScheduledMessages(sms []*model.ScheduledMessage) []*ScheduledMessage {
+	res := make([]*ScheduledMessage, len(sms))
+	for i, sm := range sms {
+		res[i] = formatScheduledMessage(sm)
+	}
+	return res
+}
+
+type MessageReminder struct {
+	ID        uuid.UUID `json:"id"`
+	MessageID uuid.UUID `json:"messageId"`
+	RemindAt  time.Time `json:"remindAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func formatMessageReminder(r *model.MessageReminder) *MessageReminder {
+	return &MessageReminder{
+		ID:        r.ID,
+		MessageID: r.MessageID,
+		RemindAt:  r.RemindAt,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func formatMessageReminders(rs []*model.MessageReminder) []*MessageReminder {
+	res := make([]*MessageReminder, len(rs))
+	for i, r := range rs {
+		res[i] = formatMessageReminder(r)
+	}
+	return res
+}
+
+type BotCommand struct {
+	ID          uuid.UUID                 `json:"id"`
+	BotID       uuid.UUID                 `json:"botId"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Arguments   model.BotCommandArguments `json:"arguments"`
+	ChannelIDs  []uuid.UUID               `json:"channelIds"`
+	CreatedAt   time.Time                 `json:"createdAt"`
+}
+
+func formatBotCommand(cmd *model.BotCommand) *BotCommand {
+	res := &BotCommand{
+		ID:          cmd.ID,
+		BotID:       cmd.BotID,
+		Name:        cmd.Name,
+		Description: cmd.Description,
+		Arguments:   cmd.Arguments,
+		ChannelIDs:  cmd.ChannelIDs,
+		CreatedAt:   cmd.CreatedAt,
+	}
+	if res.Arguments == nil {
+		res.Arguments = model.BotCommandArguments{}
+	}
+	if res.ChannelIDs == nil {
+		res.ChannelIDs = []uuid.UUID{}
+	}
+	return res
+}
+
+func formatBotCommands(cmds []*model.BotCommand) []*BotCommand {
+	res := make([]*BotCommand, len(cmds))
+	for i, cmd := range cmds {
+		res[i] = formatBotCommand(cmd)
+	}
+	return res
+}
+
+type SavedSearch struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	Notify    bool      `json:"notify"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func formatSavedSearch(s *model.SavedSearch) *SavedSearch {
+	return &SavedSearch{
+		ID:        s.ID,
+		Name:      s.Name,
+		Query:     s.Query,
+		Notify:    s.Notify,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func formatSavedSearches(ss []*model.SavedSearch) []*SavedSearch {
+	res := make([]*SavedSearch, len(ss))
+	for i, s := range ss {
+		res[i] = formatSavedSearch(s)
+	}
+	return res
+}
+
+type InboxItem struct {
+	ID        uuid.UUID              `json:"id"`
+	Type      model.InboxItemType    `json:"type"`
+	MessageID uuid.UUID              `json:"messageId"`
+	ChannelID uuid.UUID              `json:"channelId"`
+	ActorID   uuid.UUID              `json:"actorId"`
+	StampID   optional.Of[uuid.UUID] `json:"stampId"`
+	IsRead    bool                   `json:"isRead"`
+	CreatedAt time.Time              `json:"createdAt"`
+}
+
+func formatInboxItem(i *model.InboxItem) *InboxItem {
+	return &InboxItem{
+		ID:        i.ID,
+		Type:      i.Type,
+		MessageID: i.MessageID,
+		ChannelID: i.ChannelID,
+		ActorID:   i.ActorID,
+		StampID:   i.StampID,
+		IsRead:    i.IsRead,
+		CreatedAt: i.CreatedAt,
+	}
+}
+
+func formatInboxItems(is []*model.InboxItem) []*InboxItem {
+	res := make([]*InboxItem, len(is))
+	for i, item := range is {
+		res[i] = formatInboxItem(item)
+	}
+	return res
+}
+
+type Inbox struct {
+	Items       []*InboxItem           `json:"items"`
+	NextCursor  optional.Of[uuid.UUID] `json:"nextCursor"`
+	UnreadCount int64                  `json:"unreadCount"`
+}
+
+type WebPushSubscription struct {
+	ID        uuid.UUID `json:"id"`
+	Endpoint  string    `json:"endpoint"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func formatWebPushSubscription(s *model.WebPushSubscription) *WebPushSubscription {
+	return &WebPushSubscription{
+		ID:        s.ID,
+		Endpoint:  s.Endpoint,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+func formatWebPushSubscriptions(ss []*model.WebPushSubscription) []*WebPushSubscription {
+	res := make([]*WebPushSubscription, len(ss))
+	for i, s := range ss {
+		res[i] = formatWebPushSubscription(s)
+	}
+	return res
+}
+
+type MessageReport struct {
+	ID         uuid.UUID                `json:"id"`
+	MessageID  uuid.UUID                `json:"messageId"`
+	ReporterID uuid.UUID                `json:"reporterId"`
+	Reason     string                   `json:"reason"`
+	State      model.MessageReportState `json:"state"`
+	ResolverID optional.Of[uuid.UUID]   `json:"resolverId"`
+	Note       string                   `json:"note"`
+	CreatedAt  time.Time                `json:"createdAt"`
+	ResolvedAt optional.Of[time.Time]   `json:"resolvedAt"`
+}
+
+func formatMessageReport(r *model.MessageReport) *MessageReport {
+	return &MessageReport{
+		ID:         r.ID,
+		MessageID:  r.MessageID,
+		ReporterID: r.Reporter,
+		Reason:     r.Reason,
+		State:      r.State,
+		ResolverID: r.ResolverID,
+		Note:       r.Note,
+		CreatedAt:  r.CreatedAt,
+		ResolvedAt: r.ResolvedAt,
+	}
+}
+
+func formatMessageReports(rs []*model.MessageReport) []*MessageReport {
+	res := make([]*MessageReport, len(rs))
+	for i, r := range rs {
+		res[i] = formatMessageReport(r)
+	}
+	return res
+}
+
+type MessageSearchFacetBucket struct {
+	Key   string `json:"key"`
+	Count int64  `json:"count"`
+}
+
+type MessageSearchFacets struct {
+	Channels []MessageSearchFacetBucket `json:"channels"`
+	Users    []MessageSearchFacetBucket `json:"users"`
+	Months   []MessageSearchFacetBucket `json:"months"`
+}
+
+func formatMessageSearchFacetBuckets(bs []search.FacetBucket) []MessageSearchFacetBucket {
+	res := make([]MessageSearchFacetBucket, len(bs))
+	for i, b := range bs {
+		res[i] = MessageSearchFacetBucket{Key: b.Key, Count: b.Count}
+	}
+	return res
+}
+
+func formatMessageSearchFacets(f search.Facets) MessageSearchFacets {
+	return MessageSearchFacets{
+		Channels: formatMessageSearchFacetBuckets(f.Channels),
+		Users:    formatMessageSearchFacetBuckets(f.Users),
+		Months:   formatMessageSearchFacetBuckets(f.Months),
+	}
+}
+
+type MessageSearchQueryError struct {
+	Message  string `json:"message"`
+	Code     string `json:"code"`
+	Position int    `json:"position"`
+	Token    string `json:"token"`
+}
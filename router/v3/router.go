@@ -17,6 +17,7 @@ import (
 	"github.com/traPtitech/traQ/service/counter"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/imaging"
+	"github.com/traPtitech/traQ/service/mailer"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/ogp"
 	"github.com/traPtitech/traQ/service/oidc"
@@ -48,11 +49,14 @@ type Handlers struct {
 	ChannelManager channel.Manager
 	MessageManager message.Manager
 	FileManager    file.Manager
+	Mailer         mailer.Mailer
 	Replacer       *mutil.Replacer
 	NonceManager   *mutil.NonceManager
 	Soundboard     qall.Soundboard
 	QallRepo       qall.RoomStateManager
 	Config
+
+	digestLimiter digestVerificationLimiter
 }
 
 type Config struct {
@@ -71,6 +75,9 @@ type Config struct {
 	// LiveKitAPISecret LiveKit APIシークレットキー
 	LiveKitAPISecret string
 
+	// WebPushVAPIDPublicKey Web PushのVAPID公開鍵 (base64url) 空の場合はWeb Push無効
+	WebPushVAPIDPublicKey string
+
 	// AllowSignUp ユーザーが自分自身で登録できるかどうか
 	AllowSignUp bool
 
@@ -140,6 +147,12 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiUsersMe.PUT("/icon", h.ChangeMyIcon, requires(permission.ChangeMyIcon))
 				apiUsersMe.PUT("/password", h.PutMyPassword, requires(permission.ChangeMyPassword), blockBot)
 				apiUsersMe.POST("/fcm-device", h.PostMyFCMDevice, requires(permission.RegisterFCMDevice), blockBot)
+				apiUsersMeWebPushSubscriptions := apiUsersMe.Group("/webpush-subscriptions", blockBot)
+				{
+					apiUsersMeWebPushSubscriptions.GET("", h.GetMyWebPushSubscriptions, requires(permission.GetMe))
+					apiUsersMeWebPushSubscriptions.POST("", h.PostMyWebPushSubscription, requires(permission.RegisterFCMDevice))
+					apiUsersMeWebPushSubscriptions.DELETE("/:subscriptionID", h.DeleteMyWebPushSubscription, requires(permission.RegisterFCMDevice))
+				}
 				apiUsersMe.GET("/view-states", h.GetMyViewStates, requires(permission.ConnectNotificationStream), blockBot)
 				apiUsersMeTags := apiUsersMe.Group("/tags")
 				{
@@ -162,10 +175,16 @@ func (h *Handlers) Setup(e *echo.Group) {
 					apiUsersMeUnread.GET("", h.GetMyUnreadChannels, requires(permission.GetUnread))
 					apiUsersMeUnread.DELETE("/:channelID", h.ReadChannel, requires(permission.DeleteUnread))
 				}
+				apiUsersMeNotifications := apiUsersMe.Group("/notifications", blockBot)
+				{
+					apiUsersMeNotifications.GET("", h.GetMyInbox, requires(permission.GetUnread))
+					apiUsersMeNotifications.POST("/read", h.ReadMyInboxItems, requires(permission.DeleteUnread))
+				}
 				apiUsersMeSubscriptions := apiUsersMe.Group("/subscriptions", blockBot)
 				{
 					apiUsersMeSubscriptions.GET("", h.GetMyChannelSubscriptions, requires(permission.GetChannelSubscription))
 					apiUsersMeSubscriptions.PUT("/:channelID", h.SetChannelSubscribeLevel, requires(permission.EditChannelSubscription))
+					apiUsersMeSubscriptions.PUT("/:channelID/overrides", h.SetChannelNotifyOverrides, requires(permission.EditChannelSubscription))
 				}
 				apiUsersMeSessions := apiUsersMe.Group("/sessions", blockBot)
 				{
@@ -183,11 +202,46 @@ func (h *Handlers) Setup(e *echo.Group) {
 					apiUsersMeExAccounts.POST("/link", h.LinkExternalAccount, requires(permission.EditMyExternalAccount))
 					apiUsersMeExAccounts.POST("/unlink", h.UnlinkExternalAccount, requires(permission.EditMyExternalAccount))
 				}
+				apiUsersMeScheduledMessages := apiUsersMe.Group("/scheduled-messages")
+				{
+					apiUsersMeScheduledMessages.GET("", h.GetMyScheduledMessages, requires(permission.GetMessage))
+					apiUsersMeScheduledMessages.POST("", h.CreateScheduledMessage, bodyLimit(100), requires(permission.PostMessage))
+					apiUsersMeScheduledMessagesSID := apiUsersMeScheduledMessages.Group("/:scheduleID")
+					{
+						apiUsersMeScheduledMessagesSID.GET("", h.GetMyScheduledMessage, requires(permission.GetMessage))
+						apiUsersMeScheduledMessagesSID.PATCH("", h.EditMyScheduledMessage, bodyLimit(100), requires(permission.PostMessage))
+						apiUsersMeScheduledMessagesSID.DELETE("", h.DeleteMyScheduledMessage, requires(permission.PostMessage))
+					}
+				}
+				apiUsersMeReminders := apiUsersMe.Group("/reminders", blockBot)
+				{
+					apiUsersMeReminders.GET("", h.GetMyMessageReminders, requires(permission.GetMessage))
+					apiUsersMeReminders.DELETE("/:reminderID", h.DeleteMyMessageReminder, requires(permission.GetMessage))
+				}
+				apiUsersMeSavedSearches := apiUsersMe.Group("/saved-searches", blockBot)
+				{
+					apiUsersMeSavedSearches.GET("", h.GetMySavedSearches, requires(permission.GetMessage))
+					apiUsersMeSavedSearches.POST("", h.CreateSavedSearch, requires(permission.EditMe))
+					apiUsersMeSavedSearchesSID := apiUsersMeSavedSearches.Group("/:savedSearchID")
+					{
+						apiUsersMeSavedSearchesSID.GET("", h.GetMySavedSearch, requires(permission.GetMessage))
+						apiUsersMeSavedSearchesSID.PATCH("", h.EditMySavedSearch, requires(permission.EditMe))
+						apiUsersMeSavedSearchesSID.DELETE("", h.DeleteMySavedSearch, requires(permission.EditMe))
+					}
+				}
 				apiUsersMeSettings := apiUsersMe.Group("/settings", blockBot)
 				{
 					apiUsersMeSettings.GET("", h.GetMySettings, requires(permission.GetMe))
 					apiUsersMeSettings.GET("/notify-citation", h.GetMyNotifyCitation, requires(permission.GetMe))
 					apiUsersMeSettings.PUT("/notify-citation", h.PutMyNotifyCitation, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/notify-keywords", h.GetMyNotifyKeywords, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/notify-keywords", h.PutMyNotifyKeywords, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/do-not-disturb", h.GetMyDoNotDisturb, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/do-not-disturb", h.PutMyDoNotDisturb, requires(permission.EditMe))
+					apiUsersMeSettings.PUT("/snooze", h.PutMySnooze, requires(permission.EditMe))
+					apiUsersMeSettings.GET("/email-digest", h.GetMyEmailDigest, requires(permission.GetMe))
+					apiUsersMeSettings.PUT("/email-digest", h.PutMyEmailDigest, requires(permission.EditMe))
+					apiUsersMeSettings.POST("/email-digest/verify", h.VerifyMyEmailDigest, requires(permission.EditMe))
 				}
 			}
 		}
@@ -210,6 +264,8 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiChannelsCID.PUT("/subscribers", h.SetChannelSubscribers, requires(permission.EditChannelSubscription))
 				apiChannelsCID.PATCH("/subscribers", h.EditChannelSubscribers, requires(permission.EditChannelSubscription))
 				apiChannelsCID.GET("/bots", h.GetChannelBots, requires(permission.GetChannel))
+				apiChannelsCID.GET("/bot-commands", h.GetChannelBotCommands, requires(permission.GetChannel))
+				apiChannelsCID.POST("/bot-commands/invoke", h.InvokeBotCommand, requires(permission.PostMessage), blockBot)
 				apiChannelsCID.GET("/events", h.GetChannelEvents, requires(permission.GetChannel))
 				apiChannelsCID.GET("/path", h.GetChannelPath, requires(permission.GetChannel))
 			}
@@ -222,10 +278,25 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiMessagesMID.GET("", h.GetMessage, requires(permission.GetMessage))
 				apiMessagesMID.PUT("", h.EditMessage, bodyLimit(100), requires(permission.EditMessage))
 				apiMessagesMID.DELETE("", h.DeleteMessage, requires(permission.DeleteMessage))
+				apiMessagesMID.GET("/history", h.GetMessageHistory, requires(permission.GetMessageHistory))
+				apiMessagesMID.POST("/report", h.ReportMessage, requires(permission.ReportMessage), blockBot)
 				apiMessagesMID.GET("/pin", h.GetPin, requires(permission.GetMessage))
 				apiMessagesMID.POST("/pin", h.CreatePin, requires(permission.CreateMessagePin))
 				apiMessagesMID.DELETE("/pin", h.RemovePin, requires(permission.DeleteMessagePin))
 				apiMessagesMID.GET("/clips", h.GetMessageClips, requires(permission.GetClipFolder))
+				apiMessagesMID.GET("/poll", h.GetMessagePoll, requires(permission.GetMessage))
+				apiMessagesMID.POST("/poll", h.CreateMessagePoll, requires(permission.PostMessage))
+				apiMessagesMID.POST("/poll/votes", h.VoteMessagePoll, requires(permission.PostMessage))
+				apiMessagesMID.DELETE("/poll/votes", h.RetractMessagePollVote, requires(permission.PostMessage))
+				apiMessagesMID.POST("/poll/close", h.CloseMessagePoll, requires(permission.PostMessage))
+				apiMessagesMID.POST("/reminders", h.CreateMessageReminder, requires(permission.GetMessage), blockBot)
+				apiMessagesMID.PUT("/components", h.SetMessageComponents, requires(permission.PostMessage), blockNonBot)
+				apiMessagesMID.PATCH("/components/:customID", h.EditMessageComponent, requires(permission.PostMessage), blockNonBot)
+				apiMessagesMID.POST("/interactions", h.InteractMessageComponent, requires(permission.PostMessage), blockBot)
+				apiMessagesMID.GET("/replies", h.GetMessageReplies, requires(permission.GetMessage))
+				apiMessagesMID.POST("/replies", h.PostMessageReply, bodyLimit(100), requires(permission.PostMessage))
+				apiMessagesMID.GET("/thread-subscription", h.GetMessageThreadSubscription, requires(permission.GetChannelSubscription), blockBot)
+				apiMessagesMID.PUT("/thread-subscription", h.PutMessageThreadSubscription, requires(permission.EditChannelSubscription), blockBot)
 				apiMessagesMIDStamps := apiMessagesMID.Group("/stamps")
 				{
 					apiMessagesMIDStamps.GET("", h.GetMessageStamps, requires(permission.GetMessage))
@@ -237,6 +308,15 @@ func (h *Handlers) Setup(e *echo.Group) {
 				}
 			}
 		}
+		apiMessageReports := api.Group("/message-reports", blockBot)
+		{
+			apiMessageReports.GET("", h.GetMessageReports, requires(permission.GetMessageReports))
+			apiMessageReportsRID := apiMessageReports.Group("/:reportID")
+			{
+				apiMessageReportsRID.GET("", h.GetMessageReport, requires(permission.GetMessageReports))
+				apiMessageReportsRID.PATCH("", h.EditMessageReport, requires(permission.ManageMessageReports))
+			}
+		}
 		apiFiles := api.Group("/files")
 		{
 			apiFiles.GET("", h.GetFiles, requires(permission.DownloadFile))
@@ -360,6 +440,10 @@ func (h *Handlers) Setup(e *echo.Group) {
 				apiBotsBID.GET("/icon", h.GetBotIcon, requires(permission.GetBot))
 				apiBotsBID.PUT("/icon", h.ChangeBotIcon, requiresBotAccessPerm, requires(permission.EditBot))
 				apiBotsBID.GET("/logs", h.GetBotLogs, requiresBotAccessPerm, requires(permission.GetBot))
+				apiBotsBID.GET("/events", h.GetBotEventDeliveries, requiresBotAccessPerm, requires(permission.GetBot))
+				apiBotsBID.POST("/events/:requestID/redeliver", h.RedeliverBotEvent, requiresBotAccessPerm, requires(permission.EditBot))
+				apiBotsBID.GET("/commands", h.GetBotCommands, requiresBotAccessPerm, requires(permission.GetBot))
+				apiBotsBID.PUT("/commands", h.SetBotCommands, requiresBotAccessPerm, requires(permission.EditBot))
 				apiBotsBIDActions := apiBotsBID.Group("/actions", requiresBotAccessPerm)
 				{
 					apiBotsBIDActions.POST("/activate", h.ActivateBot, requires(permission.EditBot))
@@ -370,6 +454,10 @@ func (h *Handlers) Setup(e *echo.Group) {
 				}
 			}
 		}
+		apiBotCommandInvocations := api.Group("/bot-command-invocations", blockNonBot)
+		{
+			apiBotCommandInvocations.POST("/:invocationID/reply", h.ReplyBotCommandInvocation, bodyLimit(100), requires(permission.PostMessage))
+		}
 		apiWebRTC := api.Group("/webrtc", requires(permission.WebRTC))
 		{
 			apiWebRTC.GET("/state", h.GetWebRTCState)
@@ -410,6 +498,7 @@ func (h *Handlers) Setup(e *echo.Group) {
 			apiQall.PATCH(("/rooms/:roomID/participants"), h.PatchRoomParticipants, requires(permission.WebRTC))
 		}
 
+		api.GET("/search", h.Search, requires(permission.GetUser, permission.GetChannel, permission.DownloadFile, permission.GetStamp))
 		api.GET("/ws", echo.WrapHandler(h.WS), requires(permission.ConnectNotificationStream), blockBot)
 	}
 
@@ -143,6 +143,7 @@ func TestMain(m *testing.M) {
 			ChannelManager: env.CM,
 			MessageManager: env.MM,
 			FileManager:    env.FM,
+			Mailer:         &testMailer{},
 			Logger:         l,
 			Imaging:        env.IP,
 			Config: Config{
@@ -174,6 +175,17 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// testMailer 何もせずに送信に成功するMailer
+type testMailer struct{}
+
+func (m *testMailer) Send(_ context.Context, _, _, _ string) error {
+	return nil
+}
+
+func (m *testMailer) Enabled() bool {
+	return true
+}
+
 type Env struct {
 	Server     *httptest.Server
 	DB         *gorm.DB
@@ -495,6 +507,58 @@ func (env *Env) CreateClipFolder(t *testing.T, name, desc string, creatorID uuid
 	return cf
 }
 
+// CreateScheduledMessage 予約投稿メッセージを必ず作成します
+func (env *Env) CreateScheduledMessage(t *testing.T, userID, channelID uuid.UUID) *model.ScheduledMessage {
+	t.Helper()
+	sm, err := env.Repository.CreateScheduledMessage(context.TODO(), repository.CreateScheduledMessageArgs{
+		UserID:      userID,
+		ChannelID:   channelID,
+		Text:        "scheduled",
+		ScheduledAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	return sm
+}
+
+// CreateSavedSearch 保存された検索を必ず作成します
+func (env *Env) CreateSavedSearch(t *testing.T, userID uuid.UUID, query string) *model.SavedSearch {
+	t.Helper()
+	s, err := env.Repository.CreateSavedSearch(context.TODO(), repository.CreateSavedSearchArgs{
+		UserID: userID,
+		Name:   "saved search",
+		Query:  query,
+	})
+	require.NoError(t, err)
+	return s
+}
+
+// CreateWebPushSubscription Web Push購読を必ず作成します
+func (env *Env) CreateWebPushSubscription(t *testing.T, userID uuid.UUID) *model.WebPushSubscription {
+	t.Helper()
+	s, err := env.Repository.RegisterWebPushSubscription(context.TODO(), repository.RegisterWebPushSubscriptionArgs{
+		UserID:   userID,
+		Endpoint: "https://push.example.com/" + uuid.Must(uuid.NewV4()).String(),
+		P256dh:   "p256dh",
+		Auth:     "auth",
+	})
+	require.NoError(t, err)
+	return s
+}
+
+// CreateInboxItem 通知受信箱のアイテムを必ず作成します
+func (env *Env) CreateInboxItem(t *testing.T, userID uuid.UUID, m message.Message) *model.InboxItem {
+	t.Helper()
+	items, err := env.Repository.CreateInboxItems(context.TODO(), []repository.CreateInboxItemArgs{{
+		UserID:    userID,
+		Type:      model.InboxItemTypeMention,
+		MessageID: m.GetID(),
+		ChannelID: m.GetChannelID(),
+		ActorID:   m.GetUserID(),
+	}})
+	require.NoError(t, err)
+	return items[0]
+}
+
 func getEnvOrDefault(env string, def string) string {
 	s := os.Getenv(env)
 	if len(s) == 0 {
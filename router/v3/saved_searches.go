@@ -0,0 +1,184 @@
+package v3
+
+import (
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/service/search"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// maxSavedSearchesPerUser ユーザーあたりの保存された検索の最大数
+const maxSavedSearchesPerUser = 50
+
+// PostSavedSearchRequest POST /users/me/saved-searches リクエストボディ
+type PostSavedSearchRequest struct {
+	Name   string `json:"name"`
+	Query  string `json:"query"`
+	Notify bool   `json:"notify"`
+}
+
+func (r PostSavedSearchRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, vd.Required, vd.RuneLength(1, 100)),
+		vd.Field(&r.Query, vd.Required, vd.RuneLength(1, 1000)),
+	)
+}
+
+// PatchSavedSearchRequest PATCH /users/me/saved-searches/:savedSearchID リクエストボディ
+type PatchSavedSearchRequest struct {
+	Name   optional.Of[string] `json:"name"`
+	Query  optional.Of[string] `json:"query"`
+	Notify optional.Of[bool]   `json:"notify"`
+}
+
+func (r PatchSavedSearchRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Name, validator.RequiredIfValid, vd.RuneLength(1, 100)),
+		vd.Field(&r.Query, validator.RequiredIfValid, vd.RuneLength(1, 1000)),
+	)
+}
+
+// GetMySavedSearches GET /users/me/saved-searches
+func (h *Handlers) GetMySavedSearches(c *echo.Context) error {
+	userID := getRequestUserID(c)
+
+	ss, err := h.Repo.GetSavedSearchesByUserID(c.Request().Context(), userID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatSavedSearches(ss))
+}
+
+// CreateSavedSearch POST /users/me/saved-searches
+func (h *Handlers) CreateSavedSearch(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+
+	var req PostSavedSearchRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	// クエリが解析可能か確認
+	qe, err := h.parseSearchQuery(ctx, req.Query, &search.Query{})
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if qe != nil {
+		return c.JSON(http.StatusBadRequest, qe)
+	}
+
+	ss, err := h.Repo.GetSavedSearchesByUserID(ctx, userID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if len(ss) >= maxSavedSearchesPerUser {
+		return herror.BadRequest("too many saved searches")
+	}
+
+	s, err := h.Repo.CreateSavedSearch(ctx, repository.CreateSavedSearchArgs{
+		UserID: userID,
+		Name:   req.Name,
+		Query:  req.Query,
+		Notify: req.Notify,
+	})
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusCreated, formatSavedSearch(s))
+}
+
+// GetMySavedSearch GET /users/me/saved-searches/:savedSearchID
+func (h *Handlers) GetMySavedSearch(c *echo.Context) error {
+	s, err := h.getMySavedSearch(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, formatSavedSearch(s))
+}
+
+// EditMySavedSearch PATCH /users/me/saved-searches/:savedSearchID
+func (h *Handlers) EditMySavedSearch(c *echo.Context) error {
+	ctx := c.Request().Context()
+	s, err := h.getMySavedSearch(c)
+	if err != nil {
+		return err
+	}
+
+	var req PatchSavedSearchRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.Query.Valid {
+		// クエリが解析可能か確認
+		qe, err := h.parseSearchQuery(ctx, req.Query.V, &search.Query{})
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		if qe != nil {
+			return c.JSON(http.StatusBadRequest, qe)
+		}
+	}
+
+	args := repository.UpdateSavedSearchArgs{
+		Name:   req.Name,
+		Query:  req.Query,
+		Notify: req.Notify,
+	}
+	if err := h.Repo.UpdateSavedSearch(ctx, s.ID, args); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteMySavedSearch DELETE /users/me/saved-searches/:savedSearchID
+func (h *Handlers) DeleteMySavedSearch(c *echo.Context) error {
+	s, err := h.getMySavedSearch(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Repo.DeleteSavedSearch(c.Request().Context(), s.ID); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getMySavedSearch リクエストユーザーのパスパラメータの保存された検索を取得
+func (h *Handlers) getMySavedSearch(c *echo.Context) (*model.SavedSearch, error) {
+	id := getParamAsUUID(c, consts.ParamSavedSearchID)
+
+	s, err := h.Repo.GetSavedSearch(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, herror.NotFound()
+		default:
+			return nil, herror.InternalServerError(err)
+		}
+	}
+	// 他人の保存された検索は存在しないものとして扱う
+	if s.UserID != getRequestUserID(c) {
+		return nil, herror.NotFound()
+	}
+	return s, nil
+}
@@ -0,0 +1,239 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestHandlers_GetMySavedSearches(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/saved-searches"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ss := env.CreateSavedSearch(t, user.GetID(), "error")
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		first := obj.Value(0).Object()
+		first.Value("id").String().IsEqual(ss.ID.String())
+		first.Value("name").String().IsEqual("saved search")
+		first.Value("query").String().IsEqual("error")
+		first.Value("notify").Boolean().IsFalse()
+	})
+}
+
+func TestHandlers_CreateSavedSearch(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/saved-searches"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostSavedSearchRequest{Name: "a", Query: "a"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (empty query)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostSavedSearchRequest{Name: "a"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid query)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostSavedSearchRequest{Name: "a", Query: "error has:pdf"}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().
+			Object()
+
+		obj.Value("code").String().IsEqual("invalid_has")
+		obj.Value("position").Number().IsEqual(6)
+		obj.Value("token").String().IsEqual("has:pdf")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostSavedSearchRequest{Name: "deploy errors", Query: "error from:@" + user.GetName(), Notify: true}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("name").String().IsEqual("deploy errors")
+		obj.Value("query").String().IsEqual("error from:@" + user.GetName())
+		obj.Value("notify").Boolean().IsTrue()
+	})
+}
+
+func TestHandlers_GetMySavedSearch(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/saved-searches/{savedSearchID}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ss := env.CreateSavedSearch(t, user.GetID(), "error")
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, ss.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV7())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (other user's)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, ss.ID).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, ss.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("id").String().IsEqual(ss.ID.String())
+	})
+}
+
+func TestHandlers_EditMySavedSearch(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/saved-searches/{savedSearchID}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ss := env.CreateSavedSearch(t, user.GetID(), "error")
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, ss.ID).
+			WithJSON(&PatchSavedSearchRequest{Notify: optional.From(true)}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid query)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, ss.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchSavedSearchRequest{Query: optional.From(`"error`)}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().
+			Object().
+			Value("code").String().IsEqual("unclosed_quote")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, ss.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchSavedSearchRequest{Name: optional.From("updated"), Notify: optional.From(true)}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.GET(path, ss.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("notify").Boolean().IsTrue()
+	})
+}
+
+func TestHandlers_DeleteMySavedSearch(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/saved-searches/{savedSearchID}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ss := env.CreateSavedSearch(t, user.GetID(), "error")
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, ss.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, ss.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		e.GET(path, ss.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+}
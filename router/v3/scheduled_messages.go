@@ -0,0 +1,181 @@
+package v3
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v5"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/router/utils"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// PostScheduledMessageRequest POST /users/me/scheduled-messages リクエストボディ
+type PostScheduledMessageRequest struct {
+	ChannelID   optional.Of[uuid.UUID] `json:"channelId"`
+	UserID      optional.Of[uuid.UUID] `json:"userId"`
+	Content     string                 `json:"content"`
+	Embed       bool                   `json:"embed"`
+	ScheduledAt time.Time              `json:"scheduledAt"`
+}
+
+func (r PostScheduledMessageRequest) ValidateWithContext(ctx context.Context) error {
+	return vd.ValidateStructWithContext(ctx, &r,
+		vd.Field(&r.ChannelID, vd.When(!r.UserID.Valid, vd.Required).Else(vd.Nil), validator.NotNilUUID, utils.IsPublicChannelID),
+		vd.Field(&r.UserID, validator.NotNilUUID, utils.IsUserID, utils.IsNotWebhookUserID),
+		vd.Field(&r.Content, vd.Required, vd.RuneLength(1, 10000)),
+		vd.Field(&r.ScheduledAt, vd.Required, vd.Min(time.Now()).Error("must be a future time")),
+	)
+}
+
+// PatchScheduledMessageRequest PATCH /users/me/scheduled-messages/:scheduleID リクエストボディ
+type PatchScheduledMessageRequest struct {
+	Content     optional.Of[string]    `json:"content"`
+	Embed       bool                   `json:"embed"`
+	ScheduledAt optional.Of[time.Time] `json:"scheduledAt"`
+}
+
+func (r PatchScheduledMessageRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Content, validator.RequiredIfValid, vd.RuneLength(1, 10000)),
+		vd.Field(&r.ScheduledAt, vd.Min(time.Now()).Error("must be a future time")),
+	)
+}
+
+// GetMyScheduledMessages GET /users/me/scheduled-messages
+func (h *Handlers) GetMyScheduledMessages(c *echo.Context) error {
+	userID := getRequestUserID(c)
+
+	sms, err := h.Repo.GetScheduledMessagesByUserID(c.Request().Context(), userID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatScheduledMessages(sms))
+}
+
+// CreateScheduledMessage POST /users/me/scheduled-messages
+func (h *Handlers) CreateScheduledMessage(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+
+	var req PostScheduledMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	args := repository.CreateScheduledMessageArgs{
+		UserID:      userID,
+		ChannelID:   req.ChannelID.V,
+		Text:        req.Content,
+		ScheduledAt: req.ScheduledAt,
+	}
+	if req.UserID.Valid {
+		// DM
+		ch, err := h.ChannelManager.GetDMChannel(ctx, userID, req.UserID.V)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		args.ChannelID = ch.ID
+		args.DMUserID = req.UserID
+	} else if h.ChannelManager.PublicChannelTree(ctx).IsArchivedChannel(req.ChannelID.V) {
+		return herror.BadRequest("this channel has been archived")
+	}
+
+	if req.Embed {
+		args.Text = h.Replacer.Replace(args.Text)
+	}
+
+	sm, err := h.Repo.CreateScheduledMessage(ctx, args)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusCreated, formatScheduledMessage(sm))
+}
+
+// GetMyScheduledMessage GET /users/me/scheduled-messages/:scheduleID
+func (h *Handlers) GetMyScheduledMessage(c *echo.Context) error {
+	sm, err := h.getMyScheduledMessage(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, formatScheduledMessage(sm))
+}
+
+// EditMyScheduledMessage PATCH /users/me/scheduled-messages/:scheduleID
+func (h *Handlers) EditMyScheduledMessage(c *echo.Context) error {
+	sm, err := h.getMyScheduledMessage(c)
+	if err != nil {
+		return err
+	}
+
+	var req PatchScheduledMessageRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if req.Content.Valid && req.Embed {
+		req.Content.V = h.Replacer.Replace(req.Content.V)
+	}
+
+	args := repository.UpdateScheduledMessageArgs{
+		Text:        req.Content,
+		ScheduledAt: req.ScheduledAt,
+	}
+	if err := h.Repo.UpdateScheduledMessage(c.Request().Context(), sm.ID, args); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		case repository.ErrForbidden:
+			return herror.BadRequest("this message is being posted")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// DeleteMyScheduledMessage DELETE /users/me/scheduled-messages/:scheduleID
+func (h *Handlers) DeleteMyScheduledMessage(c *echo.Context) error {
+	sm, err := h.getMyScheduledMessage(c)
+	if err != nil {
+		return err
+	}
+
+	if err := h.Repo.DeleteScheduledMessage(c.Request().Context(), sm.ID); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// getMyScheduledMessage リクエストユーザーのパスパラメータの予約投稿メッセージを取得
+func (h *Handlers) getMyScheduledMessage(c *echo.Context) (*model.ScheduledMessage, error) {
+	id := getParamAsUUID(c, consts.ParamScheduleID)
+
+	sm, err := h.Repo.GetScheduledMessage(c.Request().Context(), id)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, herror.NotFound()
+		default:
+			return nil, herror.InternalServerError(err)
+		}
+	}
+	// 他人の予約投稿メッセージは存在しないものとして扱う
+	if sm.UserID != getRequestUserID(c) {
+		return nil, herror.NotFound()
+	}
+	return sm, nil
+}
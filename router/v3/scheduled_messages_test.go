@@ -0,0 +1,281 @@
+package v3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestHandlers_GetMyScheduledMessages(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/scheduled-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		first := obj.Value(0).Object()
+		first.Value("id").String().IsEqual(sm.ID.String())
+		first.Value("userId").String().IsEqual(user.GetID().String())
+		first.Value("channelId").String().IsEqual(ch.ID.String())
+		first.Value("dmUserId").IsNull()
+		first.Value("content").String().IsEqual("scheduled")
+	})
+}
+
+func TestHandlers_CreateScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/scheduled-messages"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	archived := env.CreateChannel(t, rand)
+	require.NoError(t, env.CM.ArchiveChannel(context.TODO(), archived.ID, user.GetID()))
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: optional.From(ch.ID), Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (past)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: optional.From(ch.ID), Content: "a", ScheduledAt: time.Now().Add(-time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (both channel and user)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: optional.From(ch.ID), UserID: optional.From(user2.GetID()), Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (archived)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: optional.From(archived.ID), Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success (channel)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{ChannelID: optional.From(ch.ID), Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("id").String().NotEmpty()
+		obj.Value("userId").String().IsEqual(user.GetID().String())
+		obj.Value("channelId").String().IsEqual(ch.ID.String())
+		obj.Value("dmUserId").IsNull()
+		obj.Value("content").String().IsEqual("a")
+	})
+
+	t.Run("success (dm)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostScheduledMessageRequest{UserID: optional.From(user2.GetID()), Content: "a", ScheduledAt: time.Now().Add(time.Hour)}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("dmUserId").String().IsEqual(user2.GetID().String())
+		dm, err := env.CM.GetDMChannel(context.TODO(), user.GetID(), user2.GetID())
+		if assert.NoError(t, err) {
+			obj.Value("channelId").String().IsEqual(dm.ID.String())
+		}
+	})
+}
+
+func TestHandlers_GetMyScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/scheduled-messages/{scheduleId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, sm.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, uuid.Must(uuid.NewV7())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("other user's", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path, sm.ID).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("id").String().IsEqual(sm.ID.String())
+		obj.Value("content").String().IsEqual(sm.Text)
+	})
+}
+
+func TestHandlers_EditMyScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/scheduled-messages/{scheduleId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm.ID).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.From("b")}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchScheduledMessageRequest{ScheduledAt: optional.From(time.Now().Add(-time.Hour))}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PATCH(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PatchScheduledMessageRequest{Content: optional.From("b")}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		r, err := env.Repository.GetScheduledMessage(context.TODO(), sm.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "b", r.Text)
+	})
+}
+
+func TestHandlers_DeleteMyScheduledMessage(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/scheduled-messages/{scheduleId}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	sm := env.CreateScheduledMessage(t, user.GetID(), ch.ID)
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sm.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("other user's", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sm.ID).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sm.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+
+		_, err := env.Repository.GetScheduledMessage(context.TODO(), sm.ID)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
@@ -0,0 +1,166 @@
+package v3
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/labstack/echo/v5"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+const (
+	searchTypeUser    = "user"
+	searchTypeChannel = "channel"
+	searchTypeFile    = "file"
+	searchTypeStamp   = "stamp"
+)
+
+// SearchRequest GET /search 用クエリ
+type SearchRequest struct {
+	Word  string   `query:"word"`
+	Types []string `query:"types"`
+	Limit int      `query:"limit"`
+}
+
+func (r *SearchRequest) Validate() error {
+	if r.Limit == 0 {
+		r.Limit = 10
+	}
+	if len(r.Types) == 0 {
+		r.Types = []string{searchTypeUser, searchTypeChannel, searchTypeFile, searchTypeStamp}
+	}
+	return vd.ValidateStruct(r,
+		vd.Field(&r.Word, vd.Required, vd.RuneLength(1, 100)),
+		vd.Field(&r.Types, vd.Each(vd.In(searchTypeUser, searchTypeChannel, searchTypeFile, searchTypeStamp))),
+		vd.Field(&r.Limit, vd.Min(1), vd.Max(50)),
+	)
+}
+
+func (r *SearchRequest) includes(t string) bool {
+	return slices.Contains(r.Types, t)
+}
+
+// SearchResultChannel GET /search のチャンネル検索結果
+type SearchResultChannel struct {
+	ID       uuid.UUID `json:"id"`
+	Path     string    `json:"path"`
+	Topic    string    `json:"topic"`
+	Archived bool      `json:"archived"`
+}
+
+// SearchResult GET /search レスポンス
+type SearchResult struct {
+	Users    []User                      `json:"users"`
+	Channels []SearchResultChannel       `json:"channels"`
+	Files    []*FileInfo                 `json:"files"`
+	Stamps   []*model.StampWithThumbnail `json:"stamps"`
+}
+
+// searchMatchRank 検索ワードとの一致度 (小さいほど上位)
+func searchMatchRank(name, word string) int {
+	name, word = strings.ToLower(name), strings.ToLower(word)
+	switch {
+	case name == word:
+		return 0
+	case strings.HasPrefix(name, word):
+		return 1
+	case strings.Contains(name, word):
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Search GET /search
+func (h *Handlers) Search(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+
+	var req SearchRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	res := SearchResult{
+		Users:    []User{},
+		Channels: []SearchResultChannel{},
+		Files:    []*FileInfo{},
+		Stamps:   []*model.StampWithThumbnail{},
+	}
+
+	if req.includes(searchTypeUser) {
+		users, err := h.Repo.GetUsers(ctx, repository.UsersQuery{}.Active().Contains(req.Word).LimitOf(req.Limit))
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		res.Users = formatUsers(users)
+	}
+
+	if req.includes(searchTypeChannel) {
+		// DBから全チャンネルを取得しないよう、メモリ上のチャンネルツリーから検索する
+		tree := h.ChannelManager.PublicChannelTree(ctx)
+		word := strings.ToLower(req.Word)
+		for _, id := range tree.GetDescendantIDs(uuid.Nil) {
+			ch, err := tree.GetModel(id)
+			if err != nil {
+				continue // 検索中に削除された
+			}
+			path := tree.GetChannelPath(ch.ID)
+			if !strings.Contains(strings.ToLower(path), word) && !strings.Contains(strings.ToLower(ch.Topic), word) {
+				continue
+			}
+			res.Channels = append(res.Channels, SearchResultChannel{
+				ID:       ch.ID,
+				Path:     path,
+				Topic:    ch.Topic,
+				Archived: ch.IsArchived(),
+			})
+		}
+		sort.Slice(res.Channels, func(i, j int) bool {
+			a, b := res.Channels[i], res.Channels[j]
+			if a.Archived != b.Archived {
+				return !a.Archived
+			}
+			ra := searchMatchRank(a.Path[strings.LastIndex(a.Path, "/")+1:], req.Word)
+			rb := searchMatchRank(b.Path[strings.LastIndex(b.Path, "/")+1:], req.Word)
+			if ra != rb {
+				return ra < rb
+			}
+			return a.Path < b.Path
+		})
+		if len(res.Channels) > req.Limit {
+			res.Channels = res.Channels[:req.Limit]
+		}
+	}
+
+	if req.includes(searchTypeFile) {
+		files, _, err := h.FileManager.List(ctx, repository.FilesQuery{
+			Type:         model.FileTypeUserFile,
+			Word:         optional.From(req.Word),
+			AccessibleBy: optional.From(userID),
+			Limit:        req.Limit,
+		})
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		res.Files = formatFileInfos(files)
+	}
+
+	if req.includes(searchTypeStamp) {
+		stamps, err := h.Repo.SearchStamps(ctx, req.Word, req.Limit)
+		if err != nil {
+			return herror.InternalServerError(err)
+		}
+		res.Stamps = stamps
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
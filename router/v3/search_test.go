@@ -0,0 +1,99 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/random"
+)
+
+func TestHandlers_Search(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/search"
+	env := Setup(t, common1)
+	word := random.AlphaNumeric(12)
+	user := env.CreateUser(t, "u"+word)
+	ch := env.CreateChannel(t, "c"+word)
+	stamp := env.CreateStamp(t, user.GetID(), "s"+word)
+	f := env.CreateFileWithName(t, user.GetID(), ch.ID, "f"+word+".txt")
+	env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithQuery("word", word).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (no word)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (invalid type)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			WithQuery("word", word).
+			WithQuery("types", "message").
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithQuery("word", word).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		users := obj.Value("users").Array()
+		users.Length().IsEqual(1)
+		users.Value(0).Object().Value("id").String().IsEqual(user.GetID().String())
+
+		channels := obj.Value("channels").Array()
+		channels.Length().IsEqual(1)
+		channels.Value(0).Object().Value("id").String().IsEqual(ch.ID.String())
+		channels.Value(0).Object().Value("path").String().IsEqual("c" + word)
+
+		files := obj.Value("files").Array()
+		files.Length().IsEqual(1)
+		files.Value(0).Object().Value("id").String().IsEqual(f.GetID().String())
+
+		stamps := obj.Value("stamps").Array()
+		stamps.Length().IsEqual(1)
+		stamps.Value(0).Object().Value("id").String().IsEqual(stamp.ID.String())
+	})
+
+	t.Run("success (types=user)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithQuery("word", word).
+			WithQuery("types", "user").
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("users").Array().Length().IsEqual(1)
+		obj.Value("channels").Array().Length().IsEqual(0)
+		obj.Value("files").Array().Length().IsEqual(0)
+		obj.Value("stamps").Array().Length().IsEqual(0)
+	})
+}
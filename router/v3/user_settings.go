@@ -1,11 +1,24 @@
 package v3
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/gofrs/uuid"
 	"github.com/labstack/echo/v5"
+	"go.uber.org/zap"
 
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/random"
 )
 
 // PutMyNotifyCitationRequest PUT /user/me/settings/notify-citation リクエストボディ
@@ -56,3 +69,367 @@ func (h *Handlers) GetMyNotifyCitation(c *echo.Context) error {
 
 	return c.JSON(http.StatusOK, &res{NotifyCitation: nc})
 }
+
+// maxNotifyKeywordsPerUser 1ユーザーが設定できる通知キーワードの最大数
+const maxNotifyKeywordsPerUser = 50
+
+// NotifyKeywordRequest 通知キーワード
+type NotifyKeywordRequest struct {
+	Keyword       string `json:"keyword"`
+	WholeWord     bool   `json:"wholeWord"`
+	CaseSensitive bool   `json:"caseSensitive"`
+}
+
+func (r NotifyKeywordRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Keyword, vd.Required, vd.RuneLength(1, 100)),
+	)
+}
+
+// PutMyNotifyKeywordsRequest PUT /user/me/settings/notify-keywords リクエストボディ
+type PutMyNotifyKeywordsRequest struct {
+	NotifyKeywords []NotifyKeywordRequest `json:"notifyKeywords"`
+}
+
+func (r PutMyNotifyKeywordsRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.NotifyKeywords, vd.NotNil, vd.Length(0, maxNotifyKeywordsPerUser)),
+	)
+}
+
+// PutMyNotifyKeywords PUT /user/me/settings/notify-keywords
+func (h *Handlers) PutMyNotifyKeywords(c *echo.Context) error {
+	id := getRequestUserID(c)
+
+	var req PutMyNotifyKeywordsRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	keywords := make(model.NotifyKeywords, len(req.NotifyKeywords))
+	for i, k := range req.NotifyKeywords {
+		keywords[i] = model.NotifyKeyword{
+			Keyword:       k.Keyword,
+			WholeWord:     k.WholeWord,
+			CaseSensitive: k.CaseSensitive,
+		}
+	}
+	if err := h.Repo.UpdateNotifyKeywords(c.Request().Context(), id, keywords); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetMyNotifyKeywords GET /user/me/settings/notify-keywords
+func (h *Handlers) GetMyNotifyKeywords(c *echo.Context) error {
+	id := getRequestUserID(c)
+
+	us, err := h.Repo.GetUserSettings(c.Request().Context(), id)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	type res struct {
+		NotifyKeywords model.NotifyKeywords `json:"notifyKeywords"`
+	}
+
+	keywords := us.NotifyKeywords
+	if keywords == nil {
+		keywords = model.NotifyKeywords{}
+	}
+	return c.JSON(http.StatusOK, &res{NotifyKeywords: keywords})
+}
+
+// maxDNDSchedulesPerUser 1ユーザーが設定できるおやすみモードの時間帯の最大数
+const maxDNDSchedulesPerUser = 20
+
+// DNDScheduleRequest おやすみモードの時間帯
+type DNDScheduleRequest struct {
+	Weekdays []time.Weekday `json:"weekdays"`
+	Start    string         `json:"start"`
+	End      string         `json:"end"`
+	Timezone string         `json:"timezone"`
+}
+
+func validateDNDClock(value any) error {
+	s, _ := value.(string)
+	_, err := model.ParseDNDClock(s)
+	return err
+}
+
+func validateTimezone(value any) error {
+	s, _ := value.(string)
+	_, err := time.LoadLocation(s)
+	return err
+}
+
+func (r DNDScheduleRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Weekdays, vd.Required, vd.Length(1, 7), vd.Each(vd.Min(0), vd.Max(6))),
+		vd.Field(&r.Start, vd.Required, vd.By(validateDNDClock)),
+		vd.Field(&r.End, vd.Required, vd.By(validateDNDClock), vd.NotIn(r.Start).Error("must be different from start")),
+		vd.Field(&r.Timezone, vd.Required, vd.By(validateTimezone)),
+	)
+}
+
+// PutMyDoNotDisturbRequest PUT /user/me/settings/do-not-disturb リクエストボディ
+type PutMyDoNotDisturbRequest struct {
+	Schedules     []DNDScheduleRequest `json:"schedules"`
+	AllowForced   bool                 `json:"allowForced"`
+	AllowMentions bool                 `json:"allowMentions"`
+}
+
+func (r PutMyDoNotDisturbRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Schedules, vd.NotNil, vd.Length(0, maxDNDSchedulesPerUser)),
+	)
+}
+
+// DoNotDisturb GET /user/me/settings/do-not-disturb レスポンス
+type DoNotDisturb struct {
+	Schedules     model.DNDSchedules     `json:"schedules"`
+	AllowForced   bool                   `json:"allowForced"`
+	AllowMentions bool                   `json:"allowMentions"`
+	SnoozeUntil   optional.Of[time.Time] `json:"snoozeUntil"`
+}
+
+// GetMyDoNotDisturb GET /user/me/settings/do-not-disturb
+func (h *Handlers) GetMyDoNotDisturb(c *echo.Context) error {
+	id := getRequestUserID(c)
+
+	us, err := h.Repo.GetUserSettings(c.Request().Context(), id)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	schedules := us.DNDSchedules
+	if schedules == nil {
+		schedules = model.DNDSchedules{}
+	}
+	return c.JSON(http.StatusOK, &DoNotDisturb{
+		Schedules:     schedules,
+		AllowForced:   us.DNDAllowForced,
+		AllowMentions: us.DNDAllowMentions,
+		SnoozeUntil:   us.SnoozeUntil,
+	})
+}
+
+// PutMyDoNotDisturb PUT /user/me/settings/do-not-disturb
+func (h *Handlers) PutMyDoNotDisturb(c *echo.Context) error {
+	id := getRequestUserID(c)
+
+	var req PutMyDoNotDisturbRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	schedules := make(model.DNDSchedules, len(req.Schedules))
+	for i, s := range req.Schedules {
+		schedules[i] = model.DNDSchedule{
+			Weekdays: s.Weekdays,
+			Start:    s.Start,
+			End:      s.End,
+			Timezone: s.Timezone,
+		}
+	}
+	args := repository.UpdateDoNotDisturbArgs{
+		Schedules:     schedules,
+		AllowForced:   req.AllowForced,
+		AllowMentions: req.AllowMentions,
+	}
+	if err := h.Repo.UpdateDoNotDisturb(c.Request().Context(), id, args); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// PutMySnoozeRequest PUT /user/me/settings/snooze リクエストボディ
+type PutMySnoozeRequest struct {
+	Until optional.Of[time.Time] `json:"until"`
+}
+
+// PutMySnooze PUT /user/me/settings/snooze
+func (h *Handlers) PutMySnooze(c *echo.Context) error {
+	id := getRequestUserID(c)
+
+	var req PutMySnoozeRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+	if req.Until.Valid && !req.Until.V.After(time.Now()) {
+		return herror.BadRequest("until must be in the future")
+	}
+
+	if err := h.Repo.UpdateSnoozeUntil(c.Request().Context(), id, req.Until); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// digestVerificationCodeLength メールダイジェストの送信先の確認コードの長さ
+const digestVerificationCodeLength = 8
+
+// digestVerificationInterval 同じユーザーに確認メールを送信できる最短間隔
+const digestVerificationInterval = time.Minute
+
+// digestVerificationLimiter ユーザーごとに確認メールの送信間隔を制限します
+//
+// ゼロ値でそのまま使用できます
+type digestVerificationLimiter struct {
+	mu   sync.Mutex
+	sent map[uuid.UUID]time.Time
+}
+
+// allow userIDへの確認メールの送信を許可するかどうかを返します
+//
+// 許可した場合は送信時刻として記録します
+func (l *digestVerificationLimiter) allow(userID uuid.UUID, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.sent == nil {
+		l.sent = make(map[uuid.UUID]time.Time)
+	}
+	if last, ok := l.sent[userID]; ok && now.Sub(last) < digestVerificationInterval {
+		return false
+	}
+	// 期限切れの記録を掃除
+	for id, t := range l.sent {
+		if now.Sub(t) >= digestVerificationInterval {
+			delete(l.sent, id)
+		}
+	}
+	l.sent[userID] = now
+	return true
+}
+
+// EmailDigest GET /users/me/settings/email-digest レスポンス
+type EmailDigest struct {
+	Email     string                `json:"email"`
+	Verified  bool                  `json:"verified"`
+	Frequency model.DigestFrequency `json:"frequency"`
+}
+
+// PutMyEmailDigestRequest PUT /users/me/settings/email-digest リクエストボディ
+type PutMyEmailDigestRequest struct {
+	Email     string                `json:"email"`
+	Frequency model.DigestFrequency `json:"frequency"`
+}
+
+func validateDigestFrequency(value any) error {
+	f, _ := value.(model.DigestFrequency)
+	if !f.Valid() {
+		return errors.New("invalid frequency")
+	}
+	return nil
+}
+
+func (r PutMyEmailDigestRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Email, vd.RuneLength(0, 254), is.EmailFormat),
+		vd.Field(&r.Frequency, vd.Required, vd.By(validateDigestFrequency)),
+	)
+}
+
+// GetMyEmailDigest GET /users/me/settings/email-digest
+func (h *Handlers) GetMyEmailDigest(c *echo.Context) error {
+	id := getRequestUserID(c)
+
+	us, err := h.Repo.GetUserSettings(c.Request().Context(), id)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.JSON(http.StatusOK, &EmailDigest{
+		Email:     us.DigestEmail,
+		Verified:  us.DigestEmailVerified,
+		Frequency: us.DigestFrequency,
+	})
+}
+
+// PutMyEmailDigest PUT /users/me/settings/email-digest
+//
+// 送信先が変更された場合は確認コードをメールで非同期に送信します
+// 確認メールの送信はユーザーごとにdigestVerificationIntervalに1回までです
+func (h *Handlers) PutMyEmailDigest(c *echo.Context) error {
+	id := getRequestUserID(c)
+	ctx := c.Request().Context()
+
+	var req PutMyEmailDigestRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	us, err := h.Repo.GetUserSettings(ctx, id)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if req.Email != us.DigestEmail {
+		if len(req.Email) == 0 {
+			if err := h.Repo.SetDigestEmail(ctx, id, "", ""); err != nil {
+				return herror.InternalServerError(err)
+			}
+		} else {
+			if !h.Mailer.Enabled() {
+				return herror.HTTPError(http.StatusServiceUnavailable, "email is not available on this server")
+			}
+			if !h.digestLimiter.allow(id, time.Now()) {
+				return herror.HTTPError(http.StatusTooManyRequests, "verification email was sent recently. please try again later")
+			}
+			code := random.SecureAlphaNumeric(digestVerificationCodeLength)
+			if err := h.Repo.SetDigestEmail(ctx, id, req.Email, code); err != nil {
+				return herror.InternalServerError(err)
+			}
+			// SMTPサーバーの応答を待たずにレスポンスを返す
+			go h.sendDigestVerificationEmail(id, req.Email, code)
+		}
+	}
+	if err := h.Repo.UpdateDigestFrequency(ctx, id, req.Frequency); err != nil {
+		return herror.InternalServerError(err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *Handlers) sendDigestVerificationEmail(userID uuid.UUID, to, code string) {
+	body := fmt.Sprintf("traQのメールダイジェストの送信先を確認するため、以下の確認コードを入力してください。\n\n%s\n\nこのメールに心当たりがない場合は破棄してください。\n", code)
+	if err := h.Mailer.Send(context.Background(), to, "traQ: メールアドレスの確認", body); err != nil {
+		h.Logger.Error("failed to send digest verification email", zap.Error(err), zap.Stringer("userID", userID))
+	}
+}
+
+// PostMyEmailDigestVerifyRequest POST /users/me/settings/email-digest/verify リクエストボディ
+type PostMyEmailDigestVerifyRequest struct {
+	Code string `json:"code"`
+}
+
+func (r PostMyEmailDigestVerifyRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Code, vd.Required, vd.RuneLength(1, 16)),
+	)
+}
+
+// VerifyMyEmailDigest POST /users/me/settings/email-digest/verify
+func (h *Handlers) VerifyMyEmailDigest(c *echo.Context) error {
+	id := getRequestUserID(c)
+
+	var req PostMyEmailDigestVerifyRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.Repo.VerifyDigestEmail(c.Request().Context(), id, req.Code); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound("no email address awaiting verification")
+		case repository.ErrForbidden:
+			return herror.BadRequest("invalid code")
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/router/session"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func TestHandlers_PutMyNotifyCitation(t *testing.T) {
@@ -71,6 +75,7 @@ func TestHandlers_GetMySettings(t *testing.T) {
 
 		obj.Value("id").String().IsEqual(user.GetID().String())
 		obj.Value("notifyCitation").Boolean().IsFalse()
+		obj.Value("notifyKeywords").Array().Length().IsEqual(0)
 	})
 }
 
@@ -103,3 +108,424 @@ func TestHandlers_GetMyNotifyCitation(t *testing.T) {
 		obj.Value("notifyCitation").Boolean().IsFalse()
 	})
 }
+
+func TestHandlers_PutMyNotifyKeywords(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/notify-keywords"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyNotifyKeywordsRequest{NotifyKeywords: []NotifyKeywordRequest{}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (empty keyword)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyNotifyKeywordsRequest{NotifyKeywords: []NotifyKeywordRequest{{Keyword: ""}}}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (too many keywords)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		keywords := make([]NotifyKeywordRequest, maxNotifyKeywordsPerUser+1)
+		for i := range keywords {
+			keywords[i] = NotifyKeywordRequest{Keyword: "a"}
+		}
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyNotifyKeywordsRequest{NotifyKeywords: keywords}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyNotifyKeywordsRequest{NotifyKeywords: []NotifyKeywordRequest{{Keyword: "traQ", WholeWord: true}}}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(context.TODO(), user.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, model.NotifyKeywords{{Keyword: "traQ", WholeWord: true}}, us.NotifyKeywords)
+	})
+}
+
+func TestHandlers_GetMyNotifyKeywords(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/notify-keywords"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+	require.NoError(t, env.Repository.UpdateNotifyKeywords(context.TODO(), user.GetID(), model.NotifyKeywords{{Keyword: "traQ", CaseSensitive: true}}))
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		keywords := obj.Value("notifyKeywords").Array()
+		keywords.Length().IsEqual(1)
+		keyword := keywords.Value(0).Object()
+		keyword.Value("keyword").String().IsEqual("traQ")
+		keyword.Value("wholeWord").Boolean().IsFalse()
+		keyword.Value("caseSensitive").Boolean().IsTrue()
+	})
+}
+
+func TestHandlers_PutMyDoNotDisturb(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/do-not-disturb"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyDoNotDisturbRequest{Schedules: []DNDScheduleRequest{}}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		for _, sc := range []DNDScheduleRequest{
+			{Weekdays: []time.Weekday{}, Start: "23:00", End: "07:00", Timezone: "Asia/Tokyo"},
+			{Weekdays: []time.Weekday{7}, Start: "23:00", End: "07:00", Timezone: "Asia/Tokyo"},
+			{Weekdays: []time.Weekday{time.Monday}, Start: "25:00", End: "07:00", Timezone: "Asia/Tokyo"},
+			{Weekdays: []time.Weekday{time.Monday}, Start: "07:00", End: "07:00", Timezone: "Asia/Tokyo"},
+			{Weekdays: []time.Weekday{time.Monday}, Start: "23:00", End: "07:00", Timezone: "Invalid/Zone"},
+		} {
+			e := env.R(t)
+			e.PUT(path).
+				WithCookie(session.CookieName, s).
+				WithJSON(&PutMyDoNotDisturbRequest{Schedules: []DNDScheduleRequest{sc}}).
+				Expect().
+				Status(http.StatusBadRequest)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyDoNotDisturbRequest{
+				Schedules: []DNDScheduleRequest{
+					{Weekdays: []time.Weekday{time.Sunday, time.Saturday}, Start: "23:00", End: "07:00", Timezone: "Asia/Tokyo"},
+				},
+				AllowMentions: true,
+			}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(context.TODO(), user.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, model.DNDSchedules{
+			{Weekdays: []time.Weekday{time.Sunday, time.Saturday}, Start: "23:00", End: "07:00", Timezone: "Asia/Tokyo"},
+		}, us.DNDSchedules)
+		assert.False(t, us.DNDAllowForced)
+		assert.True(t, us.DNDAllowMentions)
+	})
+}
+
+func TestHandlers_GetMyDoNotDisturb(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/do-not-disturb"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("schedules").Array().Length().IsEqual(0)
+		obj.Value("allowForced").Boolean().IsFalse()
+		obj.Value("allowMentions").Boolean().IsFalse()
+		obj.Value("snoozeUntil").IsNull()
+	})
+}
+
+func TestHandlers_PutMySnooze(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/snooze"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMySnoozeRequest{}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (past)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMySnoozeRequest{Until: optional.From(time.Now().Add(-time.Hour))}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		until := time.Now().Add(time.Hour)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMySnoozeRequest{Until: optional.From(until)}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(context.TODO(), user.GetID())
+		require.NoError(t, err)
+		if assert.True(t, us.SnoozeUntil.Valid) {
+			assert.WithinDuration(t, until, us.SnoozeUntil.V, time.Second)
+		}
+
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMySnoozeRequest{}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err = env.Repository.GetUserSettings(context.TODO(), user.GetID())
+		require.NoError(t, err)
+		assert.False(t, us.SnoozeUntil.Valid)
+	})
+}
+
+func TestHandlers_GetMyEmailDigest(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/email-digest"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object()
+
+		obj.Value("email").String().IsEmpty()
+		obj.Value("verified").Boolean().IsFalse()
+		obj.Value("frequency").String().IsEqual("off")
+	})
+}
+
+func TestHandlers_PutMyEmailDigest(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/email-digest"
+	env := Setup(t, common1)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path).
+			WithJSON(&PutMyEmailDigestRequest{Frequency: model.DigestFrequencyDaily}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		t.Parallel()
+		s := env.S(t, env.CreateUser(t, rand).GetID())
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailDigestRequest{Email: "invalid", Frequency: model.DigestFrequencyDaily}).
+			Expect().
+			Status(http.StatusBadRequest)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailDigestRequest{Email: "user@example.com", Frequency: "monthly"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		user := env.CreateUser(t, rand)
+		s := env.S(t, user.GetID())
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailDigestRequest{Email: "user@example.com", Frequency: model.DigestFrequencyWeekly}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(context.TODO(), user.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, "user@example.com", us.DigestEmail)
+		assert.False(t, us.DigestEmailVerified)
+		assert.NotEmpty(t, us.DigestVerificationCode)
+		assert.Equal(t, model.DigestFrequencyWeekly, us.DigestFrequency)
+
+		// 送信先が変わらなければ確認コードは再発行されない
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailDigestRequest{Email: "user@example.com", Frequency: model.DigestFrequencyDaily}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us2, err := env.Repository.GetUserSettings(context.TODO(), user.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, us.DigestVerificationCode, us2.DigestVerificationCode)
+		assert.Equal(t, model.DigestFrequencyDaily, us2.DigestFrequency)
+	})
+
+	t.Run("too many requests", func(t *testing.T) {
+		t.Parallel()
+		user := env.CreateUser(t, rand)
+		s := env.S(t, user.GetID())
+		e := env.R(t)
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailDigestRequest{Email: "user1@example.com", Frequency: model.DigestFrequencyWeekly}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		// 直後に別の送信先に変更しようとすると制限される
+		e.PUT(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutMyEmailDigestRequest{Email: "user2@example.com", Frequency: model.DigestFrequencyWeekly}).
+			Expect().
+			Status(http.StatusTooManyRequests)
+
+		us, err := env.Repository.GetUserSettings(context.TODO(), user.GetID())
+		require.NoError(t, err)
+		assert.Equal(t, "user1@example.com", us.DigestEmail)
+	})
+}
+
+func TestDigestVerificationLimiter_allow(t *testing.T) {
+	t.Parallel()
+
+	var l digestVerificationLimiter
+	u1 := uuid.Must(uuid.NewV7())
+	u2 := uuid.Must(uuid.NewV7())
+	now := time.Now()
+
+	assert.True(t, l.allow(u1, now))
+	assert.False(t, l.allow(u1, now.Add(digestVerificationInterval-time.Second)))
+	assert.True(t, l.allow(u2, now))
+	assert.True(t, l.allow(u1, now.Add(digestVerificationInterval)))
+}
+
+func TestHandlers_VerifyMyEmailDigest(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/settings/email-digest/verify"
+	env := Setup(t, common1)
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostMyEmailDigestVerifyRequest{Code: "code"}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		s := env.S(t, env.CreateUser(t, rand).GetID())
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyEmailDigestVerifyRequest{Code: "code"}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		user := env.CreateUser(t, rand)
+		s := env.S(t, user.GetID())
+		require.NoError(t, env.Repository.SetDigestEmail(context.TODO(), user.GetID(), "user@example.com", "abcd1234"))
+
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyEmailDigestVerifyRequest{Code: "wrong"}).
+			Expect().
+			Status(http.StatusBadRequest)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyEmailDigestVerifyRequest{Code: "abcd1234"}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		us, err := env.Repository.GetUserSettings(context.TODO(), user.GetID())
+		require.NoError(t, err)
+		assert.True(t, us.DigestEmailVerified)
+	})
+}
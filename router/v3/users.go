@@ -2,6 +2,7 @@ package v3
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"sort"
 	"time"
@@ -451,18 +452,27 @@ func (h *Handlers) EditUser(c *echo.Context) error {
 
 // GetMyChannelSubscriptions GET /users/me/subscriptions
 func (h *Handlers) GetMyChannelSubscriptions(c *echo.Context) error {
-	subscriptions, err := h.Repo.GetChannelSubscriptions(c.Request().Context(), repository.ChannelSubscriptionQuery{}.SetUser(getRequestUserID(c)))
+	subscriptions, err := h.Repo.GetChannelSubscriptions(c.Request().Context(), repository.ChannelSubscriptionQuery{}.SetUser(getRequestUserID(c)).IncludeNotifyOverrides())
 	if err != nil {
 		return herror.InternalServerError(err)
 	}
 
 	type response struct {
-		ChannelID uuid.UUID `json:"channelId"`
-		Level     int       `json:"level"`
+		ChannelID   uuid.UUID               `json:"channelId"`
+		Level       int                     `json:"level"`
+		NotifyMode  model.ChannelNotifyMode `json:"notifyMode"`
+		MutedUntil  optional.Of[time.Time]  `json:"mutedUntil"`
+		SuppressBot bool                    `json:"suppressBot"`
 	}
 	result := make([]response, len(subscriptions))
 	for i, subscription := range subscriptions {
-		result[i] = response{ChannelID: subscription.ChannelID, Level: subscription.GetLevel().Int()}
+		result[i] = response{
+			ChannelID:   subscription.ChannelID,
+			Level:       subscription.GetLevel().Int(),
+			NotifyMode:  subscription.NotifyMode,
+			MutedUntil:  subscription.MutedUntil,
+			SuppressBot: subscription.SuppressBot,
+		}
 	}
 	sort.Slice(result, func(i, j int) bool { return result[i].ChannelID.String() < result[j].ChannelID.String() })
 
@@ -511,6 +521,68 @@ func (h *Handlers) SetChannelSubscribeLevel(c *echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// PutChannelNotifyOverridesRequest PUT /users/me/subscriptions/:channelID/overrides リクエストボディ
+type PutChannelNotifyOverridesRequest struct {
+	NotifyMode  model.ChannelNotifyMode `json:"notifyMode"`
+	MutedUntil  optional.Of[time.Time]  `json:"mutedUntil"`
+	SuppressBot bool                    `json:"suppressBot"`
+}
+
+func validateChannelNotifyMode(value any) error {
+	m, _ := value.(model.ChannelNotifyMode)
+	if !m.Valid() {
+		return errors.New("invalid notify mode")
+	}
+	return nil
+}
+
+func (r PutChannelNotifyOverridesRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.NotifyMode, vd.Required, vd.By(validateChannelNotifyMode)),
+	)
+}
+
+// SetChannelNotifyOverrides PUT /users/me/subscriptions/:channelID/overrides
+func (h *Handlers) SetChannelNotifyOverrides(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+	channelID := getParamAsUUID(c, consts.ParamChannelID)
+
+	var req PutChannelNotifyOverridesRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+	if req.MutedUntil.Valid && !req.MutedUntil.V.After(time.Now()) {
+		return herror.BadRequest("mutedUntil must be in the future")
+	}
+
+	ch, err := h.ChannelManager.GetChannel(ctx, channelID)
+	if err != nil {
+		if err == channel.ErrChannelNotFound {
+			return herror.NotFound()
+		}
+		return herror.InternalServerError(err)
+	}
+	if ok, err := h.ChannelManager.IsChannelAccessibleToUser(ctx, userID, ch.ID); err != nil {
+		return herror.InternalServerError(err)
+	} else if !ok {
+		return herror.NotFound()
+	}
+	if ch.IsForced {
+		return herror.Forbidden("the channel's notification is not configurable")
+	}
+
+	args := repository.UpdateChannelNotifyOverridesArgs{
+		NotifyMode:  req.NotifyMode,
+		MutedUntil:  req.MutedUntil,
+		SuppressBot: req.SuppressBot,
+	}
+	if err := h.Repo.UpdateChannelNotifyOverrides(ctx, userID, ch.ID, args); err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
 // GetUserStats GET /users/me/:userID/stats
 func (h *Handlers) GetUserStats(c *echo.Context) error {
 	userID := getParamAsUUID(c, consts.ParamUserID)
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gavv/httpexpect/v2"
 	"github.com/gofrs/uuid"
@@ -1165,6 +1166,116 @@ func TestHandlers_SetChannelSubscribeLevel(t *testing.T) {
 	})
 }
 
+func TestHandlers_SetChannelNotifyOverrides(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/subscriptions/{channelId}/overrides"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	user3 := env.CreateUser(t, rand)
+	ch := env.CreateChannel(t, rand)
+	forced := env.CreateChannel(t, rand)
+	dm := env.CreateDMChannel(t, user.GetID(), user2.GetID())
+	otherDM := env.CreateDMChannel(t, user2.GetID(), user3.GetID())
+	err := env.CM.UpdateChannel(context.TODO(), forced.ID, repository.UpdateChannelArgs{ForcedNotification: optional.From(true)})
+	require.NoError(t, err)
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithJSON(&PutChannelNotifyOverridesRequest{NotifyMode: model.ChannelNotifyModeMute}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (invalid mode)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutChannelNotifyOverridesRequest{NotifyMode: "all"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (past mutedUntil)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutChannelNotifyOverridesRequest{NotifyMode: model.ChannelNotifyModeDefault, MutedUntil: optional.From(time.Now().Add(-time.Hour))}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("forbidden (forced)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, forced.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutChannelNotifyOverridesRequest{NotifyMode: model.ChannelNotifyModeMute}).
+			Expect().
+			Status(http.StatusForbidden)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, uuid.Must(uuid.NewV4())).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutChannelNotifyOverridesRequest{NotifyMode: model.ChannelNotifyModeMute}).
+			Expect().
+			Status(http.StatusNotFound)
+		e.PUT(path, otherDM.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutChannelNotifyOverridesRequest{NotifyMode: model.ChannelNotifyModeMute}).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.PUT(path, ch.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutChannelNotifyOverridesRequest{NotifyMode: model.ChannelNotifyModeMentions, SuppressBot: true}).
+			Expect().
+			Status(http.StatusNoContent)
+		e.PUT(path, dm.ID).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PutChannelNotifyOverridesRequest{NotifyMode: model.ChannelNotifyModeDefault, MutedUntil: optional.From(time.Now().Add(time.Hour))}).
+			Expect().
+			Status(http.StatusNoContent)
+
+		obj := e.GET("/api/v3/users/me/subscriptions").
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+		obj.Length().IsEqual(2)
+		for _, v := range obj.Iter() {
+			sub := v.Object()
+			sub.Value("level").Number().IsEqual(model.ChannelSubscribeLevelNone)
+			switch sub.Value("channelId").String().Raw() {
+			case ch.ID.String():
+				sub.Value("notifyMode").String().IsEqual(string(model.ChannelNotifyModeMentions))
+				sub.Value("mutedUntil").IsNull()
+				sub.Value("suppressBot").Boolean().IsTrue()
+			case dm.ID.String():
+				sub.Value("notifyMode").String().IsEqual(string(model.ChannelNotifyModeDefault))
+				sub.Value("mutedUntil").String().NotEmpty()
+				sub.Value("suppressBot").Boolean().IsFalse()
+			default:
+				t.Errorf("unexpected channel: %s", sub.Value("channelId").String().Raw())
+			}
+		}
+	})
+}
+
 func TestHandlers_GetUserStats(t *testing.T) {
 	t.Parallel()
 
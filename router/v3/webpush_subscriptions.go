@@ -0,0 +1,134 @@
+package v3
+
+import (
+	"net/http"
+	"regexp"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/labstack/echo/v5"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/router/consts"
+	"github.com/traPtitech/traQ/router/extension/herror"
+	"github.com/traPtitech/traQ/utils/validator"
+)
+
+// maxWebPushSubscriptionsPerUser ユーザーあたりのWeb Push購読の最大数
+const maxWebPushSubscriptionsPerUser = 20
+
+var webPushEndpointRegex = regexp.MustCompile(`^https://`)
+
+// PostMyWebPushSubscriptionRequest POST /users/me/webpush-subscriptions リクエストボディ
+//
+// ブラウザのPushSubscription.toJSON()の形式
+type PostMyWebPushSubscriptionRequest struct {
+	Endpoint string                       `json:"endpoint"`
+	Keys     WebPushSubscriptionKeysInput `json:"keys"`
+}
+
+// WebPushSubscriptionKeysInput Web Push購読の鍵
+type WebPushSubscriptionKeysInput struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+func (r WebPushSubscriptionKeysInput) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.P256dh, vd.Required, vd.RuneLength(1, 128)),
+		vd.Field(&r.Auth, vd.Required, vd.RuneLength(1, 64)),
+	)
+}
+
+func (r PostMyWebPushSubscriptionRequest) Validate() error {
+	return vd.ValidateStruct(&r,
+		vd.Field(&r.Endpoint, vd.Required, vd.RuneLength(1, 1024), is.URL, vd.Match(webPushEndpointRegex).Error("must be https url"), validator.NotInternalURL),
+		vd.Field(&r.Keys, vd.Required),
+	)
+}
+
+// GetMyWebPushSubscriptions GET /users/me/webpush-subscriptions
+func (h *Handlers) GetMyWebPushSubscriptions(c *echo.Context) error {
+	userID := getRequestUserID(c)
+
+	subs, err := h.Repo.GetWebPushSubscriptionsByUserID(c.Request().Context(), userID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	return c.JSON(http.StatusOK, formatWebPushSubscriptions(subs))
+}
+
+// PostMyWebPushSubscription POST /users/me/webpush-subscriptions
+func (h *Handlers) PostMyWebPushSubscription(c *echo.Context) error {
+	ctx := c.Request().Context()
+	userID := getRequestUserID(c)
+
+	var req PostMyWebPushSubscriptionRequest
+	if err := bindAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	subs, err := h.Repo.GetWebPushSubscriptionsByUserID(ctx, userID)
+	if err != nil {
+		return herror.InternalServerError(err)
+	}
+	if len(subs) >= maxWebPushSubscriptionsPerUser {
+		// 同じエンドポイントの再登録は置き換えになるので許可する
+		registered := false
+		for _, s := range subs {
+			if s.Endpoint == req.Endpoint {
+				registered = true
+				break
+			}
+		}
+		if !registered {
+			return herror.BadRequest("too many web push subscriptions")
+		}
+	}
+
+	s, err := h.Repo.RegisterWebPushSubscription(ctx, repository.RegisterWebPushSubscriptionArgs{
+		UserID:   userID,
+		Endpoint: req.Endpoint,
+		P256dh:   req.Keys.P256dh,
+		Auth:     req.Keys.Auth,
+	})
+	if err != nil {
+		switch {
+		case repository.IsArgError(err):
+			return herror.BadRequest(err)
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.JSON(http.StatusCreated, formatWebPushSubscription(s))
+}
+
+// DeleteMyWebPushSubscription DELETE /users/me/webpush-subscriptions/:subscriptionID
+func (h *Handlers) DeleteMyWebPushSubscription(c *echo.Context) error {
+	ctx := c.Request().Context()
+	id := getParamAsUUID(c, consts.ParamSubscriptionID)
+
+	s, err := h.Repo.GetWebPushSubscription(ctx, id)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	// 他人の購読は存在しないものとして扱う
+	if s.UserID != getRequestUserID(c) {
+		return herror.NotFound()
+	}
+
+	if err := h.Repo.DeleteWebPushSubscription(ctx, s.ID); err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return herror.NotFound()
+		default:
+			return herror.InternalServerError(err)
+		}
+	}
+	return c.NoContent(http.StatusNoContent)
+}
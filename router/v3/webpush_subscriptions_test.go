@@ -0,0 +1,147 @@
+package v3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/router/session"
+)
+
+func TestHandlers_GetMyWebPushSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/webpush-subscriptions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	sub := env.CreateWebPushSubscription(t, user.GetID())
+	s := env.S(t, user.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.GET(path).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		obj := e.GET(path).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+
+		obj.Length().IsEqual(1)
+		first := obj.Value(0).Object()
+		first.Value("id").String().IsEqual(sub.ID.String())
+		first.Value("endpoint").String().IsEqual(sub.Endpoint)
+		first.NotContainsKey("keys")
+	})
+}
+
+func TestHandlers_PostMyWebPushSubscription(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/webpush-subscriptions"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	s := env.S(t, user.GetID())
+
+	keys := WebPushSubscriptionKeysInput{P256dh: "p256dh", Auth: "auth"}
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithJSON(&PostMyWebPushSubscriptionRequest{Endpoint: "https://push.example.com/a", Keys: keys}).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("bad request (not https)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyWebPushSubscriptionRequest{Endpoint: "http://push.example.com/a", Keys: keys}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("bad request (no keys)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyWebPushSubscriptionRequest{Endpoint: "https://push.example.com/a"}).
+			Expect().
+			Status(http.StatusBadRequest)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		endpoint := "https://push.example.com/" + uuid.Must(uuid.NewV4()).String()
+		obj := e.POST(path).
+			WithCookie(session.CookieName, s).
+			WithJSON(&PostMyWebPushSubscriptionRequest{Endpoint: endpoint, Keys: keys}).
+			Expect().
+			Status(http.StatusCreated).
+			JSON().
+			Object()
+
+		obj.Value("endpoint").String().IsEqual(endpoint)
+	})
+}
+
+func TestHandlers_DeleteMyWebPushSubscription(t *testing.T) {
+	t.Parallel()
+
+	path := "/api/v3/users/me/webpush-subscriptions/{subscriptionID}"
+	env := Setup(t, common1)
+	user := env.CreateUser(t, rand)
+	user2 := env.CreateUser(t, rand)
+	sub := env.CreateWebPushSubscription(t, user.GetID())
+	s := env.S(t, user.GetID())
+	s2 := env.S(t, user2.GetID())
+
+	t.Run("not logged in", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sub.ID).
+			Expect().
+			Status(http.StatusUnauthorized)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, uuid.Must(uuid.NewV7())).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("not found (other user's)", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sub.ID).
+			WithCookie(session.CookieName, s2).
+			Expect().
+			Status(http.StatusNotFound)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		e := env.R(t)
+		e.DELETE(path, sub.ID).
+			WithCookie(session.CookieName, s).
+			Expect().
+			Status(http.StatusNoContent)
+	})
+}
@@ -53,6 +53,7 @@ func newRouter(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, ss *servi
 	webrtcv3Manager := ss.WebRTCv3
 	processor := ss.Imaging
 	engine := ss.Search
+	mailer := ss.Mailer
 	nonceManager := message.NewNonceManager()
 	soundboard := ss.QallSoundBoard
 	roomStateManager := ss.QallRoomStateManager
@@ -75,6 +76,7 @@ func newRouter(hub2 *hub.Hub, db *gorm.DB, repo repository.Repository, ss *servi
 		ChannelManager: manager,
 		MessageManager: messageManager,
 		FileManager:    fileManager,
+		Mailer:         mailer,
 		Replacer:       replacer,
 		NonceManager:   nonceManager,
 		Soundboard:     soundboard,
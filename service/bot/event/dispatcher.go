@@ -14,6 +14,8 @@ import (
 type Dispatcher interface {
 	// Send Botにイベントを送信します
 	Send(b *model.Bot, event model.BotEventType, body []byte) (ok bool)
+	// Redeliver 送信箱にある配送に失敗したBotイベントを再送します
+	Redeliver(b *model.Bot, delivery *model.BotEventDelivery) (ok bool)
 }
 
 // Unicast 単一のBOTにイベントを送信
@@ -2,6 +2,8 @@ package event
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/prometheus/client_golang/prometheus"
@@ -44,18 +46,67 @@ func NewDispatcher(logger *zap.Logger, repo repository.BotRepository, s *botWS.S
 func (d *dispatcherImpl) Send(b *model.Bot, event model.BotEventType, body []byte) (ok bool) {
 	reqID := uuid.Must(uuid.NewV7())
 
-	var log *model.BotEventLog
+	ok, log := d.send(b, event, reqID, body)
+	if log == nil {
+		return false
+	}
+	d.writeLog(log)
+
+	// HTTP Modeで配送に失敗したイベントは送信箱に入れて後で再送する
+	// PINGはBOTの疎通確認のため再送しない
+	if !ok && b.Mode == model.BotModeHTTP && event != Ping {
+		delivery := &model.BotEventDelivery{
+			RequestID: reqID,
+			BotID:     b.ID,
+			Event:     event,
+			Body:      string(body),
+			CreatedAt: log.DateTime,
+		}
+		delivery.RecordFailure(time.Now(), failureReason(log))
+		if err := d.repo.CreateBotEventDelivery(context.Background(), delivery); err != nil {
+			d.l.Warn("failed to create bot event delivery", zap.Error(err), zap.Stringer("requestId", reqID))
+		}
+	}
+	return ok
+}
+
+func (d *dispatcherImpl) Redeliver(b *model.Bot, delivery *model.BotEventDelivery) (ok bool) {
+	ok, log := d.send(b, delivery.Event, delivery.RequestID, []byte(delivery.Body))
+	if log == nil {
+		return false
+	}
+	d.writeLog(log)
+
+	if ok {
+		if err := d.repo.DeleteBotEventDelivery(context.Background(), delivery.RequestID); err != nil {
+			d.l.Warn("failed to delete bot event delivery", zap.Error(err), zap.Stringer("requestId", delivery.RequestID))
+		}
+		return true
+	}
+
+	dead := delivery.RecordFailure(time.Now(), failureReason(log))
+	if err := d.repo.UpdateBotEventDelivery(context.Background(), delivery); err != nil {
+		d.l.Warn("failed to update bot event delivery", zap.Error(err), zap.Stringer("requestId", delivery.RequestID))
+	}
+	if dead {
+		// 再送し続けても配送できないBOTは一時停止する
+		d.l.Info("bot event delivery is dead, pausing bot", zap.Stringer("botId", b.ID), zap.Stringer("requestId", delivery.RequestID))
+		if err := d.repo.ChangeBotState(context.Background(), b.ID, model.BotPaused); err != nil {
+			d.l.Warn("failed to pause bot", zap.Error(err), zap.Stringer("botId", b.ID))
+		}
+	}
+	return false
+}
+
+func (d *dispatcherImpl) send(b *model.Bot, event model.BotEventType, reqID uuid.UUID, body []byte) (ok bool, log *model.BotEventLog) {
 	switch b.Mode {
 	case model.BotModeHTTP:
-		ok, log = d.http.send(b, event, reqID, body)
+		return d.http.send(b, event, reqID, body)
 	case model.BotModeWebSocket:
-		ok, log = d.ws.send(b, event, reqID, body)
+		return d.ws.send(b, event, reqID, body)
 	default:
-		return false
+		return false, nil
 	}
-
-	d.writeLog(log)
-	return ok
 }
 
 func (d *dispatcherImpl) writeLog(log *model.BotEventLog) {
@@ -63,3 +114,14 @@ func (d *dispatcherImpl) writeLog(log *model.BotEventLog) {
 		d.l.Warn("failed to write log", zap.Error(err), zap.Any("eventLog", log))
 	}
 }
+
+func failureReason(log *model.BotEventLog) string {
+	switch {
+	case log.Error != "":
+		return log.Error
+	case log.Result == resultDropped:
+		return "no connection"
+	default:
+		return fmt.Sprintf("unexpected status code: %d", log.Code)
+	}
+}
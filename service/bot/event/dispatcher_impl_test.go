@@ -0,0 +1,195 @@
+package event
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+)
+
+func newTestBotServer(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestDispatcherImpl_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := NewDispatcher(zap.NewNop(), repo, nil)
+		s := newTestBotServer(t, http.StatusNoContent)
+		b := &model.Bot{ID: uuid.NewV3(uuid.Nil, "b"), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+		repo.EXPECT().
+			WriteBotEventLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, log *model.BotEventLog) error {
+				assert.Equal(t, resultOK, log.Result)
+				return nil
+			}).
+			Times(1)
+
+		assert.True(t, d.Send(b, MessageCreated, []byte("{}")))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := NewDispatcher(zap.NewNop(), repo, nil)
+		s := newTestBotServer(t, http.StatusInternalServerError)
+		b := &model.Bot{ID: uuid.NewV3(uuid.Nil, "b"), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+		var reqID uuid.UUID
+		repo.EXPECT().
+			WriteBotEventLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, log *model.BotEventLog) error {
+				assert.Equal(t, resultNG, log.Result)
+				reqID = log.RequestID
+				return nil
+			}).
+			Times(1)
+		repo.EXPECT().
+			CreateBotEventDelivery(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, delivery *model.BotEventDelivery) error {
+				assert.Equal(t, reqID, delivery.RequestID)
+				assert.Equal(t, b.ID, delivery.BotID)
+				assert.Equal(t, MessageCreated, delivery.Event)
+				assert.Equal(t, "{}", delivery.Body)
+				assert.Equal(t, model.BotEventDeliveryPending, delivery.State)
+				assert.Equal(t, 1, delivery.Attempts)
+				assert.Equal(t, "unexpected status code: 500", delivery.LastError)
+				return nil
+			}).
+			Times(1)
+
+		assert.False(t, d.Send(b, MessageCreated, []byte("{}")))
+	})
+
+	t.Run("failure (ping)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := NewDispatcher(zap.NewNop(), repo, nil)
+		s := newTestBotServer(t, http.StatusInternalServerError)
+		b := &model.Bot{ID: uuid.NewV3(uuid.Nil, "b"), Mode: model.BotModeHTTP, PostURL: s.URL}
+
+		repo.EXPECT().
+			WriteBotEventLog(gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		assert.False(t, d.Send(b, Ping, []byte("{}")))
+	})
+}
+
+func TestDispatcherImpl_Redeliver(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := NewDispatcher(zap.NewNop(), repo, nil)
+		s := newTestBotServer(t, http.StatusNoContent)
+		b := &model.Bot{ID: uuid.NewV3(uuid.Nil, "b"), Mode: model.BotModeHTTP, PostURL: s.URL}
+		delivery := &model.BotEventDelivery{
+			RequestID: uuid.NewV3(uuid.Nil, "r"),
+			BotID:     b.ID,
+			Event:     MessageCreated,
+			Body:      "{}",
+			State:     model.BotEventDeliveryPending,
+			Attempts:  3,
+		}
+
+		repo.EXPECT().
+			WriteBotEventLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, log *model.BotEventLog) error {
+				assert.Equal(t, delivery.RequestID, log.RequestID)
+				return nil
+			}).
+			Times(1)
+		repo.EXPECT().
+			DeleteBotEventDelivery(gomock.Any(), delivery.RequestID).
+			Return(nil).
+			Times(1)
+
+		assert.True(t, d.Redeliver(b, delivery))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := NewDispatcher(zap.NewNop(), repo, nil)
+		s := newTestBotServer(t, http.StatusBadGateway)
+		b := &model.Bot{ID: uuid.NewV3(uuid.Nil, "b"), Mode: model.BotModeHTTP, PostURL: s.URL}
+		delivery := &model.BotEventDelivery{
+			RequestID: uuid.NewV3(uuid.Nil, "r"),
+			BotID:     b.ID,
+			Event:     MessageCreated,
+			Body:      "{}",
+			State:     model.BotEventDeliveryPending,
+			Attempts:  3,
+		}
+
+		repo.EXPECT().
+			WriteBotEventLog(gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+		repo.EXPECT().
+			UpdateBotEventDelivery(gomock.Any(), delivery).
+			Return(nil).
+			Times(1)
+
+		assert.False(t, d.Redeliver(b, delivery))
+		assert.Equal(t, model.BotEventDeliveryPending, delivery.State)
+		assert.Equal(t, 4, delivery.Attempts)
+	})
+
+	t.Run("failure (dead)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockBotRepository(ctrl)
+		d := NewDispatcher(zap.NewNop(), repo, nil)
+		s := newTestBotServer(t, http.StatusBadGateway)
+		b := &model.Bot{ID: uuid.NewV3(uuid.Nil, "b"), Mode: model.BotModeHTTP, PostURL: s.URL}
+		delivery := &model.BotEventDelivery{
+			RequestID: uuid.NewV3(uuid.Nil, "r"),
+			BotID:     b.ID,
+			Event:     MessageCreated,
+			Body:      "{}",
+			State:     model.BotEventDeliveryPending,
+			Attempts:  model.BotEventDeliveryMaxAttempts - 1,
+		}
+
+		repo.EXPECT().
+			WriteBotEventLog(gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+		repo.EXPECT().
+			UpdateBotEventDelivery(gomock.Any(), delivery).
+			Return(nil).
+			Times(1)
+		repo.EXPECT().
+			ChangeBotState(gomock.Any(), b.ID, model.BotPaused).
+			Return(nil).
+			Times(1)
+
+		assert.False(t, d.Redeliver(b, delivery))
+		assert.Equal(t, model.BotEventDeliveryDead, delivery.State)
+	})
+}
@@ -29,6 +29,12 @@ const (
 	ChannelCreated model.BotEventType = "CHANNEL_CREATED"
 	// ChannelTopicChanged チャンネルトピック変更イベント
 	ChannelTopicChanged model.BotEventType = "CHANNEL_TOPIC_CHANGED"
+	// PollClosed 投票締め切りイベント
+	PollClosed model.BotEventType = "POLL_CLOSED"
+	// SlashCommand スラッシュコマンド呼び出しイベント
+	SlashCommand model.BotEventType = "SLASH_COMMAND"
+	// Interaction メッセージコンポーネント操作イベント
+	Interaction model.BotEventType = "INTERACTION"
 	// UserCreated ユーザー作成イベント
 	UserCreated model.BotEventType = "USER_CREATED"
 	// UserActivated ユーザー凍結解除イベント
@@ -76,6 +82,9 @@ func init() {
 		DirectMessageDeleted,
 		ChannelCreated,
 		ChannelTopicChanged,
+		PollClosed,
+		SlashCommand,
+		Interaction,
 		UserCreated,
 		UserActivated,
 		StampCreated,
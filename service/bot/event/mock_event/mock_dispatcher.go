@@ -34,6 +34,20 @@ func (m *MockDispatcher) EXPECT() *MockDispatcherMockRecorder {
 	return m.recorder
 }
 
+// Redeliver mocks base method.
+func (m *MockDispatcher) Redeliver(b *model.Bot, delivery *model.BotEventDelivery) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", b, delivery)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockDispatcherMockRecorder) Redeliver(b, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockDispatcher)(nil).Redeliver), b, delivery)
+}
+
 // Send mocks base method.
 func (m *MockDispatcher) Send(b *model.Bot, event model.BotEventType, body []byte) bool {
 	m.ctrl.T.Helper()
@@ -0,0 +1,37 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// Interaction INTERACTIONイベントペイロード
+type Interaction struct {
+	Base
+	User      User                 `json:"user"`
+	Message   Message              `json:"message"`
+	Component InteractionComponent `json:"component"`
+	Value     string               `json:"value"`
+}
+
+// InteractionComponent 操作されたメッセージコンポーネントの情報
+type InteractionComponent struct {
+	CustomID string                     `json:"customId"`
+	Type     model.MessageComponentType `json:"type"`
+}
+
+func MakeInteraction(et time.Time, user model.UserInfo, m *model.Message, author model.UserInfo, parsed *message.ParseResult, c *model.MessageComponent, value string) *Interaction {
+	embedded, _ := message.ExtractEmbedding(m.Text)
+	return &Interaction{
+		Base:    MakeBase(et),
+		User:    MakeUser(user),
+		Message: MakeMessage(m, author, embedded, parsed.PlainText),
+		Component: InteractionComponent{
+			CustomID: c.CustomID,
+			Type:     c.Type,
+		},
+		Value: value,
+	}
+}
@@ -0,0 +1,26 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// PollClosed POLL_CLOSEDイベントペイロード
+type PollClosed struct {
+	Base
+	MessageID uuid.UUID   `json:"messageId"`
+	ChannelID uuid.UUID   `json:"channelId"`
+	Poll      *model.Poll `json:"poll"`
+}
+
+func MakePollClosed(eventTime time.Time, mid, cid uuid.UUID, poll *model.Poll) *PollClosed {
+	return &PollClosed{
+		Base:      MakeBase(eventTime),
+		MessageID: mid,
+		ChannelID: cid,
+		Poll:      poll,
+	}
+}
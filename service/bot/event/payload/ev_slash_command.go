@@ -0,0 +1,42 @@
+package payload
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/traPtitech/traQ/model"
+)
+
+// SlashCommand SLASH_COMMANDイベントペイロード
+type SlashCommand struct {
+	Base
+	InvocationID uuid.UUID        `json:"invocationId"`
+	Command      SlashCommandInfo `json:"command"`
+	Arguments    map[string]any   `json:"arguments"`
+	ChannelID    uuid.UUID        `json:"channelId"`
+	User         User             `json:"user"`
+}
+
+// SlashCommandInfo 呼び出されたスラッシュコマンドの情報
+type SlashCommandInfo struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+func MakeSlashCommand(et time.Time, inv *model.BotCommandInvocation, cmd *model.BotCommand, args map[string]any, user model.UserInfo) *SlashCommand {
+	if args == nil {
+		args = map[string]any{}
+	}
+	return &SlashCommand{
+		Base:         MakeBase(et),
+		InvocationID: inv.ID,
+		Command: SlashCommandInfo{
+			ID:   cmd.ID,
+			Name: cmd.Name,
+		},
+		Arguments: args,
+		ChannelID: inv.ChannelID,
+		User:      MakeUser(user),
+	}
+}
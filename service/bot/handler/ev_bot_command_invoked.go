@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func BotCommandInvoked(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	inv := fields["invocation"].(*model.BotCommandInvocation)
+	cmd := fields["command"].(*model.BotCommand)
+	args := fields["arguments"].(map[string]any)
+
+	bot, err := ctx.GetBot(inv.BotID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBot: %w", err)
+	}
+	if bot == nil {
+		return nil
+	}
+
+	user, err := ctx.R().GetUser(context.Background(), inv.UserID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	// コマンドを登録したBOTにはイベントの購読に関わらず送る
+	if err := ctx.Unicast(
+		event.SlashCommand,
+		payload.MakeSlashCommand(datetime, inv, cmd, args, user),
+		bot,
+	); err != nil {
+		return fmt.Errorf("failed to unicast: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func TestBotCommandInvoked(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	cmd := &model.BotCommand{
+		ID:    uuid.NewV3(uuid.Nil, "cmd"),
+		BotID: b.ID,
+		Name:  "echo",
+	}
+	inv := &model.BotCommandInvocation{
+		ID:        uuid.NewV3(uuid.Nil, "inv"),
+		CommandID: cmd.ID,
+		BotID:     b.ID,
+		UserID:    u.ID,
+		ChannelID: uuid.NewV3(uuid.Nil, "c"),
+	}
+	args := map[string]any{"text": "hello"}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, u)
+
+		et := time.Now()
+
+		expectUnicast(handlerCtx, event.SlashCommand, payload.MakeSlashCommand(et, inv, cmd, args, u), b)
+		assert.NoError(t, BotCommandInvoked(handlerCtx, et, intevent.BotCommandInvoked, hub.Fields{
+			"invocation": inv,
+			"command":    cmd,
+			"arguments":  args,
+			"user_id":    u.ID,
+			"channel_id": inv.ChannelID,
+		}))
+	})
+
+	t.Run("inactive bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, _ := setup(t, ctrl)
+
+		handlerCtx.EXPECT().GetBot(b.ID).Return(nil, nil).AnyTimes()
+
+		assert.NoError(t, BotCommandInvoked(handlerCtx, time.Now(), intevent.BotCommandInvoked, hub.Fields{
+			"invocation": inv,
+			"command":    cmd,
+			"arguments":  args,
+			"user_id":    u.ID,
+			"channel_id": inv.ChannelID,
+		}))
+	})
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+func MessageComponentInteracted(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	mid := fields["message_id"].(uuid.UUID)
+	userID := fields["user_id"].(uuid.UUID)
+	component := fields["component"].(*model.MessageComponent)
+	value := fields["value"].(string)
+
+	m, err := ctx.R().GetMessageByID(context.Background(), mid)
+	if err != nil {
+		return fmt.Errorf("failed to GetMessageByID: %w", err)
+	}
+
+	// コンポーネントを添付したBOTにはイベントの購読に関わらず送る
+	bot, err := ctx.GetBotByBotUserID(m.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
+	}
+	if bot == nil {
+		return nil
+	}
+
+	user, err := ctx.R().GetUser(context.Background(), userID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+	author, err := ctx.R().GetUser(context.Background(), m.UserID, false)
+	if err != nil {
+		return fmt.Errorf("failed to GetUser: %w", err)
+	}
+
+	if err := ctx.Unicast(
+		event.Interaction,
+		payload.MakeInteraction(datetime, user, m, author, message.Parse(m.Text), component, value),
+		bot,
+	); err != nil {
+		return fmt.Errorf("failed to unicast: %w", err)
+	}
+	return nil
+}
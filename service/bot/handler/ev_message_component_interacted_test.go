@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+func TestMessageComponentInteracted(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypes{},
+		State:           model.BotActive,
+	}
+	bu := &model.User{
+		ID:   b.BotUserID,
+		Name: "BOT_test",
+		Bot:  true,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	m := &model.Message{
+		ID:        uuid.NewV3(uuid.Nil, "m"),
+		UserID:    bu.ID,
+		ChannelID: uuid.NewV3(uuid.Nil, "c"),
+		Text:      "choose one",
+	}
+	c := &model.MessageComponent{
+		ID:        uuid.NewV3(uuid.Nil, "mc"),
+		MessageID: m.ID,
+		CustomID:  "menu",
+		Type:      model.MessageComponentTypeSelect,
+		Options:   model.MessageComponentOptions{{Label: "A", Value: "a"}},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+
+		registerBot(t, handlerCtx, b)
+		registerUser(repo, bu)
+		registerUser(repo, u)
+		repo.MockMessageRepository.EXPECT().
+			GetMessageByID(gomock.Any(), m.ID).
+			Return(m, nil).
+			AnyTimes()
+
+		et := time.Now()
+
+		expectUnicast(handlerCtx, event.Interaction, payload.MakeInteraction(et, u, m, bu, message.Parse(m.Text), c, "a"), b)
+		assert.NoError(t, MessageComponentInteracted(handlerCtx, et, intevent.MessageComponentInteracted, hub.Fields{
+			"message_id": m.ID,
+			"user_id":    u.ID,
+			"component":  c,
+			"value":      "a",
+		}))
+	})
+
+	t.Run("not bot message", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+
+		m := &model.Message{
+			ID:        uuid.NewV3(uuid.Nil, "m2"),
+			UserID:    u.ID,
+			ChannelID: uuid.NewV3(uuid.Nil, "c"),
+		}
+
+		handlerCtx.EXPECT().
+			GetBotByBotUserID(u.ID).
+			Return(nil, nil).
+			AnyTimes()
+		repo.MockMessageRepository.EXPECT().
+			GetMessageByID(gomock.Any(), m.ID).
+			Return(m, nil).
+			AnyTimes()
+
+		assert.NoError(t, MessageComponentInteracted(handlerCtx, time.Now(), intevent.MessageComponentInteracted, hub.Fields{
+			"message_id": m.ID,
+			"user_id":    u.ID,
+			"component":  c,
+			"value":      "",
+		}))
+	})
+}
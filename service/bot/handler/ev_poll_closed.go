@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+)
+
+func PollClosed(ctx Context, datetime time.Time, _ string, fields hub.Fields) error {
+	mid := fields["message_id"].(uuid.UUID)
+	poll := fields["poll"].(*model.Poll)
+
+	m, err := ctx.R().GetMessageByID(context.Background(), mid)
+	if err != nil {
+		return fmt.Errorf("failed to GetMessageByID: %w", err)
+	}
+
+	bots, err := ctx.GetChannelBots(m.ChannelID, event.PollClosed)
+	if err != nil {
+		return fmt.Errorf("failed to GetChannelBots: %w", err)
+	}
+
+	// 投票を作成したBOTにはチャンネルに参加していなくても送る
+	creator, err := ctx.GetBotByBotUserID(poll.CreatorID)
+	if err != nil {
+		return fmt.Errorf("failed to GetBotByBotUserID: %w", err)
+	}
+	if creator != nil && creator.SubscribeEvents.Contains(event.PollClosed) &&
+		!slices.ContainsFunc(bots, func(b *model.Bot) bool { return b.ID == creator.ID }) {
+		bots = append(bots, creator)
+	}
+	if len(bots) == 0 {
+		return nil
+	}
+
+	if err := ctx.Multicast(
+		event.PollClosed,
+		payload.MakePollClosed(datetime, mid, m.ChannelID, poll),
+		bots,
+	); err != nil {
+		return fmt.Errorf("failed to multicast: %w", err)
+	}
+	return nil
+}
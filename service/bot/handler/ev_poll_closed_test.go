@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+
+	intevent "github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/bot/event"
+	"github.com/traPtitech/traQ/service/bot/event/payload"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestPollClosed(t *testing.T) {
+	t.Parallel()
+
+	b := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.PollClosed.String()}),
+		State:           model.BotActive,
+	}
+	b2 := &model.Bot{
+		ID:              uuid.NewV3(uuid.Nil, "b2"),
+		BotUserID:       uuid.NewV3(uuid.Nil, "bu2"),
+		SubscribeEvents: model.BotEventTypesFromArray([]string{event.PollClosed.String()}),
+		State:           model.BotActive,
+	}
+	u := &model.User{
+		ID:   uuid.NewV3(uuid.Nil, "u"),
+		Name: "testman",
+	}
+	m := &model.Message{
+		ID:        uuid.NewV3(uuid.Nil, "m"),
+		UserID:    u.ID,
+		ChannelID: uuid.NewV3(uuid.Nil, "c"),
+	}
+	newPoll := func(creatorID uuid.UUID) *model.Poll {
+		return &model.Poll{
+			ID:        uuid.NewV3(uuid.Nil, "p"),
+			MessageID: m.ID,
+			CreatorID: creatorID,
+			ClosedAt:  optional.From(time.Now()),
+		}
+	}
+
+	t.Run("channel bots", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+
+		registerBot(t, handlerCtx, b)
+		handlerCtx.EXPECT().
+			GetBotByBotUserID(u.ID).
+			Return(nil, nil).
+			AnyTimes()
+		repo.MockMessageRepository.EXPECT().
+			GetMessageByID(gomock.Any(), m.ID).
+			Return(m, nil).
+			AnyTimes()
+		handlerCtx.EXPECT().
+			GetChannelBots(m.ChannelID, event.PollClosed).
+			Return([]*model.Bot{b}, nil).
+			AnyTimes()
+
+		et := time.Now()
+		poll := newPoll(u.ID)
+
+		expectMulticast(handlerCtx, event.PollClosed, payload.MakePollClosed(et, m.ID, m.ChannelID, poll), []*model.Bot{b})
+		assert.NoError(t, PollClosed(handlerCtx, et, intevent.PollClosed, hub.Fields{
+			"poll_id":    poll.ID,
+			"message_id": m.ID,
+			"poll":       poll,
+		}))
+	})
+
+	t.Run("creator bot", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+
+		registerBot(t, handlerCtx, b)
+		registerBot(t, handlerCtx, b2)
+		repo.MockMessageRepository.EXPECT().
+			GetMessageByID(gomock.Any(), m.ID).
+			Return(m, nil).
+			AnyTimes()
+		handlerCtx.EXPECT().
+			GetChannelBots(m.ChannelID, event.PollClosed).
+			Return([]*model.Bot{b}, nil).
+			AnyTimes()
+
+		et := time.Now()
+		poll := newPoll(b2.BotUserID)
+
+		expectMulticast(handlerCtx, event.PollClosed, payload.MakePollClosed(et, m.ID, m.ChannelID, poll), []*model.Bot{b, b2})
+		assert.NoError(t, PollClosed(handlerCtx, et, intevent.PollClosed, hub.Fields{
+			"poll_id":    poll.ID,
+			"message_id": m.ID,
+			"poll":       poll,
+		}))
+	})
+
+	t.Run("creator bot already in channel", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		handlerCtx, _, repo := setup(t, ctrl)
+
+		registerBot(t, handlerCtx, b)
+		repo.MockMessageRepository.EXPECT().
+			GetMessageByID(gomock.Any(), m.ID).
+			Return(m, nil).
+			AnyTimes()
+		handlerCtx.EXPECT().
+			GetChannelBots(m.ChannelID, event.PollClosed).
+			Return([]*model.Bot{b}, nil).
+			AnyTimes()
+
+		et := time.Now()
+		poll := newPoll(b.BotUserID)
+
+		expectMulticast(handlerCtx, event.PollClosed, payload.MakePollClosed(et, m.ID, m.ChannelID, poll), []*model.Bot{b})
+		assert.NoError(t, PollClosed(handlerCtx, et, intevent.PollClosed, hub.Fields{
+			"poll_id":    poll.ID,
+			"message_id": m.ID,
+			"poll":       poll,
+		}))
+	})
+}
@@ -19,6 +19,7 @@ type Repo struct {
 	*mock_repository.MockTagRepository
 	*mock_repository.MockUserRepository
 	*mock_repository.MockBotRepository
+	*mock_repository.MockMessageRepository
 	testutils.EmptyTestRepository
 }
 
@@ -27,9 +28,10 @@ func setup(_ *testing.T, ctrl *gomock.Controller) (*mock_handler.MockContext, *m
 	cm := mock_channel.NewMockManager(ctrl)
 
 	repo := &Repo{
-		MockTagRepository:  mock_repository.NewMockTagRepository(ctrl),
-		MockUserRepository: mock_repository.NewMockUserRepository(ctrl),
-		MockBotRepository:  mock_repository.NewMockBotRepository(ctrl),
+		MockTagRepository:     mock_repository.NewMockTagRepository(ctrl),
+		MockUserRepository:    mock_repository.NewMockUserRepository(ctrl),
+		MockBotRepository:     mock_repository.NewMockBotRepository(ctrl),
+		MockMessageRepository: mock_repository.NewMockMessageRepository(ctrl),
 	}
 
 	handlerCtx.EXPECT().
@@ -10,6 +10,7 @@ import (
 	"github.com/lthibault/jitterbug/v2"
 	"go.uber.org/zap"
 
+	intevent "github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/bot/event"
@@ -18,7 +19,11 @@ import (
 )
 
 const (
-	botEventLogPurgeBefore = time.Hour * 24 * 365 // BOTイベントログを1年間保持
+	botEventLogPurgeBefore          = time.Hour * 24 * 365 // BOTイベントログを1年間保持
+	botCommandInvocationPurgeBefore = time.Hour * 24       // スラッシュコマンドの呼び出し記録を1日間保持
+	botEventDeliveryPurgeBefore     = time.Hour * 24 * 7   // 配送に失敗したBOTイベントを7日間保持
+	botEventRedeliverInterval       = time.Second * 10     // 配送に失敗したBOTイベントの再送を確認する間隔
+	botEventRedeliverBatchSize      = 100                  // 一度に再送するBOTイベントの最大数
 )
 
 type serviceImpl struct {
@@ -27,12 +32,17 @@ type serviceImpl struct {
 	logger     *zap.Logger
 	dispatcher event.Dispatcher
 	hub        *hub.Hub
+	ws         *botWS.Streamer
 
-	sub         hub.Subscription
-	logPurger   *jitterbug.Ticker
-	serviceDone chan struct{}
-	hubDone     chan struct{}
-	purgerDone  chan struct{}
+	sub             hub.Subscription
+	botSub          hub.Subscription
+	logPurger       *jitterbug.Ticker
+	redeliverTicker *time.Ticker
+	serviceDone     chan struct{}
+	hubDone         chan struct{}
+	botDone         chan struct{}
+	purgerDone      chan struct{}
+	redeliverDone   chan struct{}
 }
 
 // NewService ボットサービスを生成します
@@ -42,11 +52,14 @@ func NewService(repo repository.Repository, cm channel.Manager, hub *hub.Hub, s
 		cm:         cm,
 		logger:     logger.Named("bot"),
 		hub:        hub,
+		ws:         s,
 		dispatcher: event.NewDispatcher(logger, repo, s),
 
-		serviceDone: make(chan struct{}),
-		hubDone:     make(chan struct{}),
-		purgerDone:  make(chan struct{}),
+		serviceDone:   make(chan struct{}),
+		hubDone:       make(chan struct{}),
+		botDone:       make(chan struct{}),
+		purgerDone:    make(chan struct{}),
+		redeliverDone: make(chan struct{}),
 	}
 	p.start()
 	return p
@@ -79,6 +92,15 @@ func (p *serviceImpl) start() {
 		wg.Wait()
 	}()
 
+	// BOTの削除・モード変更時に、WebSocket Mode用の再送バッファを破棄
+	p.botSub = p.hub.Subscribe(10, intevent.BotUpdated, intevent.BotDeleted)
+	go func() {
+		defer close(p.botDone)
+		for ev := range p.botSub.Receiver {
+			p.discardEventBuffer(ev)
+		}
+	}()
+
 	// BOTイベントログの定期的消去
 	p.logPurger = jitterbug.New(time.Hour*24, &jitterbug.Uniform{
 		Min: time.Hour * 23,
@@ -94,6 +116,26 @@ func (p *serviceImpl) start() {
 				if err := p.repo.PurgeBotEventLogs(context.Background(), time.Now().Add(-botEventLogPurgeBefore)); err != nil {
 					p.logger.Error("an error occurred while purging old bot event logs", zap.Error(err))
 				}
+				if err := p.repo.PurgeBotCommandInvocations(context.Background(), time.Now().Add(-botCommandInvocationPurgeBefore)); err != nil {
+					p.logger.Error("an error occurred while purging old bot command invocations", zap.Error(err))
+				}
+				if err := p.repo.PurgeBotEventDeliveries(context.Background(), time.Now().Add(-botEventDeliveryPurgeBefore)); err != nil {
+					p.logger.Error("an error occurred while purging old bot event deliveries", zap.Error(err))
+				}
+			case <-p.serviceDone:
+				return
+			}
+		}
+	}()
+
+	// 配送に失敗したBOTイベントの定期的な再送
+	p.redeliverTicker = time.NewTicker(botEventRedeliverInterval)
+	go func() {
+		defer close(p.redeliverDone)
+		for {
+			select {
+			case <-p.redeliverTicker.C:
+				p.redeliverEvents()
 			case <-p.serviceDone:
 				return
 			}
@@ -105,13 +147,73 @@ func (p *serviceImpl) start() {
 
 func (p *serviceImpl) Shutdown(_ context.Context) error {
 	p.hub.Unsubscribe(p.sub)
+	p.hub.Unsubscribe(p.botSub)
 	p.logPurger.Stop()
+	p.redeliverTicker.Stop()
 	close(p.serviceDone)
 	<-p.hubDone
+	<-p.botDone
 	<-p.purgerDone
+	<-p.redeliverDone
 	return nil
 }
 
+// discardEventBuffer 削除されたか、WebSocket ModeでなくなったBOTの再送用バッファを破棄します
+func (p *serviceImpl) discardEventBuffer(ev hub.Message) {
+	switch ev.Name {
+	case intevent.BotDeleted:
+		p.ws.DiscardEventBuffer(ev.Fields["bot_user_id"].(uuid.UUID))
+	case intevent.BotUpdated:
+		botID := ev.Fields["bot_id"].(uuid.UUID)
+		b, err := p.repo.GetBotByID(context.Background(), botID)
+		if err != nil {
+			if err != repository.ErrNotFound {
+				p.logger.Error("an error occurred while fetching bot", zap.Error(err), zap.Stringer("botId", botID))
+			}
+			return
+		}
+		if b.Mode != model.BotModeWebSocket {
+			p.ws.DiscardEventBuffer(b.BotUserID)
+		}
+	}
+}
+
+// redeliverEvents 再送予定時刻を過ぎたBOTイベントを再送します
+//
+// 同じBOTへのイベントは古い順に1つずつ、異なるBOTへのイベントは並行して再送します
+func (p *serviceImpl) redeliverEvents() {
+	deliveries, err := p.repo.GetDueBotEventDeliveries(context.Background(), time.Now(), botEventRedeliverBatchSize)
+	if err != nil {
+		p.logger.Error("an error occurred while fetching bot event deliveries", zap.Error(err))
+		return
+	}
+
+	byBot := make(map[uuid.UUID][]*model.BotEventDelivery)
+	for _, delivery := range deliveries {
+		byBot[delivery.BotID] = append(byBot[delivery.BotID], delivery)
+	}
+
+	var wg sync.WaitGroup
+	for botID, deliveries := range byBot {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b, err := p.GetBot(botID)
+			if err != nil {
+				p.logger.Error("an error occurred while fetching bot", zap.Error(err), zap.Stringer("botId", botID))
+				return
+			}
+			for _, delivery := range deliveries {
+				if b == nil || !p.dispatcher.Redeliver(b, delivery) {
+					// BOTが無効か、配送に失敗した場合は以降のイベントを次回に回す
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func (p *serviceImpl) CM() channel.Manager {
 	return p.cm
 }
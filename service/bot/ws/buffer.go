@@ -0,0 +1,70 @@
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// bufferedEvent 再送のために保持しているイベント
+type bufferedEvent struct {
+	seq  uint64
+	at   time.Time
+	data []byte
+}
+
+// eventBuffer BOTごとのイベントの連番と再送用バッファ
+//
+// 連番の払い出しからセッションへの書き込みまでをmuで保護し、イベントの順序を保証する
+type eventBuffer struct {
+	mu sync.Mutex
+	// epoch 連番の系列の識別子
+	//
+	// 連番はプロセス内でのみ有効なため、再起動やバッファの破棄で連番が振り直された後に
+	// 古い連番でresumeされても無関係なイベントを再送しないようにする
+	epoch  uuid.UUID
+	seq    uint64
+	events []*bufferedEvent
+}
+
+func newEventBuffer() *eventBuffer {
+	return &eventBuffer{epoch: uuid.Must(uuid.NewV4())}
+}
+
+// push 新しい連番を払い出し、makeDataで生成したイベントを保持します
+func (b *eventBuffer) push(now time.Time, makeData func(seq uint64) []byte) *bufferedEvent {
+	b.seq++
+	ev := &bufferedEvent{
+		seq:  b.seq,
+		at:   now,
+		data: makeData(b.seq),
+	}
+	b.events = append(b.events, ev)
+	b.trim(now)
+	return ev
+}
+
+// trim 保持期間を過ぎたイベントと、最大数を超えた古いイベントを破棄します
+func (b *eventBuffer) trim(now time.Time) {
+	n := 0
+	for n < len(b.events) && (len(b.events)-n > eventBufferSize || now.Sub(b.events[n].at) > eventBufferTTL) {
+		n++
+	}
+	if n > 0 {
+		b.events = append([]*bufferedEvent(nil), b.events[n:]...)
+	}
+}
+
+// since 連番がseqより大きいイベントを返します
+//
+// 破棄されたためにseqの直後から再送できない場合、lostはtrueになります
+func (b *eventBuffer) since(now time.Time, seq uint64) (events []*bufferedEvent, lost bool) {
+	b.trim(now)
+	for i, ev := range b.events {
+		if ev.seq > seq {
+			return b.events[i:], ev.seq > seq+1
+		}
+	}
+	return nil, seq < b.seq
+}
@@ -0,0 +1,73 @@
+package ws
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seqs(events []*bufferedEvent) []uint64 {
+	res := make([]uint64, len(events))
+	for i, ev := range events {
+		res[i] = ev.seq
+	}
+	return res
+}
+
+func TestEventBuffer(t *testing.T) {
+	t.Parallel()
+
+	makeData := func(seq uint64) []byte { return []byte(strconv.FormatUint(seq, 10)) }
+
+	t.Run("since", func(t *testing.T) {
+		t.Parallel()
+		b := &eventBuffer{}
+		now := time.Now()
+		for range 3 {
+			b.push(now, makeData)
+		}
+
+		events, lost := b.since(now, 1)
+		assert.Equal(t, []uint64{2, 3}, seqs(events))
+		assert.Equal(t, "2", string(events[0].data))
+		assert.False(t, lost)
+
+		events, lost = b.since(now, 3)
+		assert.Empty(t, events)
+		assert.False(t, lost)
+	})
+
+	t.Run("discarded by count", func(t *testing.T) {
+		t.Parallel()
+		b := &eventBuffer{}
+		now := time.Now()
+		for range eventBufferSize + 5 {
+			b.push(now, makeData)
+		}
+
+		assert.Len(t, b.events, eventBufferSize)
+		events, lost := b.since(now, 0)
+		assert.Len(t, events, eventBufferSize)
+		assert.EqualValues(t, 6, events[0].seq)
+		assert.True(t, lost)
+	})
+
+	t.Run("discarded by time", func(t *testing.T) {
+		t.Parallel()
+		b := &eventBuffer{}
+		now := time.Now()
+		b.push(now.Add(-eventBufferTTL-time.Second), makeData)
+		b.push(now.Add(-eventBufferTTL-time.Second), makeData)
+
+		events, lost := b.since(now, 1)
+		assert.Empty(t, events)
+		assert.True(t, lost)
+
+		b.push(now, makeData)
+		events, lost = b.since(now, 2)
+		assert.Equal(t, []uint64{3}, seqs(events))
+		assert.False(t, lost)
+	})
+}
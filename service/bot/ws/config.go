@@ -12,8 +12,18 @@ const (
 	writeWait          = 5 * time.Second
 	pongWait           = 60 * time.Second
 	pingPeriod         = (pongWait * 9) / 10
-	maxReadMessageSize = 1 << 9 // 512B
+	maxReadMessageSize = 1 << 16 // 64KB
 	messageBufferSize  = 256
+	// eventBufferSize 再送のためにBOTごとに保持するイベントの最大数
+	//
+	// 再送時にまとめて送信バッファに書き込むため、messageBufferSizeより小さくすること
+	eventBufferSize = 200
+	// eventBufferTTL 再送のためにイベントを保持する期間
+	eventBufferTTL = 10 * time.Minute
+	// requestTimeout BOTからのリクエストの処理のタイムアウト
+	requestTimeout = 30 * time.Second
+	// requestQueueSize セッションごとに処理待ちにできるリクエストの最大数
+	requestQueueSize = 16
 )
 
 var (
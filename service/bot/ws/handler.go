@@ -2,6 +2,7 @@ package ws
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/gofrs/uuid"
@@ -9,6 +10,16 @@ import (
 )
 
 func (s *session) commandHandler(cmd string) {
+	if strings.HasPrefix(strings.TrimSpace(cmd), "{") {
+		// JSON形式のリクエスト
+		select {
+		case s.requests <- []byte(cmd):
+		default:
+			s.sendErrorMessage("too many pending requests")
+		}
+		return
+	}
+
 	args := strings.Split(strings.TrimSpace(cmd), ":")
 
 Command:
@@ -71,6 +82,27 @@ Command:
 
 		_ = s.streamer.webrtc.SetState(s.key, s.userID, cid, sessions)
 
+	case "resume":
+		// resume:{最後に受信したイベントのepoch}:{最後に受信したイベントの連番}
+		if len(args) != 3 {
+			// 引数が不正
+			s.sendErrorMessage(fmt.Sprintf("invalid args: %s", cmd))
+			break
+		}
+		epoch, err := uuid.FromString(args[1])
+		if err != nil {
+			// 系列の識別子が不正
+			s.sendErrorMessage(fmt.Sprintf("invalid epoch: %s", args[1]))
+			break
+		}
+		seq, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			// 連番が不正
+			s.sendErrorMessage(fmt.Sprintf("invalid sequence: %s", args[2]))
+			break
+		}
+		s.streamer.resume(s, epoch, seq)
+
 	default:
 		// 不明なコマンド
 		s.sendErrorMessage(fmt.Sprintf("unknown command: %s", cmd))
@@ -16,13 +16,17 @@ func (m marshalledRaw) MarshalJSON() ([]byte, error) {
 type eventMessage struct {
 	Type  string        `json:"type"`
 	ReqID uuid.UUID     `json:"reqId"`
+	Epoch uuid.UUID     `json:"epoch"`
+	Seq   uint64        `json:"seq"`
 	Body  marshalledRaw `json:"body"`
 }
 
-func makeEventMessage(t string, reqID uuid.UUID, b []byte) (m *eventMessage) {
+func makeEventMessage(t string, reqID uuid.UUID, epoch uuid.UUID, seq uint64, b []byte) (m *eventMessage) {
 	return &eventMessage{
 		Type:  t,
 		ReqID: reqID,
+		Epoch: epoch,
+		Seq:   seq,
 		Body:  b,
 	}
 }
@@ -48,3 +52,30 @@ func (m *errorMessage) toJSON() (b []byte) {
 	b, _ = json.Marshal(m)
 	return
 }
+
+type responseMessage struct {
+	Type  string      `json:"type"`
+	ReqID string      `json:"reqId"`
+	Body  interface{} `json:"body"`
+}
+
+func makeResponseMessage(reqID string, b interface{}) (m *responseMessage) {
+	return &responseMessage{
+		Type:  "RESPONSE",
+		ReqID: reqID,
+		Body:  b,
+	}
+}
+
+func makeResponseErrorMessage(reqID string, err *requestError) (m *responseMessage) {
+	return &responseMessage{
+		Type:  "RESPONSE_ERROR",
+		ReqID: reqID,
+		Body:  err,
+	}
+}
+
+func (m *responseMessage) toJSON() (b []byte) {
+	b, _ = json.Marshal(m)
+	return
+}
@@ -0,0 +1,471 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	vd "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/gofrs/uuid"
+	"github.com/gorilla/websocket"
+	jsonIter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+)
+
+// requestMessage BOTからのリクエスト
+type requestMessage struct {
+	Type  string              `json:"type"`
+	ReqID string              `json:"reqId"`
+	Body  jsonIter.RawMessage `json:"body"`
+}
+
+// requestError リクエストのエラー
+type requestError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *requestError) Error() string {
+	return e.Message
+}
+
+func badRequest(message string) error {
+	return &requestError{Code: http.StatusBadRequest, Message: message}
+}
+
+func unauthorized(message string) error {
+	return &requestError{Code: http.StatusUnauthorized, Message: message}
+}
+
+func forbidden(message string) error {
+	return &requestError{Code: http.StatusForbidden, Message: message}
+}
+
+func notFound() error {
+	return &requestError{Code: http.StatusNotFound, Message: http.StatusText(http.StatusNotFound)}
+}
+
+// requestContext リクエストを送信したBOTの情報
+type requestContext struct {
+	userID uuid.UUID
+	scopes model.AccessScopes
+	user   model.UserInfo
+}
+
+type postMessageRequest struct {
+	ChannelID uuid.UUID `json:"channelId"`
+	Content   string    `json:"content"`
+	Embed     bool      `json:"embed"`
+}
+
+type editMessageRequest struct {
+	MessageID uuid.UUID `json:"messageId"`
+	Content   string    `json:"content"`
+	Embed     bool      `json:"embed"`
+}
+
+type deleteMessageRequest struct {
+	MessageID uuid.UUID `json:"messageId"`
+}
+
+type addMessageStampRequest struct {
+	MessageID uuid.UUID `json:"messageId"`
+	StampID   uuid.UUID `json:"stampId"`
+	Count     int       `json:"count"`
+}
+
+type channelActionRequest struct {
+	ChannelID uuid.UUID `json:"channelId"`
+}
+
+// handleRequest リクエストを実行し、結果をセッションに返信します
+func (s *session) handleRequest(data []byte) {
+	var req requestMessage
+	if err := json.Unmarshal(data, &req); err != nil {
+		s.sendErrorMessage(fmt.Sprintf("invalid request: %s", err.Error()))
+		return
+	}
+	if err := vd.Validate(req.ReqID, vd.Required, vd.RuneLength(1, 64)); err != nil {
+		s.sendErrorMessage(fmt.Sprintf("invalid reqId: %s", err.Error()))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	res, err := s.streamer.executeRequest(ctx, s.userID, s.tokenID, req.Type, req.Body)
+	if err != nil {
+		var reqErr *requestError
+		if !errors.As(err, &reqErr) {
+			s.streamer.logger.Error("an error occurred while executing bot ws request",
+				zap.Error(err),
+				zap.String("type", req.Type),
+				zap.Stringer("userID", s.userID))
+			reqErr = &requestError{Code: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError)}
+		}
+		s.sendResponse(makeResponseErrorMessage(req.ReqID, reqErr).toJSON())
+		if reqErr.Code == http.StatusUnauthorized {
+			// 接続に使用したトークンが無効になったので切断する
+			_ = s.WriteMessage(&rawMessage{t: websocket.CloseMessage, data: websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reqErr.Message)})
+		}
+		return
+	}
+	s.sendResponse(makeResponseMessage(req.ReqID, res).toJSON())
+}
+
+func (s *session) sendResponse(data []byte) {
+	if err := s.WriteMessage(&rawMessage{t: websocket.TextMessage, data: data}); err != nil {
+		s.streamer.logger.Warn("failed to send bot ws response", zap.Error(err), zap.Stringer("userID", s.userID))
+	}
+}
+
+// executeRequest REST APIと同じ権限確認を行い、リクエストを実行します
+//
+// tokenIDは接続に使用したOAuth2トークンのIDで、OAuth2トークンを使用していない場合はuuid.Nilです
+func (s *Streamer) executeRequest(ctx context.Context, userID, tokenID uuid.UUID, t string, body []byte) (any, error) {
+	// REST APIと同様にリクエストごとにトークンとユーザーの状態を確認する
+	var scopes model.AccessScopes
+	if tokenID != uuid.Nil {
+		token, err := s.repo.GetTokenByID(ctx, tokenID)
+		if err != nil {
+			if err == repository.ErrNotFound {
+				// 取り消された
+				return nil, unauthorized("invalid token")
+			}
+			return nil, err
+		}
+		if token.IsExpired() {
+			return nil, unauthorized("invalid token")
+		}
+		// スコープによる権限確認を行うため、nilとスコープなしを区別する
+		scopes = token.Scopes
+		if scopes == nil {
+			scopes = model.AccessScopes{}
+		}
+	}
+	user, err := s.repo.GetUser(ctx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive() {
+		return nil, forbidden("this account is currently suspended")
+	}
+	rc := &requestContext{userID: userID, scopes: scopes, user: user}
+
+	switch t {
+	case "postMessage":
+		var req postMessageRequest
+		if err := unmarshalRequestBody(body, &req); err != nil {
+			return nil, err
+		}
+		return s.postMessage(ctx, rc, &req)
+	case "editMessage":
+		var req editMessageRequest
+		if err := unmarshalRequestBody(body, &req); err != nil {
+			return nil, err
+		}
+		return nil, s.editMessage(ctx, rc, &req)
+	case "deleteMessage":
+		var req deleteMessageRequest
+		if err := unmarshalRequestBody(body, &req); err != nil {
+			return nil, err
+		}
+		return nil, s.deleteMessage(ctx, rc, &req)
+	case "addMessageStamp":
+		var req addMessageStampRequest
+		if err := unmarshalRequestBody(body, &req); err != nil {
+			return nil, err
+		}
+		return nil, s.addMessageStamp(ctx, rc, &req)
+	case "joinChannel":
+		var req channelActionRequest
+		if err := unmarshalRequestBody(body, &req); err != nil {
+			return nil, err
+		}
+		return nil, s.joinChannel(ctx, rc, &req)
+	case "leaveChannel":
+		var req channelActionRequest
+		if err := unmarshalRequestBody(body, &req); err != nil {
+			return nil, err
+		}
+		return nil, s.leaveChannel(ctx, rc, &req)
+	default:
+		return nil, badRequest(fmt.Sprintf("unknown request type: %s", t))
+	}
+}
+
+func unmarshalRequestBody(body []byte, v any) error {
+	if len(body) == 0 {
+		return badRequest("body is required")
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return badRequest(fmt.Sprintf("invalid body: %s", err.Error()))
+	}
+	return nil
+}
+
+// requires REST APIのアクセスコントロールミドルウェアと同様に権限を確認します
+func (s *Streamer) requires(rc *requestContext, t string, perms ...permission.Permission) error {
+	for _, p := range perms {
+		// OAuth2スコープ権限検証
+		if rc.scopes != nil && !s.rbac.IsAnyGranted(rc.scopes.StringArray(), p) {
+			return forbidden(fmt.Sprintf("you are not permitted to request to '%s'", t))
+		}
+		// ユーザー権限検証
+		if !s.rbac.IsGranted(rc.user.GetRole(), p) {
+			return forbidden(fmt.Sprintf("you are not permitted to request to '%s'", t))
+		}
+	}
+	return nil
+}
+
+// getAccessibleChannel 閲覧可能なチャンネルを取得します
+func (s *Streamer) getAccessibleChannel(ctx context.Context, rc *requestContext, channelID uuid.UUID) (*model.Channel, error) {
+	if channelID == uuid.Nil {
+		return nil, notFound()
+	}
+	ch, err := s.cm.GetChannel(ctx, channelID)
+	if err != nil {
+		if err == channel.ErrChannelNotFound {
+			return nil, notFound()
+		}
+		return nil, err
+	}
+	if ok, err := s.cm.IsChannelAccessibleToUser(ctx, rc.userID, ch.ID); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, notFound()
+	}
+	return ch, nil
+}
+
+// getAccessibleMessage 閲覧可能なメッセージを取得します
+func (s *Streamer) getAccessibleMessage(ctx context.Context, rc *requestContext, messageID uuid.UUID) (message.Message, error) {
+	if messageID == uuid.Nil {
+		return nil, notFound()
+	}
+	m, err := s.mm.Get(ctx, messageID)
+	if err != nil {
+		if err == message.ErrNotFound {
+			return nil, notFound()
+		}
+		return nil, err
+	}
+	if ok, err := s.cm.IsChannelAccessibleToUser(ctx, rc.userID, m.GetChannelID()); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, notFound()
+	}
+	return m, nil
+}
+
+func validateContent(content string) error {
+	if err := vd.Validate(content, vd.Required, vd.RuneLength(1, 10000)); err != nil {
+		return badRequest(fmt.Sprintf("content: %s", err.Error()))
+	}
+	return nil
+}
+
+// postMessage POST /channels/:channelID/messages と同等
+func (s *Streamer) postMessage(ctx context.Context, rc *requestContext, req *postMessageRequest) (message.Message, error) {
+	ch, err := s.getAccessibleChannel(ctx, rc, req.ChannelID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requires(rc, "postMessage", permission.PostMessage); err != nil {
+		return nil, err
+	}
+	if err := validateContent(req.Content); err != nil {
+		return nil, err
+	}
+
+	if req.Embed {
+		req.Content = s.replacer.Replace(req.Content)
+	}
+
+	m, err := s.mm.Create(ctx, ch.ID, rc.userID, req.Content)
+	if err != nil {
+		if err == message.ErrChannelArchived {
+			return nil, badRequest("this channel has been archived")
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// editMessage PUT /messages/:messageID と同等
+func (s *Streamer) editMessage(ctx context.Context, rc *requestContext, req *editMessageRequest) error {
+	m, err := s.getAccessibleMessage(ctx, rc, req.MessageID)
+	if err != nil {
+		return err
+	}
+	if err := s.requires(rc, "editMessage", permission.EditMessage); err != nil {
+		return err
+	}
+	if err := validateContent(req.Content); err != nil {
+		return err
+	}
+
+	// 他人のテキストは編集できない
+	if rc.userID != m.GetUserID() {
+		return forbidden("This is not your message")
+	}
+
+	if req.Embed {
+		req.Content = s.replacer.Replace(req.Content)
+	}
+
+	if err := s.mm.Edit(ctx, m.GetID(), req.Content); err != nil {
+		if err == message.ErrChannelArchived {
+			return badRequest("the channel of this message has been archived")
+		}
+		return err
+	}
+	return nil
+}
+
+// deleteMessage DELETE /messages/:messageID と同等
+func (s *Streamer) deleteMessage(ctx context.Context, rc *requestContext, req *deleteMessageRequest) error {
+	m, err := s.getAccessibleMessage(ctx, rc, req.MessageID)
+	if err != nil {
+		return err
+	}
+	if err := s.requires(rc, "deleteMessage", permission.DeleteMessage); err != nil {
+		return err
+	}
+
+	if muid := m.GetUserID(); muid != rc.userID {
+		mUser, err := s.repo.GetUser(ctx, muid, false)
+		if err != nil {
+			return err
+		}
+
+		switch mUser.GetUserType() {
+		case model.UserTypeHuman:
+			return forbidden("you are not allowed to delete this message")
+		case model.UserTypeBot:
+			// BOTのメッセージの削除権限の確認
+			b, err := s.repo.GetBotByBotUserID(ctx, mUser.GetID())
+			if err != nil {
+				if err == repository.ErrNotFound { // deleted bot
+					return forbidden("you are not allowed to delete this message")
+				}
+				return err
+			}
+			if b.CreatorID != rc.userID {
+				return forbidden("you are not allowed to delete this message")
+			}
+		case model.UserTypeWebhook:
+			// Webhookのメッセージの削除権限の確認
+			wh, err := s.repo.GetWebhookByBotUserID(ctx, mUser.GetID())
+			if err != nil {
+				if err == repository.ErrNotFound { // deleted webhook
+					return forbidden("you are not allowed to delete this message")
+				}
+				return err
+			}
+			if wh.GetCreatorID() != rc.userID {
+				return forbidden("you are not allowed to delete this message")
+			}
+		}
+	}
+
+	if err := s.mm.Delete(ctx, m.GetID()); err != nil {
+		if err == message.ErrChannelArchived {
+			return badRequest("the channel of this message has been archived")
+		}
+		return err
+	}
+	return nil
+}
+
+// addMessageStamp POST /messages/:messageID/stamps/:stampID と同等
+func (s *Streamer) addMessageStamp(ctx context.Context, rc *requestContext, req *addMessageStampRequest) error {
+	m, err := s.getAccessibleMessage(ctx, rc, req.MessageID)
+	if err != nil {
+		return err
+	}
+	if ok, err := s.repo.StampExists(ctx, req.StampID); err != nil {
+		return err
+	} else if !ok {
+		return notFound()
+	}
+	if err := s.requires(rc, "addMessageStamp", permission.AddMessageStamp); err != nil {
+		return err
+	}
+	if req.Count == 0 {
+		req.Count = 1
+	}
+	if err := vd.Validate(req.Count, vd.Min(1), vd.Max(100)); err != nil {
+		return badRequest(fmt.Sprintf("count: %s", err.Error()))
+	}
+
+	// スタンプをメッセージに押す
+	if _, err := s.mm.AddStamps(ctx, m.GetID(), req.StampID, rc.userID, req.Count); err != nil {
+		if err == message.ErrChannelArchived {
+			return badRequest("the channel of this message has been archived")
+		}
+		return err
+	}
+	return nil
+}
+
+// getRequestBot リクエストを送信したBOTを取得します
+func (s *Streamer) getRequestBot(ctx context.Context, rc *requestContext) (*model.Bot, error) {
+	b, err := s.repo.GetBotByBotUserID(ctx, rc.userID)
+	if err != nil {
+		if err == repository.ErrNotFound {
+			return nil, notFound()
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func validateChannelAction(req *channelActionRequest) error {
+	if req.ChannelID == uuid.Nil {
+		return badRequest("channelId: cannot be blank")
+	}
+	return nil
+}
+
+// joinChannel POST /bots/:botID/actions/join と同等
+func (s *Streamer) joinChannel(ctx context.Context, rc *requestContext, req *channelActionRequest) error {
+	b, err := s.getRequestBot(ctx, rc)
+	if err != nil {
+		return err
+	}
+	if err := s.requires(rc, "joinChannel", permission.BotActionJoinChannel); err != nil {
+		return err
+	}
+	if err := validateChannelAction(req); err != nil {
+		return err
+	}
+
+	// 参加
+	return s.repo.AddBotToChannel(ctx, b.ID, req.ChannelID)
+}
+
+// leaveChannel POST /bots/:botID/actions/leave と同等
+func (s *Streamer) leaveChannel(ctx context.Context, rc *requestContext, req *channelActionRequest) error {
+	b, err := s.getRequestBot(ctx, rc)
+	if err != nil {
+		return err
+	}
+	if err := s.requires(rc, "leaveChannel", permission.BotActionLeaveChannel); err != nil {
+		return err
+	}
+	if err := validateChannelAction(req); err != nil {
+		return err
+	}
+
+	// 退出
+	return s.repo.RemoveBotFromChannel(ctx, b.ID, req.ChannelID)
+}
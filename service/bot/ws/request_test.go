@@ -0,0 +1,328 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/testutils"
+)
+
+type Repo struct {
+	*mock_repository.MockUserRepository
+	*mock_repository.MockBotRepository
+	*mock_repository.MockOAuth2Repository
+	testutils.EmptyTestRepository
+}
+
+type fakeMessage struct {
+	message.Message
+	id        uuid.UUID
+	userID    uuid.UUID
+	channelID uuid.UUID
+}
+
+func (m *fakeMessage) GetID() uuid.UUID        { return m.id }
+func (m *fakeMessage) GetUserID() uuid.UUID    { return m.userID }
+func (m *fakeMessage) GetChannelID() uuid.UUID { return m.channelID }
+
+type fakeMessageManager struct {
+	message.Manager
+	err      error
+	messages map[uuid.UUID]*fakeMessage
+	created  []string
+	edited   []string
+}
+
+func (m *fakeMessageManager) Get(_ context.Context, id uuid.UUID) (message.Message, error) {
+	msg, ok := m.messages[id]
+	if !ok {
+		return nil, message.ErrNotFound
+	}
+	return msg, nil
+}
+
+func (m *fakeMessageManager) Create(_ context.Context, channelID, userID uuid.UUID, content string) (message.Message, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.created = append(m.created, content)
+	return &fakeMessage{id: uuid.NewV3(uuid.Nil, content), userID: userID, channelID: channelID}, nil
+}
+
+func (m *fakeMessageManager) Edit(_ context.Context, _ uuid.UUID, content string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.edited = append(m.edited, content)
+	return nil
+}
+
+type fakeRBAC struct {
+	rbac.RBAC
+	denied permission.Permission
+}
+
+func (r *fakeRBAC) IsGranted(_ string, p permission.Permission) bool {
+	return p != r.denied
+}
+
+func (r *fakeRBAC) IsAnyGranted(roles []string, p permission.Permission) bool {
+	for _, role := range roles {
+		if role == string(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func setupRequestTest(t *testing.T, user *model.User) (*Streamer, *Repo, *mock_channel.MockManager, *fakeMessageManager, *fakeRBAC) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := &Repo{
+		MockUserRepository:   mock_repository.NewMockUserRepository(ctrl),
+		MockBotRepository:    mock_repository.NewMockBotRepository(ctrl),
+		MockOAuth2Repository: mock_repository.NewMockOAuth2Repository(ctrl),
+	}
+	repo.MockUserRepository.
+		EXPECT().
+		GetUser(gomock.Any(), user.ID, false).
+		Return(user, nil).
+		AnyTimes()
+	cm := mock_channel.NewMockManager(ctrl)
+	mm := &fakeMessageManager{messages: map[uuid.UUID]*fakeMessage{}}
+	r := &fakeRBAC{}
+	s := NewStreamer(nil, nil, repo, cm, mm, r, nil, zap.NewNop())
+	return s, repo, cm, mm, r
+}
+
+func assertRequestError(t *testing.T, code int, err error) {
+	t.Helper()
+	var reqErr *requestError
+	if assert.True(t, errors.As(err, &reqErr), "unexpected error: %v", err) {
+		assert.Equal(t, code, reqErr.Code)
+	}
+}
+
+func TestStreamer_executeRequest(t *testing.T) {
+	t.Parallel()
+
+	botUser := &model.User{ID: uuid.NewV3(uuid.Nil, "bot"), Status: model.UserAccountStatusActive, Bot: true, Role: "bot"}
+	ch := &model.Channel{ID: uuid.NewV3(uuid.Nil, "c1")}
+	tokenID := uuid.NewV3(uuid.Nil, "token")
+
+	t.Run("suspended", func(t *testing.T) {
+		t.Parallel()
+		suspended := &model.User{ID: uuid.NewV3(uuid.Nil, "suspended"), Status: model.UserAccountStatusDeactivated, Bot: true}
+		s, _, _, _, _ := setupRequestTest(t, suspended)
+
+		_, err := s.executeRequest(context.Background(), suspended.ID, uuid.Nil, "postMessage", []byte(`{}`))
+		assertRequestError(t, http.StatusForbidden, err)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		t.Parallel()
+		s, _, _, _, _ := setupRequestTest(t, botUser)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "unknown", []byte(`{}`))
+		assertRequestError(t, http.StatusBadRequest, err)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		t.Parallel()
+		s, _, _, _, _ := setupRequestTest(t, botUser)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "postMessage", []byte(`"content"`))
+		assertRequestError(t, http.StatusBadRequest, err)
+	})
+
+	t.Run("postMessage (channel not found)", func(t *testing.T) {
+		t.Parallel()
+		s, _, cm, _, _ := setupRequestTest(t, botUser)
+		cm.EXPECT().GetChannel(gomock.Any(), ch.ID).Return(nil, channel.ErrChannelNotFound)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "postMessage", []byte(`{"channelId":"`+ch.ID.String()+`","content":"a"}`))
+		assertRequestError(t, http.StatusNotFound, err)
+	})
+
+	t.Run("postMessage (inaccessible channel)", func(t *testing.T) {
+		t.Parallel()
+		s, _, cm, _, _ := setupRequestTest(t, botUser)
+		cm.EXPECT().GetChannel(gomock.Any(), ch.ID).Return(ch, nil)
+		cm.EXPECT().IsChannelAccessibleToUser(gomock.Any(), botUser.ID, ch.ID).Return(false, nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "postMessage", []byte(`{"channelId":"`+ch.ID.String()+`","content":"a"}`))
+		assertRequestError(t, http.StatusNotFound, err)
+	})
+
+	t.Run("postMessage (forbidden by role)", func(t *testing.T) {
+		t.Parallel()
+		s, _, cm, mm, r := setupRequestTest(t, botUser)
+		r.denied = permission.PostMessage
+		cm.EXPECT().GetChannel(gomock.Any(), ch.ID).Return(ch, nil)
+		cm.EXPECT().IsChannelAccessibleToUser(gomock.Any(), botUser.ID, ch.ID).Return(true, nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "postMessage", []byte(`{"channelId":"`+ch.ID.String()+`","content":"a"}`))
+		assertRequestError(t, http.StatusForbidden, err)
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _, mm, _ := setupRequestTest(t, botUser)
+		repo.MockOAuth2Repository.EXPECT().GetTokenByID(gomock.Any(), tokenID).Return(nil, repository.ErrNotFound)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, tokenID, "postMessage", []byte(`{"channelId":"`+ch.ID.String()+`","content":"a"}`))
+		assertRequestError(t, http.StatusUnauthorized, err)
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _, mm, _ := setupRequestTest(t, botUser)
+		repo.MockOAuth2Repository.
+			EXPECT().
+			GetTokenByID(gomock.Any(), tokenID).
+			Return(&model.OAuth2Token{ID: tokenID, UserID: botUser.ID, ExpiresIn: 1, CreatedAt: time.Now().Add(-time.Minute)}, nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, tokenID, "postMessage", []byte(`{"channelId":"`+ch.ID.String()+`","content":"a"}`))
+		assertRequestError(t, http.StatusUnauthorized, err)
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("postMessage (forbidden by scope)", func(t *testing.T) {
+		t.Parallel()
+		s, repo, cm, mm, _ := setupRequestTest(t, botUser)
+		repo.MockOAuth2Repository.
+			EXPECT().
+			GetTokenByID(gomock.Any(), tokenID).
+			Return(&model.OAuth2Token{ID: tokenID, UserID: botUser.ID, ExpiresIn: 3600, CreatedAt: time.Now(), Scopes: model.AccessScopes{}}, nil)
+		cm.EXPECT().GetChannel(gomock.Any(), ch.ID).Return(ch, nil)
+		cm.EXPECT().IsChannelAccessibleToUser(gomock.Any(), botUser.ID, ch.ID).Return(true, nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, tokenID, "postMessage", []byte(`{"channelId":"`+ch.ID.String()+`","content":"a"}`))
+		assertRequestError(t, http.StatusForbidden, err)
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("postMessage (empty content)", func(t *testing.T) {
+		t.Parallel()
+		s, _, cm, _, _ := setupRequestTest(t, botUser)
+		cm.EXPECT().GetChannel(gomock.Any(), ch.ID).Return(ch, nil)
+		cm.EXPECT().IsChannelAccessibleToUser(gomock.Any(), botUser.ID, ch.ID).Return(true, nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "postMessage", []byte(`{"channelId":"`+ch.ID.String()+`","content":""}`))
+		assertRequestError(t, http.StatusBadRequest, err)
+	})
+
+	t.Run("postMessage (archived)", func(t *testing.T) {
+		t.Parallel()
+		s, _, cm, mm, _ := setupRequestTest(t, botUser)
+		mm.err = message.ErrChannelArchived
+		cm.EXPECT().GetChannel(gomock.Any(), ch.ID).Return(ch, nil)
+		cm.EXPECT().IsChannelAccessibleToUser(gomock.Any(), botUser.ID, ch.ID).Return(true, nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "postMessage", []byte(`{"channelId":"`+ch.ID.String()+`","content":"a"}`))
+		assertRequestError(t, http.StatusBadRequest, err)
+	})
+
+	t.Run("postMessage (success)", func(t *testing.T) {
+		t.Parallel()
+		s, repo, cm, mm, _ := setupRequestTest(t, botUser)
+		repo.MockOAuth2Repository.
+			EXPECT().
+			GetTokenByID(gomock.Any(), tokenID).
+			Return(&model.OAuth2Token{ID: tokenID, UserID: botUser.ID, ExpiresIn: 3600, CreatedAt: time.Now(), Scopes: model.AccessScopes{model.AccessScope(permission.PostMessage): {}}}, nil)
+		cm.EXPECT().GetChannel(gomock.Any(), ch.ID).Return(ch, nil)
+		cm.EXPECT().IsChannelAccessibleToUser(gomock.Any(), botUser.ID, ch.ID).Return(true, nil)
+
+		res, err := s.executeRequest(context.Background(), botUser.ID, tokenID, "postMessage", []byte(`{"channelId":"`+ch.ID.String()+`","content":"hello"}`))
+		if assert.NoError(t, err) {
+			m := res.(message.Message)
+			assert.Equal(t, ch.ID, m.GetChannelID())
+			assert.Equal(t, botUser.ID, m.GetUserID())
+			assert.Equal(t, []string{"hello"}, mm.created)
+		}
+	})
+
+	t.Run("editMessage (not found)", func(t *testing.T) {
+		t.Parallel()
+		s, _, _, _, _ := setupRequestTest(t, botUser)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "editMessage", []byte(`{"messageId":"`+uuid.NewV3(uuid.Nil, "m").String()+`","content":"a"}`))
+		assertRequestError(t, http.StatusNotFound, err)
+	})
+
+	t.Run("editMessage (not author)", func(t *testing.T) {
+		t.Parallel()
+		s, _, cm, mm, _ := setupRequestTest(t, botUser)
+		m := &fakeMessage{id: uuid.NewV3(uuid.Nil, "m"), userID: uuid.NewV3(uuid.Nil, "other"), channelID: ch.ID}
+		mm.messages[m.id] = m
+		cm.EXPECT().IsChannelAccessibleToUser(gomock.Any(), botUser.ID, ch.ID).Return(true, nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "editMessage", []byte(`{"messageId":"`+m.id.String()+`","content":"a"}`))
+		assertRequestError(t, http.StatusForbidden, err)
+		assert.Empty(t, mm.edited)
+	})
+
+	t.Run("editMessage (success)", func(t *testing.T) {
+		t.Parallel()
+		s, _, cm, mm, _ := setupRequestTest(t, botUser)
+		m := &fakeMessage{id: uuid.NewV3(uuid.Nil, "m"), userID: botUser.ID, channelID: ch.ID}
+		mm.messages[m.id] = m
+		cm.EXPECT().IsChannelAccessibleToUser(gomock.Any(), botUser.ID, ch.ID).Return(true, nil)
+
+		res, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "editMessage", []byte(`{"messageId":"`+m.id.String()+`","content":"edited"}`))
+		if assert.NoError(t, err) {
+			assert.Nil(t, res)
+			assert.Equal(t, []string{"edited"}, mm.edited)
+		}
+	})
+
+	t.Run("joinChannel (forbidden)", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _, _, r := setupRequestTest(t, botUser)
+		r.denied = permission.BotActionJoinChannel
+		b := &model.Bot{ID: uuid.NewV3(uuid.Nil, "b"), BotUserID: botUser.ID}
+		repo.MockBotRepository.EXPECT().GetBotByBotUserID(gomock.Any(), botUser.ID).Return(b, nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "joinChannel", []byte(`{"channelId":"`+ch.ID.String()+`"}`))
+		assertRequestError(t, http.StatusForbidden, err)
+	})
+
+	t.Run("joinChannel (success)", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _, _, _ := setupRequestTest(t, botUser)
+		b := &model.Bot{ID: uuid.NewV3(uuid.Nil, "b"), BotUserID: botUser.ID}
+		repo.MockBotRepository.EXPECT().GetBotByBotUserID(gomock.Any(), botUser.ID).Return(b, nil)
+		repo.MockBotRepository.EXPECT().AddBotToChannel(gomock.Any(), b.ID, ch.ID).Return(nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "joinChannel", []byte(`{"channelId":"`+ch.ID.String()+`"}`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("leaveChannel (nil channel)", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _, _, _ := setupRequestTest(t, botUser)
+		b := &model.Bot{ID: uuid.NewV3(uuid.Nil, "b"), BotUserID: botUser.ID}
+		repo.MockBotRepository.EXPECT().GetBotByBotUserID(gomock.Any(), botUser.ID).Return(b, nil)
+
+		_, err := s.executeRequest(context.Background(), botUser.ID, uuid.Nil, "leaveChannel", []byte(`{"channelId":"`+uuid.Nil.String()+`"}`))
+		assertRequestError(t, http.StatusBadRequest, err)
+	})
+}
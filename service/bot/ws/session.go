@@ -13,25 +13,37 @@ import (
 type session struct {
 	key      string
 	userID   uuid.UUID
+	tokenID  uuid.UUID
 	conn     *websocket.Conn
 	streamer *Streamer
 
 	*sync.RWMutex
 	send      chan *rawMessage
+	requests  chan []byte
 	closed    bool
 	closeWait *sync.Cond
+
+	// live 接続後に最初にリアルタイムで送信したイベントの系列と連番
+	//
+	// resumeで、既にリアルタイムで送信したイベントを重複して再送しないようにする
+	live struct {
+		epoch uuid.UUID
+		seq   uint64
+	}
 }
 
-func newSession(userID uuid.UUID, streamer *Streamer, conn *websocket.Conn) *session {
+func newSession(userID uuid.UUID, tokenID uuid.UUID, streamer *Streamer, conn *websocket.Conn) *session {
 	mu := sync.RWMutex{}
 	return &session{
 		key:      random.AlphaNumeric(20),
 		userID:   userID,
+		tokenID:  tokenID,
 		conn:     conn,
 		streamer: streamer,
 
 		RWMutex:   &mu,
 		send:      make(chan *rawMessage, messageBufferSize),
+		requests:  make(chan []byte, requestQueueSize),
 		closed:    false,
 		closeWait: sync.NewCond(&mu),
 	}
@@ -47,6 +59,9 @@ func (s *session) ReadLoop() {
 		return nil
 	})
 
+	// リクエストを追加するのはReadLoopのみなので、ここで閉じる
+	defer close(s.requests)
+
 	for {
 		t, m, err := s.conn.ReadMessage()
 		if err != nil {
@@ -66,6 +81,15 @@ func (s *session) ReadLoop() {
 	}
 }
 
+// RequestLoop 受信したリクエストを順に処理します
+//
+// 時間のかかるリクエストがReadLoopを止めてpongの処理が遅れないよう、ReadLoopとは別に実行する
+func (s *session) RequestLoop() {
+	for data := range s.requests {
+		s.handleRequest(data)
+	}
+}
+
 func (s *session) WriteLoop() {
 	ticker := time.NewTicker(pingPeriod)
 	defer ticker.Stop()
@@ -110,6 +134,23 @@ func (s *session) WriteMessage(msg *rawMessage) (err error) {
 	}
 }
 
+// markLive リアルタイムでイベントを送信したことを記録します
+func (s *session) markLive(epoch uuid.UUID, seq uint64) {
+	s.Lock()
+	defer s.Unlock()
+	if s.live.epoch != epoch {
+		s.live.epoch = epoch
+		s.live.seq = seq
+	}
+}
+
+// firstLiveSeq 指定した系列で最初にリアルタイムで送信したイベントの連番を返します
+func (s *session) firstLiveSeq(epoch uuid.UUID) (seq uint64, ok bool) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.live.seq, s.live.epoch == epoch
+}
+
 func (s *session) write(messageType int, data []byte) error {
 	_ = s.conn.SetWriteDeadline(time.Now().Add(writeWait))
 	return s.conn.WriteMessage(messageType, data)
@@ -2,8 +2,10 @@ package ws
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/gorilla/websocket"
@@ -11,8 +13,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/router/extension/ctxkey"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/webrtcv3"
+	mutil "github.com/traPtitech/traQ/utils/message"
 )
 
 var (
@@ -26,20 +33,33 @@ var (
 type Streamer struct {
 	hub      *hub.Hub
 	webrtc   *webrtcv3.Manager
+	repo     repository.Repository
+	cm       channel.Manager
+	mm       message.Manager
+	rbac     rbac.RBAC
+	replacer *mutil.Replacer
 	logger   *zap.Logger
 	sessions map[uuid.UUID][]*session
 	closed   bool
 	mu       sync.RWMutex
+	buffers  map[uuid.UUID]*eventBuffer
+	bufMu    sync.Mutex
 }
 
 // NewStreamer WebSocketストリーマーを生成し起動します
-func NewStreamer(hub *hub.Hub, webrtc *webrtcv3.Manager, logger *zap.Logger) *Streamer {
+func NewStreamer(hub *hub.Hub, webrtc *webrtcv3.Manager, repo repository.Repository, cm channel.Manager, mm message.Manager, rbac rbac.RBAC, replacer *mutil.Replacer, logger *zap.Logger) *Streamer {
 	h := &Streamer{
 		hub:      hub,
 		webrtc:   webrtc,
+		repo:     repo,
+		cm:       cm,
+		mm:       mm,
+		rbac:     rbac,
+		replacer: replacer,
 		logger:   logger.Named("bot.ws"),
 		sessions: make(map[uuid.UUID][]*session),
 		closed:   false,
+		buffers:  make(map[uuid.UUID]*eventBuffer),
 	}
 	return h
 }
@@ -71,11 +91,40 @@ func filterSession(sessions []*session, target *session) []*session {
 	return s
 }
 
+func (s *Streamer) getBuffer(botUserID uuid.UUID) *eventBuffer {
+	s.bufMu.Lock()
+	defer s.bufMu.Unlock()
+	b, ok := s.buffers[botUserID]
+	if !ok {
+		b = newEventBuffer()
+		s.buffers[botUserID] = b
+	}
+	return b
+}
+
+// DiscardEventBuffer 指定したBOTの再送用に保持しているイベントを破棄します
+//
+// BOTが削除された場合やWebSocket Modeでなくなった場合に呼び出されます
+func (s *Streamer) DiscardEventBuffer(botUserID uuid.UUID) {
+	s.bufMu.Lock()
+	defer s.bufMu.Unlock()
+	delete(s.buffers, botUserID)
+}
+
 // WriteMessage 指定したセッションにメッセージを書き込みます
+//
+// 書き込んだイベントは系列の識別子と連番を付けて一定期間保持され、再接続したBOTはresumeコマンドで再送を要求できます
 func (s *Streamer) WriteMessage(t string, reqID uuid.UUID, body []byte, botUserID uuid.UUID) (errs []error, attempted bool) {
+	buf := s.getBuffer(botUserID)
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	ev := buf.push(time.Now(), func(seq uint64) []byte {
+		return makeEventMessage(t, reqID, buf.epoch, seq, body).toJSON()
+	})
 	m := &rawMessage{
 		t:    websocket.TextMessage,
-		data: makeEventMessage(t, reqID, body).toJSON(),
+		data: ev.data,
 	}
 	s.mu.RLock()
 	for _, session := range s.sessions[botUserID] {
@@ -88,6 +137,8 @@ func (s *Streamer) WriteMessage(t string, reqID uuid.UUID, body []byte, botUserI
 					zap.Any("body", body),
 					zap.Stringer("userID", session.userID))
 			}
+		} else {
+			session.markLive(buf.epoch, ev.seq)
 		}
 		attempted = true
 	}
@@ -95,6 +146,42 @@ func (s *Streamer) WriteMessage(t string, reqID uuid.UUID, body []byte, botUserI
 	return
 }
 
+// resume 指定した系列の連番より後のイベントをセッションに再送します
+//
+// 接続後に既にリアルタイムで送信したイベントは再送しません
+func (s *Streamer) resume(session *session, epoch uuid.UUID, seq uint64) {
+	buf := s.getBuffer(session.userID)
+	buf.mu.Lock()
+	defer buf.mu.Unlock()
+
+	if epoch != buf.epoch {
+		// 再起動などにより連番が振り直されている
+		session.sendErrorMessage(fmt.Sprintf("unknown epoch: %s", epoch))
+		return
+	}
+	if seq > buf.seq {
+		session.sendErrorMessage(fmt.Sprintf("unknown sequence: %d", seq))
+		return
+	}
+	events, lost := buf.since(time.Now(), seq)
+	if lost {
+		session.sendErrorMessage(fmt.Sprintf("some events after sequence %d have been discarded", seq))
+	}
+	liveSeq, live := session.firstLiveSeq(buf.epoch)
+	for _, ev := range events {
+		if live && ev.seq >= liveSeq {
+			break
+		}
+		if err := session.WriteMessage(&rawMessage{t: websocket.TextMessage, data: ev.data}); err != nil {
+			s.logger.Warn("failed to resume events",
+				zap.Error(err),
+				zap.Uint64("seq", ev.seq),
+				zap.Stringer("userID", session.userID))
+			return
+		}
+	}
+}
+
 // ServeHTTP http.Handlerインターフェイスの実装
 func (s *Streamer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	s.mu.RLock()
@@ -110,7 +197,9 @@ func (s *Streamer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	session := newSession(r.Context().Value(ctxkey.UserID).(uuid.UUID), s, conn)
+	// OAuth2トークンで接続した場合は、リクエストごとにトークンの有効性とスコープを確認する
+	tokenID, _ := r.Context().Value(ctxkey.AccessTokenID).(uuid.UUID)
+	session := newSession(r.Context().Value(ctxkey.UserID).(uuid.UUID), tokenID, s, conn)
 
 	s.register(session)
 	s.hub.Publish(hub.Message{
@@ -122,6 +211,7 @@ func (s *Streamer) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	})
 
 	go session.WriteLoop()
+	go session.RequestLoop()
 	session.ReadLoop()
 
 	_ = s.webrtc.ResetState(session.key, session.userID)
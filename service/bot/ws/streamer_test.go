@@ -0,0 +1,92 @@
+package ws
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// receive セッションの送信バッファに溜まっているメッセージを全て取り出します
+func receive(s *session) []map[string]any {
+	var res []map[string]any
+	for {
+		select {
+		case m := <-s.send:
+			var v map[string]any
+			_ = json.Unmarshal(m.data, &v)
+			res = append(res, v)
+		default:
+			return res
+		}
+	}
+}
+
+func TestStreamer_resume(t *testing.T) {
+	t.Parallel()
+
+	botUserID := uuid.NewV3(uuid.Nil, "bot")
+	setup := func(t *testing.T) (*Streamer, *session, uuid.UUID) {
+		t.Helper()
+		s := NewStreamer(nil, nil, nil, nil, nil, nil, nil, zap.NewNop())
+		for range 3 {
+			s.WriteMessage("PING", uuid.Nil, []byte(`{}`), botUserID)
+		}
+		return s, newSession(botUserID, uuid.Nil, s, nil), s.getBuffer(botUserID).epoch
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s, sess, epoch := setup(t)
+
+		s.resume(sess, epoch, 1)
+		msgs := receive(sess)
+		require.Len(t, msgs, 2)
+		assert.Equal(t, epoch.String(), msgs[0]["epoch"])
+		assert.EqualValues(t, 2, msgs[0]["seq"])
+		assert.EqualValues(t, 3, msgs[1]["seq"])
+	})
+
+	t.Run("skip events sent live", func(t *testing.T) {
+		t.Parallel()
+		s, sess, epoch := setup(t)
+		s.register(sess)
+
+		// resume前に接続後のイベントがリアルタイムで送信される
+		s.WriteMessage("PING", uuid.Nil, []byte(`{}`), botUserID)
+		msgs := receive(sess)
+		require.Len(t, msgs, 1)
+		assert.EqualValues(t, 4, msgs[0]["seq"])
+
+		s.resume(sess, epoch, 1)
+		msgs = receive(sess)
+		require.Len(t, msgs, 2)
+		assert.EqualValues(t, 2, msgs[0]["seq"])
+		assert.EqualValues(t, 3, msgs[1]["seq"])
+	})
+
+	t.Run("unknown epoch", func(t *testing.T) {
+		t.Parallel()
+		s, sess, _ := setup(t)
+
+		// 再起動前など、別の系列の連番では再送しない
+		s.resume(sess, uuid.NewV3(uuid.Nil, "other"), 1)
+		msgs := receive(sess)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, "ERROR", msgs[0]["type"])
+	})
+
+	t.Run("discarded", func(t *testing.T) {
+		t.Parallel()
+		s, sess, epoch := setup(t)
+
+		s.DiscardEventBuffer(botUserID)
+		assert.NotEqual(t, epoch, s.getBuffer(botUserID).epoch)
+		s.resume(sess, epoch, 1)
+		msgs := receive(sess)
+		require.Len(t, msgs, 1)
+		assert.Equal(t, "ERROR", msgs[0]["type"])
+	})
+}
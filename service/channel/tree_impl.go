@@ -31,6 +31,7 @@ type channelNode struct {
 	topic     string                     // Nodeでロック
 	archived  bool                       // Nodeでロック
 	force     bool                       // Nodeでロック
+	noMention bool                       // Nodeでロック
 	updaterID uuid.UUID                  // Nodeでロック
 	updatedAt time.Time                  // Nodeでロック
 	sync.RWMutex
@@ -41,12 +42,13 @@ func (n *channelNode) MarshalJSON() ([]byte, error) {
 	n.RLock()
 	defer n.RUnlock()
 	v := map[string]interface{}{
-		"id":       n.id,
-		"name":     n.name,
-		"topic":    n.topic,
-		"children": n.getChildrenIDs(),
-		"archived": n.archived,
-		"force":    n.force,
+		"id":                     n.id,
+		"name":                   n.name,
+		"topic":                  n.topic,
+		"children":               n.getChildrenIDs(),
+		"archived":               n.archived,
+		"force":                  n.force,
+		"disableChannelMentions": n.noMention,
 	}
 	if n.parent == nil {
 		v["parentId"] = nil
@@ -98,17 +100,18 @@ func (n *channelNode) convertToModel() *model.Channel {
 	n.RLock()
 	defer n.RUnlock()
 	ch := &model.Channel{
-		ID:         n.id,
-		Name:       n.name,
-		Topic:      n.topic,
-		IsForced:   n.force,
-		IsPublic:   true,
-		IsVisible:  !n.archived,
-		CreatorID:  n.creatorID,
-		UpdaterID:  n.updaterID,
-		CreatedAt:  n.createdAt,
-		UpdatedAt:  n.updatedAt,
-		ChildrenID: n.getChildrenIDs(),
+		ID:                     n.id,
+		Name:                   n.name,
+		Topic:                  n.topic,
+		IsForced:               n.force,
+		IsPublic:               true,
+		IsVisible:              !n.archived,
+		DisableChannelMentions: n.noMention,
+		CreatorID:              n.creatorID,
+		UpdaterID:              n.updaterID,
+		CreatedAt:              n.createdAt,
+		UpdatedAt:              n.updatedAt,
+		ChildrenID:             n.getChildrenIDs(),
 	}
 	if n.parent != nil {
 		ch.ParentID = n.parent.id
@@ -135,6 +138,7 @@ func constructChannelNode(chMap map[uuid.UUID]*model.Channel, tree *treeImpl, id
 		topic:     ch.Topic,
 		archived:  ch.IsArchived(),
 		force:     ch.IsForced,
+		noMention: ch.DisableChannelMentions,
 		children:  map[uuid.UUID]*channelNode{},
 		creatorID: ch.CreatorID,
 		updaterID: ch.UpdaterID,
@@ -189,6 +193,7 @@ func (ct *treeImpl) add(ch *model.Channel) {
 		topic:     ch.Topic,
 		archived:  ch.IsArchived(),
 		force:     ch.IsForced,
+		noMention: ch.DisableChannelMentions,
 		children:  map[uuid.UUID]*channelNode{},
 		creatorID: ch.CreatorID,
 		updaterID: ch.UpdaterID,
@@ -265,6 +270,7 @@ func (ct *treeImpl) update(id uuid.UUID, ch *model.Channel) {
 	n.topic = ch.Topic
 	n.archived = !ch.IsVisible
 	n.force = ch.IsForced
+	n.noMention = ch.DisableChannelMentions
 	n.updaterID = ch.UpdaterID
 	n.updatedAt = ch.UpdatedAt
 	n.Unlock()
@@ -0,0 +1,252 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/mailer"
+	"github.com/traPtitech/traQ/service/variable"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+const (
+	checkInterval = time.Hour
+	// maxChannels 1通のダイジェストに含めるチャンネルの最大数
+	maxChannels = 20
+	// maxMessagesPerChannel 1チャンネルあたりに含めるメッセージの最大数
+	maxMessagesPerChannel = 5
+	// maxTextLength メッセージ本文の最大表示文字数
+	maxTextLength = 100
+
+	subject = "traQ: 未読の通知があります"
+)
+
+// Sender 未読メッセージのメールダイジェストを定期的に送信します
+//
+// 送信を確認した日時はDBに永続化されるため、サーバーが再起動しても
+// 同じ期間のダイジェストが重複して送信されることはありません。
+type Sender struct {
+	repo   repository.Repository
+	cm     channel.Manager
+	mailer mailer.Mailer
+	l      *zap.Logger
+	origin string
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	started   chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewSender(repo repository.Repository, cm channel.Manager, mailer mailer.Mailer, logger *zap.Logger, origin variable.ServerOriginString) *Sender {
+	return &Sender{
+		repo:    repo,
+		cm:      cm,
+		mailer:  mailer,
+		l:       logger.Named("digest_sender"),
+		origin:  string(origin),
+		started: make(chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start ダイジェスト送信ワーカーを起動します
+//
+// メール送信が無効な場合は何もしません
+func (s *Sender) Start() {
+	if !s.mailer.Enabled() {
+		return
+	}
+	s.startOnce.Do(func() {
+		close(s.started)
+		go s.run()
+	})
+}
+
+// Shutdown ダイジェスト送信ワーカーを停止します
+func (s *Sender) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	select {
+	case <-s.started:
+	default:
+		return nil // 起動していない
+	}
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Sender) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	s.sendDigests(context.Background(), time.Now())
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.sendDigests(context.Background(), now)
+		}
+	}
+}
+
+func (s *Sender) sendDigests(ctx context.Context, now time.Time) {
+	settings, err := s.repo.GetUserSettingsWithDigestEnabled(ctx)
+	if err != nil {
+		s.l.Error("failed to GetUserSettingsWithDigestEnabled", zap.Error(err))
+		return
+	}
+	for _, us := range settings {
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+		s.send(ctx, us, now)
+	}
+}
+
+func (s *Sender) send(ctx context.Context, us *model.UserSettings, now time.Time) {
+	logger := s.l.With(zap.Stringer("userId", us.UserID))
+
+	interval := us.DigestFrequency.Interval()
+	if interval <= 0 {
+		return
+	}
+	since := now.Add(-interval)
+	if us.DigestCheckedAt.Valid {
+		if now.Sub(us.DigestCheckedAt.V) < interval {
+			return // まだ送信時期でない
+		}
+		since = us.DigestCheckedAt.V
+	}
+
+	user, err := s.repo.GetUser(ctx, us.UserID, false)
+	if err != nil {
+		logger.Error("failed to GetUser", zap.Error(err))
+		return
+	}
+	if !user.IsActive() || user.IsBot() {
+		return
+	}
+
+	body, err := s.buildBody(ctx, us.UserID, since)
+	if err != nil {
+		logger.Error("failed to build digest", zap.Error(err))
+		return
+	}
+	if len(body) > 0 {
+		if err := s.mailer.Send(ctx, us.DigestEmail, subject, body); err != nil {
+			// 次回に再試行
+			logger.Error("failed to send digest", zap.Error(err))
+			return
+		}
+	}
+
+	if err := s.repo.UpdateDigestCheckedAt(ctx, us.UserID, now); err != nil {
+		logger.Error("failed to UpdateDigestCheckedAt", zap.Error(err))
+	}
+}
+
+// buildBody sinceより後に未読になったメッセージのある通知対象チャンネルのダイジェスト本文を作成します
+//
+// 該当するチャンネルが無い場合は空文字列を返します
+func (s *Sender) buildBody(ctx context.Context, userID uuid.UUID, since time.Time) (string, error) {
+	unreadChannels, err := s.repo.GetUserUnreadChannels(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	unreadChannels = slices.DeleteFunc(unreadChannels, func(uc *repository.UserUnreadChannel) bool {
+		return !uc.Noticeable || !uc.UpdatedAt.After(since)
+	})
+	if len(unreadChannels) == 0 {
+		return "", nil
+	}
+	// 新しいものから
+	slices.SortFunc(unreadChannels, func(a, b *repository.UserUnreadChannel) int {
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+
+	unreads, err := s.repo.GetUnreadMessagesByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	messages := make(map[uuid.UUID][]*model.Message, len(unreadChannels))
+	for _, m := range unreads {
+		if m.CreatedAt.After(since) {
+			messages[m.ChannelID] = append(messages[m.ChannelID], m)
+		}
+	}
+
+	users := map[uuid.UUID]model.UserInfo{}
+	getUserName := func(id uuid.UUID) string {
+		if u, ok := users[id]; ok {
+			return u.GetName()
+		}
+		u, err := s.repo.GetUser(ctx, id, false)
+		if err != nil {
+			return "unknown"
+		}
+		users[id] = u
+		return u.GetName()
+	}
+
+	var sb strings.Builder
+	sb.WriteString("traQに未読の通知があります。\n")
+	for i, uc := range unreadChannels {
+		if i >= maxChannels {
+			fmt.Fprintf(&sb, "\nほか%dチャンネル\n", len(unreadChannels)-maxChannels)
+			break
+		}
+
+		ms := messages[uc.ChannelID]
+		var name string
+		if s.cm.IsPublicChannel(ctx, uc.ChannelID) {
+			name = "#" + s.cm.GetChannelPathFromID(ctx, uc.ChannelID)
+		} else if len(ms) > 0 {
+			name = "DM @" + getUserName(ms[0].UserID)
+		} else {
+			name = "DM"
+		}
+		fmt.Fprintf(&sb, "\n%s (未読 %d件)\n", name, uc.Count)
+
+		if len(ms) > maxMessagesPerChannel {
+			ms = ms[len(ms)-maxMessagesPerChannel:]
+		}
+		for _, m := range ms {
+			fmt.Fprintf(&sb, "  @%s: %s\n", getUserName(m.UserID), truncate(message.Parse(m.Text).NotificationText(), maxTextLength))
+			fmt.Fprintf(&sb, "    %s/messages/%s\n", s.origin, m.ID)
+		}
+	}
+	fmt.Fprintf(&sb, "\n%s\n\nこのメールの配信設定はtraQのユーザー設定から変更できます。\n", s.origin)
+	return sb.String(), nil
+}
+
+func truncate(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "…"
+}
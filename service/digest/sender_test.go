@@ -0,0 +1,201 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/testutils"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type Repo struct {
+	*mock_repository.MockUserRepository
+	*mock_repository.MockMessageRepository
+	testutils.EmptyTestRepository
+
+	checkedAt map[uuid.UUID]time.Time
+}
+
+func (r *Repo) UpdateDigestCheckedAt(_ context.Context, userID uuid.UUID, checkedAt time.Time) error {
+	r.checkedAt[userID] = checkedAt
+	return nil
+}
+
+type sentMail struct {
+	to, subject, body string
+}
+
+type fakeMailer struct {
+	sent []sentMail
+	err  error
+}
+
+func (m *fakeMailer) Send(_ context.Context, to, subject, body string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, sentMail{to: to, subject: subject, body: body})
+	return nil
+}
+
+func (m *fakeMailer) Enabled() bool {
+	return true
+}
+
+func newSender(t *testing.T) (*Sender, *Repo, *mock_channel.MockManager, *fakeMailer) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := &Repo{
+		MockUserRepository:    mock_repository.NewMockUserRepository(ctrl),
+		MockMessageRepository: mock_repository.NewMockMessageRepository(ctrl),
+		checkedAt:             map[uuid.UUID]time.Time{},
+	}
+	cm := mock_channel.NewMockManager(ctrl)
+	m := &fakeMailer{}
+	return NewSender(repo, cm, m, zap.NewNop(), "https://traq.example.com"), repo, cm, m
+}
+
+func TestSender_send(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+	t.Run("not due yet", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _, m := newSender(t)
+		us := &model.UserSettings{
+			UserID:          uuid.Must(uuid.NewV7()),
+			DigestEmail:     "user@example.com",
+			DigestFrequency: model.DigestFrequencyDaily,
+			DigestCheckedAt: optional.From(now.Add(-time.Hour)),
+		}
+
+		s.send(context.Background(), us, now)
+		assert.Empty(t, m.sent)
+		assert.Empty(t, repo.checkedAt)
+	})
+
+	t.Run("inactive user", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _, m := newSender(t)
+		us := &model.UserSettings{
+			UserID:          uuid.Must(uuid.NewV7()),
+			DigestEmail:     "user@example.com",
+			DigestFrequency: model.DigestFrequencyDaily,
+		}
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), us.UserID, false).Return(&model.User{ID: us.UserID, Status: model.UserAccountStatusDeactivated}, nil)
+
+		s.send(context.Background(), us, now)
+		assert.Empty(t, m.sent)
+		assert.Empty(t, repo.checkedAt)
+	})
+
+	t.Run("no unreads", func(t *testing.T) {
+		t.Parallel()
+		s, repo, _, m := newSender(t)
+		us := &model.UserSettings{
+			UserID:          uuid.Must(uuid.NewV7()),
+			DigestEmail:     "user@example.com",
+			DigestFrequency: model.DigestFrequencyDaily,
+			DigestCheckedAt: optional.From(now.Add(-25 * time.Hour)),
+		}
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), us.UserID, false).Return(&model.User{ID: us.UserID, Status: model.UserAccountStatusActive}, nil)
+		// 通知対象でないチャンネルと、前回の確認以前のチャンネルは無視される
+		repo.MockMessageRepository.EXPECT().GetUserUnreadChannels(gomock.Any(), us.UserID).Return([]*repository.UserUnreadChannel{
+			{ChannelID: uuid.Must(uuid.NewV7()), Count: 3, Noticeable: false, UpdatedAt: now.Add(-time.Hour)},
+			{ChannelID: uuid.Must(uuid.NewV7()), Count: 1, Noticeable: true, UpdatedAt: now.Add(-48 * time.Hour)},
+		}, nil)
+
+		s.send(context.Background(), us, now)
+		assert.Empty(t, m.sent)
+		assert.Equal(t, now, repo.checkedAt[us.UserID])
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		s, repo, cm, m := newSender(t)
+		us := &model.UserSettings{
+			UserID:          uuid.Must(uuid.NewV7()),
+			DigestEmail:     "user@example.com",
+			DigestFrequency: model.DigestFrequencyDaily,
+		}
+		author := &model.User{ID: uuid.Must(uuid.NewV7()), Name: "alice", Status: model.UserAccountStatusActive}
+		ch := uuid.Must(uuid.NewV7())
+		dm := uuid.Must(uuid.NewV7())
+		old := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: author.ID, ChannelID: ch, Text: "old", CreatedAt: now.Add(-48 * time.Hour)}
+		m1 := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: author.ID, ChannelID: ch, Text: "!{\"type\":\"user\",\"raw\":\"@user\",\"id\":\"" + us.UserID.String() + "\"} hello", CreatedAt: now.Add(-2 * time.Hour)}
+		m2 := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: author.ID, ChannelID: dm, Text: "direct", CreatedAt: now.Add(-time.Hour)}
+
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), us.UserID, false).Return(&model.User{ID: us.UserID, Status: model.UserAccountStatusActive}, nil)
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), author.ID, false).Return(author, nil)
+		repo.MockMessageRepository.EXPECT().GetUserUnreadChannels(gomock.Any(), us.UserID).Return([]*repository.UserUnreadChannel{
+			{ChannelID: ch, Count: 2, Noticeable: true, UpdatedAt: m1.CreatedAt},
+			{ChannelID: dm, Count: 1, Noticeable: true, UpdatedAt: m2.CreatedAt},
+		}, nil)
+		repo.MockMessageRepository.EXPECT().GetUnreadMessagesByUserID(gomock.Any(), us.UserID).Return([]*model.Message{old, m1, m2}, nil)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), ch).Return(true)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), dm).Return(false)
+		cm.EXPECT().GetChannelPathFromID(gomock.Any(), ch).Return("general")
+
+		s.send(context.Background(), us, now)
+		if assert.Len(t, m.sent, 1) {
+			sent := m.sent[0]
+			assert.Equal(t, "user@example.com", sent.to)
+			assert.Contains(t, sent.body, "#general (未読 2件)")
+			assert.Contains(t, sent.body, "DM @alice (未読 1件)")
+			assert.Contains(t, sent.body, "@alice: @user hello")
+			assert.Contains(t, sent.body, "https://traq.example.com/messages/"+m1.ID.String())
+			assert.Contains(t, sent.body, "https://traq.example.com/messages/"+m2.ID.String())
+			assert.NotContains(t, sent.body, old.ID.String())
+			// 新しいチャンネルから
+			assert.Less(t, strings.Index(sent.body, "DM @alice"), strings.Index(sent.body, "#general"))
+		}
+		assert.Equal(t, now, repo.checkedAt[us.UserID])
+	})
+
+	t.Run("send failure", func(t *testing.T) {
+		t.Parallel()
+		s, repo, cm, m := newSender(t)
+		m.err = errors.New("connection refused")
+		us := &model.UserSettings{
+			UserID:          uuid.Must(uuid.NewV7()),
+			DigestEmail:     "user@example.com",
+			DigestFrequency: model.DigestFrequencyWeekly,
+		}
+		author := &model.User{ID: uuid.Must(uuid.NewV7()), Name: "alice", Status: model.UserAccountStatusActive}
+		ch := uuid.Must(uuid.NewV7())
+		m1 := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: author.ID, ChannelID: ch, Text: "hello", CreatedAt: now.Add(-time.Hour)}
+
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), us.UserID, false).Return(&model.User{ID: us.UserID, Status: model.UserAccountStatusActive}, nil)
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), author.ID, false).Return(author, nil)
+		repo.MockMessageRepository.EXPECT().GetUserUnreadChannels(gomock.Any(), us.UserID).Return([]*repository.UserUnreadChannel{
+			{ChannelID: ch, Count: 1, Noticeable: true, UpdatedAt: m1.CreatedAt},
+		}, nil)
+		repo.MockMessageRepository.EXPECT().GetUnreadMessagesByUserID(gomock.Any(), us.UserID).Return([]*model.Message{m1}, nil)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), ch).Return(true)
+		cm.EXPECT().GetChannelPathFromID(gomock.Any(), ch).Return("general")
+
+		s.send(context.Background(), us, now)
+		assert.Empty(t, repo.checkedAt) // 次回に再試行
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "abc", truncate("abc", 3))
+	assert.Equal(t, "ab…", truncate("abc", 2))
+	assert.Equal(t, "あい…", truncate("あいう", 2))
+	assert.Equal(t, "a b", truncate("a\n  b", 10))
+}
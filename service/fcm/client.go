@@ -1,10 +1,8 @@
 package fcm
 
-import "github.com/traPtitech/traQ/utils/set"
+import "github.com/traPtitech/traQ/service/push"
 
 // Client Firebase Cloud Messaging Client
 type Client interface {
-	// Send targetユーザーにpayloadを送信します
-	Send(targetUserIDs set.UUID, payload *Payload, withUnreadCount bool)
-	Close()
+	push.Client
 }
@@ -13,6 +13,7 @@ import (
 
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/push"
 	"github.com/traPtitech/traQ/service/variable"
 	"github.com/traPtitech/traQ/utils/set"
 )
@@ -72,11 +73,11 @@ func (c *clientImpl) isClosed() bool {
 	}
 }
 
-func (c *clientImpl) Send(targetUserIDs set.UUID, payload *Payload, withUnreadCount bool) {
+func (c *clientImpl) Send(targetUserIDs set.UUID, payload *push.Payload, withUnreadCount bool) {
 	_ = c.send(targetUserIDs, payload, withUnreadCount)
 }
 
-func (c *clientImpl) send(targetUserIDs set.UUID, p *Payload, withUnreadCount bool) error {
+func (c *clientImpl) send(targetUserIDs set.UUID, p *push.Payload, withUnreadCount bool) error {
 	if c.isClosed() {
 		return errors.New("fcm client has already been closed")
 	}
@@ -1,6 +1,7 @@
 package fcm
 
 import (
+	"github.com/traPtitech/traQ/service/push"
 	"github.com/traPtitech/traQ/utils/set"
 )
 
@@ -13,7 +14,7 @@ func NewNullClient() Client {
 	return nullC
 }
 
-func (n *nullClient) Send(set.UUID, *Payload, bool) {
+func (n *nullClient) Send(set.UUID, *push.Payload, bool) {
 }
 
 func (n *nullClient) Close() {
@@ -7,9 +7,6 @@ import (
 	"firebase.google.com/go/v4/messaging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"golang.org/x/exp/utf8string"
-
-	"github.com/traPtitech/traQ/utils/optional"
 )
 
 const (
@@ -40,22 +37,3 @@ var (
 		},
 	}
 )
-
-// Payload FCMペイロード
-type Payload struct {
-	Type  string
-	Title string
-	Body  string
-	Icon  string
-	Path  string
-	Tag   string
-	Image optional.Of[string]
-}
-
-// SetBodyWithEllipsis 100文字を超える場合は...で省略
-func (p *Payload) SetBodyWithEllipsis(body string) {
-	if s := utf8string.NewString(body); s.RuneCount() > 100 {
-		body = s.Slice(0, 100) + "..."
-	}
-	p.Body = body
-}
@@ -0,0 +1,207 @@
+package inbox
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// Recorder 新着メッセージ・スタンプから通知受信箱のアイテムを作成します
+//
+// 作成した場合は event.InboxItemCreated を発行します。
+type Recorder struct {
+	repo repository.Repository
+	cm   channel.Manager
+	hub  *hub.Hub
+	l    *zap.Logger
+}
+
+// NewRecorder Recorderを生成して起動します
+func NewRecorder(repo repository.Repository, cm channel.Manager, hub *hub.Hub, logger *zap.Logger) *Recorder {
+	r := &Recorder{
+		repo: repo,
+		cm:   cm,
+		hub:  hub,
+		l:    logger.Named("inbox_recorder"),
+	}
+	go func() {
+		for ev := range hub.Subscribe(100, event.MessageCreated, event.MessageStamped).Receiver {
+			switch ev.Name {
+			case event.MessageCreated:
+				r.processMessage(ev.Fields["message"].(*model.Message), ev.Fields["parse_result"].(*message.ParseResult))
+			case event.MessageStamped:
+				// 同じユーザーが同じスタンプを押し直した場合は通知しない
+				if ev.Fields["count"].(int) != 1 {
+					continue
+				}
+				r.processStamp(ev.Fields["message_id"].(uuid.UUID), ev.Fields["user_id"].(uuid.UUID), ev.Fields["stamp_id"].(uuid.UUID))
+			}
+		}
+	}()
+	return r
+}
+
+// priority 同じメッセージで複数の条件に該当した場合の種類の優先度
+func priority(t model.InboxItemType) int {
+	switch t {
+	case model.InboxItemTypeMention, model.InboxItemTypeDM:
+		return 3
+	case model.InboxItemTypeGroupMention:
+		return 2
+	case model.InboxItemTypeCitation:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (r *Recorder) processMessage(m *model.Message, parseResult *message.ParseResult) {
+	ctx := context.Background()
+	logger := r.l.With(zap.Stringer("messageId", m.ID))
+
+	targets := map[uuid.UUID]model.InboxItemType{}
+	add := func(userID uuid.UUID, t model.InboxItemType) {
+		// 自分のメッセージは通知しない
+		if userID == m.UserID {
+			return
+		}
+		if cur, ok := targets[userID]; ok && priority(cur) >= priority(t) {
+			return
+		}
+		targets[userID] = t
+	}
+
+	q := repository.UsersQuery{}.Active().NotBot()
+	if !r.cm.IsPublicChannel(ctx, m.ChannelID) {
+		// DM
+		members, err := r.repo.GetUserIDs(ctx, q.CMemberOf(m.ChannelID))
+		if err != nil {
+			logger.Error("failed to GetUserIDs", zap.Error(err), zap.Stringer("channelId", m.ChannelID))
+			return
+		}
+		for _, uid := range members {
+			add(uid, model.InboxItemTypeDM)
+		}
+	} else {
+		for _, uid := range parseResult.Mentions {
+			if r.isNotifiable(ctx, logger, uid) {
+				add(uid, model.InboxItemTypeMention)
+			}
+		}
+		for _, gid := range parseResult.GroupMentions {
+			members, err := r.repo.GetUserIDs(ctx, q.GMemberOf(gid))
+			if err != nil {
+				logger.Error("failed to GetUserIDs", zap.Error(err), zap.Stringer("groupId", gid))
+				continue
+			}
+			for _, uid := range members {
+				add(uid, model.InboxItemTypeGroupMention)
+			}
+		}
+		for _, mid := range parseResult.Citation {
+			cited, err := r.repo.GetMessageByID(ctx, mid)
+			if err != nil {
+				logger.Debug("failed to GetMessageByID", zap.Error(err), zap.Stringer("citedMessageId", mid))
+				continue
+			}
+			if r.isNotifiable(ctx, logger, cited.UserID) {
+				add(cited.UserID, model.InboxItemTypeCitation)
+			}
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	args := make([]repository.CreateInboxItemArgs, 0, len(targets))
+	for uid, t := range targets {
+		args = append(args, repository.CreateInboxItemArgs{
+			UserID:    uid,
+			Type:      t,
+			MessageID: m.ID,
+			ChannelID: m.ChannelID,
+			ActorID:   m.UserID,
+		})
+	}
+	r.create(ctx, logger, args)
+}
+
+func (r *Recorder) processStamp(messageID, userID, stampID uuid.UUID) {
+	ctx := context.Background()
+	logger := r.l.With(zap.Stringer("messageId", messageID))
+
+	m, err := r.repo.GetMessageByID(ctx, messageID)
+	if err != nil {
+		logger.Debug("failed to GetMessageByID", zap.Error(err))
+		return
+	}
+	// 自分のメッセージへのスタンプは通知しない
+	if m.UserID == userID || !r.isNotifiable(ctx, logger, m.UserID) {
+		return
+	}
+
+	r.create(ctx, logger, []repository.CreateInboxItemArgs{{
+		UserID:    m.UserID,
+		Type:      model.InboxItemTypeStamp,
+		MessageID: m.ID,
+		ChannelID: m.ChannelID,
+		ActorID:   userID,
+		StampID:   optional.From(stampID),
+	}})
+}
+
+// isNotifiable 通知受信箱にアイテムを追加するユーザーか(凍結ユーザー・Botでないか)どうか
+func (r *Recorder) isNotifiable(ctx context.Context, logger *zap.Logger, userID uuid.UUID) bool {
+	user, err := r.repo.GetUser(ctx, userID, false)
+	if err != nil {
+		logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("userId", userID))
+		return false
+	}
+	return user.IsActive() && !user.IsBot()
+}
+
+// RecordReminder 指定したユーザーの通知受信箱にメッセージのリマインダーのアイテムを作成します
+func (r *Recorder) RecordReminder(ctx context.Context, userID, messageID, channelID uuid.UUID) error {
+	items, err := r.repo.CreateInboxItems(ctx, []repository.CreateInboxItemArgs{{
+		UserID:    userID,
+		Type:      model.InboxItemTypeReminder,
+		MessageID: messageID,
+		ChannelID: channelID,
+		ActorID:   userID,
+	}})
+	if err != nil {
+		return err
+	}
+	r.publish(items)
+	return nil
+}
+
+func (r *Recorder) create(ctx context.Context, logger *zap.Logger, args []repository.CreateInboxItemArgs) {
+	items, err := r.repo.CreateInboxItems(ctx, args)
+	if err != nil {
+		logger.Error("failed to CreateInboxItems", zap.Error(err))
+		return
+	}
+	r.publish(items)
+}
+
+func (r *Recorder) publish(items []*model.InboxItem) {
+	for _, item := range items {
+		r.hub.Publish(hub.Message{
+			Name: event.InboxItemCreated,
+			Fields: hub.Fields{
+				"user_id":    item.UserID,
+				"inbox_item": item,
+			},
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package inbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/testutils"
+	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type Repo struct {
+	*mock_repository.MockUserRepository
+	*mock_repository.MockMessageRepository
+	*mock_repository.MockInboxItemRepository
+	testutils.EmptyTestRepository
+}
+
+func newRecorder(t *testing.T) (*Recorder, *Repo, *mock_channel.MockManager) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := &Repo{
+		MockUserRepository:      mock_repository.NewMockUserRepository(ctrl),
+		MockMessageRepository:   mock_repository.NewMockMessageRepository(ctrl),
+		MockInboxItemRepository: mock_repository.NewMockInboxItemRepository(ctrl),
+	}
+	cm := mock_channel.NewMockManager(ctrl)
+	return &Recorder{repo: repo, cm: cm, hub: hub.New(), l: zap.NewNop()}, repo, cm
+}
+
+func activeUser(id uuid.UUID) *model.User {
+	return &model.User{ID: id, Status: model.UserAccountStatusActive}
+}
+
+// returnCreated CreateInboxItemsの引数をそのままアイテムとして返します
+func returnCreated(got *[]repository.CreateInboxItemArgs) func(_ any, args []repository.CreateInboxItemArgs) ([]*model.InboxItem, error) {
+	return func(_ any, args []repository.CreateInboxItemArgs) ([]*model.InboxItem, error) {
+		*got = args
+		items := make([]*model.InboxItem, len(args))
+		for i, a := range args {
+			items[i] = &model.InboxItem{ID: uuid.Must(uuid.NewV7()), UserID: a.UserID, Type: a.Type, MessageID: a.MessageID}
+		}
+		return items, nil
+	}
+}
+
+func TestRecorder_processMessage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("public channel", func(t *testing.T) {
+		t.Parallel()
+		r, repo, cm := newRecorder(t)
+
+		author := uuid.Must(uuid.NewV7())
+		mentioned := uuid.Must(uuid.NewV7())
+		groupMember := uuid.Must(uuid.NewV7())
+		citedAuthor := uuid.Must(uuid.NewV7())
+		bot := uuid.Must(uuid.NewV7())
+		gid := uuid.Must(uuid.NewV7())
+		cited := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: citedAuthor}
+		m := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: author, ChannelID: uuid.Must(uuid.NewV7())}
+
+		cm.EXPECT().IsPublicChannel(gomock.Any(), m.ChannelID).Return(true)
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), mentioned, false).Return(activeUser(mentioned), nil).AnyTimes()
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), citedAuthor, false).Return(activeUser(citedAuthor), nil).AnyTimes()
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), bot, false).Return(&model.User{ID: bot, Status: model.UserAccountStatusActive, Bot: true}, nil).AnyTimes()
+		// グループメンバーにはメンションされたユーザーと投稿者を含む
+		repo.MockUserRepository.EXPECT().
+			GetUserIDs(gomock.Any(), repository.UsersQuery{}.Active().NotBot().GMemberOf(gid)).
+			Return([]uuid.UUID{author, mentioned, groupMember}, nil)
+		repo.MockMessageRepository.EXPECT().GetMessageByID(gomock.Any(), cited.ID).Return(cited, nil)
+
+		var got []repository.CreateInboxItemArgs
+		repo.MockInboxItemRepository.EXPECT().CreateInboxItems(gomock.Any(), gomock.Any()).DoAndReturn(returnCreated(&got))
+
+		r.processMessage(m, &message.ParseResult{
+			Mentions:      []uuid.UUID{mentioned, bot},
+			GroupMentions: []uuid.UUID{gid},
+			Citation:      []uuid.UUID{cited.ID},
+		})
+
+		types := map[uuid.UUID]model.InboxItemType{}
+		for _, a := range got {
+			assert.Equal(t, m.ID, a.MessageID)
+			assert.Equal(t, author, a.ActorID)
+			types[a.UserID] = a.Type
+		}
+		assert.Equal(t, map[uuid.UUID]model.InboxItemType{
+			mentioned:   model.InboxItemTypeMention,
+			groupMember: model.InboxItemTypeGroupMention,
+			citedAuthor: model.InboxItemTypeCitation,
+		}, types)
+	})
+
+	t.Run("dm", func(t *testing.T) {
+		t.Parallel()
+		r, repo, cm := newRecorder(t)
+
+		author := uuid.Must(uuid.NewV7())
+		other := uuid.Must(uuid.NewV7())
+		m := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: author, ChannelID: uuid.Must(uuid.NewV7())}
+
+		cm.EXPECT().IsPublicChannel(gomock.Any(), m.ChannelID).Return(false)
+		repo.MockUserRepository.EXPECT().
+			GetUserIDs(gomock.Any(), repository.UsersQuery{}.Active().NotBot().CMemberOf(m.ChannelID)).
+			Return([]uuid.UUID{author, other}, nil)
+
+		var got []repository.CreateInboxItemArgs
+		repo.MockInboxItemRepository.EXPECT().CreateInboxItems(gomock.Any(), gomock.Any()).DoAndReturn(returnCreated(&got))
+
+		r.processMessage(m, &message.ParseResult{Mentions: []uuid.UUID{other}})
+
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, other, got[0].UserID)
+			assert.Equal(t, model.InboxItemTypeDM, got[0].Type)
+		}
+	})
+
+	t.Run("no targets", func(t *testing.T) {
+		t.Parallel()
+		r, _, cm := newRecorder(t)
+
+		m := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: uuid.Must(uuid.NewV7()), ChannelID: uuid.Must(uuid.NewV7())}
+		cm.EXPECT().IsPublicChannel(gomock.Any(), m.ChannelID).Return(true)
+
+		// CreateInboxItemsは呼ばれない
+		r.processMessage(m, &message.ParseResult{})
+	})
+}
+
+func TestRecorder_processStamp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		r, repo, _ := newRecorder(t)
+
+		author := uuid.Must(uuid.NewV7())
+		stamper := uuid.Must(uuid.NewV7())
+		stampID := uuid.Must(uuid.NewV7())
+		m := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: author, ChannelID: uuid.Must(uuid.NewV7())}
+
+		repo.MockMessageRepository.EXPECT().GetMessageByID(gomock.Any(), m.ID).Return(m, nil)
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), author, false).Return(activeUser(author), nil)
+
+		var got []repository.CreateInboxItemArgs
+		repo.MockInboxItemRepository.EXPECT().CreateInboxItems(gomock.Any(), gomock.Any()).DoAndReturn(returnCreated(&got))
+
+		r.processStamp(m.ID, stamper, stampID)
+
+		assert.Equal(t, []repository.CreateInboxItemArgs{{
+			UserID:    author,
+			Type:      model.InboxItemTypeStamp,
+			MessageID: m.ID,
+			ChannelID: m.ChannelID,
+			ActorID:   stamper,
+			StampID:   optional.From(stampID),
+		}}, got)
+	})
+
+	t.Run("own message", func(t *testing.T) {
+		t.Parallel()
+		r, repo, _ := newRecorder(t)
+
+		author := uuid.Must(uuid.NewV7())
+		m := &model.Message{ID: uuid.Must(uuid.NewV7()), UserID: author, ChannelID: uuid.Must(uuid.NewV7())}
+		repo.MockMessageRepository.EXPECT().GetMessageByID(gomock.Any(), m.ID).Return(m, nil)
+
+		r.processStamp(m.ID, author, uuid.Must(uuid.NewV7()))
+	})
+}
+
+func TestRecorder_RecordReminder(t *testing.T) {
+	t.Parallel()
+
+	r, repo, _ := newRecorder(t)
+	uid := uuid.NewV3(uuid.Nil, "u")
+	m := &model.Message{ID: uuid.NewV3(uuid.Nil, "m"), ChannelID: uuid.NewV3(uuid.Nil, "c")}
+
+	var got []repository.CreateInboxItemArgs
+	repo.MockInboxItemRepository.EXPECT().CreateInboxItems(gomock.Any(), gomock.Any()).DoAndReturn(returnCreated(&got)).Times(1)
+
+	if assert.NoError(t, r.RecordReminder(context.TODO(), uid, m.ID, m.ChannelID)) {
+		assert.Equal(t, []repository.CreateInboxItemArgs{{
+			UserID:    uid,
+			Type:      model.InboxItemTypeReminder,
+			MessageID: m.ID,
+			ChannelID: m.ChannelID,
+			ActorID:   uid,
+		}}, got)
+	}
+}
@@ -0,0 +1,13 @@
+package mailer
+
+import "context"
+
+// Mailer メール送信サービス
+type Mailer interface {
+	// Send toにメールを送信します
+	//
+	// bodyはプレーンテキストとして送信されます。
+	Send(ctx context.Context, to, subject, body string) error
+	// Enabled メールを送信可能かどうかを返します
+	Enabled() bool
+}
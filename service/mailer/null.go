@@ -0,0 +1,24 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrDisabled メール送信が無効です
+var ErrDisabled = errors.New("mailer is disabled")
+
+type nullMailer struct{}
+
+// NewNullMailer メールを送信しないMailerを生成します
+func NewNullMailer() Mailer {
+	return &nullMailer{}
+}
+
+func (m *nullMailer) Send(_ context.Context, _, _, _ string) error {
+	return ErrDisabled
+}
+
+func (m *nullMailer) Enabled() bool {
+	return false
+}
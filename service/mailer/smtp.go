@@ -0,0 +1,157 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// sendTimeout 1通のメールの送信にかける最大時間
+const sendTimeout = 30 * time.Second
+
+// Config SMTP設定
+type Config struct {
+	// Host SMTPサーバーのホスト名
+	Host string
+	// Port SMTPサーバーのポート番号
+	Port int
+	// Username SMTP認証のユーザー名 空の場合は認証しません
+	Username string
+	// Password SMTP認証のパスワード
+	Password string
+	// From 送信元メールアドレス
+	From string
+	// TLS 接続開始時からTLSを使用するかどうか (SMTPS)
+	//
+	// falseの場合、サーバーが対応していればSTARTTLSを使用します
+	TLS bool
+}
+
+// Valid 有効な設定かどうか
+func (c Config) Valid() bool {
+	return len(c.Host) > 0 && c.Port > 0 && len(c.From) > 0
+}
+
+type smtpMailer struct {
+	config Config
+	from   *mail.Address
+}
+
+// NewSMTPMailer SMTPでメールを送信するMailerを生成します
+func NewSMTPMailer(config Config) (Mailer, error) {
+	if !config.Valid() {
+		return nil, errors.New("invalid smtp config")
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+	return &smtpMailer{config: config, from: from}, nil
+}
+
+func (m *smtpMailer) Enabled() bool {
+	return true
+}
+
+func (m *smtpMailer) Send(ctx context.Context, to, subject, body string) error {
+	rcpt, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid to address: %w", err)
+	}
+	msg, err := m.buildMessage(rcpt, subject, body, time.Now())
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port)))
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if m.config.TLS {
+		conn = tls.Client(conn, &tls.Config{ServerName: m.config.Host})
+	}
+
+	c, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if !m.config.TLS {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+				return err
+			}
+		}
+	}
+	if len(m.config.Username) > 0 {
+		if err := c.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(rcpt.Address); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		_ = w.Close()
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// buildMessage RFC 5322形式のメールを組み立てます
+func (m *smtpMailer) buildMessage(to *mail.Address, subject, body string, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	header := [][2]string{
+		{"From", m.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", uuid.Must(uuid.NewV4()), m.config.Host)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=UTF-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, h := range header {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h[0], h[1])
+	}
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,150 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sinkMail struct {
+	from string
+	rcpt []string
+	data string
+}
+
+// startSMTPSink 受信したメールをチャンネルに送るだけの最小限のSMTPサーバーを起動します
+func startSMTPSink(t *testing.T) (port int, received <-chan *sinkMail) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+
+	ch := make(chan *sinkMail, 1)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTPSink(conn, ch)
+		}
+	}()
+	return l.Addr().(*net.TCPAddr).Port, ch
+}
+
+func serveSMTPSink(conn net.Conn, ch chan<- *sinkMail) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(s string) { _, _ = io.WriteString(conn, s+"\r\n") }
+
+	m := &sinkMail{}
+	reply("220 localhost ESMTP sink")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			m.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			m.rcpt = append(m.rcpt, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			m.data = data.String()
+			reply("250 OK")
+			ch <- m
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestConfig_Valid(t *testing.T) {
+	t.Parallel()
+	assert.False(t, Config{}.Valid())
+	assert.False(t, Config{Host: "localhost", Port: 25}.Valid())
+	assert.True(t, Config{Host: "localhost", Port: 25, From: "traq@example.com"}.Valid())
+}
+
+func TestNewSMTPMailer(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSMTPMailer(Config{})
+	assert.Error(t, err)
+	_, err = NewSMTPMailer(Config{Host: "localhost", Port: 25, From: "invalid"})
+	assert.Error(t, err)
+	m, err := NewSMTPMailer(Config{Host: "localhost", Port: 25, From: "traQ <traq@example.com>"})
+	require.NoError(t, err)
+	assert.True(t, m.Enabled())
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	t.Parallel()
+
+	port, received := startSMTPSink(t)
+	m, err := NewSMTPMailer(Config{Host: "127.0.0.1", Port: port, From: "traQ <traq@example.com>"})
+	require.NoError(t, err)
+
+	t.Run("invalid to", func(t *testing.T) {
+		t.Parallel()
+		assert.Error(t, m.Send(context.Background(), "invalid", "subject", "body"))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		body := "未読のメンションがあります\n.先頭のドット\n"
+		require.NoError(t, m.Send(context.Background(), "user@example.com", "traQ 未読のお知らせ", body))
+
+		got := <-received
+		assert.Equal(t, "traq@example.com", got.from)
+		assert.Equal(t, []string{"user@example.com"}, got.rcpt)
+
+		msg, err := mail.ReadMessage(strings.NewReader(got.data))
+		require.NoError(t, err)
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "traQ 未読のお知らせ", subject)
+		assert.Equal(t, "<user@example.com>", msg.Header.Get("To"))
+		assert.Equal(t, "text/plain; charset=UTF-8", msg.Header.Get("Content-Type"))
+		decoded, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+		require.NoError(t, err)
+		assert.Equal(t, strings.ReplaceAll(body, "\n", "\r\n"), string(decoded))
+	})
+}
+
+func TestNullMailer(t *testing.T) {
+	t.Parallel()
+	m := NewNullMailer()
+	assert.False(t, m.Enabled())
+	assert.ErrorIs(t, m.Send(context.Background(), "user@example.com", "subject", "body"), ErrDisabled)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/utils/optional"
 )
 
@@ -16,11 +17,15 @@ var (
 	ErrAlreadyExists    = errors.New("already exists")
 	ErrChannelArchived  = errors.New("channel archived")
 	ErrPinLimitExceeded = errors.New("the pin limit exceeded")
+	ErrPollClosed       = errors.New("poll closed")
+	ErrInvalidPollVote  = errors.New("invalid poll vote")
 )
 
 type TimelineQuery struct {
 	User    uuid.UUID
 	Channel uuid.UUID
+	// Parent 指定したメッセージのスレッドの返信を指定
+	Parent uuid.UUID
 	// ChannelsSubscribedByUser 指定したユーザーが購読しているチャンネルのメッセージを指定
 	ChannelsSubscribedByUser uuid.UUID
 	Since                    optional.Of[time.Time]
@@ -63,6 +68,32 @@ type Manager interface {
 	// 成功した場合、メッセージとnilを返します。
 	// DBによるエラーを返すことがあります。
 	CreateDM(ctx context.Context, from, to uuid.UUID, content string) (Message, error)
+	// CreateReply 指定したメッセージのスレッドに返信メッセージを作成します
+	//
+	// 返信メッセージを親に指定した場合、そのスレッドの親メッセージへの返信として作成します。
+	// 成功した場合、メッセージとnilを返します。
+	// アーカイブされているチャンネルのメッセージを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	CreateReply(ctx context.Context, parentID, userID uuid.UUID, content string) (Message, error)
+	// GetReplies 指定したメッセージのスレッドの返信メッセージを取得します
+	//
+	// 成功した場合、タイムラインとnilを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	GetReplies(ctx context.Context, parentID uuid.UUID, query TimelineQuery) (Timeline, error)
+	// SubscribeThread 指定したユーザーの指定したメッセージのスレッドの購読状態を変更します
+	//
+	// 成功した場合、nilを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	SubscribeThread(ctx context.Context, id, userID uuid.UUID, subscribe bool) error
+	// IsThreadSubscribed 指定したユーザーが指定したメッセージのスレッドを購読しているかどうかを返します
+	//
+	// 成功した場合、購読状態とnilを返します。
+	// 存在しないメッセージを指定した場合、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	IsThreadSubscribed(ctx context.Context, id, userID uuid.UUID) (bool, error)
 	// Edit 指定したメッセージを編集します
 	//
 	// 成功した場合、nilを返します。
@@ -108,6 +139,52 @@ type Manager interface {
 	// 存在しないメッセージを指定した場合は、ErrNotFoundを返します。
 	// DBによるエラーを返すことがあります。
 	RemoveStamps(ctx context.Context, id, stampID, userID uuid.UUID) error
+	// CreatePoll 指定したメッセージに投票を添付します
+	//
+	// 成功した場合、投票とnilを返します。
+	// 既に投票が添付されている場合は、ErrAlreadyExistsを返します。
+	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージを指定した場合は、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	CreatePoll(ctx context.Context, args repository.CreatePollArgs) (*model.Poll, error)
+	// VotePoll 指定したメッセージの投票の指定したユーザーの票を指定した選択肢に置き換えます
+	//
+	// 成功した場合、nilを返します。
+	// 選択肢が空・重複している場合や、投票に含まれない選択肢を指定した場合、単一選択の投票に複数の選択肢を指定した場合は、ErrInvalidPollVoteを返します。
+	// 投票が締め切られている場合は、ErrPollClosedを返します。
+	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージや投票が添付されていないメッセージを指定した場合は、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	VotePoll(ctx context.Context, id, userID uuid.UUID, optionIDs []uuid.UUID) error
+	// RetractPollVote 指定したメッセージの投票の指定したユーザーの票を取り消します
+	//
+	// 成功した場合、或いは既に取り消されていた場合、nilを返します。
+	// 投票が締め切られている場合は、ErrPollClosedを返します。
+	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージや投票が添付されていないメッセージを指定した場合は、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	RetractPollVote(ctx context.Context, id, userID uuid.UUID) error
+	// ClosePoll 指定したメッセージの投票を締め切ります
+	//
+	// 成功した場合、締め切った投票とnilを返します。
+	// 既に締め切られている場合は、ErrPollClosedを返します。
+	// 存在しないメッセージや投票が添付されていないメッセージを指定した場合は、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	ClosePoll(ctx context.Context, id uuid.UUID) (*model.Poll, error)
+	// SetComponents 指定したメッセージのコンポーネントを置き換えます
+	//
+	// 成功した場合、表示順に並んだコンポーネントの配列とnilを返します。
+	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージを指定した場合は、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	SetComponents(ctx context.Context, id uuid.UUID, args []repository.SetMessageComponentArgs) ([]model.MessageComponent, error)
+	// UpdateComponent 指定したメッセージの指定したCustomIDのコンポーネントを更新します
+	//
+	// 成功した場合、更新後のコンポーネントとnilを返します。
+	// アーカイブされているチャンネルを指定すると、ErrChannelArchivedを返します。
+	// 存在しないメッセージやコンポーネントを指定した場合は、ErrNotFoundを返します。
+	// DBによるエラーを返すことがあります。
+	UpdateComponent(ctx context.Context, id uuid.UUID, customID string, args repository.UpdateMessageComponentArgs) (*model.MessageComponent, error)
 
 	Wait(ctx context.Context) error
 }
@@ -3,6 +3,7 @@ package message
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -79,6 +80,7 @@ func (m *manager) GetTimeline(ctx context.Context, query TimelineQuery) (Timelin
 	q := repository.MessagesQuery{
 		User:                     query.User,
 		Channel:                  query.Channel,
+		Parent:                   query.Parent,
 		ChannelsSubscribedByUser: query.ChannelsSubscribedByUser,
 		Since:                    query.Since,
 		Until:                    query.Until,
@@ -132,6 +134,90 @@ func (m *manager) create(ctx context.Context, channelID, userID uuid.UUID, conte
 	return &message{Model: msg}, nil
 }
 
+func (m *manager) CreateReply(ctx context.Context, parentID, userID uuid.UUID, content string) (Message, error) {
+	// 親メッセージ取得
+	root, err := m.getThreadRoot(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// チャンネルがアーカイブされているかどうか確認
+	if m.CM.IsPublicChannel(ctx, root.GetChannelID()) && m.CM.PublicChannelTree(context.Background()).IsArchivedChannel(root.GetChannelID()) {
+		return nil, ErrChannelArchived
+	}
+
+	// 作成
+	msg, err := m.R.CreateReplyMessage(ctx, userID, root.GetID(), content)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, ErrNotFound
+		default:
+			return nil, fmt.Errorf("failed to CreateReplyMessage: %w", err)
+		}
+	}
+
+	// 返信数が変わるのでキャッシュ削除
+	m.cache.Forget(root.GetID())
+
+	return &message{Model: msg}, nil
+}
+
+func (m *manager) GetReplies(ctx context.Context, parentID uuid.UUID, query TimelineQuery) (Timeline, error) {
+	// 親メッセージ取得
+	root, err := m.getThreadRoot(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	query.Parent = root.GetID()
+	return m.GetTimeline(ctx, query)
+}
+
+func (m *manager) SubscribeThread(ctx context.Context, id, userID uuid.UUID, subscribe bool) error {
+	// 親メッセージ取得
+	root, err := m.getThreadRoot(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if subscribe {
+		err = m.R.SubscribeMessageThread(ctx, root.GetID(), userID)
+	} else {
+		err = m.R.UnsubscribeMessageThread(ctx, root.GetID(), userID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to change thread subscription: %w", err)
+	}
+	return nil
+}
+
+func (m *manager) IsThreadSubscribed(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	// 親メッセージ取得
+	root, err := m.getThreadRoot(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	ok, err := m.R.IsMessageThreadSubscribed(ctx, root.GetID(), userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to IsMessageThreadSubscribed: %w", err)
+	}
+	return ok, nil
+}
+
+// getThreadRoot 指定したメッセージが属するスレッドの親メッセージを取得します
+func (m *manager) getThreadRoot(ctx context.Context, id uuid.UUID) (*message, error) {
+	msg, err := m.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p := msg.GetParentMessageID(); p.Valid {
+		return m.get(ctx, p.V)
+	}
+	return msg, nil
+}
+
 func (m *manager) Edit(ctx context.Context, id uuid.UUID, content string) error {
 	// メッセージ取得
 	msg, err := m.Get(ctx, id)
@@ -180,6 +266,10 @@ func (m *manager) Delete(ctx context.Context, id uuid.UUID) error {
 		}
 	}
 	m.cache.Forget(id)
+	if p := msg.GetParentMessageID(); p.Valid {
+		// 返信数が変わるので親メッセージのキャッシュも削除
+		m.cache.Forget(p.V)
+	}
 
 	return nil
 }
@@ -316,6 +406,204 @@ func (m *manager) RemoveStamps(ctx context.Context, id, stampID, userID uuid.UUI
 	return nil
 }
 
+func (m *manager) CreatePoll(ctx context.Context, args repository.CreatePollArgs) (*model.Poll, error) {
+	// メッセージ取得
+	msg, err := m.get(ctx, args.MessageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// すでに投票が添付されているか
+	if msg.GetPoll() != nil {
+		return nil, ErrAlreadyExists
+	}
+
+	// チャンネルがアーカイブされているかどうか確認
+	if m.CM.IsPublicChannel(context.Background(), msg.GetChannelID()) && m.CM.PublicChannelTree(context.Background()).IsArchivedChannel(msg.GetChannelID()) {
+		return nil, ErrChannelArchived
+	}
+
+	// 投票作成
+	poll, err := m.R.CreatePoll(ctx, args)
+	if err != nil {
+		switch err {
+		case repository.ErrAlreadyExists:
+			return nil, ErrAlreadyExists
+		default:
+			return nil, fmt.Errorf("failed to CreatePoll: %w", err)
+		}
+	}
+
+	// キャッシュ削除
+	m.cache.Forget(args.MessageID)
+
+	return poll, nil
+}
+
+func (m *manager) VotePoll(ctx context.Context, id, userID uuid.UUID, optionIDs []uuid.UUID) error {
+	poll, err := m.getVotablePoll(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// 選択肢検証
+	if len(optionIDs) == 0 || (!poll.MultipleChoice && len(optionIDs) > 1) {
+		return ErrInvalidPollVote
+	}
+	for i, optionID := range optionIDs {
+		if !poll.HasOption(optionID) || slices.Contains(optionIDs[:i], optionID) {
+			return ErrInvalidPollVote
+		}
+	}
+
+	// 投票
+	if err := m.R.SetPollVotes(ctx, poll.ID, userID, optionIDs); err != nil {
+		if err == repository.ErrForbidden {
+			// 確認後に締め切られた
+			return ErrPollClosed
+		}
+		return fmt.Errorf("failed to SetPollVotes: %w", err)
+	}
+
+	// キャッシュ削除
+	m.cache.Forget(id)
+
+	return nil
+}
+
+func (m *manager) RetractPollVote(ctx context.Context, id, userID uuid.UUID) error {
+	poll, err := m.getVotablePoll(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// 票の取り消し
+	if err := m.R.SetPollVotes(ctx, poll.ID, userID, nil); err != nil {
+		if err == repository.ErrForbidden {
+			// 確認後に締め切られた
+			return ErrPollClosed
+		}
+		return fmt.Errorf("failed to SetPollVotes: %w", err)
+	}
+
+	// キャッシュ削除
+	m.cache.Forget(id)
+
+	return nil
+}
+
+// getVotablePoll 指定したメッセージの投票を票を変更できる状態か確認して取得します
+func (m *manager) getVotablePoll(ctx context.Context, id uuid.UUID) (*model.Poll, error) {
+	// メッセージ取得
+	msg, err := m.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 投票が添付されているか
+	poll := msg.GetPoll()
+	if poll == nil {
+		return nil, ErrNotFound
+	}
+
+	// 投票が締め切られているか
+	if poll.IsClosed(time.Now()) {
+		return nil, ErrPollClosed
+	}
+
+	// チャンネルがアーカイブされているかどうか確認
+	if m.CM.IsPublicChannel(context.Background(), msg.GetChannelID()) && m.CM.PublicChannelTree(context.Background()).IsArchivedChannel(msg.GetChannelID()) {
+		return nil, ErrChannelArchived
+	}
+	return poll, nil
+}
+
+func (m *manager) ClosePoll(ctx context.Context, id uuid.UUID) (*model.Poll, error) {
+	// メッセージ取得
+	msg, err := m.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 投票が添付されているか
+	poll := msg.GetPoll()
+	if poll == nil {
+		return nil, ErrNotFound
+	}
+
+	// 締め切る
+	poll, err = m.R.ClosePoll(ctx, poll.ID, time.Now())
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, ErrNotFound
+		case repository.ErrAlreadyExists:
+			return nil, ErrPollClosed
+		default:
+			return nil, fmt.Errorf("failed to ClosePoll: %w", err)
+		}
+	}
+
+	// キャッシュ削除
+	m.cache.Forget(id)
+
+	return poll, nil
+}
+
+func (m *manager) SetComponents(ctx context.Context, id uuid.UUID, args []repository.SetMessageComponentArgs) ([]model.MessageComponent, error) {
+	if err := m.checkComponentsEditable(ctx, id); err != nil {
+		return nil, err
+	}
+
+	// コンポーネント置き換え
+	components, err := m.R.SetMessageComponents(ctx, id, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to SetMessageComponents: %w", err)
+	}
+
+	// キャッシュ削除
+	m.cache.Forget(id)
+
+	return components, nil
+}
+
+func (m *manager) UpdateComponent(ctx context.Context, id uuid.UUID, customID string, args repository.UpdateMessageComponentArgs) (*model.MessageComponent, error) {
+	if err := m.checkComponentsEditable(ctx, id); err != nil {
+		return nil, err
+	}
+
+	// コンポーネント更新
+	c, err := m.R.UpdateMessageComponent(ctx, id, customID, args)
+	if err != nil {
+		switch err {
+		case repository.ErrNotFound:
+			return nil, ErrNotFound
+		default:
+			return nil, fmt.Errorf("failed to UpdateMessageComponent: %w", err)
+		}
+	}
+
+	// キャッシュ削除
+	m.cache.Forget(id)
+
+	return c, nil
+}
+
+// checkComponentsEditable 指定したメッセージのコンポーネントを変更できる状態か確認します
+func (m *manager) checkComponentsEditable(ctx context.Context, id uuid.UUID) error {
+	// メッセージ取得
+	msg, err := m.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// チャンネルがアーカイブされているかどうか確認
+	if m.CM.IsPublicChannel(context.Background(), msg.GetChannelID()) && m.CM.PublicChannelTree(context.Background()).IsArchivedChannel(msg.GetChannelID()) {
+		return ErrChannelArchived
+	}
+	return nil
+}
+
 func (m *manager) Wait(_ context.Context) error {
 	m.P.Wait()
 	return nil
@@ -13,6 +13,7 @@ import (
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 func setupM(ctrl *gomock.Controller) (Manager, *mock_channel.MockManager, *Repo, *mock_channel.MockTree) {
@@ -215,6 +216,120 @@ func TestManager_CreateDM(t *testing.T) {
 	})
 }
 
+func TestManager_CreateReply(t *testing.T) {
+	t.Parallel()
+	const content = "reply"
+
+	t.Run("parent not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), pid).
+			Return(nil, repository.ErrNotFound).
+			Times(1)
+
+		_, err := m.CreateReply(context.TODO(), pid, uuid.NewV3(uuid.Nil, "u1"), content)
+		assert.EqualError(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("channel archived", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), pid).
+			Return(&model.Message{ID: pid, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(true).Times(1)
+
+		_, err := m.CreateReply(context.TODO(), pid, uuid.NewV3(uuid.Nil, "u1"), content)
+		assert.EqualError(t, err, ErrChannelArchived.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		pid := uuid.NewV3(uuid.Nil, "m1")
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), pid).
+			Return(&model.Message{ID: pid, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateReplyMessage(gomock.Any(), uid, pid, content).
+			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m2"), UserID: uid, ChannelID: cid, Text: content, ParentMessageID: optional.From(pid)}, nil).
+			Times(1)
+
+		msg, err := m.CreateReply(context.TODO(), pid, uid, content)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, cid, msg.GetChannelID())
+			assert.EqualValues(t, uid, msg.GetUserID())
+			assert.EqualValues(t, content, msg.GetText())
+			assert.EqualValues(t, optional.From(pid), msg.GetParentMessageID())
+		}
+
+		// 返信数が変わるので親メッセージはキャッシュから削除されている
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), pid).
+			Return(&model.Message{ID: pid, ChannelID: cid, ReplyCount: 1}, nil).
+			Times(1)
+		result, err := m.Get(context.TODO(), pid)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, 1, result.GetReplyCount())
+		}
+	})
+
+	t.Run("success (reply to reply)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		rootID := uuid.NewV3(uuid.Nil, "m1")
+		pid := uuid.NewV3(uuid.Nil, "m2")
+		cid := uuid.NewV3(uuid.Nil, "c1")
+		uid := uuid.NewV3(uuid.Nil, "u1")
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), pid).
+			Return(&model.Message{ID: pid, ChannelID: cid, ParentMessageID: optional.From(rootID)}, nil).
+			Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), rootID).
+			Return(&model.Message{ID: rootID, ChannelID: cid, ReplyCount: 1}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockMessageRepository.
+			EXPECT().
+			CreateReplyMessage(gomock.Any(), uid, rootID, content).
+			Return(&model.Message{ID: uuid.NewV3(uuid.Nil, "m3"), UserID: uid, ChannelID: cid, Text: content, ParentMessageID: optional.From(rootID)}, nil).
+			Times(1)
+
+		msg, err := m.CreateReply(context.TODO(), pid, uid, content)
+		if assert.NoError(t, err) {
+			assert.EqualValues(t, optional.From(rootID), msg.GetParentMessageID())
+		}
+	})
+}
+
 func TestManager_Edit(t *testing.T) {
 	t.Parallel()
 	const newContent = "new message"
@@ -556,3 +671,256 @@ func TestManager_RemoveStamps(t *testing.T) {
 		}
 	})
 }
+
+func TestManager_VotePoll(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.NewV3(uuid.Nil, "m1")
+	cid := uuid.NewV3(uuid.Nil, "c1")
+	uid := uuid.NewV3(uuid.Nil, "u1")
+	pid := uuid.NewV3(uuid.Nil, "p1")
+	o1 := uuid.NewV3(uuid.Nil, "o1")
+	o2 := uuid.NewV3(uuid.Nil, "o2")
+	newMessage := func(poll *model.Poll) *model.Message {
+		return &model.Message{ID: id, ChannelID: cid, Poll: poll}
+	}
+	newPoll := func(multipleChoice bool) *model.Poll {
+		return &model.Poll{ID: pid, MessageID: id, MultipleChoice: multipleChoice, Options: []model.PollOption{{ID: o1, PollID: pid}, {ID: o2, PollID: pid}}}
+	}
+
+	t.Run("poll not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(newMessage(nil), nil).
+			Times(1)
+
+		assert.EqualError(t, m.VotePoll(context.TODO(), id, uid, []uuid.UUID{o1}), ErrNotFound.Error())
+	})
+
+	t.Run("poll closed", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		poll := newPoll(false)
+		poll.Deadline = optional.From(time.Now().Add(-time.Minute))
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(newMessage(poll), nil).
+			Times(1)
+
+		assert.EqualError(t, m.VotePoll(context.TODO(), id, uid, []uuid.UUID{o1}), ErrPollClosed.Error())
+	})
+
+	t.Run("invalid votes", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(newMessage(newPoll(false)), nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).AnyTimes()
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).AnyTimes()
+
+		for _, optionIDs := range [][]uuid.UUID{
+			nil,
+			{o1, o2},
+			{uuid.NewV3(uuid.Nil, "o3")},
+		} {
+			assert.EqualError(t, m.VotePoll(context.TODO(), id, uid, optionIDs), ErrInvalidPollVote.Error())
+		}
+	})
+
+	t.Run("duplicated options", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(newMessage(newPoll(true)), nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+
+		assert.EqualError(t, m.VotePoll(context.TODO(), id, uid, []uuid.UUID{o1, o1}), ErrInvalidPollVote.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(newMessage(newPoll(true)), nil).
+			Times(2)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockPollRepository.
+			EXPECT().
+			SetPollVotes(gomock.Any(), pid, uid, []uuid.UUID{o1, o2}).
+			Return(nil).
+			Times(1)
+
+		if assert.NoError(t, m.VotePoll(context.TODO(), id, uid, []uuid.UUID{o1, o2})) {
+			// キャッシュが削除されている
+			_, err := m.Get(context.TODO(), id)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("closed while voting", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(newMessage(newPoll(true)), nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).Times(1)
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).Times(1)
+		repo.MockPollRepository.
+			EXPECT().
+			SetPollVotes(gomock.Any(), pid, uid, []uuid.UUID{o1}).
+			Return(repository.ErrForbidden).
+			Times(1)
+
+		assert.EqualError(t, m.VotePoll(context.TODO(), id, uid, []uuid.UUID{o1}), ErrPollClosed.Error())
+	})
+}
+
+func TestManager_ClosePoll(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.NewV3(uuid.Nil, "m1")
+	pid := uuid.NewV3(uuid.Nil, "p1")
+
+	t.Run("already closed", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(&model.Message{ID: id, Poll: &model.Poll{ID: pid, MessageID: id}}, nil).
+			Times(1)
+		repo.MockPollRepository.
+			EXPECT().
+			ClosePoll(gomock.Any(), pid, gomock.Any()).
+			Return(nil, repository.ErrAlreadyExists).
+			Times(1)
+
+		_, err := m.ClosePoll(context.TODO(), id)
+		assert.EqualError(t, err, ErrPollClosed.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, _, repo, _ := setupM(ctrl)
+
+		closed := &model.Poll{ID: pid, MessageID: id, ClosedAt: optional.From(time.Now())}
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(&model.Message{ID: id, Poll: &model.Poll{ID: pid, MessageID: id}}, nil).
+			Times(1)
+		repo.MockPollRepository.
+			EXPECT().
+			ClosePoll(gomock.Any(), pid, gomock.Any()).
+			Return(closed, nil).
+			Times(1)
+
+		poll, err := m.ClosePoll(context.TODO(), id)
+		if assert.NoError(t, err) {
+			assert.Equal(t, closed, poll)
+		}
+	})
+}
+
+func TestManager_UpdateComponent(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.NewV3(uuid.Nil, "m1")
+	cid := uuid.NewV3(uuid.Nil, "c1")
+	args := repository.UpdateMessageComponentArgs{Disabled: optional.From(true)}
+
+	t.Run("channel archived", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(&model.Message{ID: id, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).AnyTimes()
+		tree.EXPECT().IsArchivedChannel(cid).Return(true).AnyTimes()
+
+		_, err := m.UpdateComponent(context.TODO(), id, "approve", args)
+		assert.EqualError(t, err, ErrChannelArchived.Error())
+	})
+
+	t.Run("component not found", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(&model.Message{ID: id, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).AnyTimes()
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).AnyTimes()
+		repo.MockMessageComponentRepository.
+			EXPECT().
+			UpdateMessageComponent(gomock.Any(), id, "approve", args).
+			Return(nil, repository.ErrNotFound).
+			Times(1)
+
+		_, err := m.UpdateComponent(context.TODO(), id, "approve", args)
+		assert.EqualError(t, err, ErrNotFound.Error())
+	})
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		m, cm, repo, tree := setupM(ctrl)
+
+		updated := &model.MessageComponent{MessageID: id, CustomID: "approve", Disabled: true}
+		repo.MockMessageRepository.
+			EXPECT().
+			GetMessageByID(gomock.Any(), id).
+			Return(&model.Message{ID: id, ChannelID: cid}, nil).
+			Times(1)
+		cm.EXPECT().IsPublicChannel(gomock.Any(), cid).Return(true).AnyTimes()
+		tree.EXPECT().IsArchivedChannel(cid).Return(false).AnyTimes()
+		repo.MockMessageComponentRepository.
+			EXPECT().
+			UpdateMessageComponent(gomock.Any(), id, "approve", args).
+			Return(updated, nil).
+			Times(1)
+
+		c, err := m.UpdateComponent(context.TODO(), id, "approve", args)
+		if assert.NoError(t, err) {
+			assert.Equal(t, updated, c)
+		}
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gofrs/uuid"
 
 	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 type Message interface {
@@ -18,6 +19,11 @@ type Message interface {
 	GetUpdatedAt() time.Time
 	GetStamps() []model.MessageStamp
 	GetPin() *model.Pin
+	GetParentMessageID() optional.Of[uuid.UUID]
+	GetReplyCount() int
+	GetEditCount() int
+	GetPoll() *model.Poll
+	GetComponents() []model.MessageComponent
 
 	json.Marshaler
 }
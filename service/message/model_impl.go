@@ -124,29 +124,71 @@ func (m *message) GetPin() *model.Pin {
 	return m.Model.Pin
 }
 
+func (m *message) GetParentMessageID() optional.Of[uuid.UUID] {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.ParentMessageID
+}
+
+func (m *message) GetReplyCount() int {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.ReplyCount
+}
+
+func (m *message) GetEditCount() int {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.EditCount
+}
+
+func (m *message) GetPoll() *model.Poll {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.Poll
+}
+
+func (m *message) GetComponents() []model.MessageComponent {
+	m.RLock()
+	defer m.RUnlock()
+	return m.Model.Components
+}
+
 func (m *message) MarshalJSON() ([]byte, error) {
 	type obj struct {
-		ID        uuid.UUID              `json:"id"`
-		UserID    uuid.UUID              `json:"userId"`
-		ChannelID uuid.UUID              `json:"channelId"`
-		Content   string                 `json:"content"`
-		CreatedAt time.Time              `json:"createdAt"`
-		UpdatedAt time.Time              `json:"updatedAt"`
-		Pinned    bool                   `json:"pinned"`
-		Stamps    []model.MessageStamp   `json:"stamps"`
-		ThreadID  optional.Of[uuid.UUID] `json:"threadId"` // TODO
+		ID         uuid.UUID                `json:"id"`
+		UserID     uuid.UUID                `json:"userId"`
+		ChannelID  uuid.UUID                `json:"channelId"`
+		Content    string                   `json:"content"`
+		CreatedAt  time.Time                `json:"createdAt"`
+		UpdatedAt  time.Time                `json:"updatedAt"`
+		Pinned     bool                     `json:"pinned"`
+		Stamps     []model.MessageStamp     `json:"stamps"`
+		ThreadID   optional.Of[uuid.UUID]   `json:"threadId"`
+		ReplyCount int                      `json:"replyCount"`
+		EditCount  int                      `json:"editCount"`
+		Poll       *model.Poll              `json:"poll"`
+		Components []model.MessageComponent `json:"components"`
 	}
 	stamps := m.GetStamps()
 	m.RLock()
 	v := &obj{
-		ID:        m.Model.ID,
-		UserID:    m.Model.UserID,
-		ChannelID: m.Model.ChannelID,
-		Content:   m.Model.Text,
-		CreatedAt: m.Model.CreatedAt,
-		UpdatedAt: m.Model.UpdatedAt,
-		Pinned:    m.Model.Pin != nil,
-		Stamps:    stamps,
+		ID:         m.Model.ID,
+		UserID:     m.Model.UserID,
+		ChannelID:  m.Model.ChannelID,
+		Content:    m.Model.Text,
+		CreatedAt:  m.Model.CreatedAt,
+		UpdatedAt:  m.Model.UpdatedAt,
+		Pinned:     m.Model.Pin != nil,
+		Stamps:     stamps,
+		ThreadID:   m.Model.ParentMessageID,
+		ReplyCount: m.Model.ReplyCount,
+		EditCount:  m.Model.EditCount,
+		Poll:       m.Model.Poll,
+		Components: m.Model.Components,
+	}
+	if v.Components == nil {
+		v.Components = []model.MessageComponent{}
 	}
 	m.RUnlock()
 	return jsonIter.ConfigFastest.Marshal(v)
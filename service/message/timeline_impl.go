@@ -76,6 +76,26 @@ func (m *timelineMessage) GetPin() *model.Pin {
 	return m.Model.Pin
 }
 
+func (m *timelineMessage) GetParentMessageID() optional.Of[uuid.UUID] {
+	return m.Model.ParentMessageID
+}
+
+func (m *timelineMessage) GetReplyCount() int {
+	return m.Model.ReplyCount
+}
+
+func (m *timelineMessage) GetEditCount() int {
+	return m.Model.EditCount
+}
+
+func (m *timelineMessage) GetPoll() *model.Poll {
+	return m.Model.Poll
+}
+
+func (m *timelineMessage) GetComponents() []model.MessageComponent {
+	return m.Model.Components
+}
+
 func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 	type object struct {
 		ID        uuid.UUID `json:"id"`
@@ -87,13 +107,17 @@ func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 	}
 	type objectWithPreload struct {
 		object
-		Pinned   bool                   `json:"pinned"`
-		Stamps   []model.MessageStamp   `json:"stamps"`
-		ThreadID optional.Of[uuid.UUID] `json:"threadId"` // TODO
+		Pinned     bool                     `json:"pinned"`
+		Stamps     []model.MessageStamp     `json:"stamps"`
+		ThreadID   optional.Of[uuid.UUID]   `json:"threadId"`
+		ReplyCount int                      `json:"replyCount"`
+		EditCount  int                      `json:"editCount"`
+		Poll       *model.Poll              `json:"poll"`
+		Components []model.MessageComponent `json:"components"`
 	}
 	var v interface{}
 	if m.preloaded {
-		v = &objectWithPreload{
+		obj := &objectWithPreload{
 			object: object{
 				ID:        m.Model.ID,
 				UserID:    m.Model.UserID,
@@ -102,9 +126,18 @@ func (m *timelineMessage) MarshalJSON() ([]byte, error) {
 				CreatedAt: m.Model.CreatedAt,
 				UpdatedAt: m.Model.UpdatedAt,
 			},
-			Pinned: m.Model.Pin != nil,
-			Stamps: m.Model.Stamps,
+			Pinned:     m.Model.Pin != nil,
+			Stamps:     m.Model.Stamps,
+			ThreadID:   m.Model.ParentMessageID,
+			ReplyCount: m.Model.ReplyCount,
+			EditCount:  m.Model.EditCount,
+			Poll:       m.Model.Poll,
+			Components: m.Model.Components,
+		}
+		if obj.Components == nil {
+			obj.Components = []model.MessageComponent{}
 		}
+		v = obj
 	} else {
 		v = &object{
 			ID:        m.Model.ID,
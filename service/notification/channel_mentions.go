@@ -0,0 +1,63 @@
+package notification
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/service/rbac/permission"
+	"github.com/traPtitech/traQ/utils/message"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// channelMentionees メッセージのチャンネル全体へのメンション(@here, @channel)の対象ユーザーを返します
+//
+// @channelはsubscribers(チャンネルの通知・未読管理購読者)全員、@hereはチャンネル閲覧中のユーザーとオンラインの購読者が対象です。
+// 投稿者がmention_channelパーミッションを持っていない場合や、チャンネルでチャンネル全体へのメンションが無効な場合は空集合を返します。
+func (ns *Service) channelMentionees(parsed *message.ParseResult, channelID uuid.UUID, author model.UserInfo, subscribers set.UUID) set.UUID {
+	res := set.UUID{}
+	if !parsed.HereMention && !parsed.SubscribersMention {
+		return res
+	}
+	if !ns.rbac.IsGranted(author.GetRole(), permission.MentionChannel) {
+		return res
+	}
+	ch, err := ns.cm.GetChannel(context.Background(), channelID)
+	if err != nil {
+		ns.logger.Error("failed to GetChannel", zap.Error(err), zap.Stringer("channelId", channelID)) // 失敗
+		return res
+	}
+	if ch.DisableChannelMentions {
+		return res
+	}
+
+	if parsed.SubscribersMention {
+		res.Plus(subscribers)
+	}
+	if parsed.HereMention {
+		for uid := range subscribers {
+			if ns.oc.IsOnline(uid) {
+				res.Add(uid)
+			}
+		}
+		for uid := range ns.vm.GetChannelViewers(channelID) {
+			if res.Contains(uid) {
+				continue
+			}
+			user, err := ns.repo.GetUser(context.Background(), uid, false)
+			if err != nil {
+				ns.logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("userId", uid)) // 失敗
+				continue
+			}
+			// 凍結ユーザー / Botの除外
+			if !user.IsActive() || user.IsBot() {
+				continue
+			}
+			res.Add(uid)
+		}
+	}
+	res.Remove(author.GetID())
+	return res
+}
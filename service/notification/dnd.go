@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// filterDoNotDisturb targetsからおやすみモード中・スヌーズ中のユーザーを除外したものを返します
+//
+// bypassがtrueを返すユーザーは除外しません。bypassはnilでも構いません。
+// ユーザー設定の取得に失敗した場合はtargetsをそのまま返します。
+func (ns *Service) filterDoNotDisturb(targets set.UUID, bypass func(us *model.UserSettings) bool) set.UUID {
+	if len(targets) == 0 {
+		return targets
+	}
+
+	settings, err := ns.repo.GetUserSettingsByUserIDs(context.Background(), targets.Array())
+	if err != nil {
+		ns.logger.Error("failed to GetUserSettingsByUserIDs", zap.Error(err)) // 失敗
+		return targets
+	}
+
+	now := time.Now()
+	filtered := targets.Clone()
+	for _, us := range settings {
+		if !us.IsDoNotDisturb(now) {
+			continue
+		}
+		if bypass != nil && bypass(us) {
+			continue
+		}
+		filtered.Remove(us.UserID)
+	}
+	return filtered
+}
@@ -15,8 +15,9 @@ import (
 	"github.com/traPtitech/traQ/event"
 	"github.com/traPtitech/traQ/model"
 	"github.com/traPtitech/traQ/repository"
-	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/service/push"
 	"github.com/traPtitech/traQ/service/qall"
+	"github.com/traPtitech/traQ/service/rbac/permission"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/ws"
 	"github.com/traPtitech/traQ/utils/message"
@@ -26,53 +27,62 @@ import (
 type eventHandler func(ns *Service, ev hub.Message)
 
 var handlerMap = map[string]eventHandler{
-	event.MessageCreated:            messageCreatedHandler,
-	event.MessageUpdated:            messageUpdatedHandler,
-	event.MessageDeleted:            messageDeletedHandler,
-	event.MessagePinned:             messagePinnedHandler,
-	event.MessageUnpinned:           messageUnpinnedHandler,
-	event.MessageStamped:            messageStampedHandler,
-	event.MessageUnstamped:          messageUnstampedHandler,
-	event.ChannelCreated:            channelCreatedHandler,
-	event.ChannelUpdated:            channelUpdatedHandler,
-	event.ChannelDeleted:            channelDeletedHandler,
-	event.ChannelStared:             channelStaredHandler,
-	event.ChannelUnstared:           channelUnstaredHandler,
-	event.ChannelRead:               channelReadHandler,
-	event.ChannelViewersChanged:     channelViewersChangedHandler,
-	event.ChannelSubscribersChanged: channelSubscribersChangedHandler,
-	event.UserCreated:               userCreatedHandler,
-	event.UserUpdated:               userUpdatedHandler,
-	event.UserIconUpdated:           userIconUpdatedHandler,
-	event.UserOnline:                userOnlineHandler,
-	event.UserOffline:               userOfflineHandler,
-	event.UserViewStateChanged:      userViewStateChangedHandler,
-	event.UserTagAdded:              userTagUpdatedHandler,
-	event.UserTagRemoved:            userTagUpdatedHandler,
-	event.UserTagUpdated:            userTagUpdatedHandler,
-	event.UserGroupCreated:          userGroupCreatedHandler,
-	event.UserGroupUpdated:          userGroupUpdatedHandler,
-	event.UserGroupDeleted:          userGroupDeletedHandler,
-	event.UserGroupMemberAdded:      userGroupUpdatedHandler,
-	event.UserGroupMemberUpdated:    userGroupUpdatedHandler,
-	event.UserGroupMemberRemoved:    userGroupUpdatedHandler,
-	event.UserGroupAdminAdded:       userGroupUpdatedHandler,
-	event.UserGroupAdminRemoved:     userGroupUpdatedHandler,
-	event.StampCreated:              stampCreatedHandler,
-	event.StampUpdated:              stampUpdatedHandler,
-	event.StampDeleted:              stampDeletedHandler,
-	event.StampPaletteCreated:       stampPaletteCreatedHandler,
-	event.StampPaletteUpdated:       stampPaletteUpdatedHandler,
-	event.StampPaletteDeleted:       stampPaletteDeletedHandler,
-	event.UserWebRTCv3StateChanged:  userWebRTCv3StateChangedHandler,
-	event.ClipFolderCreated:         clipFolderCreatedHandler,
-	event.ClipFolderUpdated:         clipFolderUpdatedHandler,
-	event.ClipFolderDeleted:         clipFolderDeletedHandler,
-	event.ClipFolderMessageDeleted:  clipFolderMessageDeletedHandler,
-	event.ClipFolderMessageAdded:    clipFolderMessageAddedHandler,
-	event.QallRoomStateChanged:      qallRoomStateChangedHandler,
-	event.QallSoundboardItemCreated: qallSoundboardItemCreatedHandler,
-	event.QallSoundboardItemDeleted: qallSoundboardItemDeletedHandler,
+	event.MessageCreated:             messageCreatedHandler,
+	event.MessageUpdated:             messageUpdatedHandler,
+	event.MessageDeleted:             messageDeletedHandler,
+	event.MessagePinned:              messagePinnedHandler,
+	event.MessageUnpinned:            messageUnpinnedHandler,
+	event.MessageStamped:             messageStampedHandler,
+	event.MessageUnstamped:           messageUnstampedHandler,
+	event.PollCreated:                pollUpdatedHandler,
+	event.PollVoted:                  pollUpdatedHandler,
+	event.PollClosed:                 pollUpdatedHandler,
+	event.MessageComponentsUpdated:   messageComponentsUpdatedHandler,
+	event.BotCommandEphemeralReplied: botCommandEphemeralRepliedHandler,
+	event.SavedSearchMatched:         savedSearchMatchedHandler,
+	event.InboxItemCreated:           inboxItemCreatedHandler,
+	event.MessageReportCreated:       messageReportCreatedHandler,
+	event.MessageReportStateChanged:  messageReportStateChangedHandler,
+	event.ChannelCreated:             channelCreatedHandler,
+	event.ChannelUpdated:             channelUpdatedHandler,
+	event.ChannelDeleted:             channelDeletedHandler,
+	event.ChannelStared:              channelStaredHandler,
+	event.ChannelUnstared:            channelUnstaredHandler,
+	event.ChannelRead:                channelReadHandler,
+	event.ChannelViewersChanged:      channelViewersChangedHandler,
+	event.ChannelSubscribersChanged:  channelSubscribersChangedHandler,
+	event.UserCreated:                userCreatedHandler,
+	event.UserUpdated:                userUpdatedHandler,
+	event.UserIconUpdated:            userIconUpdatedHandler,
+	event.UserOnline:                 userOnlineHandler,
+	event.UserOffline:                userOfflineHandler,
+	event.UserViewStateChanged:       userViewStateChangedHandler,
+	event.UserTagAdded:               userTagUpdatedHandler,
+	event.UserTagRemoved:             userTagUpdatedHandler,
+	event.UserTagUpdated:             userTagUpdatedHandler,
+	event.UserGroupCreated:           userGroupCreatedHandler,
+	event.UserGroupUpdated:           userGroupUpdatedHandler,
+	event.UserGroupDeleted:           userGroupDeletedHandler,
+	event.UserGroupMemberAdded:       userGroupUpdatedHandler,
+	event.UserGroupMemberUpdated:     userGroupUpdatedHandler,
+	event.UserGroupMemberRemoved:     userGroupUpdatedHandler,
+	event.UserGroupAdminAdded:        userGroupUpdatedHandler,
+	event.UserGroupAdminRemoved:      userGroupUpdatedHandler,
+	event.StampCreated:               stampCreatedHandler,
+	event.StampUpdated:               stampUpdatedHandler,
+	event.StampDeleted:               stampDeletedHandler,
+	event.StampPaletteCreated:        stampPaletteCreatedHandler,
+	event.StampPaletteUpdated:        stampPaletteUpdatedHandler,
+	event.StampPaletteDeleted:        stampPaletteDeletedHandler,
+	event.UserWebRTCv3StateChanged:   userWebRTCv3StateChangedHandler,
+	event.ClipFolderCreated:          clipFolderCreatedHandler,
+	event.ClipFolderUpdated:          clipFolderUpdatedHandler,
+	event.ClipFolderDeleted:          clipFolderDeletedHandler,
+	event.ClipFolderMessageDeleted:   clipFolderMessageDeletedHandler,
+	event.ClipFolderMessageAdded:     clipFolderMessageAddedHandler,
+	event.QallRoomStateChanged:       qallRoomStateChangedHandler,
+	event.QallSoundboardItemCreated:  qallSoundboardItemCreatedHandler,
+	event.QallSoundboardItemDeleted:  qallSoundboardItemDeletedHandler,
 }
 
 func messageCreatedHandler(ns *Service, ev hub.Message) {
@@ -92,7 +102,7 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 		return
 	}
 
-	fcmPayload := &fcm.Payload{
+	pushPayload := &push.Payload{
 		Type: "new_message",
 		Icon: fmt.Sprintf("%s/api/v3/public/icon/%s", ns.origin, strings.ReplaceAll(mUser.GetName(), "#", "%23")),
 		Tag:  "c:" + m.ChannelID.String(),
@@ -107,31 +117,33 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 		"is_citing": true,
 	}
 
-	viewers := set.UUID{}       // バックグラウンドを含む対象チャンネル閲覧中のユーザー
-	notifiedUsers := set.UUID{} // チャンネル通知購読ユーザー
-	markedUsers := set.UUID{}   // チャンネル未読管理ユーザー
-	noticeable := set.UUID{}    // noticeableな未読追加対象のユーザー
-	citedUsers := set.UUID{}    // メッセージで引用されたメッセージを投稿したユーザー
-	dmMembers := set.UUID{}     // isDMの場合 DMのメンバー
+	viewers := set.UUID{}        // バックグラウンドを含む対象チャンネル閲覧中のユーザー
+	notifiedUsers := set.UUID{}  // チャンネル通知購読ユーザー
+	markedUsers := set.UUID{}    // チャンネル未読管理ユーザー
+	noticeable := set.UUID{}     // noticeableな未読追加対象のユーザー
+	citedUsers := set.UUID{}     // メッセージで引用されたメッセージを投稿したユーザー
+	dmMembers := set.UUID{}      // isDMの場合 DMのメンバー
+	mentionees := set.UUID{}     // メンション・グループメンションされたユーザー
+	keywordMatched := set.UUID{} // 通知キーワードに一致したユーザー
 
 	// メッセージボディ作成
 	if !isDM {
 		// 公開チャンネル
 		path := chTree.GetChannelPath(chID)
-		fcmPayload.Title = "#" + path
-		fcmPayload.Path = "/channels/" + path
-		fcmPayload.SetBodyWithEllipsis(mUser.GetResponseDisplayName() + ": " + parsed.NotificationText())
+		pushPayload.Title = "#" + path
+		pushPayload.Path = "/channels/" + path
+		pushPayload.SetBodyWithEllipsis(mUser.GetResponseDisplayName() + ": " + parsed.NotificationText())
 	} else {
 		// DM
-		fcmPayload.Title = "@" + mUser.GetResponseDisplayName()
-		fcmPayload.Path = "/users/" + mUser.GetName()
-		fcmPayload.SetBodyWithEllipsis(parsed.NotificationText())
+		pushPayload.Title = "@" + mUser.GetResponseDisplayName()
+		pushPayload.Path = "/users/" + mUser.GetName()
+		pushPayload.SetBodyWithEllipsis(parsed.NotificationText())
 	}
 
 	if len(parsed.Attachments) > 0 {
 		if f, _ := ns.fm.Get(context.Background(), parsed.Attachments[0]); f != nil {
 			if ok, _ := f.GetThumbnail(model.ThumbnailTypeImage); ok {
-				fcmPayload.Image = optional.From(fmt.Sprintf("%s/api/v3/files/%s/thumbnail", ns.origin, f.GetID()))
+				pushPayload.Image = optional.From(fmt.Sprintf("%s/api/v3/files/%s/thumbnail", ns.origin, f.GetID()))
 			}
 		}
 	}
@@ -176,6 +188,13 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 		}
 		markedUsers.Add(mark...)
 
+		// チャンネル全体へのメンション(@here, @channel)対象ユーザー取得
+		channelMentionees := ns.channelMentionees(parsed, chID, mUser, set.UUIDSetFromArray(append(notify, mark...)))
+		notifiedUsers.Plus(channelMentionees)
+		markedUsers.Plus(channelMentionees)
+		noticeable.Plus(channelMentionees)
+		mentionees.Plus(channelMentionees)
+
 		// ユーザーグループ・メンションユーザー取得
 		for _, uid := range parsed.Mentions {
 			user, err := ns.repo.GetUser(context.Background(), uid, false)
@@ -191,6 +210,7 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 			notifiedUsers.Add(uid)
 			markedUsers.Add(uid)
 			noticeable.Add(uid)
+			mentionees.Add(uid)
 		}
 		for _, gid := range parsed.GroupMentions {
 			gs, err := ns.repo.GetUserIDs(context.Background(), q.GMemberOf(gid))
@@ -201,6 +221,7 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 			notifiedUsers.Add(gs...)
 			markedUsers.Add(gs...)
 			noticeable.Add(gs...)
+			mentionees.Add(gs...)
 		}
 		// メッセージを引用されたユーザーへの通知
 		for _, mid := range parsed.Citation {
@@ -235,6 +256,46 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 				notifiedUsers.Add(uid)
 			}
 		}
+		// 通知キーワードに一致したユーザーへの通知
+		keywordSettings, err := ns.repo.GetUserSettingsWithNotifyKeywords(context.Background())
+		if err != nil {
+			logger.Error("failed to GetUserSettingsWithNotifyKeywords", zap.Error(err)) // 失敗
+		}
+		for _, us := range keywordSettings {
+			uid := us.UserID
+			if uid == m.UserID || !us.NotifyKeywords.Match(parsed.PlainText) {
+				continue
+			}
+
+			user, err := ns.repo.GetUser(context.Background(), uid, false)
+			if err != nil {
+				logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("userId", uid)) // 失敗
+				continue
+			}
+			// 凍結ユーザー / Botの除外
+			if !user.IsActive() || user.IsBot() {
+				continue
+			}
+
+			// 通知キーワードへの一致はメンションとして扱う
+			notifiedUsers.Add(uid)
+			markedUsers.Add(uid)
+			noticeable.Add(uid)
+			mentionees.Add(uid)
+			keywordMatched.Add(uid)
+		}
+	}
+
+	// スレッド購読者への通知
+	if m.ParentMessageID.Valid && !isDM {
+		subscribers, err := ns.repo.GetUserIDs(context.Background(), q.ThreadSubscriberOf(m.ParentMessageID.V))
+		if err != nil {
+			logger.Error("failed to GetUserIDs", zap.Error(err), zap.Stringer("parentMessageId", m.ParentMessageID.V)) // 失敗
+			return
+		}
+		notifiedUsers.Add(subscribers...)
+		markedUsers.Add(subscribers...)
+		noticeable.Add(subscribers...)
 	}
 
 	// チャンネル閲覧者取得
@@ -276,10 +337,22 @@ func messageCreatedHandler(ns *Service, ev hub.Message) {
 	go ns.ws.WriteMessage(wsEventType, wsPayloadNotCited, targetFuncNotCited)
 	go ns.ws.WriteMessage(wsEventType, wsPayloadCited, targetFuncCited)
 
-	// FCM送信
+	// プッシュ通知送信
 	targets := notifiedUsers.Clone()
 	targets.Remove(m.UserID)
-	ns.fcm.Send(targets, fcmPayload, true)
+	if !forceNotify {
+		if isDM {
+			// DMは全てメンションとして扱う
+			mentionees = dmMembers
+		}
+		targets = ns.filterChannelNotifyOverrides(targets, chID, mentionees, mUser.IsBot())
+	}
+	mentioned := set.UUIDSetFromArray(parsed.Mentions)
+	mentioned.Plus(keywordMatched)
+	targets = ns.filterDoNotDisturb(targets, func(us *model.UserSettings) bool {
+		return (forceNotify && us.DNDAllowForced) || (us.DNDAllowMentions && mentioned.Contains(us.UserID))
+	})
+	ns.push.Send(targets, pushPayload, true)
 }
 
 func messageUpdatedHandler(ns *Service, ev hub.Message) {
@@ -370,6 +443,109 @@ func messageUnstampedHandler(ns *Service, ev hub.Message) {
 	)
 }
 
+func pollUpdatedHandler(ns *Service, ev hub.Message) {
+	messageViewerMulticast(ns, ev.Fields["message_id"].(uuid.UUID),
+		"MESSAGE_POLL_UPDATED",
+		map[string]interface{}{
+			"message_id": ev.Fields["message_id"].(uuid.UUID),
+			"poll_id":    ev.Fields["poll_id"].(uuid.UUID),
+		},
+	)
+}
+
+func messageComponentsUpdatedHandler(ns *Service, ev hub.Message) {
+	messageViewerMulticast(ns, ev.Fields["message_id"].(uuid.UUID),
+		"MESSAGE_COMPONENTS_UPDATED",
+		map[string]interface{}{
+			"message_id": ev.Fields["message_id"].(uuid.UUID),
+			"components": ev.Fields["components"].([]model.MessageComponent),
+		},
+	)
+}
+
+func botCommandEphemeralRepliedHandler(ns *Service, ev hub.Message) {
+	inv := ev.Fields["invocation"].(*model.BotCommandInvocation)
+	userMulticast(ns, ev.Fields["user_id"].(uuid.UUID),
+		"BOT_COMMAND_EPHEMERAL_MESSAGE",
+		map[string]interface{}{
+			"invocation_id": inv.ID,
+			"channel_id":    ev.Fields["channel_id"].(uuid.UUID),
+			"user_id":       ev.Fields["bot_user_id"].(uuid.UUID),
+			"content":       ev.Fields["content"].(string),
+			"embed":         ev.Fields["embed"].(bool),
+		},
+	)
+}
+
+func savedSearchMatchedHandler(ns *Service, ev hub.Message) {
+	s := ev.Fields["saved_search"].(*model.SavedSearch)
+	m := ev.Fields["message"].(*model.Message)
+	logger := ns.logger.With(zap.Stringer("savedSearchId", s.ID), zap.Stringer("messageId", m.ID))
+
+	userMulticast(ns, s.UserID,
+		"SAVED_SEARCH_MATCHED",
+		map[string]interface{}{
+			"saved_search_id": s.ID,
+			"message_id":      m.ID,
+			"channel_id":      m.ChannelID,
+		},
+	)
+
+	// 投稿ユーザー情報を取得
+	mUser, err := ns.repo.GetUser(context.Background(), m.UserID, false)
+	if err != nil {
+		logger.Error("failed to GetUser", zap.Error(err), zap.Stringer("userId", m.UserID)) // 失敗
+		return
+	}
+
+	pushPayload := &push.Payload{
+		Type:  "saved_search",
+		Title: "保存した検索: " + s.Name,
+		Icon:  fmt.Sprintf("%s/api/v3/public/icon/%s", ns.origin, strings.ReplaceAll(mUser.GetName(), "#", "%23")),
+		Path:  "/messages/" + m.ID.String(),
+		Tag:   "s:" + s.ID.String(),
+	}
+	pushPayload.SetBodyWithEllipsis(mUser.GetResponseDisplayName() + ": " + message.Parse(m.Text).NotificationText())
+	ns.push.Send(ns.filterDoNotDisturb(set.UUIDSetFromArray([]uuid.UUID{s.UserID}), nil), pushPayload, false)
+}
+
+func inboxItemCreatedHandler(ns *Service, ev hub.Message) {
+	item := ev.Fields["inbox_item"].(*model.InboxItem)
+	userMulticast(ns, ev.Fields["user_id"].(uuid.UUID),
+		"INBOX_ITEM_CREATED",
+		map[string]interface{}{
+			"id":         item.ID,
+			"type":       item.Type,
+			"message_id": item.MessageID,
+			"channel_id": item.ChannelID,
+		},
+	)
+}
+
+func messageReportCreatedHandler(ns *Service, ev hub.Message) {
+	r := ev.Fields["report"].(*model.MessageReport)
+	messageReportViewerMulticast(ns,
+		"MESSAGE_REPORT_CREATED",
+		map[string]interface{}{
+			"id":         r.ID,
+			"message_id": r.MessageID,
+			"state":      r.State,
+		},
+	)
+}
+
+func messageReportStateChangedHandler(ns *Service, ev hub.Message) {
+	r := ev.Fields["report"].(*model.MessageReport)
+	messageReportViewerMulticast(ns,
+		"MESSAGE_REPORT_STATE_CHANGED",
+		map[string]interface{}{
+			"id":         r.ID,
+			"message_id": r.MessageID,
+			"state":      r.State,
+		},
+	)
+}
+
 func channelCreatedHandler(ns *Service, ev hub.Message) {
 	channelHandler(ns, ev, "CHANNEL_CREATED")
 }
@@ -736,6 +912,25 @@ func messageViewerMulticast(ns *Service, mid uuid.UUID, wsEventType string, wsPa
 	channelViewerMulticast(ns, m.GetChannelID(), wsEventType, wsPayload)
 }
 
+// messageReportViewerMulticast メッセージ通報の取得権限を持つユーザーに送信します
+func messageReportViewerMulticast(ns *Service, wsEventType string, wsPayload interface{}) {
+	users, err := ns.repo.GetUsers(context.Background(), repository.UsersQuery{}.Active().NotBot())
+	if err != nil {
+		ns.logger.Error("failed to GetUsers", zap.Error(err)) // 失敗
+		return
+	}
+	targets := set.UUID{}
+	for _, u := range users {
+		if ns.rbac.IsGranted(u.GetRole(), permission.GetMessageReports) {
+			targets.Add(u.GetID())
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+	go ns.ws.WriteMessage(wsEventType, wsPayload, ws.TargetUserSets(targets))
+}
+
 func broadcast(ns *Service, wsEventType string, wsPayload interface{}) {
 	go ns.ws.WriteMessage(wsEventType, wsPayload, ws.TargetAll())
 }
@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// filterChannelNotifyOverrides targetsからチャンネル通知の上書き設定によってプッシュ通知を送らないユーザーを除外したものを返します
+//
+// mentionedはメッセージでメンション(グループメンションを含む)されたユーザー、fromBotはメッセージの投稿者がBotかどうかです。
+// 上書き設定の取得に失敗した場合はtargetsをそのまま返します。
+func (ns *Service) filterChannelNotifyOverrides(targets set.UUID, channelID uuid.UUID, mentioned set.UUID, fromBot bool) set.UUID {
+	if len(targets) == 0 {
+		return targets
+	}
+
+	subscriptions, err := ns.repo.GetChannelSubscriptions(context.Background(), repository.ChannelSubscriptionQuery{}.SetChannel(channelID).IncludeNotifyOverrides())
+	if err != nil {
+		ns.logger.Error("failed to GetChannelSubscriptions", zap.Error(err), zap.Stringer("channelId", channelID)) // 失敗
+		return targets
+	}
+
+	now := time.Now()
+	filtered := targets.Clone()
+	for _, s := range subscriptions {
+		if !s.HasNotifyOverrides() || !targets.Contains(s.UserID) {
+			continue
+		}
+		if !s.ShouldPush(now, mentioned.Contains(s.UserID), fromBot) {
+			filtered.Remove(s.UserID)
+		}
+	}
+	return filtered
+}
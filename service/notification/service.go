@@ -6,9 +6,11 @@ import (
 
 	"github.com/traPtitech/traQ/repository"
 	"github.com/traPtitech/traQ/service/channel"
-	"github.com/traPtitech/traQ/service/fcm"
+	"github.com/traPtitech/traQ/service/counter"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/service/push"
+	"github.com/traPtitech/traQ/service/rbac"
 	"github.com/traPtitech/traQ/service/variable"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/ws"
@@ -22,14 +24,16 @@ type Service struct {
 	fm     file.Manager
 	hub    *hub.Hub
 	logger *zap.Logger
-	fcm    fcm.Client
+	push   push.Client
 	ws     *ws.Streamer
 	vm     *viewer.Manager
+	oc     *counter.OnlineCounter
+	rbac   rbac.RBAC
 	origin string
 }
 
 // NewService 通知サービスを作成して起動します
-func NewService(repo repository.Repository, cm channel.Manager, mm message.Manager, fm file.Manager, hub *hub.Hub, logger *zap.Logger, fcm fcm.Client, ws *ws.Streamer, vm *viewer.Manager, origin variable.ServerOriginString) *Service {
+func NewService(repo repository.Repository, cm channel.Manager, mm message.Manager, fm file.Manager, hub *hub.Hub, logger *zap.Logger, push push.Client, ws *ws.Streamer, vm *viewer.Manager, oc *counter.OnlineCounter, rbac rbac.RBAC, origin variable.ServerOriginString) *Service {
 	service := &Service{
 		repo:   repo,
 		cm:     cm,
@@ -37,9 +41,11 @@ func NewService(repo repository.Repository, cm channel.Manager, mm message.Manag
 		fm:     fm,
 		hub:    hub,
 		logger: logger.Named("notification"),
-		fcm:    fcm,
+		push:   push,
 		ws:     ws,
 		vm:     vm,
+		oc:     oc,
+		rbac:   rbac,
 		origin: string(origin),
 	}
 	go func() {
@@ -0,0 +1,34 @@
+package push
+
+import (
+	"golang.org/x/exp/utf8string"
+
+	"github.com/traPtitech/traQ/utils/optional"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// Client プッシュ通知クライアント
+type Client interface {
+	// Send targetユーザーにpayloadを送信します
+	Send(targetUserIDs set.UUID, payload *Payload, withUnreadCount bool)
+	Close()
+}
+
+// Payload プッシュ通知ペイロード
+type Payload struct {
+	Type  string
+	Title string
+	Body  string
+	Icon  string
+	Path  string
+	Tag   string
+	Image optional.Of[string]
+}
+
+// SetBodyWithEllipsis 100文字を超える場合は...で省略
+func (p *Payload) SetBodyWithEllipsis(body string) {
+	if s := utf8string.NewString(body); s.RuneCount() > 100 {
+		body = s.Slice(0, 100) + "..."
+	}
+	p.Body = body
+}
@@ -0,0 +1,24 @@
+package push
+
+import (
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type multiClient []Client
+
+// NewMultiClient 全てのclientsに同じペイロードを送信するクライアントを返します
+func NewMultiClient(clients ...Client) Client {
+	return multiClient(clients)
+}
+
+func (m multiClient) Send(targetUserIDs set.UUID, payload *Payload, withUnreadCount bool) {
+	for _, c := range m {
+		c.Send(targetUserIDs, payload, withUnreadCount)
+	}
+}
+
+func (m multiClient) Close() {
+	for _, c := range m {
+		c.Close()
+	}
+}
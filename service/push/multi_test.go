@@ -0,0 +1,55 @@
+package push
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type recordClient struct {
+	sent   []set.UUID
+	closed bool
+}
+
+func (c *recordClient) Send(targetUserIDs set.UUID, _ *Payload, _ bool) {
+	c.sent = append(c.sent, targetUserIDs)
+}
+
+func (c *recordClient) Close() {
+	c.closed = true
+}
+
+func TestMultiClient(t *testing.T) {
+	t.Parallel()
+
+	c1 := &recordClient{}
+	c2 := &recordClient{}
+	c := NewMultiClient(c1, c2)
+
+	targets := set.UUIDSetFromArray([]uuid.UUID{uuid.Must(uuid.NewV4())})
+	c.Send(targets, &Payload{Title: "test"}, false)
+	assert.Equal(t, []set.UUID{targets}, c1.sent)
+	assert.Equal(t, []set.UUID{targets}, c2.sent)
+
+	c.Close()
+	assert.True(t, c1.closed)
+	assert.True(t, c2.closed)
+}
+
+func TestPayload_SetBodyWithEllipsis(t *testing.T) {
+	t.Parallel()
+
+	p := &Payload{}
+	p.SetBodyWithEllipsis("short")
+	assert.Equal(t, "short", p.Body)
+
+	long := ""
+	for range 101 {
+		long += "あ"
+	}
+	p.SetBodyWithEllipsis(long)
+	assert.Equal(t, long[:len("あ")*100]+"...", p.Body)
+}
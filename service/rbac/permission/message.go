@@ -7,14 +7,20 @@ const (
 	PostMessage = Permission("post_message")
 	// EditMessage メッセージ編集権限
 	EditMessage = Permission("edit_message")
+	// GetMessageHistory メッセージ編集履歴取得権限
+	GetMessageHistory = Permission("get_message_history")
 	// DeleteMessage メッセージ削除権限
 	DeleteMessage = Permission("delete_message")
 	// ReportMessage メッセージ通報権限
 	ReportMessage = Permission("report_message")
 	// GetMessageReports メッセージ通報取得権限
 	GetMessageReports = Permission("get_message_reports")
+	// ManageMessageReports メッセージ通報対応権限
+	ManageMessageReports = Permission("manage_message_reports")
 	// CreateMessagePin ピン留め作成権限
 	CreateMessagePin = Permission("create_message_pin")
 	// DeleteMessagePin ピン留め削除権限
 	DeleteMessagePin = Permission("delete_message_pin")
+	// MentionChannel チャンネル全体へのメンション(@here, @channel)権限
+	MentionChannel = Permission("mention_channel")
 )
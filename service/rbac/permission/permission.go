@@ -82,9 +82,12 @@ var List = []Permission{
 	GetMessage,
 	PostMessage,
 	EditMessage,
+	GetMessageHistory,
 	DeleteMessage,
 	ReportMessage,
 	GetMessageReports,
+	ManageMessageReports,
+	MentionChannel,
 
 	GetChannelSubscription,
 	EditChannelSubscription,
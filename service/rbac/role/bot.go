@@ -11,6 +11,7 @@ var botPerms = []permission.Permission{
 	permission.GetChannel,
 	permission.EditChannelTopic,
 	permission.GetMessage,
+	permission.GetMessageHistory,
 	permission.PostMessage,
 	permission.EditMessage,
 	permission.DeleteMessage,
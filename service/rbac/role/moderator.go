@@ -0,0 +1,21 @@
+package role
+
+import (
+	"github.com/traPtitech/traQ/service/rbac/permission"
+)
+
+// Moderator モデレーターロール
+const Moderator = "moderator"
+
+var moderatorPerms = []permission.Permission{
+	// userロールのパーミッションを全て含む
+	permission.GetMessageReports,
+	permission.ManageMessageReports,
+}
+
+func init() {
+	// user.goのinitより先に呼ばれるため、read, writeロールのパーミッションもここで含める
+	moderatorPerms = append(moderatorPerms, userPerms...)
+	moderatorPerms = append(moderatorPerms, readPerms...)
+	moderatorPerms = append(moderatorPerms, writePerms...)
+}
@@ -18,6 +18,11 @@ func GetSystemRoles() Roles {
 			oauth2Scope: false,
 			permissions: permission.PermissionsFromArray(userPerms),
 		},
+		Moderator: &systemRole{
+			name:        Moderator,
+			oauth2Scope: false,
+			permissions: permission.PermissionsFromArray(moderatorPerms),
+		},
 		Read: &systemRole{
 			name:        Read,
 			oauth2Scope: true,
@@ -30,6 +30,7 @@ var userPerms = []permission.Permission{
 	permission.BotActionJoinChannel,
 	permission.BotActionLeaveChannel,
 	permission.WebRTC,
+	permission.MentionChannel,
 }
 
 func init() {
@@ -0,0 +1,149 @@
+package savedsearch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/event"
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/search"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// Watcher 新着メッセージを通知が有効な保存された検索と照合します
+//
+// 一致した場合は event.SavedSearchMatched を発行します。
+type Watcher struct {
+	repo   repository.Repository
+	cm     channel.Manager
+	hub    *hub.Hub
+	l      *zap.Logger
+	parser *search.QueryParser
+
+	// queries 解析済みのクエリのキャッシュ
+	queries   map[uuid.UUID]*cachedQuery
+	queriesMu sync.Mutex
+}
+
+type cachedQuery struct {
+	updatedAt time.Time
+	query     *search.Query
+}
+
+// NewWatcher Watcherを生成して起動します
+func NewWatcher(repo repository.Repository, cm channel.Manager, hub *hub.Hub, logger *zap.Logger) *Watcher {
+	w := &Watcher{
+		repo:    repo,
+		cm:      cm,
+		hub:     hub,
+		l:       logger.Named("saved_search_watcher"),
+		parser:  search.NewQueryParser(repo, cm),
+		queries: map[uuid.UUID]*cachedQuery{},
+	}
+	go func() {
+		for ev := range hub.Subscribe(100, event.MessageCreated).Receiver {
+			w.process(ev.Fields["message"].(*model.Message), ev.Fields["parse_result"].(*message.ParseResult))
+		}
+	}()
+	return w
+}
+
+func (w *Watcher) process(m *model.Message, parseResult *message.ParseResult) {
+	ctx := context.Background()
+	logger := w.l.With(zap.Stringer("messageId", m.ID))
+
+	savedSearches, err := w.repo.GetNotifyingSavedSearches(ctx)
+	if err != nil {
+		logger.Error("failed to GetNotifyingSavedSearches", zap.Error(err))
+		return
+	}
+	w.pruneQueries(savedSearches)
+	if len(savedSearches) == 0 {
+		return
+	}
+
+	isPublic := w.cm.IsPublicChannel(ctx, m.ChannelID)
+	target, err := search.NewMatchTarget(w.repo, w.l, m, parseResult, isPublic)
+	if err != nil {
+		logger.Error("failed to NewMatchTarget", zap.Error(err))
+		return
+	}
+
+	for _, s := range savedSearches {
+		// 自分のメッセージは通知しない
+		if s.UserID == m.UserID {
+			continue
+		}
+
+		q, err := w.getQuery(ctx, s)
+		if err != nil {
+			// ユーザーやチャンネルが削除された場合など
+			logger.Debug("failed to parse saved search query", zap.Stringer("savedSearchId", s.ID), zap.Error(err))
+			continue
+		}
+		if !q.Match(target) {
+			continue
+		}
+		if !isPublic {
+			ok, err := w.cm.IsChannelAccessibleToUser(ctx, s.UserID, m.ChannelID)
+			if err != nil {
+				logger.Error("failed to IsChannelAccessibleToUser", zap.Error(err), zap.Stringer("userId", s.UserID))
+				continue
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		w.hub.Publish(hub.Message{
+			Name: event.SavedSearchMatched,
+			Fields: hub.Fields{
+				"saved_search_id": s.ID,
+				"user_id":         s.UserID,
+				"saved_search":    s,
+				"message_id":      m.ID,
+				"message":         m,
+			},
+		})
+	}
+}
+
+// getQuery 保存された検索のクエリを解析します
+func (w *Watcher) getQuery(ctx context.Context, s *model.SavedSearch) (*search.Query, error) {
+	w.queriesMu.Lock()
+	defer w.queriesMu.Unlock()
+
+	if c, ok := w.queries[s.ID]; ok && c.updatedAt.Equal(s.UpdatedAt) {
+		return c.query, nil
+	}
+
+	var q search.Query
+	if err := w.parser.Parse(ctx, s.Query, &q); err != nil {
+		return nil, err
+	}
+	w.queries[s.ID] = &cachedQuery{updatedAt: s.UpdatedAt, query: &q}
+	return &q, nil
+}
+
+// pruneQueries 通知が無効になった・削除された保存された検索のキャッシュを削除します
+func (w *Watcher) pruneQueries(savedSearches []*model.SavedSearch) {
+	ids := make(map[uuid.UUID]struct{}, len(savedSearches))
+	for _, s := range savedSearches {
+		ids[s.ID] = struct{}{}
+	}
+
+	w.queriesMu.Lock()
+	defer w.queriesMu.Unlock()
+	for id := range w.queries {
+		if _, ok := ids[id]; !ok {
+			delete(w.queries, id)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+const (
+	pollInterval = 10 * time.Second
+	batchSize    = 100
+	// claimTimeout 処理中のまま残っているものを、処理中にサーバーが停止したとみなすまでの時間
+	claimTimeout = 5 * time.Minute
+)
+
+// MessageScheduler 予約投稿メッセージを投稿予定日時に投稿します
+//
+// 予約投稿メッセージはDBに永続化されているため、サーバーが再起動しても
+// 起動時に投稿予定日時を過ぎているものから順に投稿されます。
+// 投稿前に予約投稿メッセージを投稿処理中にすることで、同じメッセージが重複して投稿されないようにしています。
+// 投稿処理中にサーバーが停止した場合は、claimTimeout経過後に投稿待ちに戻して再度投稿します。
+// 投稿に失敗した場合はmodel.ScheduledMessageMaxAttempts回まで再試行し、それでも失敗した場合は投稿を諦めます。
+type MessageScheduler struct {
+	repo repository.Repository
+	mm   message.Manager
+	l    *zap.Logger
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	started   chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewMessageScheduler(repo repository.Repository, mm message.Manager, logger *zap.Logger) *MessageScheduler {
+	return &MessageScheduler{
+		repo:    repo,
+		mm:      mm,
+		l:       logger.Named("message_scheduler"),
+		started: make(chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start 予約投稿ワーカーを起動します
+func (s *MessageScheduler) Start() {
+	s.startOnce.Do(func() {
+		close(s.started)
+		go s.run()
+	})
+}
+
+// Shutdown 予約投稿ワーカーを停止します
+func (s *MessageScheduler) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	select {
+	case <-s.started:
+	default:
+		return nil // 起動していない
+	}
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *MessageScheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	// 停止中に投稿予定日時を過ぎたものを投稿
+	now := time.Now()
+	s.recoverStuckMessages(context.Background(), now)
+	s.postDueMessages(context.Background(), now)
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.recoverStuckMessages(context.Background(), now)
+			s.postDueMessages(context.Background(), now)
+		}
+	}
+}
+
+// recoverStuckMessages 投稿処理中のままclaimTimeoutが経過した予約投稿メッセージを投稿待ちに戻します
+func (s *MessageScheduler) recoverStuckMessages(ctx context.Context, now time.Time) {
+	n, err := s.repo.RecoverScheduledMessages(ctx, now.Add(-claimTimeout))
+	if err != nil {
+		s.l.Error("failed to RecoverScheduledMessages", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		s.l.Warn("recovered scheduled messages stuck in posting", zap.Int64("count", n))
+	}
+}
+
+func (s *MessageScheduler) postDueMessages(ctx context.Context, now time.Time) {
+	messages, err := s.repo.GetDueScheduledMessages(ctx, now, batchSize)
+	if err != nil {
+		s.l.Error("failed to GetDueScheduledMessages", zap.Error(err))
+		return
+	}
+	for _, m := range messages {
+		s.post(ctx, m)
+	}
+}
+
+func (s *MessageScheduler) post(ctx context.Context, m *model.ScheduledMessage) {
+	logger := s.l.With(zap.Stringer("scheduledMessageId", m.ID), zap.Stringer("userId", m.UserID))
+
+	// 他のワーカーや前回の処理と重複して投稿しないように、投稿前に投稿処理中にする
+	if err := s.repo.ClaimScheduledMessage(ctx, m.ID); err != nil {
+		if err != repository.ErrNotFound {
+			logger.Error("failed to ClaimScheduledMessage", zap.Error(err))
+		}
+		return
+	}
+	m.Attempts++
+
+	// 予約後に凍結されたユーザーのメッセージは投稿しない
+	user, err := s.repo.GetUser(ctx, m.UserID, false)
+	if err != nil {
+		logger.Error("failed to GetUser", zap.Error(err))
+		s.release(ctx, m, logger)
+		return
+	}
+	if !user.IsActive() {
+		logger.Info("discarded scheduled message since the user is not active")
+		s.delete(ctx, m, logger)
+		return
+	}
+
+	if m.IsDM() {
+		_, err = s.mm.CreateDM(ctx, m.UserID, m.DMUserID.V, m.Text)
+	} else {
+		_, err = s.mm.Create(ctx, m.ChannelID, m.UserID, m.Text)
+	}
+	if err != nil {
+		switch err {
+		case message.ErrChannelArchived:
+			// アーカイブされたチャンネルには投稿できないので破棄
+			logger.Info("discarded scheduled message since the channel has been archived", zap.Stringer("channelId", m.ChannelID))
+		default:
+			logger.Error("failed to post scheduled message", zap.Error(err), zap.Int("attempts", m.Attempts))
+			s.release(ctx, m, logger)
+			return
+		}
+	}
+
+	// 削除に失敗しても投稿処理中のまま残るため、claimTimeoutが経過するまでは再度投稿されない
+	s.delete(ctx, m, logger)
+}
+
+// release 投稿できなかった予約投稿メッセージを投稿待ちに戻します
+//
+// 投稿試行回数が上限に達した場合は投稿を諦めます。
+func (s *MessageScheduler) release(ctx context.Context, m *model.ScheduledMessage, logger *zap.Logger) {
+	state := model.ScheduledMessagePending
+	if m.Attempts >= model.ScheduledMessageMaxAttempts {
+		state = model.ScheduledMessageFailed
+		logger.Warn("gave up posting scheduled message", zap.Int("attempts", m.Attempts))
+	}
+	if err := s.repo.UpdateScheduledMessageState(ctx, m.ID, state); err != nil && err != repository.ErrNotFound {
+		logger.Error("failed to UpdateScheduledMessageState", zap.Error(err))
+	}
+}
+
+func (s *MessageScheduler) delete(ctx context.Context, m *model.ScheduledMessage, logger *zap.Logger) {
+	if err := s.repo.DeleteScheduledMessage(ctx, m.ID); err != nil && err != repository.ErrNotFound {
+		logger.Error("failed to DeleteScheduledMessage", zap.Error(err))
+	}
+}
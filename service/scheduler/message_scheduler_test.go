@@ -0,0 +1,265 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/testutils"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type Repo struct {
+	*mock_repository.MockScheduledMessageRepository
+	*mock_repository.MockUserRepository
+	*mock_repository.MockPollRepository
+	*mock_repository.MockMessageReminderRepository
+	*mock_repository.MockInboxItemRepository
+	testutils.EmptyTestRepository
+}
+
+type created struct {
+	channelID uuid.UUID
+	userID    uuid.UUID
+	dmUserID  uuid.UUID
+	content   string
+}
+
+type fakeMessageManager struct {
+	message.Manager
+	err     error
+	created []created
+}
+
+func (m *fakeMessageManager) Create(_ context.Context, channelID, userID uuid.UUID, content string) (message.Message, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.created = append(m.created, created{channelID: channelID, userID: userID, content: content})
+	return nil, nil
+}
+
+func (m *fakeMessageManager) CreateDM(_ context.Context, from, to uuid.UUID, content string) (message.Message, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.created = append(m.created, created{userID: from, dmUserID: to, content: content})
+	return nil, nil
+}
+
+func setup(ctrl *gomock.Controller, mmErr error) (*MessageScheduler, *Repo, *fakeMessageManager) {
+	repo := &Repo{
+		MockScheduledMessageRepository: mock_repository.NewMockScheduledMessageRepository(ctrl),
+		MockUserRepository:             mock_repository.NewMockUserRepository(ctrl),
+	}
+	mm := &fakeMessageManager{err: mmErr}
+	return NewMessageScheduler(repo, mm, zap.NewNop()), repo, mm
+}
+
+func TestMessageScheduler_postDueMessages(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	uid := uuid.NewV3(uuid.Nil, "u1")
+	cid := uuid.NewV3(uuid.Nil, "c1")
+	user := &model.User{ID: uid, Status: model.UserAccountStatusActive}
+	newChannelMessage := func(attempts int) *model.ScheduledMessage {
+		return &model.ScheduledMessage{ID: uuid.NewV3(uuid.Nil, "s1"), UserID: uid, ChannelID: cid, Text: "channel", ScheduledAt: now, State: model.ScheduledMessagePending, Attempts: attempts}
+	}
+	dmMessage := &model.ScheduledMessage{ID: uuid.NewV3(uuid.Nil, "s2"), UserID: uid, ChannelID: cid, DMUserID: optional.From(uuid.NewV3(uuid.Nil, "u2")), Text: "dm", ScheduledAt: now, State: model.ScheduledMessagePending}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, mm := setup(ctrl, nil)
+		channelMessage := newChannelMessage(0)
+
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			GetDueScheduledMessages(gomock.Any(), now, batchSize).
+			Return([]*model.ScheduledMessage{channelMessage, dmMessage}, nil).
+			Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), uid, false).Return(user, nil).Times(2)
+		gomock.InOrder(
+			repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(gomock.Any(), channelMessage.ID).Return(nil).Times(1),
+			repo.MockScheduledMessageRepository.EXPECT().DeleteScheduledMessage(gomock.Any(), channelMessage.ID).Return(nil).Times(1),
+		)
+		gomock.InOrder(
+			repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(gomock.Any(), dmMessage.ID).Return(nil).Times(1),
+			repo.MockScheduledMessageRepository.EXPECT().DeleteScheduledMessage(gomock.Any(), dmMessage.ID).Return(nil).Times(1),
+		)
+
+		s.postDueMessages(context.TODO(), now)
+		assert.Equal(t, []created{
+			{channelID: cid, userID: uid, content: "channel"},
+			{userID: uid, dmUserID: dmMessage.DMUserID.V, content: "dm"},
+		}, mm.created)
+	})
+
+	t.Run("already claimed", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, mm := setup(ctrl, nil)
+		channelMessage := newChannelMessage(0)
+
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			GetDueScheduledMessages(gomock.Any(), now, batchSize).
+			Return([]*model.ScheduledMessage{channelMessage}, nil).
+			Times(1)
+		// 他のワーカーが処理中なので投稿しない
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(gomock.Any(), channelMessage.ID).Return(repository.ErrNotFound).Times(1)
+
+		s.postDueMessages(context.TODO(), now)
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("user not active", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, mm := setup(ctrl, nil)
+		channelMessage := newChannelMessage(0)
+
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			GetDueScheduledMessages(gomock.Any(), now, batchSize).
+			Return([]*model.ScheduledMessage{channelMessage}, nil).
+			Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(gomock.Any(), channelMessage.ID).Return(nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), uid, false).Return(&model.User{ID: uid, Status: model.UserAccountStatusDeactivated}, nil).Times(1)
+		// 投稿せずに破棄される
+		repo.MockScheduledMessageRepository.EXPECT().DeleteScheduledMessage(gomock.Any(), channelMessage.ID).Return(nil).Times(1)
+
+		s.postDueMessages(context.TODO(), now)
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("channel archived", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, mm := setup(ctrl, message.ErrChannelArchived)
+		channelMessage := newChannelMessage(0)
+
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			GetDueScheduledMessages(gomock.Any(), now, batchSize).
+			Return([]*model.ScheduledMessage{channelMessage}, nil).
+			Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(gomock.Any(), channelMessage.ID).Return(nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), uid, false).Return(user, nil).Times(1)
+		// 投稿できないので破棄される
+		repo.MockScheduledMessageRepository.EXPECT().DeleteScheduledMessage(gomock.Any(), channelMessage.ID).Return(nil).Times(1)
+
+		s.postDueMessages(context.TODO(), now)
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("failed to post", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, mm := setup(ctrl, errors.New("error"))
+		channelMessage := newChannelMessage(0)
+
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			GetDueScheduledMessages(gomock.Any(), now, batchSize).
+			Return([]*model.ScheduledMessage{channelMessage}, nil).
+			Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(gomock.Any(), channelMessage.ID).Return(nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), uid, false).Return(user, nil).Times(1)
+		// 再試行するため投稿待ちに戻される
+		repo.MockScheduledMessageRepository.EXPECT().UpdateScheduledMessageState(gomock.Any(), channelMessage.ID, model.ScheduledMessagePending).Return(nil).Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().DeleteScheduledMessage(gomock.Any(), gomock.Any()).Times(0)
+
+		s.postDueMessages(context.TODO(), now)
+		assert.Empty(t, mm.created)
+	})
+
+	t.Run("failed to post (max attempts)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, mm := setup(ctrl, errors.New("error"))
+		channelMessage := newChannelMessage(model.ScheduledMessageMaxAttempts - 1)
+
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			GetDueScheduledMessages(gomock.Any(), now, batchSize).
+			Return([]*model.ScheduledMessage{channelMessage}, nil).
+			Times(1)
+		repo.MockScheduledMessageRepository.EXPECT().ClaimScheduledMessage(gomock.Any(), channelMessage.ID).Return(nil).Times(1)
+		repo.MockUserRepository.EXPECT().GetUser(gomock.Any(), uid, false).Return(user, nil).Times(1)
+		// 上限に達したので投稿を諦める
+		repo.MockScheduledMessageRepository.EXPECT().UpdateScheduledMessageState(gomock.Any(), channelMessage.ID, model.ScheduledMessageFailed).Return(nil).Times(1)
+
+		s.postDueMessages(context.TODO(), now)
+		assert.Empty(t, mm.created)
+	})
+}
+
+func TestMessageScheduler_recoverStuckMessages(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, _ := setup(ctrl, nil)
+
+		repo.MockScheduledMessageRepository.EXPECT().RecoverScheduledMessages(gomock.Any(), now.Add(-claimTimeout)).Return(int64(1), nil).Times(1)
+
+		s.recoverStuckMessages(context.TODO(), now)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, _ := setup(ctrl, nil)
+
+		repo.MockScheduledMessageRepository.EXPECT().RecoverScheduledMessages(gomock.Any(), now.Add(-claimTimeout)).Return(int64(0), errors.New("error")).Times(1)
+
+		s.recoverStuckMessages(context.TODO(), now)
+	})
+}
+
+func TestMessageScheduler_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not started", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, _, _ := setup(ctrl, nil)
+
+		assert.NoError(t, s.Shutdown(context.TODO()))
+	})
+
+	t.Run("started", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, _ := setup(ctrl, nil)
+
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			RecoverScheduledMessages(gomock.Any(), gomock.Any()).
+			Return(int64(0), nil).
+			AnyTimes()
+		repo.MockScheduledMessageRepository.
+			EXPECT().
+			GetDueScheduledMessages(gomock.Any(), gomock.Any(), batchSize).
+			Return([]*model.ScheduledMessage{}, nil).
+			AnyTimes()
+
+		s.Start()
+		assert.NoError(t, s.Shutdown(context.TODO()))
+	})
+}
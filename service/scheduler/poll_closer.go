@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+// PollCloser 締め切り日時を過ぎた投票を締め切ります
+//
+// 締め切り日時はDBに永続化されているため、サーバーが再起動しても
+// 起動時に締め切り日時を過ぎているものから順に締め切られます。
+type PollCloser struct {
+	repo repository.Repository
+	mm   message.Manager
+	l    *zap.Logger
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	started   chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewPollCloser(repo repository.Repository, mm message.Manager, logger *zap.Logger) *PollCloser {
+	return &PollCloser{
+		repo:    repo,
+		mm:      mm,
+		l:       logger.Named("poll_closer"),
+		started: make(chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start 投票締め切りワーカーを起動します
+func (s *PollCloser) Start() {
+	s.startOnce.Do(func() {
+		close(s.started)
+		go s.run()
+	})
+}
+
+// Shutdown 投票締め切りワーカーを停止します
+func (s *PollCloser) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	select {
+	case <-s.started:
+	default:
+		return nil // 起動していない
+	}
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *PollCloser) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	// 停止中に締め切り日時を過ぎたものを締め切る
+	s.closeExpiredPolls(context.Background(), time.Now())
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.closeExpiredPolls(context.Background(), now)
+		}
+	}
+}
+
+func (s *PollCloser) closeExpiredPolls(ctx context.Context, now time.Time) {
+	polls, err := s.repo.GetExpiredPolls(ctx, now, batchSize)
+	if err != nil {
+		s.l.Error("failed to GetExpiredPolls", zap.Error(err))
+		return
+	}
+	for _, p := range polls {
+		s.close(ctx, p, now)
+	}
+}
+
+func (s *PollCloser) close(ctx context.Context, p *model.Poll, now time.Time) {
+	logger := s.l.With(zap.Stringer("pollId", p.ID), zap.Stringer("messageId", p.MessageID))
+
+	_, err := s.mm.ClosePoll(ctx, p.MessageID)
+	switch err {
+	case nil, message.ErrPollClosed:
+	case message.ErrNotFound:
+		// メッセージが削除されている場合は投票のみ締め切る
+		if _, err := s.repo.ClosePoll(ctx, p.ID, now); err != nil && err != repository.ErrAlreadyExists {
+			logger.Error("failed to ClosePoll", zap.Error(err))
+		}
+	default:
+		// 次回に再試行
+		logger.Error("failed to close poll", zap.Error(err))
+	}
+}
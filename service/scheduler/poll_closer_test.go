@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+type fakePollManager struct {
+	message.Manager
+	errs   map[uuid.UUID]error
+	closed []uuid.UUID
+}
+
+func (m *fakePollManager) ClosePoll(_ context.Context, id uuid.UUID) (*model.Poll, error) {
+	if err := m.errs[id]; err != nil {
+		return nil, err
+	}
+	m.closed = append(m.closed, id)
+	return nil, nil
+}
+
+func setupPollCloser(ctrl *gomock.Controller, errs map[uuid.UUID]error) (*PollCloser, *Repo, *fakePollManager) {
+	repo := &Repo{MockPollRepository: mock_repository.NewMockPollRepository(ctrl)}
+	mm := &fakePollManager{errs: errs}
+	return NewPollCloser(repo, mm, zap.NewNop()), repo, mm
+}
+
+func TestPollCloser_closeExpiredPolls(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	p1 := &model.Poll{ID: uuid.NewV3(uuid.Nil, "p1"), MessageID: uuid.NewV3(uuid.Nil, "m1"), Deadline: optional.From(now)}
+	p2 := &model.Poll{ID: uuid.NewV3(uuid.Nil, "p2"), MessageID: uuid.NewV3(uuid.Nil, "m2"), Deadline: optional.From(now)}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, mm := setupPollCloser(ctrl, nil)
+
+		repo.MockPollRepository.
+			EXPECT().
+			GetExpiredPolls(gomock.Any(), now, batchSize).
+			Return([]*model.Poll{p1, p2}, nil).
+			Times(1)
+
+		s.closeExpiredPolls(context.TODO(), now)
+		assert.Equal(t, []uuid.UUID{p1.MessageID, p2.MessageID}, mm.closed)
+	})
+
+	t.Run("message deleted", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, mm := setupPollCloser(ctrl, map[uuid.UUID]error{p1.MessageID: message.ErrNotFound})
+
+		repo.MockPollRepository.
+			EXPECT().
+			GetExpiredPolls(gomock.Any(), now, batchSize).
+			Return([]*model.Poll{p1, p2}, nil).
+			Times(1)
+		repo.MockPollRepository.EXPECT().ClosePoll(gomock.Any(), p1.ID, now).Return(p1, nil).Times(1)
+
+		s.closeExpiredPolls(context.TODO(), now)
+		assert.Equal(t, []uuid.UUID{p2.MessageID}, mm.closed)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo, mm := setupPollCloser(ctrl, map[uuid.UUID]error{p1.MessageID: errors.New("error")})
+
+		repo.MockPollRepository.
+			EXPECT().
+			GetExpiredPolls(gomock.Any(), now, batchSize).
+			Return([]*model.Poll{p1, p2}, nil).
+			Times(1)
+
+		// 失敗したものは次回に再試行するため、他の投票の締め切りは続ける
+		s.closeExpiredPolls(context.TODO(), now)
+		assert.Equal(t, []uuid.UUID{p2.MessageID}, mm.closed)
+	})
+}
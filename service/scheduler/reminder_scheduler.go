@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/inbox"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+// ReminderScheduler メッセージのリマインダーを通知日時に通知受信箱へ届けます
+//
+// リマインダーはDBに永続化されているため、サーバーが再起動しても
+// 起動時に通知日時を過ぎているものから順に届けられます。
+// 通知処理中にサーバーが停止した場合は、claimTimeout経過後に通知待ちに戻して再度届けます。
+type ReminderScheduler struct {
+	repo  repository.Repository
+	mm    message.Manager
+	inbox *inbox.Recorder
+	l     *zap.Logger
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	started   chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewReminderScheduler(repo repository.Repository, mm message.Manager, inbox *inbox.Recorder, logger *zap.Logger) *ReminderScheduler {
+	return &ReminderScheduler{
+		repo:    repo,
+		mm:      mm,
+		inbox:   inbox,
+		l:       logger.Named("reminder_scheduler"),
+		started: make(chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// Start リマインダーワーカーを起動します
+func (s *ReminderScheduler) Start() {
+	s.startOnce.Do(func() {
+		close(s.started)
+		go s.run()
+	})
+}
+
+// Shutdown リマインダーワーカーを停止します
+func (s *ReminderScheduler) Shutdown(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	select {
+	case <-s.started:
+	default:
+		return nil // 起動していない
+	}
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *ReminderScheduler) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	// 停止中に通知日時を過ぎたものを届ける
+	now := time.Now()
+	s.recoverStuckReminders(context.Background(), now)
+	s.deliverDueReminders(context.Background(), now)
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.recoverStuckReminders(context.Background(), now)
+			s.deliverDueReminders(context.Background(), now)
+		}
+	}
+}
+
+// recoverStuckReminders 通知処理中のままclaimTimeoutが経過したリマインダーを通知待ちに戻します
+func (s *ReminderScheduler) recoverStuckReminders(ctx context.Context, now time.Time) {
+	n, err := s.repo.RecoverMessageReminders(ctx, now.Add(-claimTimeout))
+	if err != nil {
+		s.l.Error("failed to RecoverMessageReminders", zap.Error(err))
+		return
+	}
+	if n > 0 {
+		s.l.Warn("recovered reminders stuck in delivering", zap.Int64("count", n))
+	}
+}
+
+func (s *ReminderScheduler) deliverDueReminders(ctx context.Context, now time.Time) {
+	reminders, err := s.repo.GetDueMessageReminders(ctx, now, batchSize)
+	if err != nil {
+		s.l.Error("failed to GetDueMessageReminders", zap.Error(err))
+		return
+	}
+	for _, r := range reminders {
+		s.deliver(ctx, r)
+	}
+}
+
+func (s *ReminderScheduler) deliver(ctx context.Context, r *model.MessageReminder) {
+	logger := s.l.With(zap.Stringer("reminderId", r.ID), zap.Stringer("userId", r.UserID))
+
+	// 他のワーカーや前回の処理と重複して通知しないように、通知前に通知処理中にする
+	if err := s.repo.ClaimMessageReminder(ctx, r.ID); err != nil {
+		if err != repository.ErrNotFound {
+			logger.Error("failed to ClaimMessageReminder", zap.Error(err))
+		}
+		return
+	}
+	r.Attempts++
+
+	m, err := s.mm.Get(ctx, r.MessageID)
+	if err != nil {
+		switch err {
+		case message.ErrNotFound:
+			// 削除されたメッセージのリマインダーは破棄
+			logger.Info("discarded reminder since the message has been deleted", zap.Stringer("messageId", r.MessageID))
+		default:
+			logger.Error("failed to get message", zap.Error(err), zap.Int("attempts", r.Attempts))
+			s.release(ctx, r, logger)
+			return
+		}
+	} else if err := s.inbox.RecordReminder(ctx, r.UserID, m.GetID(), m.GetChannelID()); err != nil {
+		logger.Error("failed to RecordReminder", zap.Error(err), zap.Int("attempts", r.Attempts))
+		s.release(ctx, r, logger)
+		return
+	}
+
+	// 削除に失敗しても通知処理中のまま残るので、claimTimeoutが経過するまでは再度通知されない
+	s.delete(ctx, r, logger)
+}
+
+// release 通知に失敗したリマインダーを次回に再試行するため通知待ちに戻します
+//
+// 試行回数が上限に達した場合は諦めて破棄します
+func (s *ReminderScheduler) release(ctx context.Context, r *model.MessageReminder, logger *zap.Logger) {
+	if r.Attempts >= model.MessageReminderMaxAttempts {
+		logger.Warn("gave up delivering reminder", zap.Int("attempts", r.Attempts))
+		s.delete(ctx, r, logger)
+		return
+	}
+	if err := s.repo.ReleaseMessageReminder(ctx, r.ID); err != nil && err != repository.ErrNotFound {
+		logger.Error("failed to ReleaseMessageReminder", zap.Error(err))
+	}
+}
+
+func (s *ReminderScheduler) delete(ctx context.Context, r *model.MessageReminder, logger *zap.Logger) {
+	if err := s.repo.DeleteMessageReminder(ctx, r.ID); err != nil && err != repository.ErrNotFound {
+		logger.Error("failed to DeleteMessageReminder", zap.Error(err))
+	}
+}
@@ -0,0 +1,233 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/leandro-lugaresi/hub"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/inbox"
+	"github.com/traPtitech/traQ/service/message"
+)
+
+type fakeMessage struct {
+	message.Message
+	id        uuid.UUID
+	channelID uuid.UUID
+}
+
+func (m *fakeMessage) GetID() uuid.UUID {
+	return m.id
+}
+
+func (m *fakeMessage) GetChannelID() uuid.UUID {
+	return m.channelID
+}
+
+type fakeReminderManager struct {
+	message.Manager
+	messages map[uuid.UUID]*fakeMessage
+	err      error
+}
+
+func (m *fakeReminderManager) Get(_ context.Context, id uuid.UUID) (message.Message, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	msg, ok := m.messages[id]
+	if !ok {
+		return nil, message.ErrNotFound
+	}
+	return msg, nil
+}
+
+func setupReminderScheduler(ctrl *gomock.Controller, mm *fakeReminderManager) (*ReminderScheduler, *Repo) {
+	repo := &Repo{
+		MockMessageReminderRepository: mock_repository.NewMockMessageReminderRepository(ctrl),
+		MockInboxItemRepository:       mock_repository.NewMockInboxItemRepository(ctrl),
+	}
+	recorder := inbox.NewRecorder(repo, nil, hub.New(), zap.NewNop())
+	return NewReminderScheduler(repo, mm, recorder, zap.NewNop()), repo
+}
+
+func TestReminderScheduler_deliverDueReminders(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	uid := uuid.NewV3(uuid.Nil, "u1")
+	m := &fakeMessage{id: uuid.NewV3(uuid.Nil, "m1"), channelID: uuid.NewV3(uuid.Nil, "c1")}
+	newReminder := func(attempts int) *model.MessageReminder {
+		return &model.MessageReminder{ID: uuid.NewV3(uuid.Nil, "r1"), UserID: uid, MessageID: m.id, RemindAt: now, State: model.MessageReminderPending, Attempts: attempts}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo := setupReminderScheduler(ctrl, &fakeReminderManager{messages: map[uuid.UUID]*fakeMessage{m.id: m}})
+		reminder := newReminder(0)
+
+		repo.MockMessageReminderRepository.
+			EXPECT().
+			GetDueMessageReminders(gomock.Any(), now, batchSize).
+			Return([]*model.MessageReminder{reminder}, nil).
+			Times(1)
+		gomock.InOrder(
+			repo.MockMessageReminderRepository.EXPECT().ClaimMessageReminder(gomock.Any(), reminder.ID).Return(nil).Times(1),
+			repo.MockInboxItemRepository.
+				EXPECT().
+				CreateInboxItems(gomock.Any(), []repository.CreateInboxItemArgs{{
+					UserID:    uid,
+					Type:      model.InboxItemTypeReminder,
+					MessageID: m.id,
+					ChannelID: m.channelID,
+					ActorID:   uid,
+				}}).
+				Return([]*model.InboxItem{{ID: uuid.NewV3(uuid.Nil, "i1"), UserID: uid}}, nil).
+				Times(1),
+			repo.MockMessageReminderRepository.EXPECT().DeleteMessageReminder(gomock.Any(), reminder.ID).Return(nil).Times(1),
+		)
+
+		s.deliverDueReminders(context.TODO(), now)
+	})
+
+	t.Run("already claimed", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo := setupReminderScheduler(ctrl, &fakeReminderManager{messages: map[uuid.UUID]*fakeMessage{m.id: m}})
+		reminder := newReminder(0)
+
+		repo.MockMessageReminderRepository.
+			EXPECT().
+			GetDueMessageReminders(gomock.Any(), now, batchSize).
+			Return([]*model.MessageReminder{reminder}, nil).
+			Times(1)
+		// 他のワーカーが処理中なので通知しない
+		repo.MockMessageReminderRepository.EXPECT().ClaimMessageReminder(gomock.Any(), reminder.ID).Return(repository.ErrNotFound).Times(1)
+
+		s.deliverDueReminders(context.TODO(), now)
+	})
+
+	t.Run("message deleted", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo := setupReminderScheduler(ctrl, &fakeReminderManager{})
+		reminder := newReminder(0)
+
+		repo.MockMessageReminderRepository.
+			EXPECT().
+			GetDueMessageReminders(gomock.Any(), now, batchSize).
+			Return([]*model.MessageReminder{reminder}, nil).
+			Times(1)
+		repo.MockMessageReminderRepository.EXPECT().ClaimMessageReminder(gomock.Any(), reminder.ID).Return(nil).Times(1)
+		// 届けられないので破棄される
+		repo.MockMessageReminderRepository.EXPECT().DeleteMessageReminder(gomock.Any(), reminder.ID).Return(nil).Times(1)
+
+		s.deliverDueReminders(context.TODO(), now)
+	})
+
+	t.Run("failed to record", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo := setupReminderScheduler(ctrl, &fakeReminderManager{messages: map[uuid.UUID]*fakeMessage{m.id: m}})
+		reminder := newReminder(0)
+
+		repo.MockMessageReminderRepository.
+			EXPECT().
+			GetDueMessageReminders(gomock.Any(), now, batchSize).
+			Return([]*model.MessageReminder{reminder}, nil).
+			Times(1)
+		repo.MockMessageReminderRepository.EXPECT().ClaimMessageReminder(gomock.Any(), reminder.ID).Return(nil).Times(1)
+		repo.MockInboxItemRepository.
+			EXPECT().
+			CreateInboxItems(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("error")).
+			Times(1)
+		// 再試行するため通知待ちに戻される
+		repo.MockMessageReminderRepository.EXPECT().ReleaseMessageReminder(gomock.Any(), reminder.ID).Return(nil).Times(1)
+		repo.MockMessageReminderRepository.EXPECT().DeleteMessageReminder(gomock.Any(), gomock.Any()).Times(0)
+
+		s.deliverDueReminders(context.TODO(), now)
+	})
+
+	t.Run("failed to record (max attempts)", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo := setupReminderScheduler(ctrl, &fakeReminderManager{messages: map[uuid.UUID]*fakeMessage{m.id: m}})
+		reminder := newReminder(model.MessageReminderMaxAttempts - 1)
+
+		repo.MockMessageReminderRepository.
+			EXPECT().
+			GetDueMessageReminders(gomock.Any(), now, batchSize).
+			Return([]*model.MessageReminder{reminder}, nil).
+			Times(1)
+		repo.MockMessageReminderRepository.EXPECT().ClaimMessageReminder(gomock.Any(), reminder.ID).Return(nil).Times(1)
+		repo.MockInboxItemRepository.
+			EXPECT().
+			CreateInboxItems(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("error")).
+			Times(1)
+		// 上限に達したので通知を諦めて破棄する
+		repo.MockMessageReminderRepository.EXPECT().DeleteMessageReminder(gomock.Any(), reminder.ID).Return(nil).Times(1)
+		repo.MockMessageReminderRepository.EXPECT().ReleaseMessageReminder(gomock.Any(), gomock.Any()).Times(0)
+
+		s.deliverDueReminders(context.TODO(), now)
+	})
+
+	t.Run("failed to delete", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo := setupReminderScheduler(ctrl, &fakeReminderManager{messages: map[uuid.UUID]*fakeMessage{m.id: m}})
+		reminder := newReminder(0)
+
+		repo.MockMessageReminderRepository.
+			EXPECT().
+			GetDueMessageReminders(gomock.Any(), now, batchSize).
+			Return([]*model.MessageReminder{reminder}, nil).
+			Times(1)
+		repo.MockMessageReminderRepository.EXPECT().ClaimMessageReminder(gomock.Any(), reminder.ID).Return(nil).Times(1)
+		repo.MockInboxItemRepository.
+			EXPECT().
+			CreateInboxItems(gomock.Any(), gomock.Any()).
+			Return([]*model.InboxItem{{ID: uuid.NewV3(uuid.Nil, "i1"), UserID: uid}}, nil).
+			Times(1)
+		repo.MockMessageReminderRepository.EXPECT().DeleteMessageReminder(gomock.Any(), reminder.ID).Return(errors.New("error")).Times(1)
+		// 通知処理中のまま残し、重複して通知しない
+		repo.MockMessageReminderRepository.EXPECT().ReleaseMessageReminder(gomock.Any(), gomock.Any()).Times(0)
+
+		s.deliverDueReminders(context.TODO(), now)
+	})
+}
+
+func TestReminderScheduler_recoverStuckReminders(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo := setupReminderScheduler(ctrl, &fakeReminderManager{})
+
+		repo.MockMessageReminderRepository.EXPECT().RecoverMessageReminders(gomock.Any(), now.Add(-claimTimeout)).Return(int64(1), nil).Times(1)
+
+		s.recoverStuckReminders(context.TODO(), now)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		ctrl := gomock.NewController(t)
+		s, repo := setupReminderScheduler(ctrl, &fakeReminderManager{})
+
+		repo.MockMessageReminderRepository.EXPECT().RecoverMessageReminders(gomock.Any(), now.Add(-claimTimeout)).Return(int64(0), errors.New("error")).Times(1)
+
+		s.recoverStuckReminders(context.TODO(), now)
+	})
+}
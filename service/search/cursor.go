@@ -0,0 +1,69 @@
+package search
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	json "github.com/json-iterator/go"
+)
+
+// ErrInvalidCursor エラー 不正なカーソルです
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursor 検索結果の続きを取得するためのカーソル
+//
+// 直前のページの最後のメッセージのソートキーの値とメッセージIDを保持し、
+// それより後のメッセージを取得する(search_after)ために使います
+type cursor struct {
+	// SortKey カーソルを発行した時のソートキー (GetSortKeyの値)
+	SortKey string `json:"k"`
+	// Time 最後のメッセージのソートキーの値
+	Time time.Time `json:"t"`
+	// MessageID 最後のメッセージのID
+	MessageID uuid.UUID `json:"id"`
+}
+
+// encode カーソルを不透明な文字列に変換します
+func (c cursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodeCursor 文字列をカーソルに変換します
+func decodeCursor(s string) (cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+	if c.MessageID == uuid.Nil || !strings.Contains(c.SortKey, ":") {
+		return cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// isDescending ソートが降順かどうか
+func (c cursor) isDescending() bool {
+	return strings.HasSuffix(c.SortKey, ":"+descSortKey)
+}
+
+// sortKeyName ソートに使うキーの名前
+func (c cursor) sortKeyName() string {
+	name, _, _ := strings.Cut(c.SortKey, ":")
+	return name
+}
+
+// newNextCursor 次のページを取得するためのカーソルを生成します
+func newNextCursor(sortKey string, createdAt, updatedAt time.Time, messageID uuid.UUID) string {
+	c := cursor{SortKey: sortKey, Time: createdAt, MessageID: messageID}
+	if c.sortKeyName() == updatedAtSortKey {
+		c.Time = updatedAt
+	}
+	return c.encode()
+}
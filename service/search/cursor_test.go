@@ -0,0 +1,62 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	id := uuid.Must(uuid.NewV7())
+
+	t.Run("createdAt", func(t *testing.T) {
+		t.Parallel()
+		c, err := decodeCursor(newNextCursor("createdAt:desc", createdAt, updatedAt, id))
+		require.NoError(t, err)
+		assert.Equal(t, "createdAt", c.sortKeyName())
+		assert.True(t, c.isDescending())
+		assert.True(t, createdAt.Equal(c.Time))
+		assert.Equal(t, id, c.MessageID)
+	})
+
+	t.Run("updatedAt", func(t *testing.T) {
+		t.Parallel()
+		c, err := decodeCursor(newNextCursor("updatedAt:asc", createdAt, updatedAt, id))
+		require.NoError(t, err)
+		assert.Equal(t, "updatedAt", c.sortKeyName())
+		assert.False(t, c.isDescending())
+		assert.True(t, updatedAt.Equal(c.Time))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		for _, s := range []string{"", "!!!", "e30", "bm90IGpzb24"} {
+			_, err := decodeCursor(s)
+			assert.ErrorIs(t, err, ErrInvalidCursor, s)
+		}
+	})
+}
+
+func TestQuery_Validate_Cursor(t *testing.T) {
+	t.Parallel()
+
+	c := newNextCursor("createdAt:desc", time.Now(), time.Now(), uuid.Must(uuid.NewV7()))
+
+	assert.NoError(t, Query{Cursor: optional.From(c)}.Validate())
+	assert.Error(t, Query{Cursor: optional.From(c), Offset: optional.From(20)}.Validate())
+	assert.Error(t, Query{Cursor: optional.From(c), Sort: optional.From("-createdAt")}.Validate())
+	assert.Error(t, Query{Cursor: optional.From("invalid")}.Validate())
+
+	q := Query{Cursor: optional.From(c)}
+	assert.True(t, q.getCursor().Valid)
+	assert.False(t, Query{}.getCursor().Valid)
+}
@@ -44,6 +44,7 @@ type Query struct {
 	Limit          optional.Of[int]       `query:"limit"`          // 取得件数
 	Offset         optional.Of[int]       `query:"offset"`         // 取得Offset
 	Sort           optional.Of[string]    `query:"sort"`           // 並び順 /[-\+]?key/
+	Cursor         optional.Of[string]    `query:"cursor"`         // 続きを取得するためのカーソル Result.NextCursor の値
 }
 
 func (q Query) Validate() error {
@@ -53,9 +54,39 @@ func (q Query) Validate() error {
 		// https://www.elastic.co/guide/en/elasticsearch/reference/current/paginate-search-results.html
 		vd.Field(&q.Offset, vd.Min(0), vd.Max(9900)),
 		vd.Field(&q.Sort, vd.Match(allowedSortKeysRegExp)),
+		vd.Field(&q.Cursor, vd.By(func(value interface{}) error {
+			if !q.Cursor.Valid {
+				return nil
+			}
+			if q.Offset.Valid {
+				return errors.New("cannot be used with offset")
+			}
+			c, err := decodeCursor(q.Cursor.V)
+			if err != nil {
+				return err
+			}
+			if c.SortKey != q.GetSortKey() {
+				return errors.New("sort mismatch")
+			}
+			return nil
+		})),
 	)
 }
 
+// getCursor カーソルを取得します
+//
+// Validate済みのクエリに対して使用してください
+func (q Query) getCursor() optional.Of[cursor] {
+	if !q.Cursor.Valid {
+		return optional.Of[cursor]{}
+	}
+	c, err := decodeCursor(q.Cursor.V)
+	if err != nil {
+		return optional.Of[cursor]{}
+	}
+	return optional.From(c)
+}
+
 // GetSortKey ソートに使うキーの情報を抽出します
 func (q Query) GetSortKey() string {
 	if !q.Sort.Valid {
@@ -83,8 +114,43 @@ type Result interface {
 	TotalHits() int64
 	// Hits createdAtで降順にソートされた、ヒットしたメッセージ
 	Hits() []message.Message
+	// NextCursor 続きを取得するためのカーソル 続きが無い場合は無効な値
+	NextCursor() optional.Of[string]
+	// Highlights ヒットしたメッセージごとの、検索ワードに一致した部分のハイライト
+	//
+	// 一致した部分は<em></em>で囲まれます
+	Highlights() map[uuid.UUID][]string
+	// Facets ヒットしたメッセージ全体の集計
+	Facets() Facets
+}
+
+// Facets 検索結果の集計
+type Facets struct {
+	// Channels チャンネルごとのヒット件数
+	Channels []FacetBucket
+	// Users 投稿者ごとのヒット件数
+	Users []FacetBucket
+	// Months 投稿月(UTC)ごとのヒット件数
+	Months []FacetBucket
 }
 
+// FacetBucket 集計の項目
+type FacetBucket struct {
+	// Key 集計キー (チャンネルID, ユーザーID, 年月 2006-01)
+	Key string
+	// Count ヒット件数
+	Count int64
+}
+
+const (
+	// facetSize チャンネル・投稿者ごとの集計で返す最大項目数
+	facetSize = 20
+	// highlightPreTag ハイライトの開始タグ
+	highlightPreTag = "<em>"
+	// highlightPostTag ハイライトの終了タグ
+	highlightPostTag = "</em>"
+)
+
 const (
 	createdAtSortKey = "createdAt" // 作成日時の新しい順
 	updatedAtSortKey = "updatedAt" // 更新日時の新しい順
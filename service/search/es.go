@@ -52,7 +52,7 @@ type esEngine struct {
 
 // esMessageDoc Elasticsearchに入るメッセージの情報
 type esMessageDoc struct {
-	ID             uuid.UUID   `json:"-"`
+	ID             uuid.UUID   `json:"id"`
 	UserID         uuid.UUID   `json:"userId"`
 	ChannelID      uuid.UUID   `json:"channelId"`
 	IsPublic       bool        `json:"isPublic"`
@@ -71,6 +71,7 @@ type esMessageDoc struct {
 
 // esMessageDocUpdate Update用 Elasticsearchに入るメッセージの部分的な情報
 type esMessageDocUpdate struct {
+	ID             uuid.UUID   `json:"id"`
 	Text           string      `json:"text"`
 	UpdatedAt      time.Time   `json:"updatedAt"`
 	Citation       []uuid.UUID `json:"citation"`
@@ -92,6 +93,9 @@ type m map[string]any
 // esMessageDoc と同じにする
 var esMapping = m{
 	"properties": m{
+		"id": m{
+			"type": "keyword",
+		},
 		"userId": m{
 			"type": "keyword",
 		},
@@ -174,6 +178,26 @@ var esSetting = m{
 
 // NewESEngine Elasticsearch検索エンジンを生成します
 func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config ESEngineConfig) (Engine, error) {
+	engine, err := newESEngine(mm, cm, repo, logger, config)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	engine.done = done
+	go engine.syncLoop(done)
+
+	return engine, nil
+}
+
+// NewESIndexer Elasticsearchのインデックスを直接操作する Indexer を生成します
+//
+// 定期的な同期は行いません。
+func NewESIndexer(cm channel.Manager, repo repository.Repository, logger *zap.Logger, config ESEngineConfig) (Indexer, error) {
+	return newESEngine(nil, cm, repo, logger, config)
+}
+
+func newESEngine(mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger, config ESEngineConfig) (*esEngine, error) {
 	// esクライアント作成
 	client, err := elasticsearch.NewClient(elasticsearch.Config{
 		Addresses: []string{config.URL},
@@ -240,39 +264,176 @@ func NewESEngine(mm message.Manager, cm channel.Manager, repo repository.Reposit
 			return nil, fmt.Errorf("failed to create Elasticsearch index: StatusCode: %v, ResponseBody: %v", createIndexRes.StatusCode, createIndexRes.Body)
 		}
 		defer createIndexRes.Body.Close()
+	} else {
+		// 既存のindexにフィールドを追加
+		reqBody, err := json.Marshal(esMapping)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init Elasticsearch: %w", err)
+		}
+
+		putMappingRes, err := client.Indices.PutMapping(
+			[]string{getIndexName(esMessageIndex)},
+			bytes.NewBuffer(reqBody),
+			client.Indices.PutMapping.WithContext(context.Background()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to update Elasticsearch index mapping: %w", err)
+		}
+		if putMappingRes.IsError() {
+			return nil, fmt.Errorf("failed to update Elasticsearch index mapping: StatusCode: %v, ResponseBody: %v", putMappingRes.StatusCode, putMappingRes.Body)
+		}
+		defer putMappingRes.Body.Close()
+
+		// 追加したフィールドを既存のドキュメントに埋める
+		if err := backfillESMessageIDs(client, logger); err != nil {
+			return nil, err
+		}
 	}
 
-	done := make(chan struct{})
-	engine := &esEngine{
+	return &esEngine{
 		client: client,
 		mm:     mm,
 		cm:     cm,
 		repo:   repo,
 		l:      logger.Named("search"),
-		done:   done,
+	}, nil
+}
+
+// backfillESMessageIDs idフィールドを持たない古いドキュメントにドキュメントID(メッセージID)を埋めます
+//
+// 検索結果の順序の一意性とカーソルによるページングはidフィールドに依存するため、
+// 既存のindexにidフィールドを追加した場合は全てのドキュメントに埋める必要があります。
+// indexが大きい場合に起動を妨げないよう、Elasticsearchのタスクとして非同期に実行します。
+func backfillESMessageIDs(client *elasticsearch.Client, logger *zap.Logger) error {
+	index := getIndexName(esMessageIndex)
+	missingID := m{
+		"bool": m{
+			"must_not": m{
+				"exists": m{"field": "id"},
+			},
+		},
 	}
 
-	go engine.syncLoop(done)
+	countBody, err := json.Marshal(m{"query": missingID})
+	if err != nil {
+		return fmt.Errorf("failed to init Elasticsearch: %w", err)
+	}
+	countRes, err := client.Count(
+		client.Count.WithIndex(index),
+		client.Count.WithBody(bytes.NewBuffer(countBody)),
+		client.Count.WithContext(context.Background()))
+	if err != nil {
+		return fmt.Errorf("failed to count Elasticsearch documents without id: %w", err)
+	}
+	defer countRes.Body.Close()
+	if countRes.IsError() {
+		return fmt.Errorf("failed to count Elasticsearch documents without id: %s", countRes.String())
+	}
+	var count struct {
+		Count int `json:"count"`
+	}
+	if err := json.NewDecoder(countRes.Body).Decode(&count); err != nil {
+		return fmt.Errorf("failed to count Elasticsearch documents without id: %w", err)
+	}
+	if count.Count == 0 {
+		return nil
+	}
 
-	return engine, nil
+	updateBody, err := json.Marshal(m{
+		"query": missingID,
+		"script": m{
+			"source": "ctx._source.id = ctx._id",
+			"lang":   "painless",
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to init Elasticsearch: %w", err)
+	}
+	updateRes, err := client.UpdateByQuery(
+		[]string{index},
+		client.UpdateByQuery.WithBody(bytes.NewBuffer(updateBody)),
+		client.UpdateByQuery.WithConflicts("proceed"),
+		client.UpdateByQuery.WithWaitForCompletion(false),
+		client.UpdateByQuery.WithContext(context.Background()))
+	if err != nil {
+		return fmt.Errorf("failed to backfill Elasticsearch document ids: %w", err)
+	}
+	defer updateRes.Body.Close()
+	if updateRes.IsError() {
+		return fmt.Errorf("failed to backfill Elasticsearch document ids: %s", updateRes.String())
+	}
+	var task struct {
+		Task string `json:"task"`
+	}
+	if err := json.NewDecoder(updateRes.Body).Decode(&task); err != nil {
+		return fmt.Errorf("failed to backfill Elasticsearch document ids: %w", err)
+	}
+	logger.Info("started backfilling id of existing Elasticsearch documents. search results may be unstable until the task completes",
+		zap.Int("documents", count.Count),
+		zap.String("task", task.Task))
+	return nil
 }
 
 type searchQuery m
 
 type searchBody struct {
-	Query searchQuery `json:"query,omitempty"`
+	Query        searchQuery `json:"query,omitempty"`
+	SearchAfter  []any       `json:"search_after,omitempty"`
+	Highlight    m           `json:"highlight,omitempty"`
+	Aggregations m           `json:"aggs,omitempty"`
 }
 
-func newSearchBody(andQueries []searchQuery) searchBody {
+func newSearchBody(andQueries []searchQuery, searchAfter []any) searchBody {
 	return searchBody{
 		Query: searchQuery{
 			"bool": boolQuery{
 				Must: andQueries,
 			},
 		},
+		SearchAfter:  searchAfter,
+		Highlight:    esHighlight,
+		Aggregations: esAggregations,
 	}
 }
 
+// esHighlight 検索ワードに一致した部分のハイライト設定
+//
+// ハイライトはHTMLとして表示されるため、encoderでテキストをHTMLエスケープする
+var esHighlight = m{
+	"pre_tags":  []string{highlightPreTag},
+	"post_tags": []string{highlightPostTag},
+	"encoder":   "html",
+	"fields": m{
+		"text": m{
+			"fragment_size":       100,
+			"number_of_fragments": 3,
+		},
+	},
+}
+
+// esAggregations ヒットしたメッセージ全体の集計設定
+var esAggregations = m{
+	"channels": m{
+		"terms": m{
+			"field": "channelId",
+			"size":  facetSize,
+		},
+	},
+	"users": m{
+		"terms": m{
+			"field": "userId",
+			"size":  facetSize,
+		},
+	},
+	"months": m{
+		"date_histogram": m{
+			"field":             "createdAt",
+			"calendar_interval": "month",
+			"format":            "yyyy-MM",
+			"min_doc_count":     1,
+		},
+	},
+}
+
 type simpleQueryString struct {
 	Query           string   `json:"query"`
 	Fields          []string `json:"fields"`
@@ -402,8 +563,19 @@ func (e *esEngine) Do(q *Query) (Result, error) {
 
 	// NOTE: 現状`sort.Key`はそのままesのソートキーとして使える前提
 	sort := q.GetSortKey()
+	// 同じ日時のメッセージの順序を一意に定めるため、IDでもソートする
+	// NOTE: 古いドキュメントのidフィールドは起動時に埋められる (backfillESMessageIDs)
+	idSort := "id:" + ascSortKey
+	if strings.HasSuffix(sort, ":"+descSortKey) {
+		idSort = "id:" + descSortKey
+	}
+
+	var searchAfter []any
+	if c := q.getCursor(); c.Valid {
+		searchAfter = []any{c.V.Time.UnixMilli(), c.V.MessageID.String()}
+	}
 
-	b, err := json.Marshal(newSearchBody(musts))
+	b, err := json.Marshal(newSearchBody(musts, searchAfter))
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal search query: %w", err)
 	}
@@ -411,7 +583,7 @@ func (e *esEngine) Do(q *Query) (Result, error) {
 	sr, err := e.client.Search(
 		e.client.Search.WithIndex(getIndexName(esMessageIndex)),
 		e.client.Search.WithBody(bytes.NewBuffer(b)),
-		e.client.Search.WithSort(sort),
+		e.client.Search.WithSort(sort, idSort),
 		e.client.Search.WithSize(limit),
 		e.client.Search.WithFrom(offset),
 	)
@@ -430,7 +602,7 @@ func (e *esEngine) Do(q *Query) (Result, error) {
 	}
 
 	e.l.Debug("search result", zap.Reflect("hits", res.Hits))
-	return e.parseResultFromResponse(res)
+	return e.parseResultFromResponse(res, sort, limit)
 }
 
 func (e *esEngine) Available() bool {
@@ -0,0 +1,216 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8/esutil"
+	"github.com/gofrs/uuid"
+	json "github.com/json-iterator/go"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// esIndexerPageSize 集計・ID取得時の1リクエストあたりの取得件数
+const esIndexerPageSize = 1000
+
+// IndexMessages implements Indexer interface.
+func (e *esEngine) IndexMessages(ctx context.Context, messages []*model.Message) (err error) {
+	userCache, err := newUserCacheOf(ctx, e.repo, messages)
+	if err != nil {
+		return err
+	}
+
+	bulkIndexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Client: e.client,
+		Index:  getIndexName(esMessageIndex),
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		closeErr := bulkIndexer.Close(ctx)
+		if err != nil && closeErr != nil { // エラーが発生してからdeferに来た時、エラーの上書きを防ぐ
+			err = fmt.Errorf("error in bulk index: %w.\nerror in closing bulk indexer: %w", err, closeErr)
+			return
+		}
+		if closeErr != nil {
+			err = closeErr
+			return
+		}
+		if n := bulkIndexer.Stats().NumFailed; n > 0 {
+			err = fmt.Errorf("failed to index %v message(s)", n)
+		}
+	}()
+
+	for _, v := range messages {
+		doc, err := e.convertMessageCreated(v, message.Parse(v.Text), userCache)
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(*doc)
+		if err != nil {
+			return err
+		}
+
+		err = bulkIndexer.Add(ctx, esutil.BulkIndexerItem{
+			Action:     "index",
+			DocumentID: v.ID.String(),
+			Body:       bytes.NewReader(data),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteMessages implements Indexer interface.
+func (e *esEngine) DeleteMessages(ctx context.Context, ids []uuid.UUID) (err error) {
+	bulkIndexer, err := esutil.NewBulkIndexer(esutil.BulkIndexerConfig{
+		Client: e.client,
+		Index:  getIndexName(esMessageIndex),
+	})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		closeErr := bulkIndexer.Close(ctx)
+		if err != nil && closeErr != nil { // エラーが発生してからdeferに来た時、エラーの上書きを防ぐ
+			err = fmt.Errorf("error in bulk index: %w.\nerror in closing bulk indexer: %w", err, closeErr)
+			return
+		}
+		if closeErr != nil {
+			err = closeErr
+			return
+		}
+		if n := bulkIndexer.Stats().NumFailed; n > 0 {
+			err = fmt.Errorf("failed to delete %v message(s)", n)
+		}
+	}()
+
+	for _, id := range ids {
+		err = bulkIndexer.Add(ctx, esutil.BulkIndexerItem{
+			Action:     "delete",
+			DocumentID: id.String(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type esCompositeResponse struct {
+	Aggregations struct {
+		Channels struct {
+			AfterKey m `json:"after_key"`
+			Buckets  []struct {
+				Key struct {
+					ChannelID uuid.UUID `json:"channelId"`
+				} `json:"key"`
+				DocCount int64 `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"channels"`
+	} `json:"aggregations"`
+}
+
+// CountMessagesByChannel implements Indexer interface.
+func (e *esEngine) CountMessagesByChannel(ctx context.Context) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64)
+	var after m
+	for {
+		composite := m{
+			"size":    esIndexerPageSize,
+			"sources": []m{{"channelId": m{"terms": m{"field": "channelId"}}}},
+		}
+		if after != nil {
+			composite["after"] = after
+		}
+		b, err := json.Marshal(m{
+			"size": 0,
+			"aggs": m{"channels": m{"composite": composite}},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var res esCompositeResponse
+		if err := e.search(ctx, b, &res); err != nil {
+			return nil, err
+		}
+		for _, bucket := range res.Aggregations.Channels.Buckets {
+			counts[bucket.Key.ChannelID] = bucket.DocCount
+		}
+
+		after = res.Aggregations.Channels.AfterKey
+		if after == nil || len(res.Aggregations.Channels.Buckets) < esIndexerPageSize {
+			return counts, nil
+		}
+	}
+}
+
+// GetMessageIDsByChannel implements Indexer interface.
+func (e *esEngine) GetMessageIDsByChannel(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error) {
+	var (
+		ids         []uuid.UUID
+		searchAfter []any
+	)
+	for {
+		body := m{
+			"size":    esIndexerPageSize,
+			"_source": false,
+			"query":   m{"term": m{"channelId": channelID}},
+			"sort":    []m{{"id": "asc"}},
+		}
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		var res esSearchResponse
+		if err := e.search(ctx, b, &res); err != nil {
+			return nil, err
+		}
+		for _, hit := range res.Hits.Hits {
+			id, err := uuid.FromString(hit.ID)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+
+		hits := res.Hits.Hits
+		if len(hits) < esIndexerPageSize {
+			return ids, nil
+		}
+		searchAfter = hits[len(hits)-1].Sort
+	}
+}
+
+// search 検索リクエストを送り、レスポンスをresにデコードします
+func (e *esEngine) search(ctx context.Context, body []byte, res any) error {
+	sr, err := e.client.Search(
+		e.client.Search.WithContext(ctx),
+		e.client.Search.WithIndex(getIndexName(esMessageIndex)),
+		e.client.Search.WithBody(bytes.NewBuffer(body)),
+	)
+	if err != nil {
+		return err
+	}
+	defer sr.Body.Close()
+	if sr.IsError() {
+		return fmt.Errorf("failed to search: %s", sr.String())
+	}
+	return json.NewDecoder(sr.Body).Decode(res)
+}
@@ -2,18 +2,23 @@ package search
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gofrs/uuid"
 	"github.com/samber/lo"
 
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/utils"
+	"github.com/traPtitech/traQ/utils/optional"
 )
 
 // esResult search.Result 実装
 type esResult struct {
-	totalHits int64
-	messages  []message.Message
+	totalHits  int64
+	messages   []message.Message
+	nextCursor optional.Of[string]
+	highlights map[uuid.UUID][]string
+	facets     Facets
 }
 
 type esSearchResponse struct {
@@ -31,32 +36,76 @@ type esSearchResponse struct {
 			Value    int64  `json:"value"`
 		} `json:"total"`
 	} `json:"hits"`
+	Aggregations struct {
+		Channels esAggregation `json:"channels"`
+		Users    esAggregation `json:"users"`
+		Months   esAggregation `json:"months"`
+	} `json:"aggregations"`
 	TimedOut bool  `json:"timed_out"`
 	Took     int64 `json:"took"`
 }
 
+type esAggregation struct {
+	Buckets []esAggregationBucket `json:"buckets"`
+}
+
+type esAggregationBucket struct {
+	Key         any    `json:"key"`
+	KeyAsString string `json:"key_as_string"`
+	DocCount    int64  `json:"doc_count"`
+}
+
+func (a esAggregation) toFacetBuckets() []FacetBucket {
+	return utils.Map(a.Buckets, func(b esAggregationBucket) FacetBucket {
+		key := b.KeyAsString
+		if key == "" {
+			key = fmt.Sprint(b.Key)
+		}
+		return FacetBucket{Key: key, Count: b.DocCount}
+	})
+}
+
 type esSearchHit struct {
-	ID     string       `json:"_id"`
-	Index  string       `json:"_index"`
-	Score  any          `json:"_score"`
-	Source esMessageDoc `json:"_source"`
-	Type   string       `json:"_type"`
-	Sort   []int64      `json:"sort"`
+	ID        string              `json:"_id"`
+	Index     string              `json:"_index"`
+	Score     any                 `json:"_score"`
+	Source    esMessageDoc        `json:"_source"`
+	Type      string              `json:"_type"`
+	Sort      []any               `json:"sort"`
+	Highlight map[string][]string `json:"highlight"`
 }
 
-func (e *esEngine) parseResultFromResponse(searchRes esSearchResponse) (Result, error) {
+func (e *esEngine) parseResultFromResponse(searchRes esSearchResponse, sortKey string, limit int) (Result, error) {
 	totalHits := searchRes.Hits.Total.Value
 	hits := searchRes.Hits.Hits
 
 	r := &esResult{
-		totalHits: totalHits,
-		messages:  make([]message.Message, 0, len(hits)),
+		totalHits:  totalHits,
+		messages:   make([]message.Message, 0, len(hits)),
+		highlights: make(map[uuid.UUID][]string, len(hits)),
+		facets: Facets{
+			Channels: searchRes.Aggregations.Channels.toFacetBuckets(),
+			Users:    searchRes.Aggregations.Users.toFacetBuckets(),
+			Months:   searchRes.Aggregations.Months.toFacetBuckets(),
+		},
+	}
+
+	// 取得件数が上限に達している場合は続きがある可能性がある
+	if len(hits) > 0 && len(hits) == limit {
+		last := hits[len(hits)-1]
+		r.nextCursor = optional.From(newNextCursor(sortKey, last.Source.CreatedAt, last.Source.UpdatedAt, uuid.Must(uuid.FromString(last.ID))))
 	}
 
 	messageIDs := utils.Map(hits, func(hit esSearchHit) uuid.UUID {
 		return uuid.Must(uuid.FromString(hit.ID))
 	})
 
+	for i, hit := range hits {
+		if fragments := hit.Highlight["text"]; len(fragments) > 0 {
+			r.highlights[messageIDs[i]] = fragments
+		}
+	}
+
 	messages, err := e.mm.GetIn(context.Background(), messageIDs)
 	if err != nil {
 		return nil, err
@@ -84,3 +133,15 @@ func (e *esResult) TotalHits() int64 {
 func (e *esResult) Hits() []message.Message {
 	return e.messages
 }
+
+func (e *esResult) NextCursor() optional.Of[string] {
+	return e.nextCursor
+}
+
+func (e *esResult) Highlights() map[uuid.UUID][]string {
+	return e.highlights
+}
+
+func (e *esResult) Facets() Facets {
+	return e.facets
+}
@@ -51,6 +51,7 @@ func (e *esEngine) convertMessageCreated(m *model.Message, parseResult *message.
 	attr := e.getAttributes(m, parseResult)
 
 	return &esMessageDoc{
+		ID:             m.ID,
 		UserID:         m.UserID,
 		ChannelID:      m.ChannelID,
 		IsPublic:       e.cm.IsPublicChannel(context.Background(), m.ChannelID),
@@ -73,6 +74,7 @@ func (e *esEngine) convertMessageUpdated(m *model.Message, parseResult *message.
 	attr := e.getAttributes(m, parseResult)
 	// Updateする項目のみ
 	return &esMessageDocUpdate{
+		ID:             m.ID,
 		Text:           m.Text,
 		UpdatedAt:      m.UpdatedAt,
 		Citation:       attr.Citation,
@@ -85,6 +87,11 @@ func (e *esEngine) convertMessageUpdated(m *model.Message, parseResult *message.
 }
 
 func (e *esEngine) getAttributes(m *model.Message, parseResult *message.ParseResult) *attributes {
+	return getAttributes(e.repo, e.l, m, parseResult)
+}
+
+// getAttributes メッセージの検索用の属性を取得します
+func getAttributes(repo repository.Repository, l *zap.Logger, m *model.Message, parseResult *message.ParseResult) *attributes {
 	attr := &attributes{}
 
 	attr.To = append(parseResult.Mentions, parseResult.GroupMentions...)
@@ -93,9 +100,9 @@ func (e *esEngine) getAttributes(m *model.Message, parseResult *message.ParseRes
 	attr.HasAttachments = len(parseResult.Attachments) != 0
 
 	for _, attachmentID := range parseResult.Attachments {
-		meta, err := e.repo.GetFileMeta(context.Background(), attachmentID)
+		meta, err := repo.GetFileMeta(context.Background(), attachmentID)
 		if err != nil {
-			e.l.Warn(err.Error(), zap.Error(err))
+			l.Warn(err.Error(), zap.Error(err))
 			continue
 		}
 		if strings.HasPrefix(meta.Mime, "image/") {
@@ -0,0 +1,232 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// Indexer 検索インデックスを直接操作するインターフェイス
+//
+// 通常の同期は Engine が行います。インデックスの再構築・検証に使用します。
+type Indexer interface {
+	// IndexMessages メッセージをインデックスに追加・更新します
+	IndexMessages(ctx context.Context, messages []*model.Message) error
+	// DeleteMessages 指定したIDのメッセージをインデックスから削除します
+	DeleteMessages(ctx context.Context, ids []uuid.UUID) error
+	// CountMessagesByChannel インデックス上のチャンネルごとのメッセージ数を取得します
+	CountMessagesByChannel(ctx context.Context) (map[uuid.UUID]int64, error)
+	// GetMessageIDsByChannel インデックス上の指定したチャンネルのメッセージIDを全て取得します
+	GetMessageIDsByChannel(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error)
+}
+
+// ReindexOptions Reindex のオプション
+type ReindexOptions struct {
+	// After このIDより後のメッセージから再開します。uuid.Nilの場合は最初から
+	After uuid.UUID
+	// BatchSize 1回にインデックスするメッセージ数
+	BatchSize int
+	// Interval バッチ間の待機時間
+	Interval time.Duration
+	// OnProgress バッチ毎に呼ばれます。lastは最後にインデックスしたメッセージのID
+	OnProgress func(last uuid.UUID, indexed int64, total int64) error
+}
+
+// Reindex 削除されていない全てのメッセージをID順にインデックスし直します
+func Reindex(ctx context.Context, idx Indexer, repo repository.MessageRepository, opts ReindexOptions) error {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = syncMessageBulk
+	}
+
+	counts, err := repo.GetMessageCountsByChannel(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count messages: %w", err)
+	}
+	var total int64
+	for _, n := range counts {
+		total += n
+	}
+
+	var indexed int64
+	last := opts.After
+	for {
+		messages, more, err := repo.GetMessagesAfterID(ctx, last, opts.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to get messages: %w", err)
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		if err := idx.IndexMessages(ctx, messages); err != nil {
+			return fmt.Errorf("failed to index messages: %w", err)
+		}
+		last = messages[len(messages)-1].ID
+		indexed += int64(len(messages))
+
+		if opts.OnProgress != nil {
+			if err := opts.OnProgress(last, indexed, total); err != nil {
+				return err
+			}
+		}
+		if !more {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(opts.Interval):
+		}
+	}
+}
+
+// ChannelDrift チャンネルのメッセージ数の、DBとインデックスの差異
+type ChannelDrift struct {
+	ChannelID uuid.UUID
+	// DBCount DB上のメッセージ数
+	DBCount int64
+	// IndexCount インデックス上のメッセージ数
+	IndexCount int64
+	// Missing インデックスに存在しないメッセージのID
+	Missing []uuid.UUID
+	// Extra DBに存在しない(削除された)メッセージのID
+	Extra []uuid.UUID
+}
+
+// VerifyOptions Verify のオプション
+type VerifyOptions struct {
+	// Repair 差異を修復するかどうか
+	Repair bool
+	// OnDrift 差異のあるチャンネルが見つかる度に呼ばれます
+	OnDrift func(drift *ChannelDrift)
+}
+
+// Verify チャンネルごとにDBとインデックスのメッセージIDを突き合わせます
+//
+// メッセージ数が一致していても、欠落と削除漏れが同数ある場合があるため、全てのチャンネルのメッセージIDを比較します。
+// 差異のあったチャンネルの一覧を返します。
+func Verify(ctx context.Context, idx Indexer, repo repository.MessageRepository, l *zap.Logger, opts VerifyOptions) ([]*ChannelDrift, error) {
+	dbCounts, err := repo.GetMessageCountsByChannel(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count messages: %w", err)
+	}
+	indexCounts, err := idx.CountMessagesByChannel(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count indexed messages: %w", err)
+	}
+
+	channelIDs := set.UUID{}
+	for id := range dbCounts {
+		channelIDs.Add(id)
+	}
+	for id := range indexCounts {
+		channelIDs.Add(id)
+	}
+
+	var drifts []*ChannelDrift
+	for _, channelID := range channelIDs.Array() {
+		drift, err := diffChannel(ctx, idx, repo, channelID)
+		if err != nil {
+			return nil, err
+		}
+		if len(drift.Missing) == 0 && len(drift.Extra) == 0 {
+			continue
+		}
+		drift.DBCount = dbCounts[channelID]
+		drift.IndexCount = indexCounts[channelID]
+		drifts = append(drifts, drift)
+		if opts.OnDrift != nil {
+			opts.OnDrift(drift)
+		}
+
+		if opts.Repair {
+			if err := repairChannel(ctx, idx, repo, drift); err != nil {
+				return nil, err
+			}
+			l.Info("repaired channel", zap.Stringer("channelId", channelID), zap.Int("indexed", len(drift.Missing)), zap.Int("deleted", len(drift.Extra)))
+		}
+	}
+	return drifts, nil
+}
+
+func diffChannel(ctx context.Context, idx Indexer, repo repository.MessageRepository, channelID uuid.UUID) (*ChannelDrift, error) {
+	dbIDs, err := repo.GetMessageIDsByChannel(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message ids of channel %s: %w", channelID, err)
+	}
+	indexIDs, err := idx.GetMessageIDsByChannel(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get indexed message ids of channel %s: %w", channelID, err)
+	}
+
+	dbSet := set.UUIDSetFromArray(dbIDs)
+	indexSet := set.UUIDSetFromArray(indexIDs)
+	drift := &ChannelDrift{ChannelID: channelID}
+	for _, id := range dbIDs {
+		if !indexSet.Contains(id) {
+			drift.Missing = append(drift.Missing, id)
+		}
+	}
+	for _, id := range indexIDs {
+		if !dbSet.Contains(id) {
+			drift.Extra = append(drift.Extra, id)
+		}
+	}
+	return drift, nil
+}
+
+func repairChannel(ctx context.Context, idx Indexer, repo repository.MessageRepository, drift *ChannelDrift) error {
+	messages := make([]*model.Message, 0, min(len(drift.Missing), syncMessageBulk))
+	for _, id := range drift.Missing {
+		m, err := repo.GetMessageByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue // 比較後に削除された
+			}
+			return fmt.Errorf("failed to get message %s: %w", id, err)
+		}
+		messages = append(messages, m)
+
+		if len(messages) == syncMessageBulk {
+			if err := idx.IndexMessages(ctx, messages); err != nil {
+				return fmt.Errorf("failed to index messages: %w", err)
+			}
+			messages = messages[:0]
+		}
+	}
+	if len(messages) > 0 {
+		if err := idx.IndexMessages(ctx, messages); err != nil {
+			return fmt.Errorf("failed to index messages: %w", err)
+		}
+	}
+	if len(drift.Extra) > 0 {
+		if err := idx.DeleteMessages(ctx, drift.Extra); err != nil {
+			return fmt.Errorf("failed to delete messages from index: %w", err)
+		}
+	}
+	return nil
+}
+
+// newUserCacheOf メッセージの投稿者のみを含むユーザーキャッシュを作成します
+func newUserCacheOf(ctx context.Context, repo repository.UserRepository, messages []*model.Message) (userCache, error) {
+	cache := userCache{}
+	for _, m := range messages {
+		if _, ok := cache[m.UserID]; ok {
+			continue
+		}
+		user, err := repo.GetUser(ctx, m.UserID, false)
+		if err != nil {
+			return nil, err
+		}
+		cache[m.UserID] = user.IsBot()
+	}
+	return cache, nil
+}
@@ -0,0 +1,152 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+)
+
+type fakeIndexer struct {
+	docs map[uuid.UUID]*model.Message
+}
+
+func (f *fakeIndexer) IndexMessages(_ context.Context, messages []*model.Message) error {
+	for _, m := range messages {
+		f.docs[m.ID] = m
+	}
+	return nil
+}
+
+func (f *fakeIndexer) DeleteMessages(_ context.Context, ids []uuid.UUID) error {
+	for _, id := range ids {
+		delete(f.docs, id)
+	}
+	return nil
+}
+
+func (f *fakeIndexer) CountMessagesByChannel(_ context.Context) (map[uuid.UUID]int64, error) {
+	counts := map[uuid.UUID]int64{}
+	for _, m := range f.docs {
+		counts[m.ChannelID]++
+	}
+	return counts, nil
+}
+
+func (f *fakeIndexer) GetMessageIDsByChannel(_ context.Context, channelID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, m := range f.docs {
+		if m.ChannelID == channelID {
+			ids = append(ids, m.ID)
+		}
+	}
+	return ids, nil
+}
+
+func TestReindex(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := mock_repository.NewMockMessageRepository(ctrl)
+	ctx := context.Background()
+
+	ch := uuid.Must(uuid.NewV7())
+	m1 := &model.Message{ID: uuid.Must(uuid.NewV7()), ChannelID: ch}
+	m2 := &model.Message{ID: uuid.Must(uuid.NewV7()), ChannelID: ch}
+	m3 := &model.Message{ID: uuid.Must(uuid.NewV7()), ChannelID: ch}
+
+	repo.EXPECT().GetMessageCountsByChannel(ctx).Return(map[uuid.UUID]int64{ch: 3}, nil)
+	repo.EXPECT().GetMessagesAfterID(ctx, m1.ID, 1).Return([]*model.Message{m2}, true, nil)
+	repo.EXPECT().GetMessagesAfterID(ctx, m2.ID, 1).Return([]*model.Message{m3}, false, nil)
+
+	idx := &fakeIndexer{docs: map[uuid.UUID]*model.Message{}}
+	var progress []int64
+	err := Reindex(ctx, idx, repo, ReindexOptions{
+		After:     m1.ID,
+		BatchSize: 1,
+		OnProgress: func(last uuid.UUID, indexed int64, total int64) error {
+			assert.EqualValues(t, 3, total)
+			progress = append(progress, indexed)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, progress)
+	assert.Len(t, idx.docs, 2)
+	assert.Contains(t, idx.docs, m2.ID)
+	assert.Contains(t, idx.docs, m3.ID)
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	ch1 := uuid.Must(uuid.NewV7())
+	ch2 := uuid.Must(uuid.NewV7())
+	ch3 := uuid.Must(uuid.NewV7())
+	ok := &model.Message{ID: uuid.Must(uuid.NewV7()), ChannelID: ch1}
+	missing := &model.Message{ID: uuid.Must(uuid.NewV7()), ChannelID: ch1}
+	extra := &model.Message{ID: uuid.Must(uuid.NewV7()), ChannelID: ch2}
+	deleted := uuid.Must(uuid.NewV7())
+	// メッセージ数は一致しているが、IDが異なる
+	swappedMissing := &model.Message{ID: uuid.Must(uuid.NewV7()), ChannelID: ch3}
+	swappedExtra := &model.Message{ID: uuid.Must(uuid.NewV7()), ChannelID: ch3}
+	consistent := &model.Message{ID: uuid.Must(uuid.NewV7()), ChannelID: uuid.Must(uuid.NewV7())}
+
+	setup := func(t *testing.T) (*mock_repository.MockMessageRepository, *fakeIndexer) {
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockMessageRepository(ctrl)
+		repo.EXPECT().GetMessageCountsByChannel(gomock.Any()).Return(map[uuid.UUID]int64{ch1: 3, ch3: 1, consistent.ChannelID: 1}, nil)
+		repo.EXPECT().GetMessageIDsByChannel(gomock.Any(), ch1).Return([]uuid.UUID{ok.ID, missing.ID, deleted}, nil)
+		repo.EXPECT().GetMessageIDsByChannel(gomock.Any(), ch2).Return([]uuid.UUID{}, nil)
+		repo.EXPECT().GetMessageIDsByChannel(gomock.Any(), ch3).Return([]uuid.UUID{swappedMissing.ID}, nil)
+		repo.EXPECT().GetMessageIDsByChannel(gomock.Any(), consistent.ChannelID).Return([]uuid.UUID{consistent.ID}, nil)
+		idx := &fakeIndexer{docs: map[uuid.UUID]*model.Message{ok.ID: ok, extra.ID: extra, swappedExtra.ID: swappedExtra, consistent.ID: consistent}}
+		return repo, idx
+	}
+
+	t.Run("verify only", func(t *testing.T) {
+		t.Parallel()
+		repo, idx := setup(t)
+
+		drifts, err := Verify(context.Background(), idx, repo, zap.NewNop(), VerifyOptions{})
+		require.NoError(t, err)
+		require.Len(t, drifts, 3)
+		slices.SortFunc(drifts, func(a, b *ChannelDrift) int { return int(b.DBCount - a.DBCount) })
+		assert.Equal(t, ch1, drifts[0].ChannelID)
+		assert.ElementsMatch(t, []uuid.UUID{missing.ID, deleted}, drifts[0].Missing)
+		assert.Empty(t, drifts[0].Extra)
+		assert.Equal(t, ch3, drifts[1].ChannelID)
+		assert.EqualValues(t, 1, drifts[1].DBCount)
+		assert.EqualValues(t, 1, drifts[1].IndexCount)
+		assert.Equal(t, []uuid.UUID{swappedMissing.ID}, drifts[1].Missing)
+		assert.Equal(t, []uuid.UUID{swappedExtra.ID}, drifts[1].Extra)
+		assert.Equal(t, ch2, drifts[2].ChannelID)
+		assert.Equal(t, []uuid.UUID{extra.ID}, drifts[2].Extra)
+		assert.Len(t, idx.docs, 4)
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		t.Parallel()
+		repo, idx := setup(t)
+		repo.EXPECT().GetMessageByID(gomock.Any(), missing.ID).Return(missing, nil)
+		repo.EXPECT().GetMessageByID(gomock.Any(), deleted).Return(nil, repository.ErrNotFound)
+		repo.EXPECT().GetMessageByID(gomock.Any(), swappedMissing.ID).Return(swappedMissing, nil)
+
+		_, err := Verify(context.Background(), idx, repo, zap.NewNop(), VerifyOptions{Repair: true})
+		require.NoError(t, err)
+		assert.Len(t, idx.docs, 4)
+		assert.Contains(t, idx.docs, ok.ID)
+		assert.Contains(t, idx.docs, missing.ID)
+		assert.Contains(t, idx.docs, swappedMissing.ID)
+		assert.Contains(t, idx.docs, consistent.ID)
+	})
+}
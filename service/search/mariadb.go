@@ -0,0 +1,332 @@
+package search
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/message"
+	"github.com/traPtitech/traQ/utils"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// MariaDBEngineConfig MariaDB全文検索エンジン設定
+type MariaDBEngineConfig struct {
+	// Enabled MariaDBの全文検索を使用するかどうか
+	Enabled bool
+}
+
+// mariadbEngine search.Engine 実装
+type mariadbEngine struct {
+	db   *gorm.DB
+	mm   message.Manager
+	cm   channel.Manager
+	repo repository.Repository
+	l    *zap.Logger
+	done chan<- struct{}
+}
+
+// NewMariaDBEngine MariaDBの全文検索を用いた検索エンジンを生成します
+func NewMariaDBEngine(db *gorm.DB, mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger) (Engine, error) {
+	engine := newMariaDBEngine(db, mm, cm, repo, logger)
+
+	done := make(chan struct{})
+	engine.done = done
+	go engine.syncLoop(done)
+
+	return engine, nil
+}
+
+// NewMariaDBIndexer MariaDBの検索用テーブルを直接操作する Indexer を生成します
+//
+// 定期的な同期は行いません。
+func NewMariaDBIndexer(db *gorm.DB, cm channel.Manager, repo repository.Repository, logger *zap.Logger) (Indexer, error) {
+	return newMariaDBEngine(db, nil, cm, repo, logger), nil
+}
+
+func newMariaDBEngine(db *gorm.DB, mm message.Manager, cm channel.Manager, repo repository.Repository, logger *zap.Logger) *mariadbEngine {
+	return &mariadbEngine{
+		db:   db,
+		mm:   mm,
+		cm:   cm,
+		repo: repo,
+		l:    logger.Named("search"),
+	}
+}
+
+func (e *mariadbEngine) Do(q *Query) (Result, error) {
+	e.l.Debug("do search", zap.Reflect("q", q))
+
+	tx := e.db.Model(&model.MessageSearchDoc{})
+
+	if q.Word.Valid {
+		against, exclude := buildNgramBooleanQuery(q.Word.V)
+		if len(against) == 0 && len(exclude) == 0 {
+			// 検索可能な文字を含まない
+			return &mariadbResult{messages: []message.Message{}, highlights: map[uuid.UUID][]string{}}, nil
+		}
+		if len(against) > 0 {
+			tx = tx.Where("MATCH(ngram) AGAINST(? IN BOOLEAN MODE)", against)
+		}
+		if len(exclude) > 0 {
+			tx = tx.Where("NOT MATCH(ngram) AGAINST(? IN BOOLEAN MODE)", exclude)
+		}
+	}
+
+	if q.After.Valid {
+		tx = tx.Where("created_at > ?", q.After.V)
+	}
+	if q.Before.Valid {
+		tx = tx.Where("created_at < ?", q.Before.V)
+	}
+
+	// チャンネル指定があるときはそのチャンネルを検索
+	// そうでないときはPublicチャンネルを検索
+	if q.In.Valid {
+		tx = tx.Where("channel_id = ?", q.In.V)
+	} else {
+		tx = tx.Where("is_public = ?", true)
+	}
+
+	if len(q.To) > 0 {
+		or := e.db
+		for i, toID := range q.To {
+			if i == 0 {
+				or = or.Where("`to` LIKE ?", "%"+toID.String()+"%")
+			} else {
+				or = or.Or("`to` LIKE ?", "%"+toID.String()+"%")
+			}
+		}
+		tx = tx.Where(or)
+	}
+
+	if len(q.From) > 0 {
+		tx = tx.Where("user_id IN ?", q.From)
+	}
+
+	if q.Citation.Valid {
+		tx = tx.Where("citation LIKE ?", "%"+q.Citation.V.String()+"%")
+	}
+
+	if q.Bot.Valid {
+		tx = tx.Where("bot = ?", q.Bot.V)
+	}
+	if q.HasURL.Valid {
+		tx = tx.Where("has_url = ?", q.HasURL.V)
+	}
+	if q.HasAttachments.Valid {
+		tx = tx.Where("has_attachments = ?", q.HasAttachments.V)
+	}
+	if q.HasImage.Valid {
+		tx = tx.Where("has_image = ?", q.HasImage.V)
+	}
+	if q.HasVideo.Valid {
+		tx = tx.Where("has_video = ?", q.HasVideo.V)
+	}
+	if q.HasAudio.Valid {
+		tx = tx.Where("has_audio = ?", q.HasAudio.V)
+	}
+
+	// 件数の取得と結果の取得で条件を共有する
+	tx = tx.Session(&gorm.Session{})
+
+	var totalHits int64
+	if err := tx.Count(&totalHits).Error; err != nil {
+		return nil, err
+	}
+
+	facets, err := e.facets(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := 20, 0
+	if q.Limit.Valid {
+		limit = q.Limit.V
+	}
+	if q.Offset.Valid {
+		offset = q.Offset.V
+	}
+
+	// 総ヒット件数にはカーソルを反映しない
+	if c := q.getCursor(); c.Valid {
+		column := mariadbSortColumn(c.V.sortKeyName())
+		op := ">"
+		if c.V.isDescending() {
+			op = "<"
+		}
+		tx = tx.Where(
+			e.db.Where(column+" "+op+" ?", c.V.Time).
+				Or(column+" = ? AND message_id "+op+" ?", c.V.Time, c.V.MessageID),
+		)
+	}
+
+	sortKey := q.GetSortKey()
+	var docs []*model.MessageSearchDoc
+	if err := tx.
+		Select("message_id", "created_at", "updated_at").
+		Order(mariadbSortOrder(sortKey)).
+		Limit(limit).
+		Offset(offset).
+		Find(&docs).
+		Error; err != nil {
+		return nil, err
+	}
+
+	r, err := e.newResult(totalHits, docs, sortKey, limit)
+	if err != nil {
+		return nil, err
+	}
+	r.facets = facets
+	if q.Word.Valid {
+		words := highlightWords(q.Word.V)
+		for _, m := range r.messages {
+			if fragments := highlightFragments(m.GetText(), words); len(fragments) > 0 {
+				r.highlights[m.GetID()] = fragments
+			}
+		}
+	}
+	return r, nil
+}
+
+// facets 検索条件に一致するメッセージ全体を集計します
+func (e *mariadbEngine) facets(tx *gorm.DB) (Facets, error) {
+	type bucket struct {
+		Key   string
+		Count int64
+	}
+	toFacetBuckets := func(buckets []bucket) []FacetBucket {
+		return utils.Map(buckets, func(b bucket) FacetBucket {
+			return FacetBucket{Key: b.Key, Count: b.Count}
+		})
+	}
+
+	var channels, users, months []bucket
+	if err := tx.
+		Select("channel_id AS `key`, COUNT(*) AS count").
+		Group("channel_id").
+		Order("count DESC").
+		Limit(facetSize).
+		Scan(&channels).
+		Error; err != nil {
+		return Facets{}, err
+	}
+	if err := tx.
+		Select("user_id AS `key`, COUNT(*) AS count").
+		Group("user_id").
+		Order("count DESC").
+		Limit(facetSize).
+		Scan(&users).
+		Error; err != nil {
+		return Facets{}, err
+	}
+	if err := tx.
+		Select("DATE_FORMAT(created_at, '%Y-%m') AS `key`, COUNT(*) AS count").
+		Group("`key`").
+		Order("`key`").
+		Scan(&months).
+		Error; err != nil {
+		return Facets{}, err
+	}
+
+	return Facets{
+		Channels: toFacetBuckets(channels),
+		Users:    toFacetBuckets(users),
+		Months:   toFacetBuckets(months),
+	}, nil
+}
+
+func (e *mariadbEngine) newResult(totalHits int64, docs []*model.MessageSearchDoc, sortKey string, limit int) (*mariadbResult, error) {
+	r := &mariadbResult{
+		totalHits:  totalHits,
+		messages:   make([]message.Message, 0, len(docs)),
+		highlights: make(map[uuid.UUID][]string, len(docs)),
+	}
+
+	// 取得件数が上限に達している場合は続きがある可能性がある
+	if len(docs) > 0 && len(docs) == limit {
+		last := docs[len(docs)-1]
+		r.nextCursor = optional.From(newNextCursor(sortKey, last.CreatedAt, last.UpdatedAt, last.MessageID))
+	}
+
+	messageIDs := utils.Map(docs, func(doc *model.MessageSearchDoc) uuid.UUID { return doc.MessageID })
+
+	messages, err := e.mm.GetIn(context.Background(), messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	messagesMap := lo.SliceToMap(messages, func(m message.Message) (uuid.UUID, message.Message) {
+		return m.GetID(), m
+	})
+	// sort result
+	for _, id := range messageIDs {
+		msg, ok := messagesMap[id]
+		if !ok {
+			continue
+		}
+		r.messages = append(r.messages, msg)
+	}
+
+	return r, nil
+}
+
+func (e *mariadbEngine) Available() bool {
+	return true
+}
+
+func (e *mariadbEngine) Close() error {
+	e.done <- struct{}{}
+	return nil
+}
+
+// mariadbSortColumn ソートキーの名前をカラム名に変換します
+func mariadbSortColumn(key string) string {
+	if key == updatedAtSortKey {
+		return "updated_at"
+	}
+	return "created_at"
+}
+
+// mariadbSortOrder GetSortKeyの値をORDER BY句に変換します
+func mariadbSortOrder(sortKey string) string {
+	key, order, _ := strings.Cut(sortKey, ":")
+	column := mariadbSortColumn(key)
+	return column + " " + order + ", message_id " + order
+}
+
+// mariadbResult search.Result 実装
+type mariadbResult struct {
+	totalHits  int64
+	messages   []message.Message
+	nextCursor optional.Of[string]
+	highlights map[uuid.UUID][]string
+	facets     Facets
+}
+
+func (r *mariadbResult) TotalHits() int64 {
+	return r.totalHits
+}
+
+func (r *mariadbResult) Hits() []message.Message {
+	return r.messages
+}
+
+func (r *mariadbResult) NextCursor() optional.Of[string] {
+	return r.nextCursor
+}
+
+func (r *mariadbResult) Highlights() map[uuid.UUID][]string {
+	return r.highlights
+}
+
+func (r *mariadbResult) Facets() Facets {
+	return r.facets
+}
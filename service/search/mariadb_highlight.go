@@ -0,0 +1,94 @@
+package search
+
+import (
+	"html"
+	"slices"
+	"strings"
+)
+
+const (
+	// highlightFragmentSize ハイライトの断片の最大文字数
+	highlightFragmentSize = 100
+	// highlightMaxFragments ハイライトの断片の最大数
+	highlightMaxFragments = 3
+)
+
+// highlightWords 検索ワードからハイライトする単語を取り出します
+func highlightWords(word string) []string {
+	include, _ := splitSearchTerms(word)
+	var words []string
+	for _, term := range include {
+		words = append(words, splitNgramWords(term)...)
+	}
+	return words
+}
+
+// highlightFragments テキスト中の単語に一致した部分を<em></em>で囲んだ断片を返します
+//
+// 一致判定は normalizeNgramText で正規化した上で行います。
+// 断片はHTMLとして表示されるため、テキストはHTMLエスケープします。
+func highlightFragments(text string, words []string) []string {
+	if len(words) == 0 {
+		return nil
+	}
+
+	runes := []rune(text)
+	// 正規化後の文字列と、その各文字に対応する元の文字の位置
+	var (
+		normalized []rune
+		origIndex  []int
+	)
+	for i, r := range runes {
+		for _, nr := range normalizeNgramText(string(r)) {
+			normalized = append(normalized, nr)
+			origIndex = append(origIndex, i)
+		}
+	}
+
+	marked := make([]bool, len(runes))
+	for _, w := range words {
+		wr := []rune(w)
+		for i := 0; i+len(wr) <= len(normalized); i++ {
+			if slices.Equal(normalized[i:i+len(wr)], wr) {
+				for j := i; j < i+len(wr); j++ {
+					marked[origIndex[j]] = true
+				}
+			}
+		}
+	}
+
+	type span struct{ start, end int }
+	var spans []span
+	for i := 0; i < len(runes); {
+		if !marked[i] {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runes) && marked[j] {
+			j++
+		}
+		spans = append(spans, span{i, j})
+		i = j
+	}
+
+	var fragments []string
+	for i := 0; i < len(spans) && len(fragments) < highlightMaxFragments; {
+		// 一致部分が中央付近に来るように断片の範囲を決める
+		start := max(0, spans[i].start-max(0, highlightFragmentSize-(spans[i].end-spans[i].start))/2)
+		end := min(len(runes), max(start+highlightFragmentSize, spans[i].end))
+
+		var b strings.Builder
+		pos := start
+		for ; i < len(spans) && spans[i].end <= end; i++ {
+			b.WriteString(html.EscapeString(string(runes[pos:spans[i].start])))
+			b.WriteString(highlightPreTag)
+			b.WriteString(html.EscapeString(string(runes[spans[i].start:spans[i].end])))
+			b.WriteString(highlightPostTag)
+			pos = spans[i].end
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:end])))
+		fragments = append(fragments, b.String())
+	}
+	return fragments
+}
@@ -0,0 +1,56 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightFragments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no words", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, highlightFragments("hello", nil))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, highlightFragments("hello", highlightWords("world")))
+	})
+
+	t.Run("case insensitive", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []string{"Hello <em>traQ</em> world"}, highlightFragments("Hello traQ world", highlightWords("TRAQ")))
+	})
+
+	t.Run("multiple words", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []string{"<em>部内</em>の<em>traQ</em>"}, highlightFragments("部内のtraQ", highlightWords("traq 部内 -の")))
+	})
+
+	t.Run("fullwidth", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, []string{"<em>ＡＢ</em>c"}, highlightFragments("ＡＢc", highlightWords("ab")))
+	})
+
+	t.Run("html escape", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t,
+			[]string{"&lt;script&gt;alert(1)&lt;/script&gt; <em>traQ</em> &amp; &lt;<em>b</em>&gt;"},
+			highlightFragments("<script>alert(1)</script> traQ & <b>", highlightWords("traq <b>")),
+		)
+	})
+
+	t.Run("fragments", func(t *testing.T) {
+		t.Parallel()
+		text := "foo" + strings.Repeat("x", 300) + "foo" + strings.Repeat("x", 300) + "foo" + strings.Repeat("x", 300) + "foo"
+		fragments := highlightFragments(text, highlightWords("foo"))
+		assert.Len(t, fragments, highlightMaxFragments)
+		for _, f := range fragments {
+			assert.Contains(t, f, "<em>foo</em>")
+			assert.LessOrEqual(t, len([]rune(f)), highlightFragmentSize+len(highlightPreTag)+len(highlightPostTag))
+		}
+	})
+}
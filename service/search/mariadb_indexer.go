@@ -0,0 +1,75 @@
+package search
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// IndexMessages implements Indexer interface.
+func (e *mariadbEngine) IndexMessages(ctx context.Context, messages []*model.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	userCache, err := newUserCacheOf(ctx, e.repo, messages)
+	if err != nil {
+		return err
+	}
+
+	docs := make([]*model.MessageSearchDoc, 0, len(messages))
+	for _, v := range messages {
+		doc, err := e.convertMessage(v, message.Parse(v.Text), userCache)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, doc)
+	}
+	return e.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&docs).Error
+}
+
+// DeleteMessages implements Indexer interface.
+func (e *mariadbEngine) DeleteMessages(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return e.db.WithContext(ctx).Where("message_id IN ?", ids).Delete(&model.MessageSearchDoc{}).Error
+}
+
+// CountMessagesByChannel implements Indexer interface.
+func (e *mariadbEngine) CountMessagesByChannel(ctx context.Context) (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		ChannelID uuid.UUID
+		Count     int64
+	}
+	err := e.db.WithContext(ctx).
+		Model(&model.MessageSearchDoc{}).
+		Select("channel_id, COUNT(*) AS count").
+		Group("channel_id").
+		Find(&rows).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.ChannelID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetMessageIDsByChannel implements Indexer interface.
+func (e *mariadbEngine) GetMessageIDsByChannel(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0)
+	err := e.db.WithContext(ctx).
+		Model(&model.MessageSearchDoc{}).
+		Where("channel_id = ?", channelID).
+		Order("message_id").
+		Pluck("message_id", &ids).
+		Error
+	return ids, err
+}
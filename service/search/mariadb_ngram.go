@@ -0,0 +1,136 @@
+package search
+
+import (
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MariaDBのInnoDB FULLTEXTインデックスにはngramパーサーが無く、
+// 空白区切りでしか単語を認識しないため、アプリケーション側でbigramに分割してから格納する。
+// 各トークンはinnodb_ft_min_token_size(既定値3)とストップワードを回避するため、
+// 接頭辞"n"にUTF-8のバイト列を16進数で表したものを続けた文字列とする。
+const ngramTokenPrefix = "n"
+
+// normalizeNgramText 検索に用いるためにテキストを正規化します
+func normalizeNgramText(text string) string {
+	return strings.ToLower(norm.NFKC.String(text))
+}
+
+// encodeNgramToken トークンをFULLTEXTインデックスに格納可能な文字列に変換します
+func encodeNgramToken(runes []rune) string {
+	return ngramTokenPrefix + hex.EncodeToString([]byte(string(runes)))
+}
+
+// splitNgramWords テキストを空白・句読点で単語に分割します
+func splitNgramWords(text string) []string {
+	return strings.FieldsFunc(normalizeNgramText(text), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r)
+	})
+}
+
+// wordNgramTokens 単語をbigramトークンに分割します
+//
+// 1文字での前方一致検索ができるよう、末尾の1文字もトークンに含めます
+func wordNgramTokens(word string) []string {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return nil
+	}
+	tokens := make([]string, 0, len(runes))
+	for i := 0; i+1 < len(runes); i++ {
+		tokens = append(tokens, encodeNgramToken(runes[i:i+2]))
+	}
+	return append(tokens, encodeNgramToken(runes[len(runes)-1:]))
+}
+
+// ngramDocument メッセージ本文を検索用テーブルに格納する文字列に変換します
+func ngramDocument(text string) string {
+	var tokens []string
+	for _, word := range splitNgramWords(text) {
+		tokens = append(tokens, wordNgramTokens(word)...)
+	}
+	return strings.Join(tokens, " ")
+}
+
+// ngramTermExpr 検索語句をBOOLEAN MODEの式に変換します
+func ngramTermExpr(term string) []string {
+	var exprs []string
+	for _, word := range splitNgramWords(term) {
+		runes := []rune(word)
+		if len(runes) == 1 {
+			// 1文字の場合は、その文字から始まるトークンへの前方一致
+			exprs = append(exprs, encodeNgramToken(runes)+"*")
+			continue
+		}
+		tokens := make([]string, 0, len(runes)-1)
+		for i := 0; i+1 < len(runes); i++ {
+			tokens = append(tokens, encodeNgramToken(runes[i:i+2]))
+		}
+		exprs = append(exprs, `"`+strings.Join(tokens, " ")+`"`)
+	}
+	return exprs
+}
+
+// splitSearchTerms 検索ワードを語句に分割します
+//
+// ダブルクォートで囲まれた部分は1つの語句とし、先頭に"-"が付いた語句は除外する語句とします
+func splitSearchTerms(word string) (include []string, exclude []string) {
+	var (
+		current  strings.Builder
+		quoted   bool
+		negative bool
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			if negative {
+				exclude = append(exclude, current.String())
+			} else {
+				include = append(include, current.String())
+			}
+		}
+		current.Reset()
+		negative = false
+	}
+
+	for _, r := range word {
+		switch {
+		case r == '"':
+			if quoted {
+				flush()
+			}
+			quoted = !quoted
+		case !quoted && unicode.IsSpace(r):
+			flush()
+		case !quoted && r == '-' && current.Len() == 0:
+			negative = true
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+	return include, exclude
+}
+
+// buildNgramBooleanQuery 検索ワードをMATCH AGAINSTのBOOLEAN MODEの式に変換します
+//
+// againstは全てを含む必要がある式、excludeはいずれかを含むものを除外する式です
+func buildNgramBooleanQuery(word string) (against string, exclude string) {
+	include, ex := splitSearchTerms(word)
+
+	var musts []string
+	for _, term := range include {
+		for _, expr := range ngramTermExpr(term) {
+			musts = append(musts, "+"+expr)
+		}
+	}
+
+	var shoulds []string
+	for _, term := range ex {
+		shoulds = append(shoulds, ngramTermExpr(term)...)
+	}
+
+	return strings.Join(musts, " "), strings.Join(shoulds, " ")
+}
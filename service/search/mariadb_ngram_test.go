@@ -0,0 +1,83 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSearchTerms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		word    string
+		include []string
+		exclude []string
+	}{
+		{"single", "traQ", []string{"traQ"}, nil},
+		{"multiple", "traQ  部内 ", []string{"traQ", "部内"}, nil},
+		{"quoted", `"hello world" foo`, []string{"hello world", "foo"}, nil},
+		{"exclude", "foo -bar", []string{"foo"}, []string{"bar"}},
+		{"exclude quoted", `-"bar baz"`, nil, []string{"bar baz"}},
+		{"hyphen inside", "traQ-bot", []string{"traQ-bot"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			include, exclude := splitSearchTerms(tt.word)
+			assert.Equal(t, tt.include, include)
+			assert.Equal(t, tt.exclude, exclude)
+		})
+	}
+}
+
+func TestNgramDocument(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", ngramDocument(""))
+	// "ab" -> "ab", "b"
+	assert.Equal(t, "n6162 n62", ngramDocument("AB"))
+	// "あい" -> "あい", "い"
+	assert.Equal(t, "ne38182e38184 ne38184", ngramDocument("あい"))
+	// 全角英数字は正規化される
+	assert.Equal(t, ngramDocument("ab c"), ngramDocument("ＡＢ、c"))
+}
+
+func TestBuildNgramBooleanQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("phrase", func(t *testing.T) {
+		t.Parallel()
+		against, exclude := buildNgramBooleanQuery("abc")
+		assert.Equal(t, `+"n6162 n6263"`, against)
+		assert.Equal(t, "", exclude)
+	})
+
+	t.Run("single rune", func(t *testing.T) {
+		t.Parallel()
+		against, exclude := buildNgramBooleanQuery("a")
+		assert.Equal(t, "+n61*", against)
+		assert.Equal(t, "", exclude)
+	})
+
+	t.Run("include and exclude", func(t *testing.T) {
+		t.Parallel()
+		against, exclude := buildNgramBooleanQuery("ab -cd")
+		assert.Equal(t, `+"n6162"`, against)
+		assert.Equal(t, `"n6364"`, exclude)
+	})
+
+	t.Run("symbols only", func(t *testing.T) {
+		t.Parallel()
+		against, exclude := buildNgramBooleanQuery("!!")
+		assert.Equal(t, "", against)
+		assert.Equal(t, "", exclude)
+	})
+
+	t.Run("document matches", func(t *testing.T) {
+		t.Parallel()
+		// 検索語句のトークンは文書のトークン列に連続して現れる
+		assert.Contains(t, ngramDocument("xabcx"), "n6162 n6263")
+	})
+}
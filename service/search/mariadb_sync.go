@@ -0,0 +1,173 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// convertMessage メッセージを検索用テーブルに入れる型に変換する
+func (e *mariadbEngine) convertMessage(m *model.Message, parseResult *message.ParseResult, userCache userCache) (*model.MessageSearchDoc, error) {
+	var isBot, ok bool
+	if isBot, ok = userCache[m.UserID]; !ok {
+		// 新規ユーザー or キャッシュが存在しない
+		user, err := e.repo.GetUser(context.Background(), m.UserID, false)
+		if err != nil {
+			return nil, err
+		}
+		isBot = user.IsBot()
+	}
+
+	attr := getAttributes(e.repo, e.l, m, parseResult)
+
+	return &model.MessageSearchDoc{
+		MessageID:      m.ID,
+		UserID:         m.UserID,
+		ChannelID:      m.ChannelID,
+		IsPublic:       e.cm.IsPublicChannel(context.Background(), m.ChannelID),
+		Bot:            isBot,
+		Ngram:          ngramDocument(m.Text),
+		To:             joinUUIDs(attr.To),
+		Citation:       joinUUIDs(attr.Citation),
+		HasURL:         attr.HasURL,
+		HasAttachments: attr.HasAttachments,
+		HasImage:       attr.HasImage,
+		HasVideo:       attr.HasVideo,
+		HasAudio:       attr.HasAudio,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}, nil
+}
+
+func joinUUIDs(ids []uuid.UUID) string {
+	return strings.Join(utils.Map(ids, uuid.UUID.String), " ")
+}
+
+func (e *mariadbEngine) syncLoop(done <-chan struct{}) {
+	t := time.NewTicker(syncInterval)
+	defer t.Stop()
+loop:
+	for {
+		err := e.sync()
+		if err != nil {
+			e.l.Error(err.Error(), zap.Error(err))
+		}
+
+		select {
+		case <-t.C:
+		case <-done:
+			break loop
+		}
+	}
+}
+
+func (e *mariadbEngine) newUserCache() (userCache, error) {
+	users, err := e.repo.GetUsers(context.Background(), repository.UsersQuery{})
+	if err != nil {
+		return nil, err
+	}
+	e.l.Debug("making user cache of size", zap.Int("size", len(users)))
+
+	cache := make(map[uuid.UUID]bool, len(users))
+	for _, u := range users {
+		cache[u.GetID()] = u.IsBot()
+	}
+	return cache, nil
+}
+
+// sync メッセージを repository.MessageRepository から読み取り、検索用テーブルへ格納します
+func (e *mariadbEngine) sync() error {
+	e.l.Debug("syncing messages with mariadb search table")
+
+	lastSynced, err := e.lastInsertedUpdated()
+	if err != nil {
+		return err
+	}
+
+	var userCache userCache
+	lastInsert := lastSynced
+	for {
+		messages, more, err := e.repo.GetUpdatedMessagesAfter(context.Background(), lastInsert, syncMessageBulk)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		lastInsert = messages[len(messages)-1].UpdatedAt
+
+		if userCache == nil && more {
+			// 新規メッセージが2ページ以上の時のみデータが入ったキャッシュを作成
+			userCache, err = e.newUserCache()
+			if err != nil {
+				return err
+			}
+		}
+
+		docs := make([]*model.MessageSearchDoc, 0, len(messages))
+		for _, v := range messages {
+			doc, err := e.convertMessage(v, message.Parse(v.Text), userCache)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+		}
+		if err := e.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&docs).Error; err != nil {
+			return err
+		}
+		e.l.Info(fmt.Sprintf("indexed %v message(s) to mariadb search table, last insert %v", len(docs), lastInsert))
+
+		if !more {
+			break
+		}
+	}
+
+	lastDelete := lastSynced
+	for {
+		messages, more, err := e.repo.GetDeletedMessagesAfter(context.Background(), lastDelete, syncMessageBulk)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			break
+		}
+		if !messages[len(messages)-1].DeletedAt.Valid {
+			return errors.New("expected DeletedAt to exist, but found nil")
+		}
+		lastDelete = messages[len(messages)-1].DeletedAt.Time
+
+		ids := utils.Map(messages, func(m *model.Message) uuid.UUID { return m.ID })
+		result := e.db.Where("message_id IN ?", ids).Delete(&model.MessageSearchDoc{})
+		if result.Error != nil {
+			return result.Error
+		}
+		e.l.Info(fmt.Sprintf("deleted %v message(s) from mariadb search table, last delete %v", result.RowsAffected, lastDelete))
+
+		if !more {
+			break
+		}
+	}
+
+	return nil
+}
+
+// lastInsertedUpdated 検索用テーブルに存在している、updatedAtが一番新しいメッセージの値を取得します
+func (e *mariadbEngine) lastInsertedUpdated() (time.Time, error) {
+	var doc model.MessageSearchDoc
+	err := e.db.Select("updated_at").Order("updated_at DESC").Limit(1).Find(&doc).Error
+	if err != nil {
+		return time.Time{}, err
+	}
+	return doc.UpdatedAt, nil
+}
@@ -0,0 +1,146 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gofrs/uuid"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/utils/message"
+)
+
+// MatchTarget 検索クエリとの照合対象のメッセージ
+type MatchTarget struct {
+	// Message メッセージ
+	Message *model.Message
+	// IsPublic 公開チャンネルのメッセージかどうか
+	IsPublic bool
+	// Bot 投稿者がBotかどうか
+	Bot bool
+
+	text string
+	attr *attributes
+}
+
+// NewMatchTarget メッセージから照合対象を作成します
+func NewMatchTarget(repo repository.Repository, l *zap.Logger, m *model.Message, parseResult *message.ParseResult, isPublic bool) (*MatchTarget, error) {
+	user, err := repo.GetUser(context.Background(), m.UserID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &MatchTarget{
+		Message:  m,
+		IsPublic: isPublic,
+		Bot:      user.IsBot(),
+		text:     normalizeNgramText(m.Text),
+		attr:     getAttributes(repo, l, m, parseResult),
+	}, nil
+}
+
+// Match メッセージがクエリの条件を満たすかどうかを返します
+//
+// 検索ワードは空白区切りの語句を全て含むかで判定します。
+// ダブルクォートで囲まれた部分は1つの語句とし、先頭に"-"が付いた語句は含まないことを条件とします。
+// 語句"AND"は語句を並べた場合と同じ意味なので無視されます。Limit, Offset, Sort, Cursorは無視されます。
+func (q *Query) Match(t *MatchTarget) bool {
+	m := t.Message
+
+	if q.Word.Valid {
+		include, exclude := splitSearchTerms(q.Word.V)
+		for _, term := range include {
+			if term == queryAndOperator {
+				continue
+			}
+			if !containsTerm(t.text, normalizeNgramText(term)) {
+				return false
+			}
+		}
+		for _, term := range exclude {
+			if containsTerm(t.text, normalizeNgramText(term)) {
+				return false
+			}
+		}
+	}
+
+	if q.After.Valid && !m.CreatedAt.After(q.After.V) {
+		return false
+	}
+	if q.Before.Valid && !m.CreatedAt.Before(q.Before.V) {
+		return false
+	}
+
+	// チャンネル指定が無い場合はPublicチャンネルのみ
+	if q.In.Valid {
+		if m.ChannelID != q.In.V {
+			return false
+		}
+	} else if !t.IsPublic {
+		return false
+	}
+
+	if len(q.To) > 0 && !slices.ContainsFunc(q.To, func(id uuid.UUID) bool { return slices.Contains(t.attr.To, id) }) {
+		return false
+	}
+	if len(q.From) > 0 && !slices.Contains(q.From, m.UserID) {
+		return false
+	}
+	if q.Citation.Valid && !slices.Contains(t.attr.Citation, q.Citation.V) {
+		return false
+	}
+
+	for _, f := range []struct {
+		cond  bool
+		valid bool
+		value bool
+	}{
+		{q.Bot.V, q.Bot.Valid, t.Bot},
+		{q.HasURL.V, q.HasURL.Valid, t.attr.HasURL},
+		{q.HasAttachments.V, q.HasAttachments.Valid, t.attr.HasAttachments},
+		{q.HasImage.V, q.HasImage.Valid, t.attr.HasImage},
+		{q.HasVideo.V, q.HasVideo.Valid, t.attr.HasVideo},
+		{q.HasAudio.V, q.HasAudio.Valid, t.attr.HasAudio},
+	} {
+		if f.valid && f.cond != f.value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// containsTerm textがtermを語句として含むかどうかを返します
+//
+// 英数字で始まる(終わる)語句は、直前(直後)が英数字でない位置でのみ一致とみなします。
+// "error"が"terror"に一致しないようにするためで、分かち書きしない日本語などは部分一致で判定します。
+func containsTerm(text, term string) bool {
+	if len(term) == 0 {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(term)
+	last, _ := utf8.DecodeLastRuneInString(term)
+	for offset := 0; offset <= len(text)-len(term); {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !(isASCIIAlnum(first) && isASCIIAlnum(before)) && !(isASCIIAlnum(last) && isASCIIAlnum(after)) {
+			return true
+		}
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+	return false
+}
+
+func isASCIIAlnum(r rune) bool {
+	return r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,100 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestQuery_Match(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.Must(uuid.NewV7())
+	channelID := uuid.Must(uuid.NewV7())
+	mentioned := uuid.Must(uuid.NewV7())
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := &model.Message{
+		ID:        uuid.Must(uuid.NewV7()),
+		UserID:    userID,
+		ChannelID: channelID,
+		Text:      "Deploy ERROR: ＴＩＭＥＯＵＴ",
+		CreatedAt: createdAt,
+	}
+	target := &MatchTarget{
+		Message:  m,
+		IsPublic: true,
+		Bot:      true,
+		text:     normalizeNgramText(m.Text),
+		attr:     &attributes{To: []uuid.UUID{mentioned}, HasURL: false},
+	}
+
+	cases := []struct {
+		name  string
+		query Query
+		want  bool
+	}{
+		{"empty", Query{}, true},
+		{"word", Query{Word: optional.From("error")}, true},
+		{"words", Query{Word: optional.From("error timeout")}, true},
+		{"word with AND", Query{Word: optional.From("error AND timeout")}, true},
+		{"part of a word", Query{Word: optional.From("rror")}, false},
+		{"prefix of a word", Query{Word: optional.From("deplo")}, false},
+		{"phrase", Query{Word: optional.From(`"deploy error"`)}, true},
+		{"word not found", Query{Word: optional.From("error success")}, false},
+		{"exclude", Query{Word: optional.From("error -timeout")}, false},
+		{"from", Query{From: []uuid.UUID{uuid.Must(uuid.NewV7()), userID}}, true},
+		{"from other", Query{From: []uuid.UUID{uuid.Must(uuid.NewV7())}}, false},
+		{"to", Query{To: []uuid.UUID{mentioned}}, true},
+		{"to other", Query{To: []uuid.UUID{userID}}, false},
+		{"in", Query{In: optional.From(channelID)}, true},
+		{"in other", Query{In: optional.From(uuid.Must(uuid.NewV7()))}, false},
+		{"bot", Query{Bot: optional.From(true)}, true},
+		{"not bot", Query{Bot: optional.From(false)}, false},
+		{"has url", Query{HasURL: optional.From(true)}, false},
+		{"after", Query{After: optional.From(createdAt.Add(-time.Hour))}, true},
+		{"before", Query{Before: optional.From(createdAt)}, false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.query.Match(target))
+		})
+	}
+
+	t.Run("private channel", func(t *testing.T) {
+		t.Parallel()
+		private := *target
+		private.IsPublic = false
+		assert.False(t, (&Query{}).Match(&private))
+		assert.True(t, (&Query{In: optional.From(channelID)}).Match(&private))
+	})
+}
+
+func TestContainsTerm(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		text string
+		term string
+		want bool
+	}{
+		{"deploy error: timeout", "error", true},
+		{"terror", "error", false},
+		{"errors", "error", false},
+		{"terror error", "error", true},
+		{"error!", "error", true},
+		{"デプロイエラー", "エラー", true},
+		{"エラーerror", "error", true},
+		{"deploy error", "deploy error", true},
+		{"a.b", ".", true},
+		{"anything", "", true},
+	}
+	for _, tt := range cases {
+		assert.Equal(t, tt.want, containsTerm(tt.text, tt.term), "%q in %q", tt.term, tt.text)
+	}
+}
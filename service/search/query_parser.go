@@ -0,0 +1,229 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+// QueryParseErrorCode クエリ文字列の解析エラーの種類
+type QueryParseErrorCode string
+
+const (
+	// QueryParseErrorUnclosedQuote 閉じられていない引用符
+	QueryParseErrorUnclosedQuote QueryParseErrorCode = "unclosed_quote"
+	// QueryParseErrorEmptyValue 演算子の値が空
+	QueryParseErrorEmptyValue QueryParseErrorCode = "empty_value"
+	// QueryParseErrorUnknownUser 存在しないユーザー
+	QueryParseErrorUnknownUser QueryParseErrorCode = "unknown_user"
+	// QueryParseErrorUnknownChannel 存在しないチャンネル
+	QueryParseErrorUnknownChannel QueryParseErrorCode = "unknown_channel"
+	// QueryParseErrorDuplicateChannel in: が複数指定された
+	QueryParseErrorDuplicateChannel QueryParseErrorCode = "duplicate_channel"
+	// QueryParseErrorInvalidHas has: の値が不正
+	QueryParseErrorInvalidHas QueryParseErrorCode = "invalid_has"
+	// QueryParseErrorInvalidDate 日付の形式が不正
+	QueryParseErrorInvalidDate QueryParseErrorCode = "invalid_date"
+)
+
+// QueryParseError クエリ文字列の解析エラー
+type QueryParseError struct {
+	// Code エラーの種類
+	Code QueryParseErrorCode
+	// Position エラーが発生したトークンの開始位置 (0始まりの文字(rune)単位)
+	Position int
+	// Token エラーが発生したトークン
+	Token string
+}
+
+func (e *QueryParseError) Error() string {
+	return fmt.Sprintf("%s at position %d: %s", e.Code, e.Position, e.Token)
+}
+
+const (
+	queryOperatorFrom   = "from"
+	queryOperatorTo     = "to"
+	queryOperatorIn     = "in"
+	queryOperatorHas    = "has"
+	queryOperatorBefore = "before"
+	queryOperatorAfter  = "after"
+	// queryAndOperator 語句を並べた場合と同じ意味の演算子
+	queryAndOperator = "AND"
+)
+
+// queryToken クエリ文字列中のトークン
+type queryToken struct {
+	// Text トークンの文字列 (引用符を含む)
+	Text string
+	// Key 演算子 演算子でない場合は空
+	Key string
+	// Value 演算子の値
+	Value string
+	// Position トークンの開始位置 (文字単位)
+	Position int
+}
+
+// tokenizeQuery クエリ文字列を空白で区切ってトークンに分割します
+//
+// 引用符で囲まれた部分は空白を含めて1つのトークンになります
+func tokenizeQuery(s string) ([]queryToken, error) {
+	var (
+		tokens []queryToken
+		runes  = []rune(s)
+	)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		inQuote := false
+		quoteStart := 0
+		for ; i < len(runes) && (inQuote || !unicode.IsSpace(runes[i])); i++ {
+			if runes[i] == '"' {
+				if !inQuote {
+					quoteStart = i
+				}
+				inQuote = !inQuote
+			}
+		}
+		if inQuote {
+			return nil, &QueryParseError{Code: QueryParseErrorUnclosedQuote, Position: quoteStart, Token: string(runes[quoteStart:])}
+		}
+
+		token := queryToken{Text: string(runes[start:i]), Position: start}
+		if key, value, ok := strings.Cut(token.Text, ":"); ok && isQueryOperator(key) {
+			token.Key = key
+			token.Value = strings.Trim(value, `"`)
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens, nil
+}
+
+func isQueryOperator(key string) bool {
+	switch key {
+	case queryOperatorFrom, queryOperatorTo, queryOperatorIn, queryOperatorHas, queryOperatorBefore, queryOperatorAfter:
+		return true
+	default:
+		return false
+	}
+}
+
+// parseQueryDate 2006-01-02 または RFC3339 形式の日時を解析します
+//
+// 日付のみの場合はその日の0時(UTC)になります
+func parseQueryDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// QueryParser インライン検索クエリ文字列のパーサー
+type QueryParser struct {
+	repo repository.UserRepository
+	cm   channel.Manager
+}
+
+// NewQueryParser QueryParser を生成します
+func NewQueryParser(repo repository.UserRepository, cm channel.Manager) *QueryParser {
+	return &QueryParser{repo: repo, cm: cm}
+}
+
+// Parse `from:@alice in:#general has:image before:2026-01-01 "exact phrase"` のようなクエリ文字列を解析し、qに反映します
+//
+// 演算子以外のトークンは検索ワードとして q.Word に追加されます。"AND"は無視されます。
+// 文字列が不正な場合は *QueryParseError を返します。
+func (p *QueryParser) Parse(ctx context.Context, s string, q *Query) error {
+	tokens, err := tokenizeQuery(s)
+	if err != nil {
+		return err
+	}
+
+	var words []string
+	if q.Word.Valid && len(q.Word.V) > 0 {
+		words = append(words, q.Word.V)
+	}
+	for _, token := range tokens {
+		if token.Key == "" {
+			// 語句は全て含む必要があるので、ANDは無視する
+			if token.Text != queryAndOperator {
+				words = append(words, token.Text)
+			}
+			continue
+		}
+		if token.Value == "" {
+			return &QueryParseError{Code: QueryParseErrorEmptyValue, Position: token.Position, Token: token.Text}
+		}
+
+		switch token.Key {
+		case queryOperatorFrom, queryOperatorTo:
+			user, err := p.repo.GetUserByName(ctx, strings.TrimPrefix(token.Value, "@"), false)
+			if err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return &QueryParseError{Code: QueryParseErrorUnknownUser, Position: token.Position, Token: token.Text}
+				}
+				return err
+			}
+			if token.Key == queryOperatorFrom {
+				q.From = append(q.From, user.GetID())
+			} else {
+				q.To = append(q.To, user.GetID())
+			}
+
+		case queryOperatorIn:
+			if q.In.Valid {
+				return &QueryParseError{Code: QueryParseErrorDuplicateChannel, Position: token.Position, Token: token.Text}
+			}
+			ch, err := p.cm.GetChannelFromPath(ctx, strings.TrimPrefix(token.Value, "#"))
+			if err != nil {
+				if errors.Is(err, channel.ErrInvalidChannelPath) || errors.Is(err, channel.ErrChannelNotFound) {
+					return &QueryParseError{Code: QueryParseErrorUnknownChannel, Position: token.Position, Token: token.Text}
+				}
+				return err
+			}
+			q.In = optional.From(ch.ID)
+
+		case queryOperatorHas:
+			switch token.Value {
+			case "url":
+				q.HasURL = optional.From(true)
+			case "attachments", "file":
+				q.HasAttachments = optional.From(true)
+			case "image":
+				q.HasImage = optional.From(true)
+			case "video":
+				q.HasVideo = optional.From(true)
+			case "audio":
+				q.HasAudio = optional.From(true)
+			default:
+				return &QueryParseError{Code: QueryParseErrorInvalidHas, Position: token.Position, Token: token.Text}
+			}
+
+		case queryOperatorBefore, queryOperatorAfter:
+			t, err := parseQueryDate(token.Value)
+			if err != nil {
+				return &QueryParseError{Code: QueryParseErrorInvalidDate, Position: token.Position, Token: token.Text}
+			}
+			if token.Key == queryOperatorBefore {
+				q.Before = optional.From(t)
+			} else {
+				q.After = optional.From(t)
+			}
+		}
+	}
+
+	if len(words) > 0 {
+		q.Word = optional.From(strings.Join(words, " "))
+	}
+	return nil
+}
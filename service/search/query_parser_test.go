@@ -0,0 +1,123 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/channel"
+	"github.com/traPtitech/traQ/service/channel/mock_channel"
+	"github.com/traPtitech/traQ/utils/optional"
+)
+
+func TestTokenizeQuery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("operators and words", func(t *testing.T) {
+		t.Parallel()
+		tokens, err := tokenizeQuery(`from:@alice  "exact phrase" -foo http://example.com`)
+		require.NoError(t, err)
+		assert.Equal(t, []queryToken{
+			{Text: "from:@alice", Key: "from", Value: "@alice", Position: 0},
+			{Text: `"exact phrase"`, Position: 13},
+			{Text: "-foo", Position: 28},
+			{Text: "http://example.com", Position: 33},
+		}, tokens)
+	})
+
+	t.Run("quoted value", func(t *testing.T) {
+		t.Parallel()
+		tokens, err := tokenizeQuery(`in:"#general"`)
+		require.NoError(t, err)
+		assert.Equal(t, []queryToken{{Text: `in:"#general"`, Key: "in", Value: "#general"}}, tokens)
+	})
+
+	t.Run("unclosed quote", func(t *testing.T) {
+		t.Parallel()
+		_, err := tokenizeQuery(`あいう "foo bar`)
+		var pe *QueryParseError
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, QueryParseErrorUnclosedQuote, pe.Code)
+		assert.Equal(t, 4, pe.Position)
+		assert.Equal(t, `"foo bar`, pe.Token)
+	})
+}
+
+func TestQueryParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	alice := &model.User{ID: uuid.Must(uuid.NewV7()), Name: "alice"}
+	general := &model.Channel{ID: uuid.Must(uuid.NewV7()), Name: "general"}
+
+	setup := func(t *testing.T) *QueryParser {
+		ctrl := gomock.NewController(t)
+		repo := mock_repository.NewMockUserRepository(ctrl)
+		repo.EXPECT().GetUserByName(gomock.Any(), "alice", false).Return(alice, nil).AnyTimes()
+		repo.EXPECT().GetUserByName(gomock.Any(), gomock.Any(), false).Return(nil, repository.ErrNotFound).AnyTimes()
+		cm := mock_channel.NewMockManager(ctrl)
+		cm.EXPECT().GetChannelFromPath(gomock.Any(), "general").Return(general, nil).AnyTimes()
+		cm.EXPECT().GetChannelFromPath(gomock.Any(), gomock.Any()).Return(nil, channel.ErrInvalidChannelPath).AnyTimes()
+		return NewQueryParser(repo, cm)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		p := setup(t)
+		q := Query{Word: optional.From("traQ")}
+		err := p.Parse(context.Background(), `from:@alice to:alice in:#general has:image has:url before:2026-01-01 after:2025-06-01T09:00:00+09:00 "exact phrase" -foo`, &q)
+		require.NoError(t, err)
+		assert.Equal(t, optional.From(`traQ "exact phrase" -foo`), q.Word)
+		assert.Equal(t, []uuid.UUID{alice.ID}, q.From)
+		assert.Equal(t, []uuid.UUID{alice.ID}, q.To)
+		assert.Equal(t, optional.From(general.ID), q.In)
+		assert.Equal(t, optional.From(true), q.HasImage)
+		assert.Equal(t, optional.From(true), q.HasURL)
+		assert.False(t, q.HasVideo.Valid)
+		assert.True(t, q.Before.V.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, q.After.V.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("AND", func(t *testing.T) {
+		t.Parallel()
+		p := setup(t)
+		var q Query
+		err := p.Parse(context.Background(), `error AND from:@alice "AND"`, &q)
+		require.NoError(t, err)
+		assert.Equal(t, optional.From(`error "AND"`), q.Word)
+		assert.Equal(t, []uuid.UUID{alice.ID}, q.From)
+	})
+
+	cases := []struct {
+		name     string
+		query    string
+		code     QueryParseErrorCode
+		position int
+	}{
+		{"empty value", "foo from:", QueryParseErrorEmptyValue, 4},
+		{"unknown user", "from:@bob", QueryParseErrorUnknownUser, 0},
+		{"unknown channel", "in:#random", QueryParseErrorUnknownChannel, 0},
+		{"duplicate channel", "in:#general in:#general", QueryParseErrorDuplicateChannel, 12},
+		{"invalid has", "has:pdf", QueryParseErrorInvalidHas, 0},
+		{"invalid date", "before:2026/01/01", QueryParseErrorInvalidDate, 0},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := setup(t)
+			var q Query
+			err := p.Parse(context.Background(), tt.query, &q)
+			var pe *QueryParseError
+			require.ErrorAs(t, err, &pe)
+			assert.Equal(t, tt.code, pe.Code)
+			assert.Equal(t, tt.position, pe.Position)
+		})
+	}
+}
@@ -5,16 +5,21 @@ import (
 	botWS "github.com/traPtitech/traQ/service/bot/ws"
 	"github.com/traPtitech/traQ/service/channel"
 	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/digest"
 	"github.com/traPtitech/traQ/service/exevent"
-	"github.com/traPtitech/traQ/service/fcm"
 	"github.com/traPtitech/traQ/service/file"
 	"github.com/traPtitech/traQ/service/imaging"
+	"github.com/traPtitech/traQ/service/inbox"
+	"github.com/traPtitech/traQ/service/mailer"
 	"github.com/traPtitech/traQ/service/message"
 	"github.com/traPtitech/traQ/service/notification"
 	"github.com/traPtitech/traQ/service/ogp"
 	"github.com/traPtitech/traQ/service/oidc"
+	"github.com/traPtitech/traQ/service/push"
 	"github.com/traPtitech/traQ/service/qall"
 	"github.com/traPtitech/traQ/service/rbac"
+	"github.com/traPtitech/traQ/service/savedsearch"
+	"github.com/traPtitech/traQ/service/scheduler"
 	"github.com/traPtitech/traQ/service/search"
 	"github.com/traPtitech/traQ/service/viewer"
 	"github.com/traPtitech/traQ/service/webrtcv3"
@@ -30,14 +35,21 @@ type Services struct {
 	UserCounter          counter.UserCounter
 	ChannelCounter       counter.ChannelCounter
 	StampThrottler       *exevent.StampThrottler
-	FCM                  fcm.Client
+	DigestSender         *digest.Sender
 	FileManager          file.Manager
 	Imaging              imaging.Processor
+	InboxRecorder        *inbox.Recorder
+	Mailer               mailer.Mailer
 	MessageManager       message.Manager
+	MessageScheduler     *scheduler.MessageScheduler
+	PollCloser           *scheduler.PollCloser
+	ReminderScheduler    *scheduler.ReminderScheduler
 	Notification         *notification.Service
 	OGP                  ogp.Service
 	OIDC                 *oidc.Service
+	Push                 push.Client
 	RBAC                 rbac.RBAC
+	SavedSearchWatcher   *savedsearch.Watcher
 	Search               search.Engine
 	ViewerManager        *viewer.Manager
 	WebRTCv3             *webrtcv3.Manager
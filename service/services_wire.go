@@ -15,14 +15,21 @@ var ProviderSet = wire.NewSet(wire.FieldsOf(new(*Services),
 	"UserCounter",
 	"ChannelCounter",
 	"StampThrottler",
-	"FCM",
+	"DigestSender",
 	"FileManager",
 	"Imaging",
+	"InboxRecorder",
+	"Mailer",
 	"MessageManager",
+	"MessageScheduler",
+	"PollCloser",
+	"ReminderScheduler",
 	"Notification",
 	"OGP",
 	"OIDC",
+	"Push",
 	"RBAC",
+	"SavedSearchWatcher",
 	"Search",
 	"ViewerManager",
 	"WebRTCv3",
@@ -0,0 +1,8 @@
+package webpush
+
+import "github.com/traPtitech/traQ/service/push"
+
+// Client Web Push (RFC 8030) Client
+type Client interface {
+	push.Client
+}
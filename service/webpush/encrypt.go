@@ -0,0 +1,109 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	// recordSize 暗号化レコードのサイズ
+	recordSize = 4096
+	saltLength = 16
+	// maxPayloadSize 1レコードに収まる平文の最大サイズ (タグ16バイトと区切り1バイトを除く)
+	maxPayloadSize = recordSize - 16 - 1
+)
+
+// ErrPayloadTooLarge ペイロードが大きすぎます
+var ErrPayloadTooLarge = errors.New("payload too large")
+
+// encrypt RFC 8291 (aes128gcm) に従ってplaintextを暗号化し、リクエストボディを返します
+//
+// uaPublicはユーザーエージェントの非圧縮形式のECDH公開鍵、authSecretは認証シークレットです
+func encrypt(plaintext, uaPublic, authSecret []byte) ([]byte, error) {
+	if len(plaintext) > maxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	curve := ecdh.P256()
+	uaKey, err := curve.NewPublicKey(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	asKey, err := curve.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	asPublic := asKey.PublicKey().Bytes()
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	cek, nonce, err := deriveKeys(asKey, uaKey, uaPublic, asPublic, authSecret, salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	// ヘッダー: salt(16) || rs(4) || idlen(1) || keyid(as_public)
+	body := make([]byte, 0, saltLength+4+1+len(asPublic)+len(plaintext)+1+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublic)))
+	body = append(body, asPublic...)
+
+	record := make([]byte, 0, len(plaintext)+1)
+	record = append(record, plaintext...)
+	record = append(record, 0x02) // 最後のレコードの区切り
+	return gcm.Seal(body, nonce, record, nil), nil
+}
+
+// deriveKeys コンテンツ暗号鍵とナンスを導出します
+func deriveKeys(private *ecdh.PrivateKey, peer *ecdh.PublicKey, uaPublic, asPublic, authSecret, salt []byte) (cek []byte, nonce []byte, err error) {
+	ecdhSecret, err := private.ECDH(peer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prkKey, err := hkdf.Extract(sha256.New, ecdhSecret, authSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyInfo := "WebPush: info\x00" + string(uaPublic) + string(asPublic)
+	ikm, err := hkdf.Expand(sha256.New, prkKey, keyInfo, 32)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	cek, err = hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce, err = hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cek, nonce, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,99 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// decrypt encryptで暗号化されたリクエストボディをユーザーエージェントの秘密鍵で復号します
+func decrypt(body []byte, uaKey *ecdh.PrivateKey, authSecret []byte) ([]byte, error) {
+	if len(body) < saltLength+4+1 {
+		return nil, errors.New("body too short")
+	}
+	salt := body[:saltLength]
+	rs := binary.BigEndian.Uint32(body[saltLength : saltLength+4])
+	idLen := int(body[saltLength+4])
+	header := saltLength + 4 + 1 + idLen
+	if len(body) < header || rs != recordSize {
+		return nil, errors.New("invalid header")
+	}
+	asPublic := body[saltLength+5 : header]
+
+	asKey, err := ecdh.P256().NewPublicKey(asPublic)
+	if err != nil {
+		return nil, err
+	}
+	cek, nonce, err := deriveKeys(uaKey, asKey, uaKey.PublicKey().Bytes(), asPublic, authSecret, salt)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+	record, err := gcm.Open(nil, nonce, body[header:], nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(record) == 0 || record[len(record)-1] != 0x02 {
+		return nil, errors.New("invalid delimiter")
+	}
+	return record[:len(record)-1], nil
+}
+
+func newUserAgentKeys(t *testing.T) (*ecdh.PrivateKey, []byte) {
+	t.Helper()
+	uaKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, 16)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+	return uaKey, authSecret
+}
+
+func TestEncrypt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		uaKey, authSecret := newUserAgentKeys(t)
+
+		body, err := encrypt([]byte("hello, traQ"), uaKey.PublicKey().Bytes(), authSecret)
+		require.NoError(t, err)
+
+		plaintext, err := decrypt(body, uaKey, authSecret)
+		require.NoError(t, err)
+		assert.Equal(t, "hello, traQ", string(plaintext))
+	})
+
+	t.Run("wrong auth secret", func(t *testing.T) {
+		t.Parallel()
+		uaKey, authSecret := newUserAgentKeys(t)
+
+		body, err := encrypt([]byte("hello, traQ"), uaKey.PublicKey().Bytes(), authSecret)
+		require.NoError(t, err)
+
+		_, err = decrypt(body, uaKey, make([]byte, 16))
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid public key", func(t *testing.T) {
+		t.Parallel()
+		_, err := encrypt([]byte("hello, traQ"), []byte("invalid"), make([]byte, 16))
+		assert.Error(t, err)
+	})
+
+	t.Run("payload too large", func(t *testing.T) {
+		t.Parallel()
+		uaKey, authSecret := newUserAgentKeys(t)
+
+		_, err := encrypt(make([]byte, maxPayloadSize+1), uaKey.PublicKey().Bytes(), authSecret)
+		assert.ErrorIs(t, err, ErrPayloadTooLarge)
+	})
+}
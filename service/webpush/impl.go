@@ -0,0 +1,200 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	jsonIter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository"
+	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/push"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+// Config Web Push設定
+type Config struct {
+	// VAPIDPrivateKey base64urlエンコードされたVAPID秘密鍵
+	VAPIDPrivateKey string
+	// Subject プッシュサービスに通知する連絡先 (mailto: またはhttps: のURL)
+	Subject string
+}
+
+// Valid 有効な設定かどうか
+func (c Config) Valid() bool {
+	return len(c.VAPIDPrivateKey) > 0 && len(c.Subject) > 0
+}
+
+// errSubscriptionGone 購読が無効になっている
+var errSubscriptionGone = errors.New("subscription is no longer valid")
+
+type job struct {
+	subscription *model.WebPushSubscription
+	payload      []byte
+}
+
+type clientImpl struct {
+	repo          repository.Repository
+	logger        *zap.Logger
+	unreadCounter counter.UnreadMessageCounter
+	key           *VAPIDKey
+	subject       string
+	httpClient    *http.Client
+
+	queue    chan *job
+	wg       sync.WaitGroup
+	closedMu sync.RWMutex
+	closed   bool
+}
+
+// NewClient Web Push Clientを生成します
+func NewClient(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, config Config) (Client, error) {
+	return newClient(repo, logger, unreadCounter, config, &http.Client{Timeout: requestTimeout})
+}
+
+func newClient(repo repository.Repository, logger *zap.Logger, unreadCounter counter.UnreadMessageCounter, config Config, httpClient *http.Client) (*clientImpl, error) {
+	key, err := ParseVAPIDPrivateKey(config.VAPIDPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &clientImpl{
+		repo:          repo,
+		logger:        logger.Named("webpush"),
+		unreadCounter: unreadCounter,
+		key:           key,
+		subject:       config.Subject,
+		httpClient:    httpClient,
+		queue:         make(chan *job, 100),
+	}
+	for range workerCount {
+		c.wg.Add(1)
+		go c.worker()
+	}
+	return c, nil
+}
+
+func (c *clientImpl) Close() {
+	c.closedMu.Lock()
+	defer c.closedMu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.queue)
+		c.wg.Wait()
+	}
+}
+
+func (c *clientImpl) Send(targetUserIDs set.UUID, p *push.Payload, withUnreadCount bool) {
+	c.closedMu.RLock()
+	defer c.closedMu.RUnlock()
+	if c.closed {
+		return
+	}
+
+	logger := c.logger.With(zap.Reflect("payload", p))
+
+	subscriptionsMap, err := c.repo.GetWebPushSubscriptions(context.Background(), targetUserIDs)
+	if err != nil {
+		logger.Error("failed to GetWebPushSubscriptions", zap.Error(err), zap.Strings("target_user_ids", targetUserIDs.StringArray()))
+		return
+	}
+
+	for uid, subscriptions := range subscriptionsMap {
+		data := map[string]string{
+			"type":  p.Type,
+			"title": p.Title,
+			"body":  p.Body,
+			"path":  p.Path,
+			"tag":   p.Tag,
+			"icon":  p.Icon,
+		}
+		if p.Image.Valid {
+			data["image"] = p.Image.V
+		}
+		if withUnreadCount {
+			data["unread"] = strconv.Itoa(c.unreadCounter.Get(uid))
+		}
+		payload, err := jsonIter.ConfigFastest.Marshal(data)
+		if err != nil {
+			logger.Error("failed to marshal payload", zap.Error(err))
+			return
+		}
+
+		for _, s := range subscriptions {
+			c.queue <- &job{subscription: s, payload: payload}
+		}
+	}
+}
+
+func (c *clientImpl) worker() {
+	defer c.wg.Done()
+	for j := range c.queue {
+		err := c.deliver(context.Background(), j.subscription, j.payload)
+		switch {
+		case err == nil:
+			webPushSendCounter.WithLabelValues("ok").Inc()
+		case errors.Is(err, errSubscriptionGone):
+			webPushSendCounter.WithLabelValues("gone").Inc()
+			if err := c.repo.DeleteWebPushSubscriptionsByEndpoints(context.Background(), []string{j.subscription.Endpoint}); err != nil {
+				c.logger.Error("failed to DeleteWebPushSubscriptionsByEndpoints", zap.Error(err), zap.Stringer("subscriptionId", j.subscription.ID))
+			}
+		default:
+			webPushSendCounter.WithLabelValues("error").Inc()
+			c.logger.Warn("webpush: "+err.Error(), zap.Stringer("subscriptionId", j.subscription.ID))
+		}
+	}
+}
+
+// deliver 購読のエンドポイントに暗号化したペイロードを送信します
+func (c *clientImpl) deliver(ctx context.Context, s *model.WebPushSubscription, payload []byte) error {
+	uaPublic, err := decodeBase64URL(s.P256dh)
+	if err != nil {
+		return fmt.Errorf("invalid p256dh: %w", err)
+	}
+	authSecret, err := decodeBase64URL(s.Auth)
+	if err != nil {
+		return fmt.Errorf("invalid auth: %w", err)
+	}
+	body, err := encrypt(payload, uaPublic, authSecret)
+	if err != nil {
+		return err
+	}
+	authorization, err := c.key.authorization(s.Endpoint, c.subject, time.Now().Add(vapidTokenExpiry))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", messageTTLString)
+	req.Header.Set("Urgency", "high")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	switch {
+	case res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone:
+		return errSubscriptionGone
+	case res.StatusCode < 200 || res.StatusCode >= 300:
+		return fmt.Errorf("push service responded with status %d", res.StatusCode)
+	}
+	return nil
+}
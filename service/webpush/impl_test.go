@@ -0,0 +1,165 @@
+package webpush
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang/mock/gomock"
+	jsonIter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/traPtitech/traQ/model"
+	"github.com/traPtitech/traQ/repository/mock_repository"
+	"github.com/traPtitech/traQ/service/counter"
+	"github.com/traPtitech/traQ/service/push"
+	"github.com/traPtitech/traQ/testutils"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+type Repo struct {
+	*mock_repository.MockWebPushSubscriptionRepository
+	testutils.EmptyTestRepository
+}
+
+type fakeUnreadCounter struct {
+	counter.UnreadMessageCounter
+}
+
+func (fakeUnreadCounter) Get(uuid.UUID) int {
+	return 3
+}
+
+// pushServiceStub リクエストを記録するプッシュサービスのスタブ
+type pushServiceStub struct {
+	*httptest.Server
+	status   int
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newPushServiceStub(t *testing.T, status int) *pushServiceStub {
+	t.Helper()
+	s := &pushServiceStub{status: status}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, body)
+		s.mu.Unlock()
+		w.WriteHeader(s.status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func setup(t *testing.T) (*clientImpl, *Repo) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := &Repo{MockWebPushSubscriptionRepository: mock_repository.NewMockWebPushSubscriptionRepository(ctrl)}
+
+	key, err := GenerateVAPIDKey()
+	require.NoError(t, err)
+	c, err := newClient(repo, zap.NewNop(), fakeUnreadCounter{}, Config{VAPIDPrivateKey: key.PrivateKeyString(), Subject: "mailto:admin@example.com"}, http.DefaultClient)
+	require.NoError(t, err)
+	return c, repo
+}
+
+func TestClientImpl_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		c, repo := setup(t)
+		stub := newPushServiceStub(t, http.StatusCreated)
+		uaKey, authSecret := newUserAgentKeys(t)
+
+		userID := uuid.Must(uuid.NewV4())
+		targets := set.UUIDSetFromArray([]uuid.UUID{userID})
+		repo.MockWebPushSubscriptionRepository.EXPECT().
+			GetWebPushSubscriptions(gomock.Any(), targets).
+			Return(map[uuid.UUID][]*model.WebPushSubscription{
+				userID: {{
+					ID:       uuid.Must(uuid.NewV4()),
+					UserID:   userID,
+					Endpoint: stub.URL + "/push/1",
+					P256dh:   base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes()),
+					Auth:     base64.RawURLEncoding.EncodeToString(authSecret),
+				}},
+			}, nil)
+
+		c.Send(targets, &push.Payload{Type: "new_message", Title: "#general", Body: "hello", Path: "/channels/general", Tag: "c:1"}, true)
+		c.Close()
+
+		require.Len(t, stub.requests, 1)
+		req := stub.requests[0]
+		assert.Equal(t, "/push/1", req.URL.Path)
+		assert.Equal(t, "aes128gcm", req.Header.Get("Content-Encoding"))
+		assert.Equal(t, messageTTLString, req.Header.Get("TTL"))
+		assert.Contains(t, req.Header.Get("Authorization"), "vapid t=")
+
+		plaintext, err := decrypt(stub.bodies[0], uaKey, authSecret)
+		require.NoError(t, err)
+		var data map[string]string
+		require.NoError(t, jsonIter.ConfigFastest.Unmarshal(plaintext, &data))
+		assert.Equal(t, "new_message", data["type"])
+		assert.Equal(t, "#general", data["title"])
+		assert.Equal(t, "hello", data["body"])
+		assert.Equal(t, "/channels/general", data["path"])
+		assert.Equal(t, "3", data["unread"])
+	})
+
+	t.Run("gone subscription is deleted", func(t *testing.T) {
+		t.Parallel()
+		c, repo := setup(t)
+		stub := newPushServiceStub(t, http.StatusGone)
+		uaKey, authSecret := newUserAgentKeys(t)
+
+		userID := uuid.Must(uuid.NewV4())
+		targets := set.UUIDSetFromArray([]uuid.UUID{userID})
+		endpoint := stub.URL + "/push/gone"
+		repo.MockWebPushSubscriptionRepository.EXPECT().
+			GetWebPushSubscriptions(gomock.Any(), targets).
+			Return(map[uuid.UUID][]*model.WebPushSubscription{
+				userID: {{
+					ID:       uuid.Must(uuid.NewV4()),
+					UserID:   userID,
+					Endpoint: endpoint,
+					P256dh:   base64.RawURLEncoding.EncodeToString(uaKey.PublicKey().Bytes()),
+					Auth:     base64.RawURLEncoding.EncodeToString(authSecret),
+				}},
+			}, nil)
+		repo.MockWebPushSubscriptionRepository.EXPECT().
+			DeleteWebPushSubscriptionsByEndpoints(gomock.Any(), []string{endpoint}).
+			Return(nil)
+
+		c.Send(targets, &push.Payload{Type: "new_message"}, false)
+		c.Close()
+
+		assert.Len(t, stub.requests, 1)
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+		c, _ := setup(t)
+		c.Close()
+
+		assert.NotPanics(t, func() {
+			c.Send(set.UUIDSetFromArray([]uuid.UUID{uuid.Must(uuid.NewV4())}), &push.Payload{}, false)
+		})
+	})
+}
+
+func TestConfig_Valid(t *testing.T) {
+	t.Parallel()
+	assert.False(t, Config{}.Valid())
+	assert.False(t, Config{VAPIDPrivateKey: "a"}.Valid())
+	assert.True(t, Config{VAPIDPrivateKey: "a", Subject: "mailto:admin@example.com"}.Valid())
+}
@@ -0,0 +1,21 @@
+package webpush
+
+import (
+	"github.com/traPtitech/traQ/service/push"
+	"github.com/traPtitech/traQ/utils/set"
+)
+
+var nullC = &nullClient{}
+
+type nullClient struct{}
+
+// NewNullClient 何もしないWeb Pushクライアントを返します
+func NewNullClient() Client {
+	return nullC
+}
+
+func (n *nullClient) Send(set.UUID, *push.Payload, bool) {
+}
+
+func (n *nullClient) Close() {
+}
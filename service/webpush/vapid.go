@@ -0,0 +1,86 @@
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// VAPIDKey VAPID (RFC 8292) の鍵ペア
+type VAPIDKey struct {
+	private *ecdsa.PrivateKey
+	public  []byte
+}
+
+// GenerateVAPIDKey 新しいVAPID鍵ペアを生成します
+func GenerateVAPIDKey() (*VAPIDKey, error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newVAPIDKey(priv)
+}
+
+// ParseVAPIDPrivateKey base64urlエンコードされたP-256秘密鍵からVAPID鍵ペアを生成します
+func ParseVAPIDPrivateKey(s string) (*VAPIDKey, error) {
+	b, err := decodeBase64URL(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	priv, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vapid private key: %w", err)
+	}
+	return newVAPIDKey(priv)
+}
+
+func newVAPIDKey(priv *ecdsa.PrivateKey) (*VAPIDKey, error) {
+	public, err := priv.PublicKey.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return &VAPIDKey{private: priv, public: public}, nil
+}
+
+// PrivateKeyString base64urlエンコードされた秘密鍵を返します
+func (k *VAPIDKey) PrivateKeyString() string {
+	b, _ := k.private.Bytes()
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// PublicKeyString base64urlエンコードされた非圧縮形式の公開鍵を返します
+//
+// ブラウザでの購読時に applicationServerKey として使用します
+func (k *VAPIDKey) PublicKeyString() string {
+	return base64.RawURLEncoding.EncodeToString(k.public)
+}
+
+// authorization endpointへのリクエストに付与するAuthorizationヘッダーの値を返します
+func (k *VAPIDKey) authorization(endpoint string, subject string, exp time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": exp.Unix(),
+		"sub": subject,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(k.private)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("vapid t=%s, k=%s", token, k.PublicKeyString()), nil
+}
+
+// decodeBase64URL パディングの有無に関わらずbase64urlをデコードします
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
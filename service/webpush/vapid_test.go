@@ -0,0 +1,55 @@
+package webpush
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVAPIDPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateVAPIDKey()
+	require.NoError(t, err)
+
+	parsed, err := ParseVAPIDPrivateKey(key.PrivateKeyString())
+	if assert.NoError(t, err) {
+		assert.Equal(t, key.PublicKeyString(), parsed.PublicKeyString())
+	}
+
+	_, err = ParseVAPIDPrivateKey("invalid")
+	assert.Error(t, err)
+}
+
+func TestVAPIDKey_authorization(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateVAPIDKey()
+	require.NoError(t, err)
+
+	exp := time.Now().Add(time.Hour)
+	header, err := key.authorization("https://push.example.com/send/abc?x=y", "mailto:admin@example.com", exp)
+	require.NoError(t, err)
+
+	token, k, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	require.True(t, ok)
+	assert.Equal(t, key.PublicKeyString(), k)
+
+	pub, err := decodeBase64URL(k)
+	require.NoError(t, err)
+	publicKey, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), pub)
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) { return publicKey, nil }, jwt.WithValidMethods([]string{"ES256"}))
+	require.NoError(t, err)
+	assert.Equal(t, "https://push.example.com", claims["aud"])
+	assert.Equal(t, "mailto:admin@example.com", claims["sub"])
+	assert.EqualValues(t, exp.Unix(), claims["exp"])
+}
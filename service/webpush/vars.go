@@ -0,0 +1,24 @@
+package webpush
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	workerCount       = 8
+	messageTTLSeconds = 60 * 60 * 24 * 2 // 2日
+	vapidTokenExpiry  = 12 * time.Hour
+	requestTimeout    = 30 * time.Second
+)
+
+var (
+	webPushSendCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "webpush",
+		Name:      "send_count_total",
+	}, []string{"result"})
+	messageTTLString = strconv.Itoa(messageTTLSeconds)
+)
@@ -25,4 +25,12 @@ type EmptyTestRepository struct {
 	repository.ClipRepository
 	repository.OgpCacheRepository
 	repository.SoundboardRepository
+	repository.ScheduledMessageRepository
+	repository.SavedSearchRepository
+	repository.WebPushSubscriptionRepository
+	repository.InboxItemRepository
+	repository.PollRepository
+	repository.MessageReminderRepository
+	repository.BotCommandRepository
+	repository.MessageComponentRepository
 }
@@ -1,6 +1,12 @@
 package gormutil
 
-import "gorm.io/gorm"
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
 
 // RecordExists 指定した条件のレコードが1行以上存在するかどうか
 func RecordExists(db *gorm.DB, where interface{}, tableName ...string) (exists bool, err error) {
@@ -35,3 +41,13 @@ func LimitAndOffset(limit, offset int) func(db *gorm.DB) *gorm.DB {
 		return db
 	}
 }
+
+// LikeContains LIKE句で部分一致検索するためのパターンを返します。sに含まれるワイルドカードはエスケープされます。
+func LikeContains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// LikePrefix LIKE句で前方一致検索するためのパターンを返します。sに含まれるワイルドカードはエスケープされます。
+func LikePrefix(s string) string {
+	return likeEscaper.Replace(s) + "%"
+}
@@ -28,6 +28,10 @@ type ParseResult struct {
 	ChannelLink   []uuid.UUID
 	Attachments   []uuid.UUID
 	Citation      []uuid.UUID
+	// HereMention @hereによるチャンネルを見ている・オンラインのユーザーへのメンションを含むかどうか
+	HereMention bool
+	// SubscribersMention @channelによるチャンネルの購読者全員へのメンションを含むかどうか
+	SubscribersMention bool
 }
 
 // NotificationText PlainTextを通知用に処理したものを返します
@@ -68,6 +72,12 @@ func Parse(m string) *ParseResult {
 		case "channel":
 			r.ChannelLink = append(r.ChannelLink, info.ID)
 			return info.Raw
+		case "here":
+			r.HereMention = true
+			return info.Raw
+		case "subscribers":
+			r.SubscribersMention = true
+			return info.Raw
 		default:
 			return s
 		}
@@ -50,6 +50,11 @@ func TestParse(t *testing.T) {
 			PlainText:   `!{ test message #a/e`,
 			ChannelLink: []uuid.UUID{u1},
 		},
+		`!{"raw": "@here","type":"here"} test message !{"type":"subscribers","raw":"@channel"}`: {
+			PlainText:          `@here test message @channel`,
+			HereMention:        true,
+			SubscribersMention: true,
+		},
 		`!{ test message !{"raw": 1,"type":"user","id":"test_id"}`: {
 			PlainText: `!{ test message !{"raw": 1,"type":"user","id":"test_id"}`,
 		},
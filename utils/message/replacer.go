@@ -15,6 +15,13 @@ var (
 	channelRegex    = regexp.MustCompile(`[#＃]([a-zA-Z0-9_/-]+)`)
 )
 
+const (
+	// hereMentionName チャンネルを見ている・オンラインのユーザー全員へのメンション名
+	hereMentionName = "here"
+	// subscribersMentionName チャンネルの購読者全員へのメンション名
+	subscribersMentionName = "channel"
+)
+
 const (
 	backQuoteRune          = rune('`')
 	dollarRune             = rune('$')
@@ -138,6 +145,13 @@ func (re *Replacer) replaceMention(m string) string {
 		if gid, ok := re.mapper.Group(name); ok {
 			return fmt.Sprintf(`!{"type":"group","raw":"%s","id":"%s"}`, s, gid)
 		}
+		// 同名のユーザー・グループが存在しない場合のみチャンネル全体へのメンションとする
+		switch name {
+		case hereMentionName:
+			return fmt.Sprintf(`!{"type":"here","raw":"%s"}`, s)
+		case subscribersMentionName:
+			return fmt.Sprintf(`!{"type":"subscribers","raw":"%s"}`, s)
+		}
 
 		return userStartsRegex.ReplaceAllStringFunc(s, func(s string) string {
 			name := strings.ToLower(strings.TrimLeft(s, "@＠"))
@@ -116,6 +116,14 @@ func TestReplacer_Replace(t *testing.T) {
 			"@a",
 			"!{\"type\":\"user\",\"raw\":\"@a\",\"id\":\"dfdff0c9-5de0-46ee-9721-2525e8bb3d44\"}",
 		},
+		{
+			"@channel `@channel` :@channel:",
+			"!{\"type\":\"subscribers\",\"raw\":\"@channel\"} `@channel` :@channel:",
+		},
+		{
+			"@here @HERE @heree",
+			"!{\"type\":\"here\",\"raw\":\"@here\"} !{\"type\":\"here\",\"raw\":\"@HERE\"} @heree",
+		},
 	}
 	for _, v := range tt {
 		assert.Equal(t, v[1], re.Replace(v[0]))
@@ -50,6 +50,17 @@ var BotUserNameRuleRequired = append([]vd.Rule{
 	vd.Required,
 }, BotUserNameRule...)
 
+// BotCommandNameRule BOTのスラッシュコマンド名バリデーションルール
+var BotCommandNameRule = []vd.Rule{
+	vd.Match(regexp.MustCompile(`^[a-z0-9_-]+$`)).Error("must contain [a-z0-9_-] only"),
+	vd.RuneLength(1, 32),
+}
+
+// BotCommandNameRuleRequired BOTのスラッシュコマンド名バリデーションルール with Required
+var BotCommandNameRuleRequired = append([]vd.Rule{
+	vd.Required,
+}, BotCommandNameRule...)
+
 // UserGroupNameRule ユーザーグループ名バリデーションルール
 var UserGroupNameRule = []vd.Rule{
 	vd.Match(regexp.MustCompile(`^[^@＠#＃:： 　]*$`)).Error("must not contain [@＠#＃:：] and spaces"),